@@ -16,6 +16,25 @@ It also starts any plugins defined in a task file
 and calls their initialize function.
 Pass --start-plugins=false to prevent this.
 
+Besides the schema of a task, "ci" checks values that saturn-bot
+would otherwise only detect during a run:
+
+- templates in "branchName", "prTitle" and "prBody"
+- jq expressions of webhook triggers
+- the duration in "autoMergeAfter"
+- files referenced by "contentFromFile" and by plugins
+
+Each error contains the file, line and column of the invalid value.
+
+"ci" also applies lint rules to valid tasks and reports warnings
+for settings that likely have unintended consequences.
+Setting "ciLintRules" in the configuration file changes the
+severity of a rule.
+A comment "# saturn-bot:ignore <rule>" in a task file suppresses
+a rule for the line of the comment and the line after it.
+Pass --output=junit or --output=sarif to write a report that
+continuous integration systems can display as annotations.
+
 The command exits with exit code "1" if validation fails.
 
 Examples:
@@ -25,10 +44,14 @@ saturn-bot ci ./task.yaml
 
 # Validate multiple task files
 saturn-bot ci ./*.yaml
+
+# Write a SARIF report to upload it to GitHub code scanning
+saturn-bot ci --output sarif ./*.yaml > saturn-bot.sarif
 `
 )
 
 func createCiCommand() *cobra.Command {
+	var outputFormat string
 	var skipPlugins bool
 
 	cmd := &cobra.Command{
@@ -39,11 +62,13 @@ func createCiCommand() *cobra.Command {
 		Run: func(cmd *cobra.Command, args []string) {
 			runner, err := command.NewCiRunnerFromConfig(cfgFile, skipPlugins)
 			handleError(err, cmd.ErrOrStderr())
+			runner.OutputFormat = outputFormat
 			err = runner.Run(cmd.OutOrStdout(), args...)
 			handleError(err, cmd.ErrOrStderr())
 		},
 	}
 	cmd.Flags().StringVar(&cfgFile, "config", "", "Path to config file")
+	cmd.Flags().StringVar(&outputFormat, "output", command.CiOutputFormatText, "Format of the report. One of junit, sarif or text.")
 	cmd.Flags().BoolVar(&skipPlugins, "skip-plugins", false, "Skip starting plugins as part of the CI run.")
 	return cmd
 }
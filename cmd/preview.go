@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/wndhydrnt/saturn-bot/pkg/command"
+	"github.com/wndhydrnt/saturn-bot/pkg/config"
+	"github.com/wndhydrnt/saturn-bot/pkg/options"
+)
+
+var (
+	previewCommandHelp = `Preview the changes of tasks across repositories.
+
+"preview" applies tasks to repositories like "run" does, but in dry-run mode.
+It never pushes changes or creates pull requests.
+
+It lists all repositories from the source, for example GitHub or GitLab,
+executes the filters of each task and applies the actions of a task
+if all filters match.
+It processes multiple repositories in parallel.
+
+At the end, it writes a report that lists which repositories match,
+which repositories change, the diff of each change and errors.
+The report also contains the decision of every filter.
+
+Examples:
+
+# Preview task in file "task.yaml" across all repositories
+# and write a Markdown report to stdout.
+saturn-bot preview task.yaml
+
+# Write an HTML report to file "report.html".
+saturn-bot preview \
+  --output html \
+  --output-file report.html \
+  task.yaml
+
+# Preview only repositories "github.com/wndhydrnt/saturn-bot-example"
+# and "github.com/wndhydrnt/saturn-bot".
+# Unlike "run", "preview" still executes the filters of the task.
+saturn-bot preview \
+  --output json \
+  --repository github.com/wndhydrnt/saturn-bot-example \
+  --repository github.com/wndhydrnt/saturn-bot \
+  task.yaml
+`
+)
+
+func createPreviewCommand() *cobra.Command {
+	var inputs map[string]string
+	var outputFile string
+	var outputFormat string
+	var parallel int
+	var repositories []string
+
+	var cmd = &cobra.Command{
+		Use:   "preview FILE [FILE...]",
+		Short: "Preview the changes of tasks across repositories",
+		Long:  previewCommandHelp,
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			cfg, err := config.Read(cfgFile)
+			handleError(err, cmd.ErrOrStderr())
+			opts, err := options.ToOptions(cfg)
+			handleError(err, cmd.ErrOrStderr())
+			var outReport io.Writer = cmd.OutOrStdout()
+			if outputFile != "" {
+				f, err := os.Create(outputFile)
+				handleError(err, cmd.ErrOrStderr())
+				defer f.Close()
+				outReport = f
+			}
+
+			// Stop the preview cleanly on Ctrl+C.
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			err = command.ExecutePreview(ctx, opts, command.PreviewOptions{
+				Inputs:          inputs,
+				Out:             cmd.ErrOrStderr(),
+				OutputFormat:    outputFormat,
+				OutReport:       outReport,
+				Parallel:        parallel,
+				RepositoryNames: repositories,
+				TaskFiles:       args,
+			})
+			handleError(err, cmd.ErrOrStderr())
+		},
+	}
+	cmd.Flags().StringVar(&cfgFile, "config", "", "Path to config file")
+	cmd.Flags().StringToStringVar(&inputs, "input", map[string]string{}, `Key/value pair in the format <key>=<value>
+to use as an input parameter of a task.
+Can be supplied multiple times to set multiple inputs.`)
+	cmd.Flags().StringVar(&outputFormat, "output", command.PreviewOutputFormatMarkdown, "The format of the report. One of html, json or markdown.")
+	cmd.Flags().StringVar(&outputFile, "output-file", "", "Path to the file to write the report to. Writes to stdout if empty.")
+	cmd.Flags().IntVar(&parallel, "parallel", 4, "Number of repositories to process in parallel.")
+	cmd.Flags().StringArrayVar(&repositories, "repository", []string{}, `Name of a repository to preview the tasks for.
+Can be supplied multiple times.`)
+	return cmd
+}
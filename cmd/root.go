@@ -25,10 +25,13 @@ func Execute() int {
 	rootCmd.AddCommand(createCiCommand())
 	rootCmd.AddCommand(createExperimentalCommand())
 	rootCmd.AddCommand(createPluginCommand())
+	rootCmd.AddCommand(createPreviewCommand())
 	rootCmd.AddCommand(createRunCommand())
 	rootCmd.AddCommand(createTryCommand())
 	rootCmd.AddCommand(createVersionCommand())
 	rootCmd.AddCommand(createScheduleCommand())
+	rootCmd.AddCommand(createTestCommand())
+	rootCmd.AddCommand(createTokenCommand())
 	rootCmd.AddCommand(createServerCommand())
 	rootCmd.AddCommand(createWorkerCommand())
 	if err := rootCmd.Execute(); err != nil {
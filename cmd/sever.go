@@ -10,19 +10,29 @@ var (
 
 "server" serves the API and the UI.
 
+Send the signal SIGHUP to the process to reload all tasks from FILE
+without a restart. The API endpoint POST /api/v1/tasks/reload does the same.
+
+Omit FILE and set tasksGitUrl in the configuration to read tasks
+from a git repository instead. The server checks the repository
+for new commits every tasksGitPollInterval.
+
 Examples:
 
 # Start the server
 saturn-bot server --config config.yaml ./tasks/**/*.yaml
+
+# Start the server and read tasks from the git repository set in config.yaml
+saturn-bot server --config config.yaml
 `
 )
 
 func createServerCommand() *cobra.Command {
 	var cmd = &cobra.Command{
-		Use:   "server FILE [FILE...]",
+		Use:   "server [FILE...]",
 		Short: "Starts the server component",
 		Long:  serverCommandHelp,
-		Args:  cobra.MinimumNArgs(1),
+		Args:  cobra.ArbitraryArgs,
 		Run: func(cmd *cobra.Command, args []string) {
 			err := server.Run(cfgFile, args)
 			handleError(err, cmd.ErrOrStderr())
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/wndhydrnt/saturn-bot/pkg/command"
+)
+
+var (
+	testCommandHelp = `Test tasks against fixture repositories.
+
+"test" reads the tests of a task file from a file next to it.
+The tests of task file "task.yaml" are stored in "task_test.yaml".
+
+Each test copies the files of a "before" directory into a temporary
+directory, executes the filters of the task and applies its actions.
+It then compares the files in the temporary directory with the files
+in an "after" directory.
+
+A test fails if the filters don't return the expected verdict
+or if the files differ.
+Pass --update to write the files of the temporary directory
+to the "after" directory instead of comparing them.
+
+The command exits with exit code "1" if a test fails.
+
+Examples:
+
+# Execute the tests in "task_test.yaml"
+saturn-bot test ./task.yaml
+
+# Execute the tests of multiple task files
+saturn-bot test ./*.yaml
+
+# Update the "after" directories of all tests in "task_test.yaml"
+saturn-bot test --update ./task.yaml
+`
+)
+
+func createTestCommand() *cobra.Command {
+	var update bool
+
+	cmd := &cobra.Command{
+		Use:   "test FILE [FILE...]",
+		Short: "Test tasks against fixture repositories",
+		Long:  testCommandHelp,
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runner, err := command.NewTestRunnerFromConfig(cfgFile, update)
+			handleError(err, cmd.ErrOrStderr())
+			err = runner.Run(cmd.OutOrStdout(), args...)
+			handleError(err, cmd.ErrOrStderr())
+		},
+	}
+	cmd.Flags().StringVar(&cfgFile, "config", "", "Path to config file")
+	cmd.Flags().BoolVar(&update, "update", false, `Write the files of the repository after the actions
+have been applied to the "after" directory of each test.`)
+	return cmd
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/wndhydrnt/saturn-bot/pkg/command"
+)
+
+var (
+	tokenCommandHelp = `Manage API tokens via the server API.
+
+API tokens authenticate clients at the server API.
+Each token has a scope that defines the operations it can execute:
+
+  read-only  Read runs, tasks and task results.
+  scheduler  Schedule and cancel runs of specific tasks. Includes read-only.
+  worker     Get and report work. Use it in the configuration of workers.
+
+Managing API tokens requires the admin key configured via serverApiKey.
+
+Examples:
+
+# Create a token for workers.
+saturn-bot token create \
+  --server-url http://saturn-bot.local \
+  --server-api-key admin-secret \
+  --scope worker \
+  workers
+
+# Create a token that can schedule runs of all tasks
+# whose names start with "team-a-".
+saturn-bot token create \
+  --server-url http://saturn-bot.local \
+  --server-api-key admin-secret \
+  --scope scheduler \
+  --task 'team-a-*' \
+  team-a
+
+# List all tokens.
+saturn-bot token list \
+  --server-url http://saturn-bot.local \
+  --server-api-key admin-secret
+
+# Revoke a token.
+saturn-bot token revoke \
+  --server-url http://saturn-bot.local \
+  --server-api-key admin-secret \
+  team-a
+`
+)
+
+func createTokenCommand() *cobra.Command {
+	var serverApiKey string
+	var serverUrl string
+	newRunner := func() (*command.TokenRunner, error) {
+		return command.NewTokenRunner(command.NewTokenRunnerOptions{
+			ServerApiKey: serverApiKey,
+			ServerUrl:    serverUrl,
+		})
+	}
+
+	tokenCmd := &cobra.Command{
+		Use:   "token",
+		Short: "Manage API tokens via the server API",
+		Long:  tokenCommandHelp,
+	}
+	tokenCmd.PersistentFlags().StringVar(&serverApiKey, "server-api-key", "", "Admin key to authenticate at the server API.")
+	tokenCmd.PersistentFlags().StringVar(&serverUrl, "server-url", "http://localhost:3035", "Base URL of the server API.")
+
+	var scope string
+	var tasks []string
+	createCmd := &cobra.Command{
+		Use:   "create NAME",
+		Short: "Create an API token",
+		Long: `Create an API token.
+
+Prints the secret of the new token.
+The server does not store the secret.
+It can't be retrieved again.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("requires exactly one argument NAME, %d given", len(args))
+			}
+
+			runner, err := newRunner()
+			if err != nil {
+				return err
+			}
+
+			return runner.Create(command.TokenRunnerCreateOptions{
+				Name:      args[0],
+				OutLog:    cmd.ErrOrStderr(),
+				OutSecret: cmd.OutOrStdout(),
+				Scope:     scope,
+				Tasks:     tasks,
+			})
+		},
+	}
+	createCmd.Flags().StringVar(&scope, "scope", "read-only", "Scope of the token. One of read-only, scheduler or worker.")
+	createCmd.Flags().StringArrayVar(&tasks, "task", []string{}, `Name or glob pattern of a task the token can schedule runs for.
+Required if --scope is scheduler.
+Can be supplied multiple times.`)
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List API tokens",
+		Long:  "List API tokens.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			runner, err := newRunner()
+			if err != nil {
+				return err
+			}
+
+			return runner.List(command.TokenRunnerListOptions{
+				OutLog:    cmd.ErrOrStderr(),
+				OutReport: cmd.OutOrStdout(),
+			})
+		},
+	}
+
+	revokeCmd := &cobra.Command{
+		Use:   "revoke NAME",
+		Short: "Revoke an API token",
+		Long: `Revoke an API token.
+
+Clients can't authenticate with the token afterwards.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return fmt.Errorf("requires exactly one argument NAME, %d given", len(args))
+			}
+
+			runner, err := newRunner()
+			if err != nil {
+				return err
+			}
+
+			return runner.Revoke(command.TokenRunnerRevokeOptions{
+				Name:   args[0],
+				OutLog: cmd.ErrOrStderr(),
+			})
+		},
+	}
+
+	tokenCmd.AddCommand(createCmd, listCmd, revokeCmd)
+	return tokenCmd
+}
@@ -21,6 +21,11 @@ provides feedback on whether they match or not.
 If all filters match, it clones the repository, applies all
 actions and provides feedback on whether files have changed or not.
 
+If files have changed, it prints the branch name, the commit message,
+the title and the body of the pull request that saturn-bot would create,
+followed by a diff of the changes.
+It doesn't push any changes or create a pull request.
+
 Examples:
 
 # Try all tasks in file "task.yaml" against
@@ -43,12 +48,27 @@ saturn-bot try \
 	--input version=1.2.3 \
 	--input date=2024-11-10 \
   task.yaml
+
+# Print only a summary of the changed files.
+saturn-bot try \
+  --repository github.com/wndhydrnt/saturn-bot-example \
+  --diff-stat \
+  task.yaml
+
+# Write the results as JSON to stdout.
+saturn-bot try \
+  --repository github.com/wndhydrnt/saturn-bot-example \
+  --output json \
+  task.yaml
 `
 )
 
 func createTryCommand() *cobra.Command {
+	var color string
 	var dataDir string
+	var diffStat bool
 	var inputs map[string]string
+	var outputFormat string
 	var repository string
 	var taskName string
 
@@ -62,7 +82,11 @@ func createTryCommand() *cobra.Command {
 			handleError(err, cmd.ErrOrStderr())
 			opts, err := options.ToOptions(cfg)
 			handleError(err, cmd.ErrOrStderr())
-			runner, err := command.NewTryRunner(opts, dataDir, repository, args[0], taskName, inputs)
+			runner, err := command.NewTryRunner(opts, dataDir, repository, args[0], taskName, inputs, command.TryRunnerOutputOptions{
+				Color:    color,
+				DiffStat: diffStat,
+				Format:   outputFormat,
+			})
 			if err != nil {
 				handleError(err, cmd.ErrOrStderr())
 			}
@@ -72,7 +96,12 @@ func createTryCommand() *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVar(&cfgFile, "config", "", "Path to config file.")
+	cmd.Flags().StringVar(&color, "color", command.TryColorAuto, `Colour the diff of the changes. One of always, auto or never.
+auto colours the diff if stdout is a terminal and NO_COLOR is not set.`)
 	cmd.Flags().StringVar(&dataDir, "data-dir", "", "Path to directory to clone the repository.")
+	cmd.Flags().BoolVar(&diffStat, "diff-stat", false, "Print a summary of the changed files instead of the full diff.")
+	cmd.Flags().StringVar(&outputFormat, "output", command.TryOutputFormatText, `The output format to use. One of json or text.
+json writes the results to stdout and all other messages to stderr.`)
 	cmd.Flags().StringVar(&repository, "repository", "", "Name of the repository to test against.")
 	cmd.Flags().StringVar(&taskName, "task-name", "", `If set, try only the task that matches the name.
 Useful if a task file contains multiple tasks.`)
@@ -11,19 +11,30 @@ var (
 "worker" queries the server component for tasks to execute,
 executes them and reports the results back to the server.
 
+Send the signal SIGHUP to the process to reload all tasks from FILE
+without a restart. The worker also reloads all tasks if the server
+requests a task that the worker doesn't know.
+
+Omit FILE and set tasksGitUrl in the configuration to read tasks
+from a git repository instead. The worker fetches the revision
+of the repository that the server requests.
+
 Examples:
 
 # Start the worker
 saturn-bot worker --config config.yaml ./tasks/**/*.yaml
+
+# Start the worker and read tasks from the git repository set in config.yaml
+saturn-bot worker --config config.yaml
 `
 )
 
 func createWorkerCommand() *cobra.Command {
 	var cmd = &cobra.Command{
-		Use:   "worker FILE [FILE...]",
+		Use:   "worker [FILE...]",
 		Short: "Starts the worker component",
 		Long:  workerCommandHelp,
-		Args:  cobra.MinimumNArgs(1),
+		Args:  cobra.ArbitraryArgs,
 		Run: func(cmd *cobra.Command, args []string) {
 			err := worker.Run(cfgFile, args)
 			handleError(err, cmd.ErrOrStderr())
@@ -0,0 +1,18 @@
+package action
+
+import (
+	"context"
+	"os/exec"
+)
+
+// commandContext wraps [exec.CommandContext].
+// It starts the command in its own process group.
+// It kills the whole group, including all child processes of the command, when ctx is done.
+func commandContext(ctx context.Context, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, args...) // #nosec G204 -- users can pass arbitrary values here
+	setProcessGroup(cmd)
+	cmd.Cancel = func() error {
+		return killProcessGroup(cmd)
+	}
+	return cmd
+}
@@ -0,0 +1,13 @@
+//go:build !unix
+
+package action
+
+import (
+	"os/exec"
+)
+
+func setProcessGroup(_ *exec.Cmd) {}
+
+func killProcessGroup(cmd *exec.Cmd) error {
+	return cmd.Process.Kill()
+}
@@ -0,0 +1,17 @@
+//go:build unix
+
+package action
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+func setProcessGroup(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+func killProcessGroup(cmd *exec.Cmd) error {
+	// A negative PID sends the signal to every process in the group.
+	return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+}
@@ -5,11 +5,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
 
+	sbcontext "github.com/wndhydrnt/saturn-bot/pkg/context"
 	"github.com/wndhydrnt/saturn-bot/pkg/params"
 )
 
@@ -86,33 +86,31 @@ type execAction struct {
 	timeout time.Duration
 }
 
-func (a *execAction) Apply(_ context.Context) error {
+func (a *execAction) Apply(ctx context.Context) error {
+	cmdCtx, cancel := context.WithTimeout(ctx, a.timeout)
+	defer cancel()
 	stdout := &bytes.Buffer{}
 	stderr := &bytes.Buffer{}
-	cmd := exec.Command(a.name, a.args...) // #nosec G204 -- users can pass arbitrary values here
+	cmd := commandContext(cmdCtx, a.name, a.args...)
 	cmd.Stdout = stdout
 	cmd.Stderr = stderr
-	errChan := make(chan error)
-	go func() {
-		err := cmd.Run()
-		errChan <- err
-	}()
-	timer := time.NewTimer(a.timeout)
-	select {
-	case err := <-errChan:
-		if !timer.Stop() {
-			// Drain the channel
-			<-timer.C
-		}
-		if err != nil {
-			return fmt.Errorf("%w\nstdout:\n%s\nstderr:\n%s", err, stdout.String(), stderr.String())
-		}
-
+	logger := sbcontext.Log(ctx)
+	logger.Debugf("Executing command '%s'", a.String())
+	err := cmd.Run()
+	logger.Debugw("Executed command", "stdout", stdout.String(), "stderr", stderr.String())
+	if err == nil {
 		return nil
-	case <-timer.C:
-		err := cmd.Process.Kill()
-		return errors.Join(fmt.Errorf("command timed out"), err)
 	}
+
+	if ctx.Err() != nil {
+		return fmt.Errorf("command cancelled: %w", ctx.Err())
+	}
+
+	if cmdCtx.Err() != nil {
+		return errors.Join(fmt.Errorf("command timed out"), cmdCtx.Err())
+	}
+
+	return fmt.Errorf("%w\nstdout:\n%s\nstderr:\n%s", err, stdout.String(), stderr.String())
 }
 
 func (a *execAction) String() string {
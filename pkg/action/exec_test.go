@@ -6,8 +6,10 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/wndhydrnt/saturn-bot/pkg/params"
 )
 
 const script = `#!/usr/bin/env bash
@@ -131,3 +133,20 @@ func TestExec_Apply(t *testing.T) {
 		runTestCase(t, tc)
 	}
 }
+
+func TestExec_Apply_Cancel(t *testing.T) {
+	f := ExecFactory{}
+	// The child process keeps stdout open.
+	// Apply() would block until the child exits if only the shell gets killed.
+	a, err := f.Create(params.Params{"args": []any{"-c", "sleep 30 &\nwait"}, "command": "sh"}, "")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+	start := time.Now()
+	err = a.Apply(ctx)
+
+	require.ErrorIs(t, err, context.Canceled)
+	require.EqualError(t, err, "command cancelled: context canceled")
+	require.Less(t, time.Since(start), 10*time.Second, "Kills child processes of the command")
+}
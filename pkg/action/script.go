@@ -7,11 +7,10 @@ import (
 	"fmt"
 	"html/template"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"time"
 
-	"github.com/wndhydrnt/saturn-bot/pkg/log"
+	sbcontext "github.com/wndhydrnt/saturn-bot/pkg/context"
 	"github.com/wndhydrnt/saturn-bot/pkg/params"
 	sbtemplate "github.com/wndhydrnt/saturn-bot/pkg/template"
 )
@@ -112,36 +111,33 @@ func (a *scriptAction) Apply(ctx context.Context) error {
 		return fmt.Errorf("render script: %w", err)
 	}
 
+	cmdCtx, cmdCancel := context.WithTimeout(ctx, a.timeout)
+	defer cmdCancel()
 	stdout := &bytes.Buffer{}
 	stderr := &bytes.Buffer{}
-	cmd := exec.Command(a.shell, scriptFile.Name()) // #nosec G204
+	cmd := commandContext(cmdCtx, a.shell, scriptFile.Name())
 	cmd.Stderr = stderr
 	cmd.Stdout = stdout
 	env := cmd.Environ()
 	env = append(env, "TASK_DIR="+a.taskDir)
 	cmd.Env = env
-	cmdCtx, cmdCancel := context.WithTimeout(context.Background(), a.timeout)
-	defer cmdCancel()
-	errChan := make(chan error)
-	go func() {
-		log.Log().Debugf("Executing script action '%s %s'", a.shell, scriptFile.Name())
-		err := cmd.Run()
-		errChan <- err
-	}()
-	select {
-	case err := <-errChan:
-		if err != nil {
-			return fmt.Errorf("%w\nstdout: %s\nstderr:%s", err, stdout.String(), stderr.String())
-		}
-
+	logger := sbcontext.Log(ctx)
+	logger.Debugf("Executing script action '%s %s'", a.shell, scriptFile.Name())
+	err = cmd.Run()
+	logger.Debugw("Executed script action", "stdout", stdout.String(), "stderr", stderr.String())
+	if err == nil {
 		return nil
-	case <-cmdCtx.Done():
-		var killErr error
-		if cmd.Process != nil {
-			killErr = cmd.Process.Kill()
-		}
-		return errors.Join(fmt.Errorf("execution of script took longer than %s", a.timeout), cmdCtx.Err(), killErr)
 	}
+
+	if ctx.Err() != nil {
+		return fmt.Errorf("execution of script cancelled: %w", ctx.Err())
+	}
+
+	if cmdCtx.Err() != nil {
+		return errors.Join(fmt.Errorf("execution of script took longer than %s", a.timeout), cmdCtx.Err())
+	}
+
+	return fmt.Errorf("%w\nstdout: %s\nstderr:%s", err, stdout.String(), stderr.String())
 }
 
 // String implements Action.
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/wndhydrnt/saturn-bot/pkg/params"
@@ -128,6 +129,23 @@ func TestScript_Apply(t *testing.T) {
 	}
 }
 
+func TestScript_Apply_Cancel(t *testing.T) {
+	f := ScriptFactory{}
+	// The child process keeps stdout open.
+	// Apply() would block until the child exits if only the shell gets killed.
+	a, err := f.Create(params.Params{"script": "sleep 30 &\nwait", "timeout": "1m"}, "")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+	start := time.Now()
+	err = a.Apply(ctx)
+
+	require.ErrorIs(t, err, context.Canceled)
+	require.EqualError(t, err, "execution of script cancelled: context canceled")
+	require.Less(t, time.Since(start), 10*time.Second, "Kills child processes of the script")
+}
+
 func TestScript_String(t *testing.T) {
 	f := ScriptFactory{}
 	a, err := f.Create(params.Params{"script": "echo 'test'"}, "")
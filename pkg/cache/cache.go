@@ -37,13 +37,29 @@ func New(dbPath string) (*Cache, error) {
 }
 
 // Delete deletes the item identified by key in the cache.
+// It also deletes the tags of the item.
 func (c *Cache) Delete(key string) error {
-	result := c.db.Where("key = ?", key).Delete(&item{})
-	if result.Error != nil {
-		return fmt.Errorf("delete cache item %s: %w", key, result.Error)
-	}
+	return c.db.Transaction(func(tx *gorm.DB) error {
+		var it item
+		result := tx.Where("key = ?", key).First(&it)
+		if result.Error != nil {
+			if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+				return nil
+			}
 
-	return nil
+			return fmt.Errorf("get cache item %s to delete: %w", key, result.Error)
+		}
+
+		if err := tx.Where("item_id = ?", it.ID).Delete(&tag{}).Error; err != nil {
+			return fmt.Errorf("delete tags of cache item %s: %w", key, err)
+		}
+
+		if err := tx.Delete(&it).Error; err != nil {
+			return fmt.Errorf("delete cache item %s: %w", key, err)
+		}
+
+		return nil
+	})
 }
 
 // DeleteAllByTag deletes all items in the cache that have been tagged by tagName.
@@ -1,11 +1,14 @@
 package cache_test
 
 import (
+	"database/sql"
 	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"github.com/wndhydrnt/saturn-bot/pkg/cache"
+
+	_ "github.com/ncruces/go-sqlite3/driver"
 )
 
 func setupCache(t *testing.T) *cache.Cache {
@@ -26,6 +29,26 @@ func TestCache_Delete(t *testing.T) {
 	require.Nil(t, value, "does not find the item")
 }
 
+func TestCache_Delete_WithTags(t *testing.T) {
+	underTest := setupCache(t)
+	err := underTest.SetWithTags("unittest", []byte("value"), "tag")
+	require.NoError(t, err, "stores the item")
+	err = underTest.Delete("unittest")
+
+	require.NoError(t, err, "deletes the item")
+	values, err := underTest.GetAllByTag("tag")
+	require.NoError(t, err)
+	require.Empty(t, values, "deletes the tags of the item")
+}
+
+func TestCache_Delete_Unknown(t *testing.T) {
+	underTest := setupCache(t)
+
+	err := underTest.Delete("unknown")
+
+	require.NoError(t, err, "ignores an item that does not exist")
+}
+
 func TestCache_DeleteAllByTag(t *testing.T) {
 	underTest := setupCache(t)
 	err := underTest.SetWithTags("first", []byte("value"), "test")
@@ -117,3 +140,26 @@ func TestCache_SetWithTag(t *testing.T) {
 	require.Equal(t, 1, len(valuesTwoAfter), "second get by tag 'two' returns the expected number of items")
 	require.Equal(t, []byte("other"), valuesTwoAfter[0], "second get by tag 'two' returns the expected value")
 }
+
+func TestCache_Migration_ResetPullRequestTimestamps(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "cache.db")
+	_, err := cache.New(dbPath)
+	require.NoError(t, err, "instantiates the cache")
+	// Simulate a cache written by a version before the migration.
+	sqlDb, err := sql.Open("sqlite3", dbPath)
+	require.NoError(t, err, "opens the database")
+	defer sqlDb.Close()
+	_, err = sqlDb.Exec("UPDATE schema_migrations SET version = 20250614142729")
+	require.NoError(t, err, "resets the version of the schema")
+	_, err = sqlDb.Exec("INSERT INTO items (key, value) VALUES ('github.com_pr_ts', '1700000000'), ('github.com/unit/test_saturn-bot--unittest', '{}')")
+	require.NoError(t, err, "inserts items")
+
+	underTest, err := cache.New(dbPath)
+	require.NoError(t, err, "migrates the cache")
+
+	_, err = underTest.Get("github.com_pr_ts")
+	require.ErrorIs(t, err, cache.ErrNotFound, "deletes the point in time of the last update")
+	value, err := underTest.Get("github.com/unit/test_saturn-bot--unittest")
+	require.NoError(t, err, "keeps other items")
+	require.Equal(t, []byte("{}"), value)
+}
@@ -0,0 +1 @@
+-- Nothing to revert. The next run updates the cache of pull requests.
@@ -0,0 +1,4 @@
+-- Pull requests cached by previous versions lack the tag of their repository.
+-- Deleting the points in time of the last updates of the cache makes the next run perform a full update,
+-- which tags all pull requests.
+DELETE FROM `items` WHERE `key` LIKE '%\_pr\_ts' ESCAPE '\';
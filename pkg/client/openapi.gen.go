@@ -21,6 +21,13 @@ const (
 	ApiKeyAuthScopes = "ApiKeyAuth.Scopes"
 )
 
+// Defines values for ApiTokenScopeV1.
+const (
+	ReadOnly  ApiTokenScopeV1 = "read-only"
+	Scheduler ApiTokenScopeV1 = "scheduler"
+	Worker    ApiTokenScopeV1 = "worker"
+)
+
 // Defines values for ReportWorkV1ResponseResult.
 const (
 	Ok ReportWorkV1ResponseResult = "ok"
@@ -28,20 +35,23 @@ const (
 
 // Defines values for RunStatusV1.
 const (
-	Failed   RunStatusV1 = "failed"
-	Finished RunStatusV1 = "finished"
-	Pending  RunStatusV1 = "pending"
-	Running  RunStatusV1 = "running"
+	Cancelled RunStatusV1 = "cancelled"
+	Failed    RunStatusV1 = "failed"
+	Finished  RunStatusV1 = "finished"
+	Pending   RunStatusV1 = "pending"
+	Running   RunStatusV1 = "running"
 )
 
 // Defines values for RunV1Reason.
 const (
-	Changed RunV1Reason = "changed"
-	Cron    RunV1Reason = "cron"
-	Manual  RunV1Reason = "manual"
-	New     RunV1Reason = "new"
-	Next    RunV1Reason = "next"
-	Webhook RunV1Reason = "webhook"
+	Changed    RunV1Reason = "changed"
+	Command    RunV1Reason = "command"
+	Cron       RunV1Reason = "cron"
+	Dependency RunV1Reason = "dependency"
+	Manual     RunV1Reason = "manual"
+	New        RunV1Reason = "new"
+	Next       RunV1Reason = "next"
+	Webhook    RunV1Reason = "webhook"
 )
 
 // Defines values for TaskResultStateV1.
@@ -55,6 +65,84 @@ const (
 	TaskResultStateV1Unknown  TaskResultStateV1 = "unknown"
 )
 
+// Defines values for UpdateTaskRolloutV1RequestAction.
+const (
+	Pause   UpdateTaskRolloutV1RequestAction = "pause"
+	Promote UpdateTaskRolloutV1RequestAction = "promote"
+	Resume  UpdateTaskRolloutV1RequestAction = "resume"
+)
+
+// Defines values for WebhookDeliveryStatusV1.
+const (
+	WebhookDeliveryStatusV1Failed    WebhookDeliveryStatusV1 = "failed"
+	WebhookDeliveryStatusV1Pending   WebhookDeliveryStatusV1 = "pending"
+	WebhookDeliveryStatusV1Processed WebhookDeliveryStatusV1 = "processed"
+)
+
+// Defines values for WebhookDeliveryV1Type.
+const (
+	WebhookDeliveryV1TypeGeneric WebhookDeliveryV1Type = "generic"
+	WebhookDeliveryV1TypeGithub  WebhookDeliveryV1Type = "github"
+	WebhookDeliveryV1TypeGitlab  WebhookDeliveryV1Type = "gitlab"
+)
+
+// ApiTokenScopeV1 Scope of an API token.
+// `read-only` allows reading runs, tasks and task results.
+// `scheduler` allows scheduling runs of the tasks the token lists. Includes the permissions of `read-only`.
+// `worker` allows workers to get and report work.
+type ApiTokenScopeV1 string
+
+// ApiTokenV1 defines model for ApiTokenV1.
+type ApiTokenV1 struct {
+	// CreatedAt Time at which the token has been created.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Name Name of the token.
+	Name string `json:"name"`
+
+	// Scope Scope of an API token.
+	// `read-only` allows reading runs, tasks and task results.
+	// `scheduler` allows scheduling runs of the tasks the token lists. Includes the permissions of `read-only`.
+	// `worker` allows workers to get and report work.
+	Scope ApiTokenScopeV1 `json:"scope"`
+
+	// Tasks Names or glob patterns of tasks the token is allowed to schedule runs for. Only set if scope is `scheduler`.
+	Tasks *[]string `json:"tasks,omitempty"`
+}
+
+// CancelRunV1Response defines model for CancelRunV1Response.
+type CancelRunV1Response struct {
+	// Deleted True if the run was pending and has been deleted.
+	Deleted bool `json:"deleted"`
+}
+
+// CreateApiTokenV1Request defines model for CreateApiTokenV1Request.
+type CreateApiTokenV1Request struct {
+	// Name Unique name of the token.
+	Name string `json:"name"`
+
+	// Scope Scope of an API token.
+	// `read-only` allows reading runs, tasks and task results.
+	// `scheduler` allows scheduling runs of the tasks the token lists. Includes the permissions of `read-only`.
+	// `worker` allows workers to get and report work.
+	Scope ApiTokenScopeV1 `json:"scope"`
+
+	// Tasks Names or glob patterns of tasks the token is allowed to schedule runs for.
+	// Required if scope is `scheduler`.
+	Tasks *[]string `json:"tasks,omitempty"`
+}
+
+// CreateApiTokenV1Response defines model for CreateApiTokenV1Response.
+type CreateApiTokenV1Response struct {
+	ApiToken ApiTokenV1 `json:"apiToken"`
+
+	// Secret Secret of the token. Clients set the secret as the value of the HTTP header X-API-KEY.
+	Secret string `json:"secret"`
+}
+
+// DeleteApiTokenV1Response defines model for DeleteApiTokenV1Response.
+type DeleteApiTokenV1Response = map[string]interface{}
+
 // DeleteRunV1Response defines model for DeleteRunV1Response.
 type DeleteRunV1Response = map[string]interface{}
 
@@ -76,6 +164,21 @@ type ErrorDetail struct {
 	Message string `json:"message"`
 }
 
+// GetRepositoryLogV1Response defines model for GetRepositoryLogV1Response.
+type GetRepositoryLogV1Response struct {
+	// CreatedAt Date and time at which the server received the log.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Log Content of the log.
+	Log string `json:"log"`
+
+	// RepositoryName Name of the repository.
+	RepositoryName string `json:"repositoryName"`
+
+	// RunId Numeric identifier of the run.
+	RunId int `json:"runId"`
+}
+
 // GetRunV1Response defines model for GetRunV1Response.
 type GetRunV1Response struct {
 	Run RunV1 `json:"run"`
@@ -87,6 +190,9 @@ type GetTaskV1Response struct {
 	Hash    string         `json:"hash"`
 	Inputs  *[]TaskV1Input `json:"inputs,omitempty"`
 	Name    string         `json:"name"`
+
+	// Rollout Progress of the rollout of a task.
+	Rollout *TaskRolloutV1 `json:"rollout,omitempty"`
 }
 
 // GetWorkV1Response defines model for GetWorkV1Response.
@@ -104,6 +210,24 @@ type GetWorkV1Response struct {
 	Task WorkTaskV1 `json:"task"`
 }
 
+// HeartbeatWorkV1Request defines model for HeartbeatWorkV1Request.
+type HeartbeatWorkV1Request struct {
+	// RunID Internal identifier of the unit of work.
+	RunID int `json:"runID"`
+}
+
+// HeartbeatWorkV1Response defines model for HeartbeatWorkV1Response.
+type HeartbeatWorkV1Response struct {
+	// Cancelled True if a user has cancelled the run. The worker stops the run.
+	Cancelled bool `json:"cancelled"`
+}
+
+// ListApiTokensV1Response defines model for ListApiTokensV1Response.
+type ListApiTokensV1Response struct {
+	ApiTokens []ApiTokenV1 `json:"apiTokens"`
+	Page      Page         `json:"page"`
+}
+
 // ListOptions defines model for ListOptions.
 type ListOptions struct {
 	Limit int `json:"limit"`
@@ -145,6 +269,14 @@ type ListTasksV1ResponseTask struct {
 	Name     string `json:"name"`
 }
 
+// ListWebhookDeliveriesV1Response defines model for ListWebhookDeliveriesV1Response.
+type ListWebhookDeliveriesV1Response struct {
+	Page Page `json:"page"`
+
+	// Result List of webhook deliveries.
+	Result []WebhookDeliveryV1 `json:"result"`
+}
+
 // Page defines model for Page.
 type Page struct {
 	// CurrentPage Number of the current page.
@@ -168,8 +300,17 @@ type Page struct {
 	TotalPages int `json:"totalPages"`
 }
 
+// ReloadTasksV1Response defines model for ReloadTasksV1Response.
+type ReloadTasksV1Response struct {
+	// Results Tasks known to the server after the reload.
+	Results []ListTasksV1ResponseTask `json:"results"`
+}
+
 // ReportWorkV1Request defines model for ReportWorkV1Request.
 type ReportWorkV1Request struct {
+	// Cancelled True if the worker stopped the run early because a user cancelled it.
+	Cancelled *bool `json:"cancelled,omitempty"`
+
 	// Error General that occurred during the run, if any.
 	Error *string `json:"error,omitempty"`
 
@@ -197,6 +338,10 @@ type ReportWorkV1TaskResult struct {
 	// Error Error encountered during the run, if any.
 	Error *string `json:"error,omitempty"`
 
+	// Log Log captured while the worker applied the task to the repository.
+	// The server stores it compressed and deletes it once it exceeds the configured retention.
+	Log *string `json:"log,omitempty"`
+
 	// PullRequestUrl URL of the pull request for humans to view.
 	PullRequestUrl *string `json:"pullRequestUrl,omitempty"`
 
@@ -206,6 +351,9 @@ type ReportWorkV1TaskResult struct {
 	// Result Identifier of the result.
 	Result int `json:"result"`
 
+	// RolloutWave Index of the wave of the rollout of the task that contains the repository. Not set if the task doesn't define a rollout.
+	RolloutWave *int `json:"rolloutWave,omitempty"`
+
 	// State State of the result.
 	// `archived` indicates that the repository of a pull request has been archived.
 	// `closed` indicates that a pull request existed and has been closed.
@@ -215,6 +363,9 @@ type ReportWorkV1TaskResult struct {
 	// `pushed` indicates that changes were pushed to the default branch.
 	// `unknown` is a fallback value for any unexpected status.
 	State TaskResultStateV1 `json:"state"`
+
+	// TargetBranch Branch that the pull request targets. Not set if the task doesn't define target branches.
+	TargetBranch *string `json:"targetBranch,omitempty"`
 }
 
 // RunStatusV1 defines model for RunStatusV1.
@@ -222,9 +373,15 @@ type RunStatusV1 string
 
 // RunV1 defines model for RunV1.
 type RunV1 struct {
-	Error      *string    `json:"error,omitempty"`
-	FinishedAt *time.Time `json:"finishedAt,omitempty"`
-	Id         uint       `json:"id"`
+	// ApiTokenName Name of the API token that scheduled the run, if any.
+	ApiTokenName *string `json:"apiTokenName,omitempty"`
+
+	// CancelRequestedAt Point in time at which a user requested to cancel the run.
+	// The worker stops the run once it sends the next heartbeat.
+	CancelRequestedAt *time.Time `json:"cancelRequestedAt,omitempty"`
+	Error             *string    `json:"error,omitempty"`
+	FinishedAt        *time.Time `json:"finishedAt,omitempty"`
+	Id                uint       `json:"id"`
 
 	// Reason The reason why a run has been scheduled.
 	// The following reasons are deprecated: changed, new, next
@@ -235,6 +392,10 @@ type RunV1 struct {
 	StartedAt     *time.Time         `json:"startedAt,omitempty"`
 	Status        RunStatusV1        `json:"status"`
 	Task          string             `json:"task"`
+
+	// TaskRevision Commit SHA of the git repository of tasks at the time the run started.
+	// Not set if the server reads tasks from local files.
+	TaskRevision *string `json:"taskRevision,omitempty"`
 }
 
 // RunV1Reason The reason why a run has been scheduled.
@@ -301,6 +462,27 @@ type TaskResultV1 struct {
 	// `pushed` indicates that changes were pushed to the default branch.
 	// `unknown` is a fallback value for any unexpected status.
 	Status TaskResultStateV1 `json:"status"`
+
+	// TargetBranch Branch that the pull request targets. Not set if the task doesn't define target branches.
+	TargetBranch *string `json:"targetBranch,omitempty"`
+}
+
+// TaskRolloutV1 Progress of the rollout of a task.
+type TaskRolloutV1 struct {
+	// CurrentWave Index of the last wave that has started.
+	CurrentWave int `json:"currentWave"`
+
+	// Paused If `true`, the rollout doesn't start the next wave.
+	Paused bool `json:"paused"`
+
+	// PausedReason Reason why the rollout has been paused.
+	PausedReason *string `json:"pausedReason,omitempty"`
+
+	// WaveStartedAt Point in time at which the current wave has started.
+	WaveStartedAt time.Time `json:"waveStartedAt"`
+
+	// Waves Names of all waves of the rollout.
+	Waves []string `json:"waves"`
 }
 
 // TaskV1Input defines model for TaskV1Input.
@@ -321,6 +503,60 @@ type TaskV1Input struct {
 	Validation *string `json:"validation,omitempty"`
 }
 
+// UpdateTaskRolloutV1Request defines model for UpdateTaskRolloutV1Request.
+type UpdateTaskRolloutV1Request struct {
+	// Action Action to apply to the rollout.
+	Action UpdateTaskRolloutV1RequestAction `json:"action"`
+}
+
+// UpdateTaskRolloutV1RequestAction Action to apply to the rollout.
+type UpdateTaskRolloutV1RequestAction string
+
+// WebhookDeliveryStatusV1 `pending` - The server hasn't processed the delivery yet or retries processing it.
+// `processed` - The server matched the delivery against all tasks.
+// `failed` - Processing failed too often. The server doesn't retry processing.
+type WebhookDeliveryStatusV1 string
+
+// WebhookDeliveryV1 defines model for WebhookDeliveryV1.
+type WebhookDeliveryV1 struct {
+	// Attempts Number of times the server tried to process the delivery.
+	Attempts int `json:"attempts"`
+
+	// CreatedAt Date and time at which the server received the delivery.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// DeliveryId ID of the delivery assigned by the sender. Generated by the server if the sender didn't send one.
+	DeliveryId string `json:"deliveryId"`
+
+	// Error Error of the last failed attempt to process the delivery.
+	Error *string `json:"error,omitempty"`
+
+	// Event Event of the webhook.
+	Event string `json:"event"`
+	Id    uint   `json:"id"`
+
+	// MatchedTasks Names of the tasks for which the delivery scheduled a run.
+	MatchedTasks []string `json:"matchedTasks"`
+
+	// NextAttemptAt Date and time after which the server retries to process the delivery. Only set if status is `pending`.
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty"`
+
+	// ProcessedAt Date and time at which the server processed the delivery.
+	ProcessedAt *time.Time `json:"processedAt,omitempty"`
+
+	// Source Name of the source of a generic webhook.
+	Source *string `json:"source,omitempty"`
+
+	// Status `pending` - The server hasn't processed the delivery yet or retries processing it.
+	// `processed` - The server matched the delivery against all tasks.
+	// `failed` - Processing failed too often. The server doesn't retry processing.
+	Status WebhookDeliveryStatusV1 `json:"status"`
+	Type   WebhookDeliveryV1Type   `json:"type"`
+}
+
+// WebhookDeliveryV1Type defines model for WebhookDeliveryV1.Type.
+type WebhookDeliveryV1Type string
+
 // WorkTaskV1 The task to execute.
 type WorkTaskV1 struct {
 	// Hash Hash of the task. Used to detect if server and worker are out of sync.
@@ -328,11 +564,23 @@ type WorkTaskV1 struct {
 
 	// Name Name of the task to execute.
 	Name string `json:"name"`
+
+	// Revision Commit SHA of the git repository of tasks that contains the task.
+	// Not set if the server reads tasks from local files.
+	Revision *string `json:"revision,omitempty"`
 }
 
+// Forbidden defines model for Forbidden.
+type Forbidden = Error
+
 // Unauthorized defines model for Unauthorized.
 type Unauthorized = Error
 
+// ListApiTokensV1Params defines parameters for ListApiTokensV1.
+type ListApiTokensV1Params struct {
+	ListOptions *ListOptions `form:"listOptions,omitempty" json:"listOptions,omitempty"`
+}
+
 // ListRunsV1Params defines parameters for ListRunsV1.
 type ListRunsV1Params struct {
 	// Task Name of the task to filter by.
@@ -341,6 +589,12 @@ type ListRunsV1Params struct {
 	Status      *[]RunStatusV1 `form:"status,omitempty" json:"status,omitempty"`
 }
 
+// GetRepositoryLogV1Params defines parameters for GetRepositoryLogV1.
+type GetRepositoryLogV1Params struct {
+	// RepositoryName Name of the repository.
+	RepositoryName string `form:"repositoryName" json:"repositoryName"`
+}
+
 // ListTaskResultsV1Params defines parameters for ListTaskResultsV1.
 type ListTaskResultsV1Params struct {
 	// RepositoryName Name of a repository to filter by.
@@ -364,9 +618,24 @@ type ListTaskRecentTaskResultsV1Params struct {
 	ListOptions *ListOptions         `form:"listOptions,omitempty" json:"listOptions,omitempty"`
 }
 
+// ListWebhookDeliveriesV1Params defines parameters for ListWebhookDeliveriesV1.
+type ListWebhookDeliveriesV1Params struct {
+	ListOptions *ListOptions               `form:"listOptions,omitempty" json:"listOptions,omitempty"`
+	Status      *[]WebhookDeliveryStatusV1 `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// CreateApiTokenV1JSONRequestBody defines body for CreateApiTokenV1 for application/json ContentType.
+type CreateApiTokenV1JSONRequestBody = CreateApiTokenV1Request
+
 // ScheduleRunV1JSONRequestBody defines body for ScheduleRunV1 for application/json ContentType.
 type ScheduleRunV1JSONRequestBody = ScheduleRunV1Request
 
+// UpdateTaskRolloutV1JSONRequestBody defines body for UpdateTaskRolloutV1 for application/json ContentType.
+type UpdateTaskRolloutV1JSONRequestBody = UpdateTaskRolloutV1Request
+
+// HeartbeatWorkV1JSONRequestBody defines body for HeartbeatWorkV1 for application/json ContentType.
+type HeartbeatWorkV1JSONRequestBody = HeartbeatWorkV1Request
+
 // ReportWorkV1JSONRequestBody defines body for ReportWorkV1 for application/json ContentType.
 type ReportWorkV1JSONRequestBody = ReportWorkV1Request
 
@@ -443,6 +712,17 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// ListApiTokensV1 request
+	ListApiTokensV1(ctx context.Context, params *ListApiTokensV1Params, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateApiTokenV1WithBody request with any body
+	CreateApiTokenV1WithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateApiTokenV1(ctx context.Context, body CreateApiTokenV1JSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteApiTokenV1 request
+	DeleteApiTokenV1(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// ListRunsV1 request
 	ListRunsV1(ctx context.Context, params *ListRunsV1Params, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -457,18 +737,40 @@ type ClientInterface interface {
 	// GetRunV1 request
 	GetRunV1(ctx context.Context, runId int, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// CancelRunV1 request
+	CancelRunV1(ctx context.Context, runId int, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRepositoryLogV1 request
+	GetRepositoryLogV1(ctx context.Context, runId int, params *GetRepositoryLogV1Params, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// ListTaskResultsV1 request
 	ListTaskResultsV1(ctx context.Context, params *ListTaskResultsV1Params, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// ListTasksV1 request
 	ListTasksV1(ctx context.Context, params *ListTasksV1Params, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// ReloadTasksV1 request
+	ReloadTasksV1(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetTaskV1 request
 	GetTaskV1(ctx context.Context, task string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	// ListTaskRecentTaskResultsV1 request
 	ListTaskRecentTaskResultsV1(ctx context.Context, task string, params *ListTaskRecentTaskResultsV1Params, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// UpdateTaskRolloutV1WithBody request with any body
+	UpdateTaskRolloutV1WithBody(ctx context.Context, task string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	UpdateTaskRolloutV1(ctx context.Context, task string, body UpdateTaskRolloutV1JSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListWebhookDeliveriesV1 request
+	ListWebhookDeliveriesV1(ctx context.Context, params *ListWebhookDeliveriesV1Params, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// HeartbeatWorkV1WithBody request with any body
+	HeartbeatWorkV1WithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	HeartbeatWorkV1(ctx context.Context, body HeartbeatWorkV1JSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetWorkV1 request
 	GetWorkV1(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -478,6 +780,54 @@ type ClientInterface interface {
 	ReportWorkV1(ctx context.Context, body ReportWorkV1JSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
 }
 
+func (c *Client) ListApiTokensV1(ctx context.Context, params *ListApiTokensV1Params, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListApiTokensV1Request(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateApiTokenV1WithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateApiTokenV1RequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateApiTokenV1(ctx context.Context, body CreateApiTokenV1JSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateApiTokenV1Request(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteApiTokenV1(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteApiTokenV1Request(c.Server, name)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) ListRunsV1(ctx context.Context, params *ListRunsV1Params, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewListRunsV1Request(c.Server, params)
 	if err != nil {
@@ -538,6 +888,30 @@ func (c *Client) GetRunV1(ctx context.Context, runId int, reqEditors ...RequestE
 	return c.Client.Do(req)
 }
 
+func (c *Client) CancelRunV1(ctx context.Context, runId int, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCancelRunV1Request(c.Server, runId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetRepositoryLogV1(ctx context.Context, runId int, params *GetRepositoryLogV1Params, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRepositoryLogV1Request(c.Server, runId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) ListTaskResultsV1(ctx context.Context, params *ListTaskResultsV1Params, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewListTaskResultsV1Request(c.Server, params)
 	if err != nil {
@@ -562,6 +936,18 @@ func (c *Client) ListTasksV1(ctx context.Context, params *ListTasksV1Params, req
 	return c.Client.Do(req)
 }
 
+func (c *Client) ReloadTasksV1(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReloadTasksV1Request(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetTaskV1(ctx context.Context, task string, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetTaskV1Request(c.Server, task)
 	if err != nil {
@@ -586,6 +972,66 @@ func (c *Client) ListTaskRecentTaskResultsV1(ctx context.Context, task string, p
 	return c.Client.Do(req)
 }
 
+func (c *Client) UpdateTaskRolloutV1WithBody(ctx context.Context, task string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateTaskRolloutV1RequestWithBody(c.Server, task, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UpdateTaskRolloutV1(ctx context.Context, task string, body UpdateTaskRolloutV1JSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUpdateTaskRolloutV1Request(c.Server, task, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListWebhookDeliveriesV1(ctx context.Context, params *ListWebhookDeliveriesV1Params, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListWebhookDeliveriesV1Request(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) HeartbeatWorkV1WithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewHeartbeatWorkV1RequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) HeartbeatWorkV1(ctx context.Context, body HeartbeatWorkV1JSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewHeartbeatWorkV1Request(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetWorkV1(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetWorkV1Request(c.Server)
 	if err != nil {
@@ -622,8 +1068,8 @@ func (c *Client) ReportWorkV1(ctx context.Context, body ReportWorkV1JSONRequestB
 	return c.Client.Do(req)
 }
 
-// NewListRunsV1Request generates requests for ListRunsV1
-func NewListRunsV1Request(server string, params *ListRunsV1Params) (*http.Request, error) {
+// NewListApiTokensV1Request generates requests for ListApiTokensV1
+func NewListApiTokensV1Request(server string, params *ListApiTokensV1Params) (*http.Request, error) {
 	var err error
 
 	serverURL, err := url.Parse(server)
@@ -631,7 +1077,7 @@ func NewListRunsV1Request(server string, params *ListRunsV1Params) (*http.Reques
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/api/v1/runs")
+	operationPath := fmt.Sprintf("/api/v1/apiTokens")
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -644,22 +1090,6 @@ func NewListRunsV1Request(server string, params *ListRunsV1Params) (*http.Reques
 	if params != nil {
 		queryValues := queryURL.Query()
 
-		if params.Task != nil {
-
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "task", runtime.ParamLocationQuery, *params.Task); err != nil {
-				return nil, err
-			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-				return nil, err
-			} else {
-				for k, v := range parsed {
-					for _, v2 := range v {
-						queryValues.Add(k, v2)
-					}
-				}
-			}
-
-		}
-
 		if params.ListOptions != nil {
 
 			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "listOptions", runtime.ParamLocationQuery, *params.ListOptions); err != nil {
@@ -676,13 +1106,152 @@ func NewListRunsV1Request(server string, params *ListRunsV1Params) (*http.Reques
 
 		}
 
-		if params.Status != nil {
+		queryURL.RawQuery = queryValues.Encode()
+	}
 
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "status", runtime.ParamLocationQuery, *params.Status); err != nil {
-				return nil, err
-			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
-				return nil, err
-			} else {
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateApiTokenV1Request calls the generic CreateApiTokenV1 builder with application/json body
+func NewCreateApiTokenV1Request(server string, body CreateApiTokenV1JSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateApiTokenV1RequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateApiTokenV1RequestWithBody generates requests for CreateApiTokenV1 with any type of body
+func NewCreateApiTokenV1RequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/apiTokens")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewDeleteApiTokenV1Request generates requests for DeleteApiTokenV1
+func NewDeleteApiTokenV1Request(server string, name string) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "name", runtime.ParamLocationPath, name)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/apiTokens/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListRunsV1Request generates requests for ListRunsV1
+func NewListRunsV1Request(server string, params *ListRunsV1Params) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/runs")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Task != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "task", runtime.ParamLocationQuery, *params.Task); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.ListOptions != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "listOptions", runtime.ParamLocationQuery, *params.ListOptions); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Status != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "status", runtime.ParamLocationQuery, *params.Status); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
 				for k, v := range parsed {
 					for _, v2 := range v {
 						queryValues.Add(k, v2)
@@ -811,6 +1380,92 @@ func NewGetRunV1Request(server string, runId int) (*http.Request, error) {
 	return req, nil
 }
 
+// NewCancelRunV1Request generates requests for CancelRunV1
+func NewCancelRunV1Request(server string, runId int) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "runId", runtime.ParamLocationPath, runId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/runs/%s/cancel", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetRepositoryLogV1Request generates requests for GetRepositoryLogV1
+func NewGetRepositoryLogV1Request(server string, runId int, params *GetRepositoryLogV1Params) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "runId", runtime.ParamLocationPath, runId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/runs/%s/logs", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "repositoryName", runtime.ParamLocationQuery, params.RepositoryName); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewListTaskResultsV1Request generates requests for ListTaskResultsV1
 func NewListTaskResultsV1Request(server string, params *ListTaskResultsV1Params) (*http.Request, error) {
 	var err error
@@ -973,6 +1628,33 @@ func NewListTasksV1Request(server string, params *ListTasksV1Params) (*http.Requ
 	return req, nil
 }
 
+// NewReloadTasksV1Request generates requests for ReloadTasksV1
+func NewReloadTasksV1Request(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/tasks/reload")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewGetTaskV1Request generates requests for GetTaskV1
 func NewGetTaskV1Request(server string, task string) (*http.Request, error) {
 	var err error
@@ -1079,16 +1761,34 @@ func NewListTaskRecentTaskResultsV1Request(server string, task string, params *L
 	return req, nil
 }
 
-// NewGetWorkV1Request generates requests for GetWorkV1
-func NewGetWorkV1Request(server string) (*http.Request, error) {
+// NewUpdateTaskRolloutV1Request calls the generic UpdateTaskRolloutV1 builder with application/json body
+func NewUpdateTaskRolloutV1Request(server string, task string, body UpdateTaskRolloutV1JSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewUpdateTaskRolloutV1RequestWithBody(server, task, "application/json", bodyReader)
+}
+
+// NewUpdateTaskRolloutV1RequestWithBody generates requests for UpdateTaskRolloutV1 with any type of body
+func NewUpdateTaskRolloutV1RequestWithBody(server string, task string, contentType string, body io.Reader) (*http.Request, error) {
 	var err error
 
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "task", runtime.ParamLocationPath, task)
+	if err != nil {
+		return nil, err
+	}
+
 	serverURL, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
 
-	operationPath := fmt.Sprintf("/api/v1/worker/work")
+	operationPath := fmt.Sprintf("/api/v1/tasks/%s/rollout", pathParam0)
 	if operationPath[0] == '/' {
 		operationPath = "." + operationPath
 	}
@@ -1098,19 +1798,153 @@ func NewGetWorkV1Request(server string) (*http.Request, error) {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	req, err := http.NewRequest("POST", queryURL.String(), body)
 	if err != nil {
 		return nil, err
 	}
 
+	req.Header.Add("Content-Type", contentType)
+
 	return req, nil
 }
 
-// NewReportWorkV1Request calls the generic ReportWorkV1 builder with application/json body
-func NewReportWorkV1Request(server string, body ReportWorkV1JSONRequestBody) (*http.Request, error) {
-	var bodyReader io.Reader
-	buf, err := json.Marshal(body)
-	if err != nil {
+// NewListWebhookDeliveriesV1Request generates requests for ListWebhookDeliveriesV1
+func NewListWebhookDeliveriesV1Request(server string, params *ListWebhookDeliveriesV1Params) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/webhookDeliveries")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.ListOptions != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "listOptions", runtime.ParamLocationQuery, *params.ListOptions); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Status != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "status", runtime.ParamLocationQuery, *params.Status); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewHeartbeatWorkV1Request calls the generic HeartbeatWorkV1 builder with application/json body
+func NewHeartbeatWorkV1Request(server string, body HeartbeatWorkV1JSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewHeartbeatWorkV1RequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewHeartbeatWorkV1RequestWithBody generates requests for HeartbeatWorkV1 with any type of body
+func NewHeartbeatWorkV1RequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/worker/heartbeat")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetWorkV1Request generates requests for GetWorkV1
+func NewGetWorkV1Request(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/api/v1/worker/work")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewReportWorkV1Request calls the generic ReportWorkV1 builder with application/json body
+func NewReportWorkV1Request(server string, body ReportWorkV1JSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
 		return nil, err
 	}
 	bodyReader = bytes.NewReader(buf)
@@ -1189,6 +2023,17 @@ func WithBaseURL(baseURL string) ClientOption {
 
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
+	// ListApiTokensV1WithResponse request
+	ListApiTokensV1WithResponse(ctx context.Context, params *ListApiTokensV1Params, reqEditors ...RequestEditorFn) (*ListApiTokensV1ResponseBody, error)
+
+	// CreateApiTokenV1WithBodyWithResponse request with any body
+	CreateApiTokenV1WithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateApiTokenV1ResponseBody, error)
+
+	CreateApiTokenV1WithResponse(ctx context.Context, body CreateApiTokenV1JSONRequestBody, reqEditors ...RequestEditorFn) (*CreateApiTokenV1ResponseBody, error)
+
+	// DeleteApiTokenV1WithResponse request
+	DeleteApiTokenV1WithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*DeleteApiTokenV1ResponseBody, error)
+
 	// ListRunsV1WithResponse request
 	ListRunsV1WithResponse(ctx context.Context, params *ListRunsV1Params, reqEditors ...RequestEditorFn) (*ListRunsV1ResponseBody, error)
 
@@ -1203,18 +2048,40 @@ type ClientWithResponsesInterface interface {
 	// GetRunV1WithResponse request
 	GetRunV1WithResponse(ctx context.Context, runId int, reqEditors ...RequestEditorFn) (*GetRunV1ResponseBody, error)
 
+	// CancelRunV1WithResponse request
+	CancelRunV1WithResponse(ctx context.Context, runId int, reqEditors ...RequestEditorFn) (*CancelRunV1ResponseBody, error)
+
+	// GetRepositoryLogV1WithResponse request
+	GetRepositoryLogV1WithResponse(ctx context.Context, runId int, params *GetRepositoryLogV1Params, reqEditors ...RequestEditorFn) (*GetRepositoryLogV1ResponseBody, error)
+
 	// ListTaskResultsV1WithResponse request
 	ListTaskResultsV1WithResponse(ctx context.Context, params *ListTaskResultsV1Params, reqEditors ...RequestEditorFn) (*ListTaskResultsV1ResponseBody, error)
 
 	// ListTasksV1WithResponse request
 	ListTasksV1WithResponse(ctx context.Context, params *ListTasksV1Params, reqEditors ...RequestEditorFn) (*ListTasksV1ResponseBody, error)
 
+	// ReloadTasksV1WithResponse request
+	ReloadTasksV1WithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ReloadTasksV1ResponseBody, error)
+
 	// GetTaskV1WithResponse request
 	GetTaskV1WithResponse(ctx context.Context, task string, reqEditors ...RequestEditorFn) (*GetTaskV1ResponseBody, error)
 
 	// ListTaskRecentTaskResultsV1WithResponse request
 	ListTaskRecentTaskResultsV1WithResponse(ctx context.Context, task string, params *ListTaskRecentTaskResultsV1Params, reqEditors ...RequestEditorFn) (*ListTaskRecentTaskResultsV1ResponseBody, error)
 
+	// UpdateTaskRolloutV1WithBodyWithResponse request with any body
+	UpdateTaskRolloutV1WithBodyWithResponse(ctx context.Context, task string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateTaskRolloutV1ResponseBody, error)
+
+	UpdateTaskRolloutV1WithResponse(ctx context.Context, task string, body UpdateTaskRolloutV1JSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateTaskRolloutV1ResponseBody, error)
+
+	// ListWebhookDeliveriesV1WithResponse request
+	ListWebhookDeliveriesV1WithResponse(ctx context.Context, params *ListWebhookDeliveriesV1Params, reqEditors ...RequestEditorFn) (*ListWebhookDeliveriesV1ResponseBody, error)
+
+	// HeartbeatWorkV1WithBodyWithResponse request with any body
+	HeartbeatWorkV1WithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*HeartbeatWorkV1ResponseBody, error)
+
+	HeartbeatWorkV1WithResponse(ctx context.Context, body HeartbeatWorkV1JSONRequestBody, reqEditors ...RequestEditorFn) (*HeartbeatWorkV1ResponseBody, error)
+
 	// GetWorkV1WithResponse request
 	GetWorkV1WithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetWorkV1ResponseBody, error)
 
@@ -1224,11 +2091,86 @@ type ClientWithResponsesInterface interface {
 	ReportWorkV1WithResponse(ctx context.Context, body ReportWorkV1JSONRequestBody, reqEditors ...RequestEditorFn) (*ReportWorkV1ResponseBody, error)
 }
 
+type ListApiTokensV1ResponseBody struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ListApiTokensV1Response
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+}
+
+// Status returns HTTPResponse.Status
+func (r ListApiTokensV1ResponseBody) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListApiTokensV1ResponseBody) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateApiTokenV1ResponseBody struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *CreateApiTokenV1Response
+	JSON400      *Error
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateApiTokenV1ResponseBody) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateApiTokenV1ResponseBody) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteApiTokenV1ResponseBody struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *DeleteApiTokenV1Response
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteApiTokenV1ResponseBody) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteApiTokenV1ResponseBody) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type ListRunsV1ResponseBody struct {
 	Body         []byte
 	HTTPResponse *http.Response
 	JSON200      *ListRunsV1Response
 	JSON401      *Unauthorized
+	JSON403      *Forbidden
 }
 
 // Status returns HTTPResponse.Status
@@ -1253,6 +2195,7 @@ type ScheduleRunV1ResponseBody struct {
 	JSON200      *ScheduleRunV1Response
 	JSON400      *Error
 	JSON401      *Unauthorized
+	JSON403      *Forbidden
 }
 
 // Status returns HTTPResponse.Status
@@ -1277,6 +2220,7 @@ type DeleteRunV1ResponseBody struct {
 	JSON200      *DeleteRunV1Response
 	JSON400      *Error
 	JSON401      *Unauthorized
+	JSON403      *Forbidden
 	JSON404      *Error
 }
 
@@ -1301,6 +2245,7 @@ type GetRunV1ResponseBody struct {
 	HTTPResponse *http.Response
 	JSON200      *GetRunV1Response
 	JSON401      *Unauthorized
+	JSON403      *Forbidden
 	JSON404      *Error
 }
 
@@ -1320,15 +2265,18 @@ func (r GetRunV1ResponseBody) StatusCode() int {
 	return 0
 }
 
-type ListTaskResultsV1ResponseBody struct {
+type CancelRunV1ResponseBody struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *ListTaskResultsV1Response
+	JSON200      *CancelRunV1Response
+	JSON400      *Error
 	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *Error
 }
 
 // Status returns HTTPResponse.Status
-func (r ListTaskResultsV1ResponseBody) Status() string {
+func (r CancelRunV1ResponseBody) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1336,22 +2284,24 @@ func (r ListTaskResultsV1ResponseBody) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r ListTaskResultsV1ResponseBody) StatusCode() int {
+func (r CancelRunV1ResponseBody) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type ListTasksV1ResponseBody struct {
+type GetRepositoryLogV1ResponseBody struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *ListTasksV1Response
+	JSON200      *GetRepositoryLogV1Response
 	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *Error
 }
 
 // Status returns HTTPResponse.Status
-func (r ListTasksV1ResponseBody) Status() string {
+func (r GetRepositoryLogV1ResponseBody) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1359,24 +2309,23 @@ func (r ListTasksV1ResponseBody) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r ListTasksV1ResponseBody) StatusCode() int {
+func (r GetRepositoryLogV1ResponseBody) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetTaskV1ResponseBody struct {
+type ListTaskResultsV1ResponseBody struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *GetTaskV1Response
+	JSON200      *ListTaskResultsV1Response
 	JSON401      *Unauthorized
-	JSON404      *Error
-	JSON500      *Error
+	JSON403      *Forbidden
 }
 
 // Status returns HTTPResponse.Status
-func (r GetTaskV1ResponseBody) Status() string {
+func (r ListTaskResultsV1ResponseBody) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1384,24 +2333,23 @@ func (r GetTaskV1ResponseBody) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetTaskV1ResponseBody) StatusCode() int {
+func (r ListTaskResultsV1ResponseBody) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type ListTaskRecentTaskResultsV1ResponseBody struct {
+type ListTasksV1ResponseBody struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *ListTaskRecentTaskResultsV1Response
+	JSON200      *ListTasksV1Response
 	JSON401      *Unauthorized
-	JSON404      *Error
-	JSON500      *Error
+	JSON403      *Forbidden
 }
 
 // Status returns HTTPResponse.Status
-func (r ListTaskRecentTaskResultsV1ResponseBody) Status() string {
+func (r ListTasksV1ResponseBody) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1409,22 +2357,24 @@ func (r ListTaskRecentTaskResultsV1ResponseBody) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r ListTaskRecentTaskResultsV1ResponseBody) StatusCode() int {
+func (r ListTasksV1ResponseBody) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type GetWorkV1ResponseBody struct {
+type ReloadTasksV1ResponseBody struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON200      *GetWorkV1Response
+	JSON200      *ReloadTasksV1Response
+	JSON400      *Error
 	JSON401      *Unauthorized
+	JSON403      *Forbidden
 }
 
 // Status returns HTTPResponse.Status
-func (r GetWorkV1ResponseBody) Status() string {
+func (r ReloadTasksV1ResponseBody) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1432,22 +2382,25 @@ func (r GetWorkV1ResponseBody) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r GetWorkV1ResponseBody) StatusCode() int {
+func (r ReloadTasksV1ResponseBody) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-type ReportWorkV1ResponseBody struct {
+type GetTaskV1ResponseBody struct {
 	Body         []byte
 	HTTPResponse *http.Response
-	JSON201      *ReportWorkV1Response
+	JSON200      *GetTaskV1Response
 	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *Error
+	JSON500      *Error
 }
 
 // Status returns HTTPResponse.Status
-func (r ReportWorkV1ResponseBody) Status() string {
+func (r GetTaskV1ResponseBody) Status() string {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.Status
 	}
@@ -1455,24 +2408,208 @@ func (r ReportWorkV1ResponseBody) Status() string {
 }
 
 // StatusCode returns HTTPResponse.StatusCode
-func (r ReportWorkV1ResponseBody) StatusCode() int {
+func (r GetTaskV1ResponseBody) StatusCode() int {
 	if r.HTTPResponse != nil {
 		return r.HTTPResponse.StatusCode
 	}
 	return 0
 }
 
-// ListRunsV1WithResponse request returning *ListRunsV1ResponseBody
-func (c *ClientWithResponses) ListRunsV1WithResponse(ctx context.Context, params *ListRunsV1Params, reqEditors ...RequestEditorFn) (*ListRunsV1ResponseBody, error) {
-	rsp, err := c.ListRunsV1(ctx, params, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParseListRunsV1ResponseBody(rsp)
-}
-
-// ScheduleRunV1WithBodyWithResponse request with arbitrary body returning *ScheduleRunV1ResponseBody
-func (c *ClientWithResponses) ScheduleRunV1WithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ScheduleRunV1ResponseBody, error) {
+type ListTaskRecentTaskResultsV1ResponseBody struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ListTaskRecentTaskResultsV1Response
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *Error
+	JSON500      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ListTaskRecentTaskResultsV1ResponseBody) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListTaskRecentTaskResultsV1ResponseBody) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UpdateTaskRolloutV1ResponseBody struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TaskRolloutV1
+	JSON400      *Error
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r UpdateTaskRolloutV1ResponseBody) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UpdateTaskRolloutV1ResponseBody) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListWebhookDeliveriesV1ResponseBody struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ListWebhookDeliveriesV1Response
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+}
+
+// Status returns HTTPResponse.Status
+func (r ListWebhookDeliveriesV1ResponseBody) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListWebhookDeliveriesV1ResponseBody) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type HeartbeatWorkV1ResponseBody struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *HeartbeatWorkV1Response
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+	JSON404      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r HeartbeatWorkV1ResponseBody) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r HeartbeatWorkV1ResponseBody) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetWorkV1ResponseBody struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *GetWorkV1Response
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+}
+
+// Status returns HTTPResponse.Status
+func (r GetWorkV1ResponseBody) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetWorkV1ResponseBody) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReportWorkV1ResponseBody struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *ReportWorkV1Response
+	JSON401      *Unauthorized
+	JSON403      *Forbidden
+}
+
+// Status returns HTTPResponse.Status
+func (r ReportWorkV1ResponseBody) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReportWorkV1ResponseBody) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// ListApiTokensV1WithResponse request returning *ListApiTokensV1ResponseBody
+func (c *ClientWithResponses) ListApiTokensV1WithResponse(ctx context.Context, params *ListApiTokensV1Params, reqEditors ...RequestEditorFn) (*ListApiTokensV1ResponseBody, error) {
+	rsp, err := c.ListApiTokensV1(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListApiTokensV1ResponseBody(rsp)
+}
+
+// CreateApiTokenV1WithBodyWithResponse request with arbitrary body returning *CreateApiTokenV1ResponseBody
+func (c *ClientWithResponses) CreateApiTokenV1WithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateApiTokenV1ResponseBody, error) {
+	rsp, err := c.CreateApiTokenV1WithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateApiTokenV1ResponseBody(rsp)
+}
+
+func (c *ClientWithResponses) CreateApiTokenV1WithResponse(ctx context.Context, body CreateApiTokenV1JSONRequestBody, reqEditors ...RequestEditorFn) (*CreateApiTokenV1ResponseBody, error) {
+	rsp, err := c.CreateApiTokenV1(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateApiTokenV1ResponseBody(rsp)
+}
+
+// DeleteApiTokenV1WithResponse request returning *DeleteApiTokenV1ResponseBody
+func (c *ClientWithResponses) DeleteApiTokenV1WithResponse(ctx context.Context, name string, reqEditors ...RequestEditorFn) (*DeleteApiTokenV1ResponseBody, error) {
+	rsp, err := c.DeleteApiTokenV1(ctx, name, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteApiTokenV1ResponseBody(rsp)
+}
+
+// ListRunsV1WithResponse request returning *ListRunsV1ResponseBody
+func (c *ClientWithResponses) ListRunsV1WithResponse(ctx context.Context, params *ListRunsV1Params, reqEditors ...RequestEditorFn) (*ListRunsV1ResponseBody, error) {
+	rsp, err := c.ListRunsV1(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListRunsV1ResponseBody(rsp)
+}
+
+// ScheduleRunV1WithBodyWithResponse request with arbitrary body returning *ScheduleRunV1ResponseBody
+func (c *ClientWithResponses) ScheduleRunV1WithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ScheduleRunV1ResponseBody, error) {
 	rsp, err := c.ScheduleRunV1WithBody(ctx, contentType, body, reqEditors...)
 	if err != nil {
 		return nil, err
@@ -1506,6 +2643,24 @@ func (c *ClientWithResponses) GetRunV1WithResponse(ctx context.Context, runId in
 	return ParseGetRunV1ResponseBody(rsp)
 }
 
+// CancelRunV1WithResponse request returning *CancelRunV1ResponseBody
+func (c *ClientWithResponses) CancelRunV1WithResponse(ctx context.Context, runId int, reqEditors ...RequestEditorFn) (*CancelRunV1ResponseBody, error) {
+	rsp, err := c.CancelRunV1(ctx, runId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCancelRunV1ResponseBody(rsp)
+}
+
+// GetRepositoryLogV1WithResponse request returning *GetRepositoryLogV1ResponseBody
+func (c *ClientWithResponses) GetRepositoryLogV1WithResponse(ctx context.Context, runId int, params *GetRepositoryLogV1Params, reqEditors ...RequestEditorFn) (*GetRepositoryLogV1ResponseBody, error) {
+	rsp, err := c.GetRepositoryLogV1(ctx, runId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRepositoryLogV1ResponseBody(rsp)
+}
+
 // ListTaskResultsV1WithResponse request returning *ListTaskResultsV1ResponseBody
 func (c *ClientWithResponses) ListTaskResultsV1WithResponse(ctx context.Context, params *ListTaskResultsV1Params, reqEditors ...RequestEditorFn) (*ListTaskResultsV1ResponseBody, error) {
 	rsp, err := c.ListTaskResultsV1(ctx, params, reqEditors...)
@@ -1524,6 +2679,15 @@ func (c *ClientWithResponses) ListTasksV1WithResponse(ctx context.Context, param
 	return ParseListTasksV1ResponseBody(rsp)
 }
 
+// ReloadTasksV1WithResponse request returning *ReloadTasksV1ResponseBody
+func (c *ClientWithResponses) ReloadTasksV1WithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ReloadTasksV1ResponseBody, error) {
+	rsp, err := c.ReloadTasksV1(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReloadTasksV1ResponseBody(rsp)
+}
+
 // GetTaskV1WithResponse request returning *GetTaskV1ResponseBody
 func (c *ClientWithResponses) GetTaskV1WithResponse(ctx context.Context, task string, reqEditors ...RequestEditorFn) (*GetTaskV1ResponseBody, error) {
 	rsp, err := c.GetTaskV1(ctx, task, reqEditors...)
@@ -1542,6 +2706,49 @@ func (c *ClientWithResponses) ListTaskRecentTaskResultsV1WithResponse(ctx contex
 	return ParseListTaskRecentTaskResultsV1ResponseBody(rsp)
 }
 
+// UpdateTaskRolloutV1WithBodyWithResponse request with arbitrary body returning *UpdateTaskRolloutV1ResponseBody
+func (c *ClientWithResponses) UpdateTaskRolloutV1WithBodyWithResponse(ctx context.Context, task string, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*UpdateTaskRolloutV1ResponseBody, error) {
+	rsp, err := c.UpdateTaskRolloutV1WithBody(ctx, task, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateTaskRolloutV1ResponseBody(rsp)
+}
+
+func (c *ClientWithResponses) UpdateTaskRolloutV1WithResponse(ctx context.Context, task string, body UpdateTaskRolloutV1JSONRequestBody, reqEditors ...RequestEditorFn) (*UpdateTaskRolloutV1ResponseBody, error) {
+	rsp, err := c.UpdateTaskRolloutV1(ctx, task, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUpdateTaskRolloutV1ResponseBody(rsp)
+}
+
+// ListWebhookDeliveriesV1WithResponse request returning *ListWebhookDeliveriesV1ResponseBody
+func (c *ClientWithResponses) ListWebhookDeliveriesV1WithResponse(ctx context.Context, params *ListWebhookDeliveriesV1Params, reqEditors ...RequestEditorFn) (*ListWebhookDeliveriesV1ResponseBody, error) {
+	rsp, err := c.ListWebhookDeliveriesV1(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListWebhookDeliveriesV1ResponseBody(rsp)
+}
+
+// HeartbeatWorkV1WithBodyWithResponse request with arbitrary body returning *HeartbeatWorkV1ResponseBody
+func (c *ClientWithResponses) HeartbeatWorkV1WithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*HeartbeatWorkV1ResponseBody, error) {
+	rsp, err := c.HeartbeatWorkV1WithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseHeartbeatWorkV1ResponseBody(rsp)
+}
+
+func (c *ClientWithResponses) HeartbeatWorkV1WithResponse(ctx context.Context, body HeartbeatWorkV1JSONRequestBody, reqEditors ...RequestEditorFn) (*HeartbeatWorkV1ResponseBody, error) {
+	rsp, err := c.HeartbeatWorkV1(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseHeartbeatWorkV1ResponseBody(rsp)
+}
+
 // GetWorkV1WithResponse request returning *GetWorkV1ResponseBody
 func (c *ClientWithResponses) GetWorkV1WithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetWorkV1ResponseBody, error) {
 	rsp, err := c.GetWorkV1(ctx, reqEditors...)
@@ -1565,30 +2772,258 @@ func (c *ClientWithResponses) ReportWorkV1WithResponse(ctx context.Context, body
 	if err != nil {
 		return nil, err
 	}
-	return ParseReportWorkV1ResponseBody(rsp)
+	return ParseReportWorkV1ResponseBody(rsp)
+}
+
+// ParseListApiTokensV1ResponseBody parses an HTTP response from a ListApiTokensV1WithResponse call
+func ParseListApiTokensV1ResponseBody(rsp *http.Response) (*ListApiTokensV1ResponseBody, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListApiTokensV1ResponseBody{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ListApiTokensV1Response
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateApiTokenV1ResponseBody parses an HTTP response from a CreateApiTokenV1WithResponse call
+func ParseCreateApiTokenV1ResponseBody(rsp *http.Response) (*CreateApiTokenV1ResponseBody, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateApiTokenV1ResponseBody{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest CreateApiTokenV1Response
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteApiTokenV1ResponseBody parses an HTTP response from a DeleteApiTokenV1WithResponse call
+func ParseDeleteApiTokenV1ResponseBody(rsp *http.Response) (*DeleteApiTokenV1ResponseBody, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteApiTokenV1ResponseBody{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest DeleteApiTokenV1Response
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListRunsV1ResponseBody parses an HTTP response from a ListRunsV1WithResponse call
+func ParseListRunsV1ResponseBody(rsp *http.Response) (*ListRunsV1ResponseBody, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListRunsV1ResponseBody{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ListRunsV1Response
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseScheduleRunV1ResponseBody parses an HTTP response from a ScheduleRunV1WithResponse call
+func ParseScheduleRunV1ResponseBody(rsp *http.Response) (*ScheduleRunV1ResponseBody, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ScheduleRunV1ResponseBody{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ScheduleRunV1Response
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
 }
 
-// ParseListRunsV1ResponseBody parses an HTTP response from a ListRunsV1WithResponse call
-func ParseListRunsV1ResponseBody(rsp *http.Response) (*ListRunsV1ResponseBody, error) {
+// ParseDeleteRunV1ResponseBody parses an HTTP response from a DeleteRunV1WithResponse call
+func ParseDeleteRunV1ResponseBody(rsp *http.Response) (*DeleteRunV1ResponseBody, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ListRunsV1ResponseBody{
+	response := &DeleteRunV1ResponseBody{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest ListRunsV1Response
+		var dest DeleteRunV1Response
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
 		var dest Unauthorized
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1596,67 +3031,88 @@ func ParseListRunsV1ResponseBody(rsp *http.Response) (*ListRunsV1ResponseBody, e
 		}
 		response.JSON401 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
 	}
 
 	return response, nil
 }
 
-// ParseScheduleRunV1ResponseBody parses an HTTP response from a ScheduleRunV1WithResponse call
-func ParseScheduleRunV1ResponseBody(rsp *http.Response) (*ScheduleRunV1ResponseBody, error) {
+// ParseGetRunV1ResponseBody parses an HTTP response from a GetRunV1WithResponse call
+func ParseGetRunV1ResponseBody(rsp *http.Response) (*GetRunV1ResponseBody, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &ScheduleRunV1ResponseBody{
+	response := &GetRunV1ResponseBody{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest ScheduleRunV1Response
+		var dest GetRunV1Response
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
 		response.JSON200 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
-		var dest Error
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON400 = &dest
+		response.JSON401 = &dest
 
-	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
-		var dest Unauthorized
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
-		response.JSON401 = &dest
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
 
 	}
 
 	return response, nil
 }
 
-// ParseDeleteRunV1ResponseBody parses an HTTP response from a DeleteRunV1WithResponse call
-func ParseDeleteRunV1ResponseBody(rsp *http.Response) (*DeleteRunV1ResponseBody, error) {
+// ParseCancelRunV1ResponseBody parses an HTTP response from a CancelRunV1WithResponse call
+func ParseCancelRunV1ResponseBody(rsp *http.Response) (*CancelRunV1ResponseBody, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &DeleteRunV1ResponseBody{
+	response := &CancelRunV1ResponseBody{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest DeleteRunV1Response
+		var dest CancelRunV1Response
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1676,6 +3132,13 @@ func ParseDeleteRunV1ResponseBody(rsp *http.Response) (*DeleteRunV1ResponseBody,
 		}
 		response.JSON401 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1688,22 +3151,22 @@ func ParseDeleteRunV1ResponseBody(rsp *http.Response) (*DeleteRunV1ResponseBody,
 	return response, nil
 }
 
-// ParseGetRunV1ResponseBody parses an HTTP response from a GetRunV1WithResponse call
-func ParseGetRunV1ResponseBody(rsp *http.Response) (*GetRunV1ResponseBody, error) {
+// ParseGetRepositoryLogV1ResponseBody parses an HTTP response from a GetRepositoryLogV1WithResponse call
+func ParseGetRepositoryLogV1ResponseBody(rsp *http.Response) (*GetRepositoryLogV1ResponseBody, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
 	defer func() { _ = rsp.Body.Close() }()
 	if err != nil {
 		return nil, err
 	}
 
-	response := &GetRunV1ResponseBody{
+	response := &GetRepositoryLogV1ResponseBody{
 		Body:         bodyBytes,
 		HTTPResponse: rsp,
 	}
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest GetRunV1Response
+		var dest GetRepositoryLogV1Response
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1716,6 +3179,13 @@ func ParseGetRunV1ResponseBody(rsp *http.Response) (*GetRunV1ResponseBody, error
 		}
 		response.JSON401 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1756,6 +3226,13 @@ func ParseListTaskResultsV1ResponseBody(rsp *http.Response) (*ListTaskResultsV1R
 		}
 		response.JSON401 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
 	}
 
 	return response, nil
@@ -1789,6 +3266,60 @@ func ParseListTasksV1ResponseBody(rsp *http.Response) (*ListTasksV1ResponseBody,
 		}
 		response.JSON401 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseReloadTasksV1ResponseBody parses an HTTP response from a ReloadTasksV1WithResponse call
+func ParseReloadTasksV1ResponseBody(rsp *http.Response) (*ReloadTasksV1ResponseBody, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReloadTasksV1ResponseBody{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReloadTasksV1Response
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
 	}
 
 	return response, nil
@@ -1822,6 +3353,13 @@ func ParseGetTaskV1ResponseBody(rsp *http.Response) (*GetTaskV1ResponseBody, err
 		}
 		response.JSON401 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1869,6 +3407,13 @@ func ParseListTaskRecentTaskResultsV1ResponseBody(rsp *http.Response) (*ListTask
 		}
 		response.JSON401 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
 		var dest Error
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
@@ -1888,6 +3433,147 @@ func ParseListTaskRecentTaskResultsV1ResponseBody(rsp *http.Response) (*ListTask
 	return response, nil
 }
 
+// ParseUpdateTaskRolloutV1ResponseBody parses an HTTP response from a UpdateTaskRolloutV1WithResponse call
+func ParseUpdateTaskRolloutV1ResponseBody(rsp *http.Response) (*UpdateTaskRolloutV1ResponseBody, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UpdateTaskRolloutV1ResponseBody{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TaskRolloutV1
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListWebhookDeliveriesV1ResponseBody parses an HTTP response from a ListWebhookDeliveriesV1WithResponse call
+func ParseListWebhookDeliveriesV1ResponseBody(rsp *http.Response) (*ListWebhookDeliveriesV1ResponseBody, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListWebhookDeliveriesV1ResponseBody{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ListWebhookDeliveriesV1Response
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseHeartbeatWorkV1ResponseBody parses an HTTP response from a HeartbeatWorkV1WithResponse call
+func ParseHeartbeatWorkV1ResponseBody(rsp *http.Response) (*HeartbeatWorkV1ResponseBody, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &HeartbeatWorkV1ResponseBody{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest HeartbeatWorkV1Response
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Unauthorized
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON404 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseGetWorkV1ResponseBody parses an HTTP response from a GetWorkV1WithResponse call
 func ParseGetWorkV1ResponseBody(rsp *http.Response) (*GetWorkV1ResponseBody, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -1916,6 +3602,13 @@ func ParseGetWorkV1ResponseBody(rsp *http.Response) (*GetWorkV1ResponseBody, err
 		}
 		response.JSON401 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
 	}
 
 	return response, nil
@@ -1949,6 +3642,13 @@ func ParseReportWorkV1ResponseBody(rsp *http.Response) (*ReportWorkV1ResponseBod
 		}
 		response.JSON401 = &dest
 
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Forbidden
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
 	}
 
 	return response, nil
@@ -0,0 +1,268 @@
+// Package codeowners parses CODEOWNERS files of GitHub and GitLab.
+package codeowners
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	// Locations are the paths, relative to the root of a repository,
+	// at which GitHub and GitLab look for a CODEOWNERS file.
+	// GitHub and GitLab use the first file that they find.
+	Locations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS", ".gitlab/CODEOWNERS"}
+
+	// sectionRegex matches the header of a section in GitLab, like "[Docs]", "^[Docs]" or "[Docs][2] @owner".
+	sectionRegex = regexp.MustCompile(`^\^?\[([^\]]+)\](?:\[\d+\])?(.*)$`)
+)
+
+type rule struct {
+	owners  []string
+	pattern *regexp.Regexp
+}
+
+type section struct {
+	defaultOwners []string
+	rules         []rule
+}
+
+// File is a parsed CODEOWNERS file.
+type File struct {
+	sections []*section
+}
+
+// Find reads the CODEOWNERS file in the repository checked out at dir.
+// It returns nil if the repository doesn't contain a CODEOWNERS file.
+func Find(dir string) (*File, error) {
+	for _, location := range Locations {
+		f, err := os.Open(filepath.Join(dir, location))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("open CODEOWNERS file %s: %w", location, err)
+		}
+
+		file, err := Parse(f)
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("parse CODEOWNERS file %s: %w", location, err)
+		}
+
+		return file, nil
+	}
+
+	return nil, nil
+}
+
+// Parse reads a CODEOWNERS file from r.
+//
+// It supports the syntax of GitHub and the sections of GitLab.
+// Owners are usernames, like "@ellie", or teams, like "@org/team".
+// Parse drops the leading "@" of each owner.
+// It ignores email addresses and roles of GitLab, like "@@developer",
+// because they can't be requested as reviewers.
+func Parse(r io.Reader) (*File, error) {
+	current := &section{}
+	file := &File{sections: []*section{current}}
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if match := sectionRegex.FindStringSubmatch(line); match != nil {
+			current = &section{defaultOwners: parseOwners(splitFields(match[2]))}
+			file.sections = append(file.sections, current)
+			continue
+		}
+
+		fields := splitFields(line)
+		pattern, err := compilePattern(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("compile pattern in line %d: %w", lineNumber, err)
+		}
+
+		owners := parseOwners(fields[1:])
+		if len(fields) == 1 {
+			owners = current.defaultOwners
+		}
+
+		current.rules = append(current.rules, rule{owners: owners, pattern: pattern})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// Owners returns the owners of the file at path.
+// path is relative to the root of the repository.
+//
+// The last rule that matches path in a section wins.
+// Owners combines the owners of all sections.
+func (f *File) Owners(path string) []string {
+	path = strings.TrimPrefix(filepath.ToSlash(path), "/")
+	var owners []string
+	for _, s := range f.sections {
+		for i := len(s.rules) - 1; i >= 0; i-- {
+			if s.rules[i].pattern.MatchString(path) {
+				owners = append(owners, s.rules[i].owners...)
+				break
+			}
+		}
+	}
+
+	slices.Sort(owners)
+	return slices.Compact(owners)
+}
+
+// Rank returns the owners of all files in paths.
+// Owners of more files come first.
+// Owners of the same number of files are sorted by name.
+func (f *File) Rank(paths []string) []string {
+	counts := map[string]int{}
+	var owners []string
+	for _, path := range paths {
+		for _, owner := range f.Owners(path) {
+			if counts[owner] == 0 {
+				owners = append(owners, owner)
+			}
+
+			counts[owner]++
+		}
+	}
+
+	slices.SortFunc(owners, func(a, b string) int {
+		if counts[a] != counts[b] {
+			return counts[b] - counts[a]
+		}
+
+		return strings.Compare(a, b)
+	})
+	return owners
+}
+
+func parseOwners(fields []string) []string {
+	var owners []string
+	for _, field := range fields {
+		if !strings.HasPrefix(field, "@") || strings.HasPrefix(field, "@@") {
+			continue
+		}
+
+		owners = append(owners, strings.TrimPrefix(field, "@"))
+	}
+
+	return owners
+}
+
+// splitFields splits line at whitespace that isn't escaped by a backslash.
+// It stops at the start of a comment.
+func splitFields(line string) []string {
+	var fields []string
+	var field strings.Builder
+	escaped := false
+	for _, r := range line {
+		switch {
+		case escaped:
+			field.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '#' && field.Len() == 0:
+			if len(fields) > 0 {
+				return fields
+			}
+
+			field.WriteRune(r)
+		case r == ' ' || r == '\t':
+			if field.Len() > 0 {
+				fields = append(fields, field.String())
+				field.Reset()
+			}
+		default:
+			field.WriteRune(r)
+		}
+	}
+
+	if field.Len() > 0 {
+		fields = append(fields, field.String())
+	}
+
+	return fields
+}
+
+// compilePattern converts a pattern in the format of .gitignore files into a regular expression.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	// A slash at the start or in the middle anchors the pattern at the root of the repository.
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	var expr strings.Builder
+	if anchored {
+		expr.WriteString("^")
+	} else {
+		expr.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case c == '*':
+			expr.WriteString("[^/]*")
+		case c == '?':
+			expr.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end == -1 {
+				expr.WriteString(regexp.QuoteMeta(string(c)))
+				continue
+			}
+
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+
+			expr.WriteString("[" + class + "]")
+			i += end + 1
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	switch {
+	case dirOnly:
+		// A directory matches all files in it.
+		expr.WriteString("/.*$")
+	case strings.HasSuffix(pattern, "*") && !strings.HasSuffix(pattern, "**"):
+		// "docs/*" matches the files in "docs", but not the files in its subdirectories.
+		expr.WriteString("$")
+	default:
+		// The pattern can match a directory, which matches all files in it.
+		expr.WriteString("(?:/.*)?$")
+	}
+
+	return regexp.Compile(expr.String())
+}
@@ -0,0 +1,114 @@
+package codeowners_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wndhydrnt/saturn-bot/pkg/codeowners"
+)
+
+const githubCodeowners = `# Default owners
+*       @ellie
+
+*.js    @org/frontend   # inline comment
+/docs/  @joel docs@example.com
+apps/   @tommy
+/build/logs/ @abby
+scripts/* @dina
+**/deploy @jesse
+/my\ file.txt @riley
+/vendor/
+`
+
+func TestFile_Owners_GitHub(t *testing.T) {
+	testCases := []struct {
+		path string
+		want []string
+	}{
+		{path: "main.go", want: []string{"ellie"}},
+		{path: "web/app.js", want: []string{"org/frontend"}},
+		{path: "docs/index.md", want: []string{"joel"}},
+		{path: "docs/guides/setup.md", want: []string{"joel"}},
+		{path: "sub/docs/index.md", want: []string{"ellie"}},
+		{path: "apps/api/main.go", want: []string{"tommy"}},
+		{path: "services/apps/main.go", want: []string{"tommy"}},
+		{path: "build/logs/out.log", want: []string{"abby"}},
+		{path: "scripts/run.sh", want: []string{"dina"}},
+		{path: "scripts/ci/run.sh", want: []string{"ellie"}},
+		{path: "a/b/deploy/values.yaml", want: []string{"jesse"}},
+		{path: "my file.txt", want: []string{"riley"}},
+		{path: "vendor/lib/lib.go", want: nil},
+	}
+
+	file, err := codeowners.Parse(strings.NewReader(githubCodeowners))
+	require.NoError(t, err)
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			assert.Equal(t, tc.want, file.Owners(tc.path))
+		})
+	}
+}
+
+const gitlabCodeowners = `* @ellie
+
+[Docs] @joel
+docs/
+README.md @riley
+
+^[Frontend][2] @org/frontend
+*.js
+*.css @dina @@developer
+`
+
+func TestFile_Owners_GitLabSections(t *testing.T) {
+	testCases := []struct {
+		path string
+		want []string
+	}{
+		{path: "main.go", want: []string{"ellie"}},
+		{path: "docs/index.md", want: []string{"ellie", "joel"}},
+		{path: "README.md", want: []string{"ellie", "riley"}},
+		{path: "web/app.js", want: []string{"ellie", "org/frontend"}},
+		{path: "web/app.css", want: []string{"dina", "ellie"}},
+	}
+
+	file, err := codeowners.Parse(strings.NewReader(gitlabCodeowners))
+	require.NoError(t, err)
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			assert.Equal(t, tc.want, file.Owners(tc.path))
+		})
+	}
+}
+
+func TestFile_Rank(t *testing.T) {
+	file, err := codeowners.Parse(strings.NewReader(githubCodeowners))
+	require.NoError(t, err)
+
+	result := file.Rank([]string{"docs/a.md", "docs/b.md", "web/app.js", "apps/main.go"})
+
+	assert.Equal(t, []string{"joel", "org/frontend", "tommy"}, result)
+}
+
+func TestFind(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".github"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".github", "CODEOWNERS"), []byte("* @ellie\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "CODEOWNERS"), []byte("* @joel\n"), 0600))
+
+	file, err := codeowners.Find(dir)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ellie"}, file.Owners("main.go"))
+}
+
+func TestFind_NotFound(t *testing.T) {
+	file, err := codeowners.Find(t.TempDir())
+
+	require.NoError(t, err)
+	assert.Nil(t, file)
+}
@@ -1,17 +1,50 @@
 package command
 
 import (
+	"encoding/json"
+	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
+	"path/filepath"
+	"slices"
 
 	"github.com/wndhydrnt/saturn-bot/pkg/config"
 	"github.com/wndhydrnt/saturn-bot/pkg/options"
 	"github.com/wndhydrnt/saturn-bot/pkg/task"
 )
 
+const (
+	CiOutputFormatJunit = "junit"
+	CiOutputFormatSarif = "sarif"
+	CiOutputFormatText  = "text"
+)
+
+var (
+	// ciRuleDescriptions describes each rule in SARIF reports.
+	ciRuleDescriptions = map[string]string{
+		task.LintRuleAutoMergeWithoutChangeLimit:     "autoMerge is enabled without changeLimit",
+		task.LintRuleCronBelowCacheTtl:               "Cron trigger runs more often than the repository cache TTL",
+		task.LintRuleDuplicateBranchName:             "Tasks share a branch name",
+		task.LintRuleNoFilters:                       "Task has no filters",
+		task.LintRulePushToDefaultBranchBroadFilters: "pushToDefaultBranch is enabled without a filter that selects repositories by exact name",
+		task.RuleDuration:                            "Value is not a duration",
+		task.RuleFile:                                "Referenced file does not exist",
+		task.RuleJq:                                  "jq expression does not compile",
+		task.RuleSchema:                              "Value does not match the schema of a task",
+		task.RuleTask:                                "Actions, filters or plugins of the task cannot be created",
+		task.RuleTemplate:                            "Template does not parse",
+		task.RuleYaml:                                "YAML does not decode",
+	}
+	ciRules = append([]string{task.RuleDuration, task.RuleFile, task.RuleJq, task.RuleSchema, task.RuleTask, task.RuleTemplate, task.RuleYaml}, task.LintRules()...)
+)
+
 type CiRunner struct {
 	Opts options.Opts
+	// OutputFormat is the format of the report written to out.
+	// One of "text", "junit" or "sarif".
+	// Defaults to "text".
+	OutputFormat string
 }
 
 func NewCiRunner(opts options.Opts) (*CiRunner, error) {
@@ -41,29 +74,326 @@ func NewCiRunnerFromConfig(configFile string, skipPlugins bool) (*CiRunner, erro
 	return NewCiRunner(opts)
 }
 
-// Run reads and validates taskFiles and writes a message to out for each file.
+// ciFileResult is the result of the validation of one task file.
+type ciFileResult struct {
+	file     string
+	problems []task.Problem
+	// tasks are the names of all valid tasks in the file.
+	tasks []string
+}
+
+func (r ciFileResult) failed() bool {
+	return slices.ContainsFunc(r.problems, func(p task.Problem) bool {
+		return p.Severity == task.SeverityError
+	})
+}
+
+// Run reads and validates taskFiles and writes a report to out.
+// Each entry of taskFiles can be a glob pattern.
+// It lints all task files that pass validation.
 func (ci *CiRunner) Run(out io.Writer, taskFiles ...string) error {
-	failed := false
+	switch ci.OutputFormat {
+	case "", CiOutputFormatJunit, CiOutputFormatSarif, CiOutputFormatText:
+	default:
+		return fmt.Errorf("unknown output format %s - one of junit, sarif or text", ci.OutputFormat)
+	}
+
+	var results []ciFileResult
 	for _, taskFile := range taskFiles {
-		// Create a new registry for each file.
-		// Makes it easier to connect an error to a task file.
-		reg := task.NewRegistry(ci.Opts)
-		err := reg.ReadTasks(taskFile)
+		files, err := filepath.Glob(taskFile)
 		if err != nil {
-			fmt.Fprintf(out, "❌ Validation failed: %s\n", err)
-			failed = true
-			continue
+			return fmt.Errorf("globbing task file '%s': %w", taskFile, err)
 		}
 
-		for _, task := range reg.GetTasks() {
-			fmt.Fprintf(out, "✅ Valid task %s found\n", task.Name)
-			task.Stop()
+		for _, file := range files {
+			results = append(results, ci.validate(file))
 		}
 	}
 
-	if failed {
-		return fmt.Errorf("validation failed")
+	if err := ci.lint(results); err != nil {
+		return err
+	}
+
+	var err error
+	switch ci.OutputFormat {
+	case CiOutputFormatJunit:
+		err = writeCiJunit(out, results)
+	case CiOutputFormatSarif:
+		err = writeCiSarif(out, results)
+	default:
+		for _, result := range results {
+			printCiFileResult(out, result)
+		}
+	}
+
+	if err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+
+	for _, result := range results {
+		if result.failed() {
+			return fmt.Errorf("validation failed")
+		}
 	}
 
 	return nil
 }
+
+// lint applies the lint rules to all files in results that contain valid tasks.
+func (ci *CiRunner) lint(results []ciFileResult) error {
+	var files []string
+	for _, result := range results {
+		if !result.failed() {
+			files = append(files, result.file)
+		}
+	}
+
+	problems, err := task.Lint(files, task.LintOptions{
+		RepositoryCacheTtl: ci.Opts.RepositoryCacheTtl,
+		Severities:         ci.Opts.Config.CiLintRules,
+	})
+	if err != nil {
+		return fmt.Errorf("lint task files: %w", err)
+	}
+
+	for _, problem := range problems {
+		for idx := range results {
+			if results[idx].file == problem.File {
+				results[idx].problems = append(results[idx].problems, problem)
+			}
+		}
+	}
+
+	return nil
+}
+
+// validate checks the tasks in file statically first.
+// It then creates the actions, filters and plugins of each task if the static checks pass.
+func (ci *CiRunner) validate(file string) ciFileResult {
+	result := ciFileResult{file: file}
+	problems, err := task.CheckFile(file)
+	if err != nil {
+		result.problems = []task.Problem{{File: file, Message: err.Error(), Rule: task.RuleFile, Severity: task.SeverityError}}
+		return result
+	}
+
+	if len(problems) > 0 {
+		result.problems = problems
+		return result
+	}
+
+	// Create a new registry for each file.
+	// Makes it easier to connect an error to a task file.
+	reg := task.NewRegistry(ci.Opts)
+	defer reg.Stop()
+	err = reg.ReadTasks(file)
+	if err != nil {
+		result.problems = []task.Problem{{File: file, Message: err.Error(), Rule: task.RuleTask, Severity: task.SeverityError}}
+		return result
+	}
+
+	for _, t := range reg.GetTasks() {
+		result.tasks = append(result.tasks, t.Name)
+	}
+
+	return result
+}
+
+func printCiFileResult(out io.Writer, result ciFileResult) {
+	for _, problem := range result.problems {
+		location := problem.Location()
+		// Name the rule so that users know what to pass to a suppression comment.
+		if slices.Contains(task.LintRules(), problem.Rule) {
+			location += ", " + problem.Rule
+		}
+
+		if problem.Severity == task.SeverityWarning {
+			fmt.Fprintf(out, "⚠️  Warning: %s (%s)\n", problem.Message, location)
+		} else {
+			fmt.Fprintf(out, "❌ Validation failed: %s (%s)\n", problem.Message, location)
+		}
+	}
+
+	for _, name := range result.tasks {
+		fmt.Fprintf(out, "✅ Valid task %s found\n", name)
+	}
+}
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Name       string           `xml:"name,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Tests      int              `xml:"tests,attr"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Tests     int             `xml:"tests,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Classname string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeCiJunit writes results as a JUnit XML report.
+// Each task file is a test suite.
+// Each valid task and each problem is a test case.
+// Test cases of warnings pass and contain the warning in their output.
+func writeCiJunit(out io.Writer, results []ciFileResult) error {
+	report := junitTestSuites{Name: "saturn-bot ci"}
+	for _, result := range results {
+		suite := junitTestSuite{Name: result.file}
+		for _, problem := range result.problems {
+			name := problem.Task
+			if name == "" {
+				name = result.file
+			}
+
+			testCase := junitTestCase{Classname: result.file, Name: name}
+			text := problem.Location() + ": " + problem.Message
+			if problem.Severity == task.SeverityWarning {
+				testCase.SystemOut = "warning: " + text + " (" + problem.Rule + ")"
+			} else {
+				testCase.Failure = &junitFailure{Message: problem.Message, Type: problem.Rule, Text: text}
+				suite.Failures++
+			}
+
+			suite.TestCases = append(suite.TestCases, testCase)
+		}
+
+		for _, name := range result.tasks {
+			suite.TestCases = append(suite.TestCases, junitTestCase{Classname: result.file, Name: name})
+		}
+
+		suite.Tests = len(suite.TestCases)
+		report.Failures += suite.Failures
+		report.Tests += suite.Tests
+		report.TestSuites = append(report.TestSuites, suite)
+	}
+
+	if _, err := io.WriteString(out, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(out)
+	enc.Indent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(out, "\n")
+	return err
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	InformationUri string      `json:"informationUri"`
+	Name           string      `json:"name"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	Level     string          `json:"level"`
+	Locations []sarifLocation `json:"locations"`
+	Message   sarifMessage    `json:"message"`
+	RuleID    string          `json:"ruleId"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartColumn int `json:"startColumn"`
+	StartLine   int `json:"startLine"`
+}
+
+// writeCiSarif writes the problems in results as a SARIF 2.1.0 report.
+func writeCiSarif(out io.Writer, results []ciFileResult) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			InformationUri: "https://github.com/wndhydrnt/saturn-bot",
+			Name:           "saturn-bot",
+		}},
+		// Initialize to encode an empty list instead of null.
+		Results: []sarifResult{},
+	}
+	for _, rule := range ciRules {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			ID:               rule,
+			ShortDescription: sarifMessage{Text: ciRuleDescriptions[rule]},
+		})
+	}
+
+	for _, result := range results {
+		for _, problem := range result.problems {
+			location := sarifLocation{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(problem.File)},
+				},
+			}
+			if problem.Line > 0 {
+				location.PhysicalLocation.Region = &sarifRegion{StartColumn: problem.Column, StartLine: problem.Line}
+			}
+
+			run.Results = append(run.Results, sarifResult{
+				Level:     problem.Severity,
+				Locations: []sarifLocation{location},
+				Message:   sarifMessage{Text: problem.Message},
+				RuleID:    problem.Rule,
+			})
+		}
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
@@ -2,10 +2,13 @@ package command_test
 
 import (
 	"bytes"
+	"encoding/json"
+	"encoding/xml"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/wndhydrnt/saturn-bot/pkg/command"
 	"github.com/wndhydrnt/saturn-bot/pkg/config"
@@ -14,13 +17,17 @@ import (
 
 const (
 	taskValid = `
+# saturn-bot:ignore no-filters
 name: Valid Task One
 ---
-name: Valid Task Two
+name: Valid Task Two # saturn-bot:ignore
 `
 	taskInvalid = `
 name: Invalid Task
 active: abc
+`
+	taskInvalidTemplate = `name: Invalid Template
+branchName: "{{ .TaskName"
 `
 )
 
@@ -35,7 +42,7 @@ func TestCiRunner_Run_Valid(t *testing.T) {
 	validFile.Close()
 
 	runner, err := command.NewCiRunner(options.Opts{
-		Config: config.Configuration{RepositoryCacheTtl: "6h", ServerShutdownTimeout: "5m", WorkerLoopInterval: "1m"},
+		Config: config.Configuration{RepositoryCacheTtl: "6h", ServerRepositoryLogRetention: "720h", ServerShutdownTimeout: "5m", ServerUiSessionTtl: "8h", ServerWebhookDeliveryRetention: "720h", TasksGitPollInterval: "5m", WorkerLoopInterval: "1m"},
 	})
 	require.NoError(t, err)
 
@@ -60,7 +67,7 @@ func TestCiRunner_Run_Invalid(t *testing.T) {
 	invalidFile.Close()
 
 	runner, err := command.NewCiRunner(options.Opts{
-		Config: config.Configuration{RepositoryCacheTtl: "6h", ServerShutdownTimeout: "5m", WorkerLoopInterval: "1m"},
+		Config: config.Configuration{RepositoryCacheTtl: "6h", ServerRepositoryLogRetention: "720h", ServerShutdownTimeout: "5m", ServerUiSessionTtl: "8h", ServerWebhookDeliveryRetention: "720h", TasksGitPollInterval: "5m", WorkerLoopInterval: "1m"},
 	})
 	require.NoError(t, err)
 
@@ -81,3 +88,118 @@ func TestNewCiRunnerFromConfig(t *testing.T) {
 	require.NoError(t, err, "Should not error when config file is empty")
 	require.IsType(t, &command.CiRunner{}, runner)
 }
+
+func setupCiRunnerInvalidTemplate(t *testing.T, outputFormat string) (*command.CiRunner, string) {
+	taskFile := filepath.Join(t.TempDir(), "task.yaml")
+	err := os.WriteFile(taskFile, []byte(taskInvalidTemplate), 0600)
+	require.NoError(t, err)
+	runner := &command.CiRunner{OutputFormat: outputFormat}
+	return runner, taskFile
+}
+
+func TestCiRunner_Run_Position(t *testing.T) {
+	runner, taskFile := setupCiRunnerInvalidTemplate(t, "text")
+
+	out := &bytes.Buffer{}
+	err := runner.Run(out, taskFile)
+
+	require.EqualError(t, err, "validation failed")
+	assert.Equal(t, "❌ Validation failed: parse template of branchName: template: :1: unclosed action ("+taskFile+":2:13)\n", out.String())
+}
+
+func TestCiRunner_Run_Junit(t *testing.T) {
+	runner, taskFile := setupCiRunnerInvalidTemplate(t, "junit")
+
+	out := &bytes.Buffer{}
+	err := runner.Run(out, taskFile)
+
+	require.EqualError(t, err, "validation failed")
+	var report struct {
+		Failures   int `xml:"failures,attr"`
+		TestSuites []struct {
+			Name      string `xml:"name,attr"`
+			TestCases []struct {
+				Name    string `xml:"name,attr"`
+				Failure struct {
+					Message string `xml:"message,attr"`
+					Type    string `xml:"type,attr"`
+				} `xml:"failure"`
+			} `xml:"testcase"`
+		} `xml:"testsuite"`
+	}
+	err = xml.Unmarshal(out.Bytes(), &report)
+	require.NoError(t, err, "writes valid XML")
+	assert.Equal(t, 1, report.Failures)
+	require.Len(t, report.TestSuites, 1)
+	assert.Equal(t, taskFile, report.TestSuites[0].Name)
+	require.Len(t, report.TestSuites[0].TestCases, 1)
+	assert.Equal(t, "Invalid Template", report.TestSuites[0].TestCases[0].Name)
+	assert.Equal(t, "parse template of branchName: template: :1: unclosed action", report.TestSuites[0].TestCases[0].Failure.Message)
+	assert.Equal(t, "template", report.TestSuites[0].TestCases[0].Failure.Type)
+}
+
+func TestCiRunner_Run_Sarif(t *testing.T) {
+	runner, taskFile := setupCiRunnerInvalidTemplate(t, "sarif")
+
+	out := &bytes.Buffer{}
+	err := runner.Run(out, taskFile)
+
+	require.EqualError(t, err, "validation failed")
+	var report map[string]any
+	err = json.Unmarshal(out.Bytes(), &report)
+	require.NoError(t, err, "writes valid JSON")
+	assert.Equal(t, "2.1.0", report["version"])
+	results := report["runs"].([]any)[0].(map[string]any)["results"].([]any)
+	require.Len(t, results, 1)
+	assert.Equal(t, map[string]any{
+		"level": "error",
+		"locations": []any{
+			map[string]any{
+				"physicalLocation": map[string]any{
+					"artifactLocation": map[string]any{"uri": filepath.ToSlash(taskFile)},
+					"region":           map[string]any{"startColumn": float64(13), "startLine": float64(2)},
+				},
+			},
+		},
+		"message": map[string]any{"text": "parse template of branchName: template: :1: unclosed action"},
+		"ruleId":  "template",
+	}, results[0])
+}
+
+func TestCiRunner_Run_UnknownOutputFormat(t *testing.T) {
+	runner := &command.CiRunner{OutputFormat: "yaml"}
+
+	err := runner.Run(&bytes.Buffer{}, "task.yaml")
+
+	require.EqualError(t, err, "unknown output format yaml - one of junit, sarif or text")
+}
+
+func TestCiRunner_Run_Lint(t *testing.T) {
+	dir := t.TempDir()
+	content := `name: Lint One
+autoMerge: true
+branchName: shared
+---
+# saturn-bot:ignore no-filters
+name: Lint Two
+branchName: shared
+`
+	err := os.WriteFile(filepath.Join(dir, "task.yaml"), []byte(content), 0600)
+	require.NoError(t, err)
+	taskFile := filepath.Join(dir, "task.yaml")
+	runner := &command.CiRunner{Opts: options.Opts{Config: config.Configuration{
+		CiLintRules: map[string]string{"auto-merge-without-change-limit": "error"},
+	}}}
+
+	out := &bytes.Buffer{}
+	err = runner.Run(out, taskFile)
+
+	require.EqualError(t, err, "validation failed")
+	want := "⚠️  Warning: task has no filters and modifies every repository (" + taskFile + ":1:1, no-filters)\n" +
+		"❌ Validation failed: autoMerge is enabled without changeLimit - the task can merge changes into all matching repositories in one run (" + taskFile + ":2:12, auto-merge-without-change-limit)\n" +
+		"⚠️  Warning: branch shared is also used by Lint Two (" + taskFile + ") - the tasks modify the same pull request (" + taskFile + ":3:13, duplicate-branch-name)\n" +
+		"⚠️  Warning: branch shared is also used by Lint One (" + taskFile + ") - the tasks modify the same pull request (" + taskFile + ":7:13, duplicate-branch-name)\n" +
+		"✅ Valid task Lint One found\n" +
+		"✅ Valid task Lint Two found\n"
+	assert.Equal(t, want, out.String())
+}
@@ -0,0 +1,442 @@
+package command
+
+import (
+	"cmp"
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	htmlTemplate "html/template"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	textTemplate "text/template"
+
+	"github.com/wndhydrnt/saturn-bot/pkg/action"
+	"github.com/wndhydrnt/saturn-bot/pkg/cache"
+	"github.com/wndhydrnt/saturn-bot/pkg/clock"
+	sbcontext "github.com/wndhydrnt/saturn-bot/pkg/context"
+	"github.com/wndhydrnt/saturn-bot/pkg/filter"
+	"github.com/wndhydrnt/saturn-bot/pkg/git"
+	"github.com/wndhydrnt/saturn-bot/pkg/host"
+	"github.com/wndhydrnt/saturn-bot/pkg/log"
+	"github.com/wndhydrnt/saturn-bot/pkg/options"
+	"github.com/wndhydrnt/saturn-bot/pkg/task"
+	"github.com/wndhydrnt/saturn-bot/pkg/template"
+)
+
+const (
+	PreviewOutputFormatHtml     = "html"
+	PreviewOutputFormatJson     = "json"
+	PreviewOutputFormatMarkdown = "markdown"
+)
+
+var (
+	//go:embed templates/preview.*
+	previewTemplateFiles embed.FS
+	previewTemplateHtml  = htmlTemplate.Must(htmlTemplate.ParseFS(previewTemplateFiles, "templates/preview.html.tpl"))
+	// The Markdown report uses text/template because html/template would escape the content of diffs.
+	previewTemplateMarkdown = textTemplate.Must(textTemplate.ParseFS(previewTemplateFiles, "templates/preview.md.tpl"))
+)
+
+// PreviewFilterResult is the decision of a filter of a task for a repository.
+type PreviewFilterResult struct {
+	Error  string `json:"error,omitempty"`
+	Filter string `json:"filter"`
+	Match  bool   `json:"match"`
+}
+
+// PreviewResult describes what a task would change in a repository.
+type PreviewResult struct {
+	BranchName    string                `json:"branchName,omitempty"`
+	Changed       bool                  `json:"changed"`
+	CommitMessage string                `json:"commitMessage,omitempty"`
+	Diff          string                `json:"diff,omitempty"`
+	DiffStat      string                `json:"diffStat,omitempty"`
+	Error         string                `json:"error,omitempty"`
+	Filters       []PreviewFilterResult `json:"filters"`
+	Matched       bool                  `json:"matched"`
+	PrBody        string                `json:"prBody,omitempty"`
+	PrTitle       string                `json:"prTitle,omitempty"`
+	Repository    string                `json:"repository"`
+	Task          string                `json:"task"`
+}
+
+// PreviewReport is the aggregated result of a [PreviewRunner].
+type PreviewReport struct {
+	// Changed is the number of repositories and tasks that would lead to a pull request.
+	Changed int `json:"changed"`
+	// Failed is the number of repositories and tasks that returned an error.
+	Failed int `json:"failed"`
+	// Matched is the number of repositories and tasks for which all filters match.
+	Matched int             `json:"matched"`
+	Results []PreviewResult `json:"results"`
+}
+
+// PreviewRunner applies tasks to repositories in dry-run mode and reports
+// which repositories match, which ones change and the diff of each change.
+// It doesn't push changes or create pull requests.
+type PreviewRunner struct {
+	ApplyActionsFunc func(actions []action.Action, ctx context.Context, dir string) error
+	// DefaultCommitMessage is the commit message to use if a task doesn't define one.
+	DefaultCommitMessage string
+	Hosts                []host.Host
+	// NewGitClient returns a git client.
+	// The runner creates one client for each repository that it processes in parallel.
+	NewGitClient func() (git.GitClient, error)
+	// Out is the writer that [PreviewRunner] writes progress messages to.
+	Out io.Writer
+	// OutputFormat is the format of the report.
+	// One of html, json or markdown.
+	OutputFormat string
+	// OutReport is the writer that [PreviewRunner] writes the report to.
+	OutReport io.Writer
+	// Parallel is the number of repositories to process in parallel.
+	Parallel         int
+	Registry         *task.Registry
+	RepositoryLister host.RepositoryLister
+
+	// mu serializes the application of actions and the rendering of templates.
+	// Actions change the working directory of the process
+	// and tasks cache their templates on first use.
+	mu sync.Mutex
+}
+
+// Run applies the tasks in taskFiles to all repositories known to the hosts,
+// or to repositoryNames if set, and writes the report.
+// Unlike [Run.Run], it executes the filters of each task even if repositoryNames is set.
+func (r *PreviewRunner) Run(ctx context.Context, repositoryNames, taskFiles []string, inputs map[string]string) error {
+	if len(r.Hosts) == 0 {
+		return ErrNoHostsConfigured
+	}
+
+	err := r.Registry.ReadAll(taskFiles)
+	if err != nil {
+		return err
+	}
+
+	defer r.Registry.Stop()
+	tasks := setInputs(r.Registry.GetTasks(), inputs)
+	if len(tasks) == 0 {
+		return errors.New("no tasks to preview")
+	}
+
+	gitClients := make([]git.GitClient, max(r.Parallel, 1))
+	for i := range gitClients {
+		gitClients[i], err = r.NewGitClient()
+		if err != nil {
+			return fmt.Errorf("new git client for preview: %w", err)
+		}
+	}
+
+	repos := make(chan host.Repository)
+	doneChan := make(chan error)
+	if len(repositoryNames) > 0 {
+		go discoverRepositoriesFromCLI(r.Hosts, repositoryNames, repos, doneChan)
+	} else {
+		go r.RepositoryLister.List(r.Hosts, repos, doneChan)
+	}
+
+	work := make(chan host.Repository)
+	resultsMu := sync.Mutex{}
+	var results []PreviewResult
+	wg := sync.WaitGroup{}
+	for _, gitClient := range gitClients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for repo := range work {
+				repoResults := r.previewRepository(ctx, gitClient, repo, tasks)
+				resultsMu.Lock()
+				results = append(results, repoResults...)
+				resultsMu.Unlock()
+			}
+		}()
+	}
+
+	var listErr error
+	done := false
+	for !done {
+		select {
+		case <-ctx.Done():
+			go drainRepositories(repos, doneChan)
+			listErr = ErrRunCancelled
+			done = true
+		case repo := <-repos:
+			select {
+			case work <- repo:
+			case <-ctx.Done():
+			}
+		case err := <-doneChan:
+			listErr = err
+			done = true
+		}
+	}
+
+	close(work)
+	wg.Wait()
+	if listErr != nil {
+		return listErr
+	}
+
+	return r.writeReport(newPreviewReport(results))
+}
+
+func (r *PreviewRunner) previewRepository(ctx context.Context, gitClient git.GitClient, repo host.Repository, tasks []*task.Task) []PreviewResult {
+	if repo.IsArchived() {
+		return nil
+	}
+
+	ctx = context.WithValue(ctx, sbcontext.RepositoryKey{}, repo)
+	var results []*PreviewResult
+	for _, t := range tasks {
+		if ctx.Err() != nil {
+			break
+		}
+
+		result := &PreviewResult{Repository: repo.FullName(), Task: t.Name}
+		results = append(results, result)
+		if !t.HasFilters() {
+			// A task without filters doesn't match, like in a regular run.
+			continue
+		}
+
+		taskCtx := sbcontext.WithRunData(ctx, t.RunData())
+		result.Matched = applyPreviewFilters(taskCtx, t.FiltersPreClone(), result)
+	}
+
+	var checkoutPath string
+	for i, t := range tasks {
+		if i >= len(results) || !results[i].Matched {
+			continue
+		}
+
+		result := results[i]
+		if checkoutPath == "" {
+			var err error
+			checkoutPath, err = gitClient.Prepare(repo, false)
+			if err != nil {
+				result.Error = fmt.Sprintf("prepare repository: %s", err)
+				fmt.Fprintf(r.Out, "⛔️ Failed to prepare repository %s: %s\n", repo.FullName(), err)
+				break
+			}
+		}
+
+		taskCtx := sbcontext.WithRunData(ctx, t.RunData())
+		taskCtx = context.WithValue(taskCtx, sbcontext.CheckoutPath{}, checkoutPath)
+		result.Matched = applyPreviewFilters(taskCtx, t.FiltersPostClone(), result)
+		if !result.Matched {
+			continue
+		}
+
+		err := r.previewChanges(taskCtx, gitClient, repo, t, checkoutPath, result)
+		if err != nil {
+			result.Error = err.Error()
+			fmt.Fprintf(r.Out, "⛔️ Task %s failed in repository %s: %s\n", t.Name, repo.FullName(), err)
+		} else if result.Changed {
+			fmt.Fprintf(r.Out, "😍 Task %s changes repository %s\n", t.Name, repo.FullName())
+		} else {
+			fmt.Fprintf(r.Out, "⚠️  Task %s matches repository %s but doesn't change it\n", t.Name, repo.FullName())
+		}
+	}
+
+	previewResults := make([]PreviewResult, 0, len(results))
+	for _, result := range results {
+		previewResults = append(previewResults, *result)
+	}
+
+	return previewResults
+}
+
+// previewChanges applies the actions of t and records the changes in result.
+// It discards the changes afterwards to let the next task start from a clean checkout.
+func (r *PreviewRunner) previewChanges(ctx context.Context, gitClient git.GitClient, repo host.Repository, t *task.Task, checkoutPath string, result *PreviewResult) error {
+	templateData := template.Data{
+		Run: t.RunData(),
+		Repository: template.DataRepository{
+			FullName: repo.FullName(),
+			Host:     repo.Host().Name(),
+			Name:     repo.Name(),
+			Owner:    repo.Owner(),
+			WebUrl:   repo.WebUrl(),
+		},
+		TaskName: t.Name,
+	}
+	r.mu.Lock()
+	branchName, err := t.RenderBranchName(templateData)
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("render branch name: %w", err)
+	}
+
+	result.BranchName = branchName
+	hasMergeConflict, err := gitClient.UpdateTaskBranch(branchName, false, repo)
+	if err != nil {
+		return fmt.Errorf("prepare branch: %w", err)
+	}
+
+	if hasMergeConflict {
+		return fmt.Errorf("merge conflict in branch %s", branchName)
+	}
+
+	defer discardChanges(gitClient)
+	r.mu.Lock()
+	err = r.ApplyActionsFunc(t.Actions(), ctx, checkoutPath)
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	result.Changed, err = gitClient.HasLocalChanges()
+	if err != nil {
+		return fmt.Errorf("check local changes: %w", err)
+	}
+
+	if !result.Changed {
+		return nil
+	}
+
+	result.DiffStat, err = gitClient.Diff(true, false)
+	if err != nil {
+		return fmt.Errorf("diff changes: %w", err)
+	}
+
+	result.Diff, err = gitClient.Diff(false, false)
+	if err != nil {
+		return fmt.Errorf("diff changes: %w", err)
+	}
+
+	r.mu.Lock()
+	pr, err := renderPullRequestPreview(t, templateData, r.DefaultCommitMessage)
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	result.CommitMessage = pr.CommitMessage
+	result.PrBody = pr.Body
+	result.PrTitle = pr.Title
+	return nil
+}
+
+func (r *PreviewRunner) writeReport(report PreviewReport) error {
+	switch r.OutputFormat {
+	case PreviewOutputFormatHtml:
+		return previewTemplateHtml.Execute(r.OutReport, report)
+	case PreviewOutputFormatJson:
+		enc := json.NewEncoder(r.OutReport)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case PreviewOutputFormatMarkdown:
+		return previewTemplateMarkdown.Execute(r.OutReport, report)
+	default:
+		return fmt.Errorf("unknown output format %s - one of html, json or markdown", r.OutputFormat)
+	}
+}
+
+// applyPreviewFilters executes filters and records each decision in result.
+// It stops at the first filter that doesn't match.
+func applyPreviewFilters(ctx context.Context, filters []filter.Filter, result *PreviewResult) bool {
+	for _, f := range filters {
+		match, err := f.Do(ctx)
+		filterResult := PreviewFilterResult{Filter: f.String(), Match: match}
+		if err != nil {
+			filterResult.Error = err.Error()
+			result.Error = fmt.Sprintf("filter %s failed: %s", f.String(), err)
+		}
+
+		result.Filters = append(result.Filters, filterResult)
+		if !match || err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// discardChanges resets the checkout of a repository.
+func discardChanges(gitClient git.GitClient) {
+	if _, _, err := gitClient.Execute("reset", "--hard"); err != nil {
+		log.Log().Warnf("Failed to reset checkout after preview: %s", err)
+	}
+
+	if _, _, err := gitClient.Execute("clean", "-d", "--force"); err != nil {
+		log.Log().Warnf("Failed to clean checkout after preview: %s", err)
+	}
+}
+
+func newPreviewReport(results []PreviewResult) PreviewReport {
+	slices.SortFunc(results, func(a, b PreviewResult) int {
+		return cmp.Or(
+			strings.Compare(a.Repository, b.Repository),
+			strings.Compare(a.Task, b.Task),
+		)
+	})
+	report := PreviewReport{Results: results}
+	for _, result := range results {
+		if result.Matched {
+			report.Matched++
+		}
+
+		if result.Changed {
+			report.Changed++
+		}
+
+		if result.Error != "" {
+			report.Failed++
+		}
+	}
+
+	return report
+}
+
+// PreviewOptions defines the options of [ExecutePreview].
+type PreviewOptions struct {
+	Inputs       map[string]string
+	Out          io.Writer
+	OutputFormat string
+	OutReport    io.Writer
+	Parallel     int
+	// RepositoryNames limits the preview to these repositories.
+	RepositoryNames []string
+	TaskFiles       []string
+}
+
+// ExecutePreview previews the changes of the tasks in previewOpts.TaskFiles.
+// See [PreviewRunner.Run].
+func ExecutePreview(ctx context.Context, opts options.Opts, previewOpts PreviewOptions) error {
+	switch previewOpts.OutputFormat {
+	case PreviewOutputFormatHtml, PreviewOutputFormatJson, PreviewOutputFormatMarkdown:
+	default:
+		return fmt.Errorf("unknown output format %s - one of html, json or markdown", previewOpts.OutputFormat)
+	}
+
+	err := options.Initialize(&opts)
+	if err != nil {
+		return fmt.Errorf("initialize options: %w", err)
+	}
+
+	dataCache, err := cache.New(filepath.Join(opts.DataDir, "cache.db"))
+	if err != nil {
+		return err
+	}
+
+	r := &PreviewRunner{
+		ApplyActionsFunc:     applyActionsInDirectory,
+		DefaultCommitMessage: opts.Config.GitCommitMessage,
+		Hosts:                opts.Hosts,
+		NewGitClient: func() (git.GitClient, error) {
+			return git.New(opts)
+		},
+		Out:              previewOpts.Out,
+		OutputFormat:     previewOpts.OutputFormat,
+		OutReport:        previewOpts.OutReport,
+		Parallel:         previewOpts.Parallel,
+		Registry:         task.NewRegistry(opts),
+		RepositoryLister: host.NewRepositoryCache(dataCache, clock.Default, filepath.Join(opts.DataDir, "cache"), opts.RepositoryCacheTtl),
+	}
+	return r.Run(ctx, previewOpts.RepositoryNames, previewOpts.TaskFiles, previewOpts.Inputs)
+}
@@ -0,0 +1,131 @@
+package command_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wndhydrnt/saturn-bot/pkg/action"
+	"github.com/wndhydrnt/saturn-bot/pkg/command"
+	"github.com/wndhydrnt/saturn-bot/pkg/git"
+	"github.com/wndhydrnt/saturn-bot/pkg/host"
+	"github.com/wndhydrnt/saturn-bot/pkg/task"
+	gitmock "github.com/wndhydrnt/saturn-bot/test/mock/git"
+	hostmock "github.com/wndhydrnt/saturn-bot/test/mock/host"
+	"go.uber.org/mock/gomock"
+)
+
+type previewRepositoryLister struct {
+	repositories []host.Repository
+}
+
+func (l *previewRepositoryLister) List(_ []host.Host, result chan host.Repository, errChan chan error) {
+	for _, repo := range l.repositories {
+		result <- repo
+	}
+
+	errChan <- nil
+}
+
+func setupPreviewRepoMock(ctrl *gomock.Controller, owner, name string) *hostmock.MockRepository {
+	hostDetailMock := hostmock.NewMockHostDetail(ctrl)
+	hostDetailMock.EXPECT().Name().Return("git.local").AnyTimes()
+	repoMock := hostmock.NewMockRepository(ctrl)
+	repoMock.EXPECT().FullName().Return("git.local/" + owner + "/" + name).AnyTimes()
+	repoMock.EXPECT().Host().Return(hostDetailMock).AnyTimes()
+	repoMock.EXPECT().IsArchived().Return(false).AnyTimes()
+	repoMock.EXPECT().Owner().Return(owner).AnyTimes()
+	repoMock.EXPECT().Name().Return(name).AnyTimes()
+	repoMock.EXPECT().WebUrl().Return("http://git.local/" + owner + "/" + name).AnyTimes()
+	return repoMock
+}
+
+func setupPreviewRunner(t *testing.T, outputFormat string) (*command.PreviewRunner, *bytes.Buffer) {
+	ctrl := gomock.NewController(t)
+	matchingRepo := setupPreviewRepoMock(ctrl, "unit", "test")
+	otherRepo := setupPreviewRepoMock(ctrl, "other", "test")
+	gitcMock := gitmock.NewMockGitClient(ctrl)
+	gitcMock.EXPECT().Prepare(matchingRepo, false).Return("/checkout", nil)
+	gitcMock.EXPECT().UpdateTaskBranch("saturn-bot--unit-test", false, matchingRepo).Return(false, nil)
+	gitcMock.EXPECT().HasLocalChanges().Return(true, nil)
+	gitcMock.EXPECT().Diff(true, false).Return(" test.txt | 1 +\n", nil)
+	gitcMock.EXPECT().Diff(false, false).Return("diff --git a/test.txt b/test.txt\n+<changed>\n", nil)
+	gitcMock.EXPECT().Execute("reset", "--hard").Return("", "", nil)
+	gitcMock.EXPECT().Execute("clean", "-d", "--force").Return("", "", nil)
+	out := &bytes.Buffer{}
+	runner := &command.PreviewRunner{
+		ApplyActionsFunc:     func(actions []action.Action, ctx context.Context, dir string) error { return nil },
+		DefaultCommitMessage: "changes by saturn-bot",
+		Hosts:                []host.Host{hostmock.NewMockHost(ctrl)},
+		NewGitClient:         func() (git.GitClient, error) { return gitcMock, nil },
+		Out:                  &bytes.Buffer{},
+		OutputFormat:         outputFormat,
+		OutReport:            out,
+		Parallel:             1,
+		Registry:             task.NewRegistry(tryTestOpts),
+		RepositoryLister:     &previewRepositoryLister{repositories: []host.Repository{matchingRepo, otherRepo}},
+	}
+	return runner, out
+}
+
+func TestPreviewRunner_Run_Json(t *testing.T) {
+	runner, out := setupPreviewRunner(t, "json")
+	taskFile := createTestTaskFile(createTestTask("git.local/unit/test"))
+
+	err := runner.Run(context.Background(), nil, []string{taskFile}, nil)
+
+	require.NoError(t, err)
+	var report command.PreviewReport
+	err = json.Unmarshal(out.Bytes(), &report)
+	require.NoError(t, err, "writes valid JSON")
+	assert.Equal(t, 1, report.Changed)
+	assert.Equal(t, 0, report.Failed)
+	assert.Equal(t, 1, report.Matched)
+	require.Len(t, report.Results, 2)
+	assert.Equal(t, command.PreviewResult{
+		Filters: []command.PreviewFilterResult{
+			{Filter: "repository(host=^git.local$,owner=^unit$,name=^test$)", Match: false},
+		},
+		Repository: "git.local/other/test",
+		Task:       "Unit Test",
+	}, report.Results[0], "sorts results by name of the repository")
+	assert.Equal(t, "git.local/unit/test", report.Results[1].Repository)
+	assert.Equal(t, "saturn-bot--unit-test", report.Results[1].BranchName)
+	assert.True(t, report.Results[1].Changed)
+	assert.Equal(t, "changes by saturn-bot", report.Results[1].CommitMessage)
+	assert.Equal(t, "diff --git a/test.txt b/test.txt\n+<changed>\n", report.Results[1].Diff)
+	assert.Equal(t, " test.txt | 1 +\n", report.Results[1].DiffStat)
+	assert.Equal(t, []command.PreviewFilterResult{
+		{Filter: "repository(host=^git.local$,owner=^unit$,name=^test$)", Match: true},
+	}, report.Results[1].Filters)
+	assert.True(t, report.Results[1].Matched)
+	assert.Equal(t, "saturn-bot: task Unit Test", report.Results[1].PrTitle)
+}
+
+func TestPreviewRunner_Run_Markdown(t *testing.T) {
+	runner, out := setupPreviewRunner(t, "markdown")
+	taskFile := createTestTaskFile(createTestTask("git.local/unit/test"))
+
+	err := runner.Run(context.Background(), nil, []string{taskFile}, nil)
+
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "| 1 | 1 | 0 |")
+	assert.Contains(t, out.String(), "| git.local/unit/test | Unit Test | yes | yes |  |")
+	assert.Contains(t, out.String(), "### git.local/unit/test - Unit Test")
+	assert.Contains(t, out.String(), "+<changed>", "doesn't escape the diff")
+	assert.Contains(t, out.String(), "| git.local/other/test | Unit Test | `repository(host=^git.local$,owner=^unit$,name=^test$)` | no |  |")
+}
+
+func TestPreviewRunner_Run_Html(t *testing.T) {
+	runner, out := setupPreviewRunner(t, "html")
+	taskFile := createTestTaskFile(createTestTask("git.local/unit/test"))
+
+	err := runner.Run(context.Background(), nil, []string{taskFile}, nil)
+
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "<h3>git.local/unit/test - Unit Test</h3>")
+	assert.Contains(t, out.String(), "&#43;&lt;changed&gt;", "escapes the diff")
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/wndhydrnt/saturn-bot/pkg/action"
 	"github.com/wndhydrnt/saturn-bot/pkg/cache"
 	"github.com/wndhydrnt/saturn-bot/pkg/clock"
+	sbcontext "github.com/wndhydrnt/saturn-bot/pkg/context"
 	"github.com/wndhydrnt/saturn-bot/pkg/git"
 	"github.com/wndhydrnt/saturn-bot/pkg/host"
 	"github.com/wndhydrnt/saturn-bot/pkg/log"
@@ -24,14 +25,24 @@ import (
 
 var (
 	ErrNoHostsConfigured = errors.New("no hosts configured")
+	// ErrRunCancelled indicates that the run stopped early because its context has been cancelled.
+	ErrRunCancelled = errors.New("run cancelled")
 )
 
 type RunResult struct {
-	Error          error
-	PullRequest    *host.PullRequest
+	Error       error
+	PullRequest *host.PullRequest
+	// Log contains the log of the repository if the run captures logs.
+	Log            string
 	RepositoryName string
 	Result         processor.Result
-	TaskName       string
+	// RolloutWave is the index of the wave of the rollout of the task that contains the repository.
+	// Nil if the task doesn't define a rollout.
+	RolloutWave *int
+	// TargetBranch is the branch that the pull request targets.
+	// Empty if the task doesn't define target branches.
+	TargetBranch string
+	TaskName     string
 }
 
 type Run struct {
@@ -42,10 +53,15 @@ type Run struct {
 	PullRequestCache host.PullRequestCache
 	PushGateway      *push.Pusher
 	RepositoryLister host.RepositoryLister
-	TaskRegistry     *task.Registry
+	// RepositoryLogMaxSize is the maximum size in bytes of the log captured for each repository.
+	// The run doesn't capture logs if it is 0.
+	RepositoryLogMaxSize int
+	TaskRegistry         *task.Registry
 }
 
-func (r *Run) Run(repositoryNames, taskFiles []string, inputs map[string]string) ([]RunResult, error) {
+// Run applies the tasks in taskFiles to repositories.
+// It stops once ctx is done and returns [ErrRunCancelled].
+func (r *Run) Run(ctx context.Context, repositoryNames, taskFiles []string, inputs map[string]string) ([]RunResult, error) {
 	metrics.RunStart.SetToCurrentTime()
 	defer func() {
 		metrics.RunFinish.SetToCurrentTime()
@@ -89,11 +105,33 @@ func (r *Run) Run(repositoryNames, taskFiles []string, inputs map[string]string)
 	var results []RunResult
 	done := false
 	for {
+		if ctx.Err() != nil {
+			log.Log().Info("Stopping run because it has been cancelled")
+			// Discard the remaining repositories to let the lister finish.
+			go drainRepositories(repos, doneChan)
+			return results, ErrRunCancelled
+		}
+
 		select {
+		case <-ctx.Done():
+			// Handled at the start of the next iteration.
 		case repo := <-repos:
-			doFilter := len(repositoryNames) == 0
-			processResults := r.Processor.Process(r.DryRun, repo, tasks, doFilter)
+			// Repositories that a user has explicitly selected bypass the filters of the task.
+			doFilter := len(repositoryNames) == 0 || inputs[sbcontext.RunDataKeyFilterRepositories] == "true"
+			repoCtx := ctx
+			var capture *log.Capture
+			if r.RepositoryLogMaxSize > 0 {
+				capture = log.NewCapture(r.RepositoryLogMaxSize)
+				repoCtx = sbcontext.WithLogCapture(ctx, capture)
+			}
+
+			processResults := r.Processor.Process(repoCtx, r.DryRun, repo, tasks, doFilter)
 			for _, p := range processResults {
+				if errors.Is(p.Error, context.Canceled) {
+					// The task has been interrupted and its result is incomplete.
+					continue
+				}
+
 				if p.Error == nil && taskSuccessTracker[p.Task.Name] == nil {
 					taskSuccessTracker[p.Task.Name] = ptr.To(float64(1))
 				}
@@ -105,9 +143,12 @@ func (r *Run) Run(repositoryNames, taskFiles []string, inputs map[string]string)
 
 				results = append(results, RunResult{
 					Error:          p.Error,
+					Log:            capture.String(),
 					PullRequest:    p.PullRequest,
 					RepositoryName: repo.FullName(),
 					Result:         p.Result,
+					RolloutWave:    p.RolloutWave,
+					TargetBranch:   p.TargetBranch,
 					TaskName:       p.Task.Name,
 				})
 			}
@@ -131,6 +172,16 @@ func (r *Run) Run(repositoryNames, taskFiles []string, inputs map[string]string)
 	return results, nil
 }
 
+func drainRepositories(repos <-chan host.Repository, doneChan <-chan error) {
+	for {
+		select {
+		case <-repos:
+		case <-doneChan:
+			return
+		}
+	}
+}
+
 func (r *Run) pushMetrics() {
 	if r.PushGateway != nil {
 		err := r.PushGateway.Push()
@@ -140,7 +191,9 @@ func (r *Run) pushMetrics() {
 	}
 }
 
-func ExecuteRun(opts options.Opts, repositoryNames, taskFiles []string, inputs map[string]string) ([]RunResult, error) {
+// ExecuteRun applies the tasks in taskFiles to repositories.
+// See [Run.Run].
+func ExecuteRun(ctx context.Context, opts options.Opts, repositoryNames, taskFiles []string, inputs map[string]string) ([]RunResult, error) {
 	err := options.Initialize(&opts)
 	if err != nil {
 		return nil, fmt.Errorf("initialize options: %w", err)
@@ -170,12 +223,13 @@ func ExecuteRun(opts options.Opts, repositoryNames, taskFiles []string, inputs m
 			Git:              gitClient,
 			PullRequestCache: prCache,
 		},
-		PullRequestCache: prCache,
-		PushGateway:      opts.PushGateway,
-		RepositoryLister: repositoryCache,
-		TaskRegistry:     taskRegistry,
+		PullRequestCache:     prCache,
+		PushGateway:          opts.PushGateway,
+		RepositoryLister:     repositoryCache,
+		RepositoryLogMaxSize: opts.RepositoryLogMaxSize,
+		TaskRegistry:         taskRegistry,
 	}
-	return e.Run(repositoryNames, taskFiles, inputs)
+	return e.Run(ctx, repositoryNames, taskFiles, inputs)
 }
 
 func applyActionsInDirectory(actions []action.Action, ctx context.Context, dir string) error {
@@ -2,7 +2,9 @@ package command_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -17,8 +19,10 @@ import (
 	"github.com/wndhydrnt/saturn-bot/pkg/cache"
 	"github.com/wndhydrnt/saturn-bot/pkg/clock"
 	"github.com/wndhydrnt/saturn-bot/pkg/command"
+	sbcontext "github.com/wndhydrnt/saturn-bot/pkg/context"
 	"github.com/wndhydrnt/saturn-bot/pkg/filter"
 	"github.com/wndhydrnt/saturn-bot/pkg/host"
+	"github.com/wndhydrnt/saturn-bot/pkg/log"
 	"github.com/wndhydrnt/saturn-bot/pkg/options"
 	"github.com/wndhydrnt/saturn-bot/pkg/processor"
 	"github.com/wndhydrnt/saturn-bot/pkg/task"
@@ -26,6 +30,7 @@ import (
 	hostmock "github.com/wndhydrnt/saturn-bot/test/mock/host"
 	processormock "github.com/wndhydrnt/saturn-bot/test/mock/processor"
 	"go.uber.org/mock/gomock"
+	"go.uber.org/zap"
 	"gopkg.in/yaml.v3"
 )
 
@@ -212,12 +217,12 @@ func TestExecuteRunner_Run(t *testing.T) {
 	procMock := processormock.NewMockRepositoryTaskProcessor(ctrl)
 	anyTask := []*task.Task{}
 	procMock.EXPECT().
-		Process(false, repoOne, gomock.AssignableToTypeOf(anyTask), true).
+		Process(gomock.Any(), false, repoOne, gomock.AssignableToTypeOf(anyTask), true).
 		Return([]processor.ProcessResult{
 			{Result: processor.ResultNoChanges, Task: &task.Task{Task: testTask}},
 		})
 	procMock.EXPECT().
-		Process(false, repoTwo, gomock.AssignableToTypeOf(anyTask), true).
+		Process(gomock.Any(), false, repoTwo, gomock.AssignableToTypeOf(anyTask), true).
 		Return([]processor.ProcessResult{
 			{Result: processor.ResultNoChanges, Task: &task.Task{Task: testTask}},
 		})
@@ -245,7 +250,7 @@ func TestExecuteRunner_Run(t *testing.T) {
 		RepositoryLister: host.NewRepositoryCache(setupCacher(t), clock.Default, filepath.Join(tmpDir, "cache"), 0),
 		TaskRegistry:     taskRegistry,
 	}
-	_, err := runner.Run([]string{}, []string{taskFile}, map[string]string{})
+	_, err := runner.Run(context.Background(), []string{}, []string{taskFile}, map[string]string{})
 
 	require.NoError(t, err)
 	require.True(t, gock.IsDone(), "All HTTP requests sent")
@@ -270,7 +275,7 @@ func TestExecuteRunner_Run_DryRun(t *testing.T) {
 	procMock := processormock.NewMockRepositoryTaskProcessor(ctrl)
 	anyTask := []*task.Task{}
 	procMock.EXPECT().
-		Process(true, repo, gomock.AssignableToTypeOf(anyTask), true).
+		Process(gomock.Any(), true, repo, gomock.AssignableToTypeOf(anyTask), true).
 		Return([]processor.ProcessResult{
 			{Result: processor.ResultNoChanges, Task: &task.Task{Task: testTask}},
 		})
@@ -283,7 +288,7 @@ func TestExecuteRunner_Run_DryRun(t *testing.T) {
 		RepositoryLister: host.NewRepositoryCache(setupCacher(t), clock.Default, filepath.Join(tmpDir, "cache"), 0),
 		TaskRegistry:     taskRegistry,
 	}
-	_, err := runner.Run([]string{}, []string{taskFile}, map[string]string{})
+	_, err := runner.Run(context.Background(), []string{}, []string{taskFile}, map[string]string{})
 
 	require.NoError(t, err)
 }
@@ -304,7 +309,7 @@ func TestExecuteRunner_Run_RepositoriesCLI(t *testing.T) {
 	procMock := processormock.NewMockRepositoryTaskProcessor(ctrl)
 	anyTask := []*task.Task{}
 	procMock.EXPECT().
-		Process(false, repo, gomock.AssignableToTypeOf(anyTask), false).
+		Process(gomock.Any(), false, repo, gomock.AssignableToTypeOf(anyTask), false).
 		Return([]processor.ProcessResult{
 			{Result: processor.ResultNoChanges, Task: &task.Task{Task: testTask}},
 		})
@@ -316,11 +321,90 @@ func TestExecuteRunner_Run_RepositoriesCLI(t *testing.T) {
 		Processor:    procMock,
 		TaskRegistry: taskRegistry,
 	}
-	_, err := runner.Run([]string{"git.local/unittest/repo"}, []string{taskFile}, map[string]string{})
+	_, err := runner.Run(context.Background(), []string{"git.local/unittest/repo"}, []string{taskFile}, map[string]string{})
 
 	require.NoError(t, err)
 }
 
+func TestExecuteRunner_Run_RepositoriesWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := setupRunRepoMock(ctrl, "repo")
+	hostm := &mockHost{
+		repositories: []host.Repository{repo},
+	}
+	testTask := createTestTask("git.local/unittest/other")
+	taskFile := createTestTaskFile(testTask)
+	defer func() {
+		if err := os.Remove(taskFile); err != nil {
+			panic(err)
+		}
+	}()
+	procMock := processormock.NewMockRepositoryTaskProcessor(ctrl)
+	anyTask := []*task.Task{}
+	// Verifies that the filters of the task decide if the repository gets processed.
+	procMock.EXPECT().
+		Process(gomock.Any(), false, repo, gomock.AssignableToTypeOf(anyTask), true).
+		Return([]processor.ProcessResult{
+			{Result: processor.ResultNoMatch, Task: &task.Task{Task: testTask}},
+		})
+	taskRegistry := task.NewRegistry(runTestOpts)
+
+	runner := &command.Run{
+		DryRun:       false,
+		Hosts:        []host.Host{hostm},
+		Processor:    procMock,
+		TaskRegistry: taskRegistry,
+	}
+	inputs := map[string]string{sbcontext.RunDataKeyFilterRepositories: "true"}
+	_, err := runner.Run(context.Background(), []string{"git.local/unittest/repo"}, []string{taskFile}, inputs)
+
+	require.NoError(t, err)
+}
+
+func TestExecuteRunner_Run_Cancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := setupRunRepoMock(ctrl, "repo")
+	repoTwo := setupRunRepoMock(ctrl, "repoTwo")
+	hostm := &mockHost{
+		repositories: []host.Repository{repo, repoTwo},
+	}
+	testTask := createTestTask("git.local/unittest/repo.*")
+	taskFile := createTestTaskFile(testTask)
+	defer func() {
+		if err := os.Remove(taskFile); err != nil {
+			panic(err)
+		}
+	}()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	procMock := processormock.NewMockRepositoryTaskProcessor(ctrl)
+	anyTask := []*task.Task{}
+	// Only the first repository gets processed.
+	// A user cancels the run while the first repository is being processed.
+	procMock.EXPECT().
+		Process(ctx, false, repo, gomock.AssignableToTypeOf(anyTask), false).
+		DoAndReturn(func(_ context.Context, _ bool, _ host.Repository, _ []*task.Task, _ bool) []processor.ProcessResult {
+			cancel()
+			return []processor.ProcessResult{
+				{Result: processor.ResultNoChanges, Task: &task.Task{Task: testTask}},
+				{Error: context.Canceled, Result: processor.ResultUnknown, Task: &task.Task{Task: testTask}},
+			}
+		})
+	taskRegistry := task.NewRegistry(runTestOpts)
+
+	runner := &command.Run{
+		DryRun:       false,
+		Hosts:        []host.Host{hostm},
+		Processor:    procMock,
+		TaskRegistry: taskRegistry,
+	}
+	results, err := runner.Run(ctx, []string{"git.local/unittest/repo", "git.local/unittest/repoTwo"}, []string{taskFile}, map[string]string{})
+
+	require.ErrorIs(t, err, command.ErrRunCancelled)
+	require.Len(t, results, 1, "Discards the result of the interrupted task")
+	require.Equal(t, "git.local/unittest/repo", results[0].RepositoryName)
+}
+
 func TestExecuteRunner_Run_Inputs(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	repo := setupRunRepoMock(ctrl, "repo")
@@ -356,7 +440,7 @@ func TestExecuteRunner_Run_Inputs(t *testing.T) {
 	}
 
 	procMock.EXPECT().
-		Process(false, repo, gomock.Cond(isTask), false).
+		Process(gomock.Any(), false, repo, gomock.Cond(isTask), false).
 		Return([]processor.ProcessResult{
 			{Result: processor.ResultNoChanges, Task: &task.Task{Task: taskOk}},
 		})
@@ -367,7 +451,47 @@ func TestExecuteRunner_Run_Inputs(t *testing.T) {
 		Processor:    procMock,
 		TaskRegistry: task.NewRegistry(runTestOpts),
 	}
-	_, err := runner.Run([]string{"git.local/unittest/repo"}, []string{taskOkFile, taskMissingInputFile}, map[string]string{"test": "unit"})
+	_, err := runner.Run(context.Background(), []string{"git.local/unittest/repo"}, []string{taskOkFile, taskMissingInputFile}, map[string]string{"test": "unit"})
 
 	require.NoError(t, err)
 }
+
+func TestExecuteRunner_Run_RepositoryLog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := setupRunRepoMock(ctrl, "repo")
+	hostm := &mockHost{
+		repositories: []host.Repository{repo},
+	}
+	testTask := createTestTask("git.local/unittest/repo")
+	taskFile := createTestTaskFile(testTask)
+	defer func() {
+		if err := os.Remove(taskFile); err != nil {
+			panic(err)
+		}
+	}()
+	procMock := processormock.NewMockRepositoryTaskProcessor(ctrl)
+	anyTask := []*task.Task{}
+	procMock.EXPECT().
+		Process(gomock.Any(), false, repo, gomock.AssignableToTypeOf(anyTask), false).
+		DoAndReturn(func(ctx context.Context, _ bool, _ host.Repository, _ []*task.Task, _ bool) []processor.ProcessResult {
+			capture := sbcontext.LogCapture(ctx)
+			require.NotNil(t, capture, "Passes the log capture to the processor")
+			log.Tee(zap.NewNop().Sugar(), capture).Debugw("Executed command", "stdout", "hello")
+			return []processor.ProcessResult{
+				{Error: errors.New("action failed"), Result: processor.ResultUnknown, Task: &task.Task{Task: testTask}},
+			}
+		})
+	taskRegistry := task.NewRegistry(runTestOpts)
+
+	runner := &command.Run{
+		DryRun:               false,
+		Hosts:                []host.Host{hostm},
+		Processor:            procMock,
+		RepositoryLogMaxSize: 1024,
+		TaskRegistry:         taskRegistry,
+	}
+	results, _ := runner.Run(context.Background(), []string{"git.local/unittest/repo"}, []string{taskFile}, map[string]string{})
+
+	require.Len(t, results, 1)
+	require.Contains(t, results[0].Log, "DEBUG\tExecuted command\t{\"stdout\": \"hello\"}")
+}
@@ -98,6 +98,9 @@ func (s *ScheduleRunner) Run(opts ScheduleRunnerRunOptions) error {
 	case response.JSON401 != nil:
 		handleApiError(opts.OutLog, response.JSON401)
 		return errSchedulingFailed
+	case response.JSON403 != nil:
+		handleApiError(opts.OutLog, response.JSON403)
+		return errSchedulingFailed
 	default:
 		return fmt.Errorf("unexpected HTTP response with code %d", response.HTTPResponse.StatusCode)
 	}
@@ -132,6 +135,8 @@ func (s *ScheduleRunner) waitForRun(id int, opts ScheduleRunnerRunOptions) error
 				}
 			case response.JSON401 != nil:
 				return fmt.Errorf("failed to authenticate")
+			case response.JSON403 != nil:
+				return fmt.Errorf("not allowed to read run %d", id)
 			case response.JSON404 != nil:
 				return fmt.Errorf("run %d not found", id)
 			default:
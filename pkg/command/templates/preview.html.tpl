@@ -0,0 +1,69 @@
+<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>saturn-bot preview</title>
+  <style>
+    body { font-family: sans-serif; margin: 2rem; }
+    table { border-collapse: collapse; margin-bottom: 2rem; }
+    th, td { border: 1px solid #ccc; padding: 0.25rem 0.5rem; text-align: left; vertical-align: top; }
+    pre { background: #f6f8fa; padding: 0.5rem; overflow-x: auto; }
+    .error { color: #b00020; }
+  </style>
+</head>
+<body>
+  <h1>Preview</h1>
+  <table>
+    <tr><th>Matched</th><th>Changed</th><th>Failed</th></tr>
+    <tr><td>{{ .Matched }}</td><td>{{ .Changed }}</td><td>{{ .Failed }}</td></tr>
+  </table>
+
+  <h2>Results</h2>
+  <table>
+    <tr><th>Repository</th><th>Task</th><th>Matched</th><th>Changed</th><th>Error</th></tr>
+    {{- range .Results }}
+    {{- if or .Matched .Error }}
+    <tr>
+      <td>{{ .Repository }}</td>
+      <td>{{ .Task }}</td>
+      <td>{{ if .Matched }}yes{{ else }}no{{ end }}</td>
+      <td>{{ if .Changed }}yes{{ else }}no{{ end }}</td>
+      <td class="error">{{ .Error }}</td>
+    </tr>
+    {{- end }}
+    {{- end }}
+  </table>
+
+  {{- range .Results }}
+  {{- if .Changed }}
+  <h3>{{ .Repository }} - {{ .Task }}</h3>
+  <ul>
+    <li>Branch: <code>{{ .BranchName }}</code></li>
+    <li>Commit message: {{ .CommitMessage }}</li>
+    <li>Pull request title: {{ .PrTitle }}</li>
+  </ul>
+  <pre>{{ .DiffStat }}</pre>
+  <details>
+    <summary>Diff</summary>
+    <pre>{{ .Diff }}</pre>
+  </details>
+  {{- end }}
+  {{- end }}
+
+  <h2>Filters</h2>
+  <table>
+    <tr><th>Repository</th><th>Task</th><th>Filter</th><th>Match</th><th>Error</th></tr>
+    {{- range $result := .Results }}
+    {{- range .Filters }}
+    <tr>
+      <td>{{ $result.Repository }}</td>
+      <td>{{ $result.Task }}</td>
+      <td><code>{{ .Filter }}</code></td>
+      <td>{{ if .Match }}yes{{ else }}no{{ end }}</td>
+      <td class="error">{{ .Error }}</td>
+    </tr>
+    {{- end }}
+    {{- end }}
+  </table>
+</body>
+</html>
@@ -0,0 +1,45 @@
+# Preview
+
+| Matched | Changed | Failed |
+| ------- | ------- | ------ |
+| {{ .Matched }} | {{ .Changed }} | {{ .Failed }} |
+
+## Results
+
+| Repository | Task | Matched | Changed | Error |
+| ---------- | ---- | ------- | ------- | ----- |
+{{- range .Results }}
+{{- if or .Matched .Error }}
+| {{ .Repository }} | {{ .Task }} | {{ if .Matched }}yes{{ else }}no{{ end }} | {{ if .Changed }}yes{{ else }}no{{ end }} | {{ .Error }} |
+{{- end }}
+{{- end }}
+{{ range .Results }}
+{{- if .Changed }}
+### {{ .Repository }} - {{ .Task }}
+
+- Branch: `{{ .BranchName }}`
+- Commit message: {{ .CommitMessage }}
+- Pull request title: {{ .PrTitle }}
+
+````
+{{ .DiffStat }}````
+
+<details>
+<summary>Diff</summary>
+
+````diff
+{{ .Diff }}````
+
+</details>
+
+{{ end }}
+{{- end }}
+## Filters
+
+| Repository | Task | Filter | Match | Error |
+| ---------- | ---- | ------ | ----- | ----- |
+{{- range $result := .Results }}
+{{- range .Filters }}
+| {{ $result.Repository }} | {{ $result.Task }} | `{{ .Filter }}` | {{ if .Match }}yes{{ else }}no{{ end }} | {{ .Error }} |
+{{- end }}
+{{- end }}
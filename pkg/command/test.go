@@ -0,0 +1,564 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gosimple/slug"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/wndhydrnt/saturn-bot/pkg/config"
+	sbcontext "github.com/wndhydrnt/saturn-bot/pkg/context"
+	"github.com/wndhydrnt/saturn-bot/pkg/filter"
+	"github.com/wndhydrnt/saturn-bot/pkg/host"
+	"github.com/wndhydrnt/saturn-bot/pkg/options"
+	"github.com/wndhydrnt/saturn-bot/pkg/ptr"
+	"github.com/wndhydrnt/saturn-bot/pkg/task"
+	"gopkg.in/yaml.v3"
+)
+
+// testFileSuffix is the suffix of a file that contains the tests of a task file.
+// The tests of task file "example.yaml" are stored in "example_test.yaml".
+const testFileSuffix = "_test"
+
+// taskTestFile is the content of a file that contains the tests of the tasks in a task file.
+type taskTestFile struct {
+	Tests []taskTestCase `yaml:"tests"`
+}
+
+// taskTestCase describes one test of a task.
+type taskTestCase struct {
+	// After is the directory that contains the expected files of the repository after the actions have been applied.
+	// Relative to the test file.
+	// Defaults to "testdata/<slug of name>/after".
+	After string `yaml:"after"`
+	// Before is the directory that contains the files of the repository before the actions are applied.
+	// Relative to the test file.
+	// Defaults to "testdata/<slug of name>/before".
+	Before string `yaml:"before"`
+	// Inputs are the inputs to set for the task.
+	Inputs map[string]string `yaml:"inputs"`
+	// Match is the expected verdict of the filters of the task.
+	// Defaults to true.
+	Match *bool `yaml:"match"`
+	// Name identifies the test.
+	Name string `yaml:"name"`
+	// Repository is the full name of the repository, for example github.com/wndhydrnt/saturn-bot.
+	Repository string `yaml:"repository"`
+	// Task is the name of the task to test.
+	// Can be empty if the task file contains only one task.
+	Task string `yaml:"task"`
+}
+
+func (tc taskTestCase) dir(dir, value, defaultName string) string {
+	if value == "" {
+		return filepath.Join(dir, "testdata", slug.Make(tc.Name), defaultName)
+	}
+
+	return filepath.Join(dir, value)
+}
+
+type TestRunner struct {
+	Opts options.Opts
+	// Update makes the runner write the files of the repository after the actions have been applied
+	// to the "after" directory of each test instead of comparing them.
+	Update bool
+}
+
+func NewTestRunner(opts options.Opts, update bool) (*TestRunner, error) {
+	err := options.Initialize(&opts)
+	if err != nil {
+		return nil, fmt.Errorf("initialize options: %w", err)
+	}
+
+	return &TestRunner{Opts: opts, Update: update}, nil
+}
+
+// NewTestRunnerFromConfig creates a new TestRunner by reading from configFile.
+// Like [NewCiRunnerFromConfig], it ignores errors about missing hosts because tests don't connect to a host.
+func NewTestRunnerFromConfig(configFile string, update bool) (*TestRunner, error) {
+	cfg, err := config.Read(configFile)
+	if err != nil && !errors.Is(err, config.ErrNoToken) {
+		return nil, err
+	}
+
+	opts, err := options.ToOptions(cfg)
+	if err != nil && !errors.Is(err, options.ErrNoHosts) {
+		return nil, err
+	}
+
+	return NewTestRunner(opts, update)
+}
+
+// Run executes the tests of each task file in files and writes a message to out for each test.
+// files can contain task files or test files.
+// The runner looks up the test file of a task file and the task file of a test file.
+func (r *TestRunner) Run(out io.Writer, files ...string) error {
+	failed := false
+	for _, taskFile := range taskFilesToTest(files) {
+		testFile := toTestFile(taskFile)
+		testFileContent, err := readTaskTestFile(testFile)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				fmt.Fprintf(out, "⚠️  No tests found for task file %s\n", taskFile)
+				continue
+			}
+
+			fmt.Fprintf(out, "❌ Failed to read test file %s: %s\n", testFile, err)
+			failed = true
+			continue
+		}
+
+		for _, tc := range testFileContent.Tests {
+			err := r.runTestCase(filepath.Dir(testFile), taskFile, tc)
+			if err != nil {
+				fmt.Fprintf(out, "❌ Test %s of task file %s failed: %s\n", tc.Name, taskFile, err)
+				failed = true
+				continue
+			}
+
+			if r.Update {
+				fmt.Fprintf(out, "📝 Test %s of task file %s updated\n", tc.Name, taskFile)
+			} else {
+				fmt.Fprintf(out, "✅ Test %s of task file %s passed\n", tc.Name, taskFile)
+			}
+		}
+	}
+
+	if failed {
+		return fmt.Errorf("tests failed")
+	}
+
+	return nil
+}
+
+func (r *TestRunner) runTestCase(dir, taskFile string, tc taskTestCase) error {
+	if tc.Name == "" {
+		return errors.New("test has no name")
+	}
+
+	repo, err := newFixtureRepository(tc.Repository)
+	if err != nil {
+		return err
+	}
+
+	// Read the task for each test to not share inputs between tests.
+	reg := task.NewRegistry(r.Opts)
+	err = reg.ReadTasks(taskFile)
+	if err != nil {
+		return fmt.Errorf("read task file: %w", err)
+	}
+
+	defer reg.Stop()
+	t, err := findTaskToTest(reg.GetTasks(), tc.Task)
+	if err != nil {
+		return err
+	}
+
+	err = t.SetInputs(tc.Inputs)
+	if err != nil {
+		return err
+	}
+
+	checkoutPath, err := os.MkdirTemp("", "saturn-bot-test-")
+	if err != nil {
+		return fmt.Errorf("create temporary checkout: %w", err)
+	}
+
+	defer os.RemoveAll(checkoutPath)
+	beforeDir := tc.dir(dir, tc.Before, "before")
+	err = copyDir(beforeDir, checkoutPath)
+	if err != nil {
+		return fmt.Errorf("copy files of before directory %s: %w", beforeDir, err)
+	}
+
+	ctx := context.WithValue(context.Background(), sbcontext.RepositoryKey{}, repo)
+	ctx = context.WithValue(ctx, sbcontext.CheckoutPath{}, checkoutPath)
+	ctx = sbcontext.WithRunData(ctx, t.RunData())
+	match, verdicts, err := applyTestFilters(ctx, t)
+	if err != nil {
+		return err
+	}
+
+	wantMatch := ptr.FromDef(tc.Match, true)
+	if match != wantMatch {
+		return fmt.Errorf("expected filters to match %t but got %t:\n%s", wantMatch, match, strings.Join(verdicts, "\n"))
+	}
+
+	if !match {
+		return nil
+	}
+
+	err = applyActionsInDirectory(t.Actions(), ctx, checkoutPath)
+	if err != nil {
+		return err
+	}
+
+	afterDir := tc.dir(dir, tc.After, "after")
+	if r.Update {
+		err := os.RemoveAll(afterDir)
+		if err != nil {
+			return fmt.Errorf("remove after directory %s: %w", afterDir, err)
+		}
+
+		return copyDir(checkoutPath, afterDir)
+	}
+
+	diff, err := diffDirs(afterDir, checkoutPath)
+	if err != nil {
+		return err
+	}
+
+	if diff != "" {
+		return fmt.Errorf("files differ from after directory %s:\n%s", afterDir, diff)
+	}
+
+	return nil
+}
+
+// applyTestFilters executes all filters of t.
+// It returns a description of the verdict of each filter.
+func applyTestFilters(ctx context.Context, t *task.Task) (bool, []string, error) {
+	if !t.HasFilters() {
+		return false, []string{"task has no filters"}, nil
+	}
+
+	var verdicts []string
+	match := true
+	filters := slices.Concat(t.FiltersPreClone(), t.FiltersPostClone())
+	for _, f := range filters {
+		filterMatch, err := f.Do(ctx)
+		if err != nil {
+			return false, nil, fmt.Errorf("filter %s failed: %w", f.String(), err)
+		}
+
+		verdicts = append(verdicts, formatFilterVerdict(f, filterMatch))
+		match = match && filterMatch
+	}
+
+	return match, verdicts, nil
+}
+
+func formatFilterVerdict(f filter.Filter, match bool) string {
+	if match {
+		return fmt.Sprintf("  ✅ %s matches", f.String())
+	}
+
+	return fmt.Sprintf("  ❌ %s doesn't match", f.String())
+}
+
+func findTaskToTest(tasks []*task.Task, name string) (*task.Task, error) {
+	if name == "" {
+		if len(tasks) != 1 {
+			return nil, fmt.Errorf("task file contains %d tasks - set the name of the task to test", len(tasks))
+		}
+
+		return tasks[0], nil
+	}
+
+	for _, t := range tasks {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+
+	return nil, fmt.Errorf("task %s not found", name)
+}
+
+// taskFilesToTest maps each test file in files to its task file.
+// It removes duplicates.
+func taskFilesToTest(files []string) []string {
+	var taskFiles []string
+	for _, f := range files {
+		ext := filepath.Ext(f)
+		base := strings.TrimSuffix(f, ext)
+		if strings.HasSuffix(base, testFileSuffix) {
+			f = strings.TrimSuffix(base, testFileSuffix) + ext
+		}
+
+		if !slices.Contains(taskFiles, f) {
+			taskFiles = append(taskFiles, f)
+		}
+	}
+
+	return taskFiles
+}
+
+func toTestFile(taskFile string) string {
+	ext := filepath.Ext(taskFile)
+	return strings.TrimSuffix(taskFile, ext) + testFileSuffix + ext
+}
+
+func readTaskTestFile(path string) (*taskTestFile, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	content := &taskTestFile{}
+	err = dec.Decode(content)
+	if err != nil {
+		return nil, fmt.Errorf("decode test file: %w", err)
+	}
+
+	return content, nil
+}
+
+// copyDir copies all files in src to dst.
+// An empty repository doesn't need a src directory.
+func copyDir(src, dst string) error {
+	_, err := os.Stat(src)
+	if errors.Is(err, os.ErrNotExist) {
+		return os.MkdirAll(dst, 0755)
+	}
+
+	return os.CopyFS(dst, os.DirFS(src))
+}
+
+// diffDirs compares the files in wantDir with those in gotDir.
+// It returns a unified diff of each file that differs.
+// It returns an empty string if the directories are equal.
+func diffDirs(wantDir, gotDir string) (string, error) {
+	wantFiles, err := listFiles(wantDir)
+	if err != nil {
+		return "", err
+	}
+
+	gotFiles, err := listFiles(gotDir)
+	if err != nil {
+		return "", err
+	}
+
+	paths := slices.Concat(wantFiles, gotFiles)
+	slices.Sort(paths)
+	paths = slices.Compact(paths)
+	sb := &strings.Builder{}
+	for _, p := range paths {
+		want, err := readFileIfExists(filepath.Join(wantDir, p))
+		if err != nil {
+			return "", err
+		}
+
+		got, err := readFileIfExists(filepath.Join(gotDir, p))
+		if err != nil {
+			return "", err
+		}
+
+		if want == got {
+			continue
+		}
+
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(want),
+			B:        difflib.SplitLines(got),
+			FromFile: "after/" + p,
+			ToFile:   "actual/" + p,
+			Context:  3,
+		})
+		if err != nil {
+			return "", fmt.Errorf("diff file %s: %w", p, err)
+		}
+
+		sb.WriteString(diff)
+	}
+
+	return sb.String(), nil
+}
+
+// listFiles returns the paths of all files in dir, relative to dir.
+// It returns no files if dir doesn't exist.
+func listFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) && path == dir {
+				return filepath.SkipDir
+			}
+
+			return err
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		files = append(files, filepath.ToSlash(rel))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list files in %s: %w", dir, err)
+	}
+
+	return files, nil
+}
+
+func readFileIfExists(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+var errFixtureRepository = errors.New("not supported by a repository in a test")
+
+type fixtureHost struct {
+	name string
+}
+
+func (h fixtureHost) AuthenticatedUser() (*host.UserInfo, error) {
+	return nil, errFixtureRepository
+}
+
+func (h fixtureHost) Name() string {
+	return h.name
+}
+
+// fixtureRepository is a [host.Repository] that exists only in a test.
+// It supports the methods that filters and actions commonly call.
+type fixtureRepository struct {
+	host  string
+	name  string
+	owner string
+}
+
+func newFixtureRepository(fullName string) (*fixtureRepository, error) {
+	parts := strings.Split(fullName, "/")
+	if len(parts) < 3 {
+		return nil, fmt.Errorf("repository '%s' is not in the format <host>/<owner>/<name>", fullName)
+	}
+
+	return &fixtureRepository{
+		host:  parts[0],
+		name:  parts[len(parts)-1],
+		owner: strings.Join(parts[1:len(parts)-1], "/"),
+	}, nil
+}
+
+func (r *fixtureRepository) BaseBranch() string {
+	return "main"
+}
+
+func (r *fixtureRepository) CanMergePullRequest(_ *host.PullRequest) (bool, error) {
+	return false, errFixtureRepository
+}
+
+func (r *fixtureRepository) CloneUrlHttp() string {
+	return fmt.Sprintf("https://%s.git", r.FullName())
+}
+
+func (r *fixtureRepository) CloneUrlSsh() string {
+	return fmt.Sprintf("git@%s:%s/%s.git", r.host, r.owner, r.name)
+}
+
+func (r *fixtureRepository) ClosePullRequest(_ string, _ *host.PullRequest) (*host.PullRequest, error) {
+	return nil, errFixtureRepository
+}
+
+func (r *fixtureRepository) CreatePullRequestComment(_ string, _ *host.PullRequest) error {
+	return errFixtureRepository
+}
+
+func (r *fixtureRepository) CreatePullRequest(_ string, _ host.PullRequestData) (*host.PullRequest, error) {
+	return nil, errFixtureRepository
+}
+
+func (r *fixtureRepository) DeleteBranch(_ *host.PullRequest) error {
+	return errFixtureRepository
+}
+
+func (r *fixtureRepository) DeletePullRequestComment(_ host.PullRequestComment, _ *host.PullRequest) error {
+	return errFixtureRepository
+}
+
+func (r *fixtureRepository) EnableAutoMerge(_ host.MergeOptions, _ *host.PullRequest) error {
+	return errFixtureRepository
+}
+
+func (r *fixtureRepository) FindPullRequest(_ string) (*host.PullRequest, error) {
+	return nil, host.ErrPullRequestNotFound
+}
+
+func (r *fixtureRepository) FullName() string {
+	return fmt.Sprintf("%s/%s/%s", r.host, r.owner, r.name)
+}
+
+func (r *fixtureRepository) GetPullRequestBody(_ *host.PullRequest) string {
+	return ""
+}
+
+func (r *fixtureRepository) HasSuccessfulPullRequestBuild(_ *host.PullRequest) (bool, error) {
+	return false, errFixtureRepository
+}
+
+func (r *fixtureRepository) Host() host.HostDetail {
+	return fixtureHost{name: r.host}
+}
+
+func (r *fixtureRepository) ID() int64 {
+	return 0
+}
+
+func (r *fixtureRepository) IsArchived() bool {
+	return false
+}
+
+func (r *fixtureRepository) ListPullRequestComments(_ *host.PullRequest) ([]host.PullRequestComment, error) {
+	return nil, errFixtureRepository
+}
+
+func (r *fixtureRepository) MarkPullRequestReady(_ *host.PullRequest) error {
+	return errFixtureRepository
+}
+
+func (r *fixtureRepository) MergePullRequest(_ host.MergeOptions, _ *host.PullRequest) error {
+	return errFixtureRepository
+}
+
+func (r *fixtureRepository) Name() string {
+	return r.name
+}
+
+func (r *fixtureRepository) Owner() string {
+	return r.owner
+}
+
+func (r *fixtureRepository) RequestPullRequestReview(_ []string, _ *host.PullRequest) error {
+	return errFixtureRepository
+}
+
+func (r *fixtureRepository) UpdatePullRequest(_ host.PullRequestData, _ *host.PullRequest) error {
+	return errFixtureRepository
+}
+
+func (r *fixtureRepository) WebUrl() string {
+	return "https://" + r.FullName()
+}
+
+func (r *fixtureRepository) Raw() any {
+	return nil
+}
+
+func (r *fixtureRepository) UpdatedAt() time.Time {
+	return time.Time{}
+}
@@ -0,0 +1,132 @@
+package command_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wndhydrnt/saturn-bot/pkg/command"
+)
+
+const testRunnerTaskContent = `name: Unit Test
+filters:
+  - filter: repository
+    params:
+      host: git.local
+      owner: unit
+      name: test
+actions:
+  - action: fileCreate
+    params:
+      content: Hello
+      path: hello.txt
+`
+
+func writeTestRunnerFile(t *testing.T, path, content string) {
+	t.Helper()
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	require.NoError(t, err)
+	err = os.WriteFile(path, []byte(content), 0600)
+	require.NoError(t, err)
+}
+
+func setupTestRunnerFiles(t *testing.T, testContent string) string {
+	dir := t.TempDir()
+	taskFile := filepath.Join(dir, "task.yaml")
+	writeTestRunnerFile(t, taskFile, testRunnerTaskContent)
+	writeTestRunnerFile(t, filepath.Join(dir, "task_test.yaml"), testContent)
+	writeTestRunnerFile(t, filepath.Join(dir, "testdata", "creates-file", "before", "README.md"), "# Test\n")
+	return taskFile
+}
+
+func TestTestRunner_Run_Passed(t *testing.T) {
+	taskFile := setupTestRunnerFiles(t, `tests:
+  - name: creates file
+    repository: git.local/unit/test
+  - name: does not match
+    repository: git.local/other/test
+    match: false
+`)
+	dir := filepath.Dir(taskFile)
+	writeTestRunnerFile(t, filepath.Join(dir, "testdata", "creates-file", "after", "README.md"), "# Test\n")
+	writeTestRunnerFile(t, filepath.Join(dir, "testdata", "creates-file", "after", "hello.txt"), "Hello")
+	out := &bytes.Buffer{}
+
+	underTest := &command.TestRunner{Opts: tryTestOpts}
+	err := underTest.Run(out, taskFile)
+
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "✅ Test creates file of task file "+taskFile+" passed")
+	assert.Contains(t, out.String(), "✅ Test does not match of task file "+taskFile+" passed")
+}
+
+func TestTestRunner_Run_FilesDiffer(t *testing.T) {
+	taskFile := setupTestRunnerFiles(t, `tests:
+  - name: creates file
+    repository: git.local/unit/test
+`)
+	dir := filepath.Dir(taskFile)
+	writeTestRunnerFile(t, filepath.Join(dir, "testdata", "creates-file", "after", "README.md"), "# Test\n")
+	writeTestRunnerFile(t, filepath.Join(dir, "testdata", "creates-file", "after", "hello.txt"), "Hi")
+	out := &bytes.Buffer{}
+
+	underTest := &command.TestRunner{Opts: tryTestOpts}
+	err := underTest.Run(out, filepath.Join(dir, "task_test.yaml"))
+
+	require.EqualError(t, err, "tests failed")
+	assert.Contains(t, out.String(), "❌ Test creates file of task file "+taskFile+" failed")
+	assert.Contains(t, out.String(), "--- after/hello.txt\n+++ actual/hello.txt\n")
+	assert.Contains(t, out.String(), "-Hi\n+Hello\n")
+}
+
+func TestTestRunner_Run_Update(t *testing.T) {
+	taskFile := setupTestRunnerFiles(t, `tests:
+  - name: creates file
+    repository: git.local/unit/test
+`)
+	dir := filepath.Dir(taskFile)
+	// Outdated file that the update removes.
+	writeTestRunnerFile(t, filepath.Join(dir, "testdata", "creates-file", "after", "outdated.txt"), "outdated")
+	out := &bytes.Buffer{}
+
+	underTest := &command.TestRunner{Opts: tryTestOpts, Update: true}
+	err := underTest.Run(out, taskFile)
+
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "📝 Test creates file of task file "+taskFile+" updated")
+	afterDir := filepath.Join(dir, "testdata", "creates-file", "after")
+	assert.FileExists(t, filepath.Join(afterDir, "README.md"))
+	assert.NoFileExists(t, filepath.Join(afterDir, "outdated.txt"))
+	content, err := os.ReadFile(filepath.Join(afterDir, "hello.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "Hello", string(content))
+}
+
+func TestTestRunner_Run_FilterVerdict(t *testing.T) {
+	taskFile := setupTestRunnerFiles(t, `tests:
+  - name: creates file
+    repository: git.local/other/test
+`)
+	out := &bytes.Buffer{}
+
+	underTest := &command.TestRunner{Opts: tryTestOpts}
+	err := underTest.Run(out, taskFile)
+
+	require.EqualError(t, err, "tests failed")
+	assert.Contains(t, out.String(), "expected filters to match true but got false:\n  ❌ repository(host=^git.local$,owner=^unit$,name=^test$) doesn't match")
+}
+
+func TestTestRunner_Run_NoTestFile(t *testing.T) {
+	taskFile := filepath.Join(t.TempDir(), "task.yaml")
+	writeTestRunnerFile(t, taskFile, testRunnerTaskContent)
+	out := &bytes.Buffer{}
+
+	underTest := &command.TestRunner{Opts: tryTestOpts}
+	err := underTest.Run(out, taskFile)
+
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "⚠️  No tests found for task file "+taskFile)
+}
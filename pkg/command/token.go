@@ -0,0 +1,186 @@
+package command
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/wndhydrnt/saturn-bot/pkg/client"
+	"github.com/wndhydrnt/saturn-bot/pkg/ptr"
+)
+
+var (
+	errTokenRequestFailed = errors.New("api token request failed")
+)
+
+// NewTokenRunnerOptions defines all options expected by [NewTokenRunner].
+type NewTokenRunnerOptions struct {
+	HttpClient   *http.Client
+	ServerApiKey string
+	ServerUrl    string
+}
+
+// NewTokenRunner initializes a new [TokenRunner] from [NewTokenRunnerOptions].
+func NewTokenRunner(opts NewTokenRunnerOptions) (*TokenRunner, error) {
+	client, err := client.NewCustomClientWithResponses(client.CustomClientWithResponsesOptions{
+		ApiKey:     opts.ServerApiKey,
+		BaseUrl:    opts.ServerUrl,
+		HttpClient: opts.HttpClient,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("NewTokenRunner: %w", err)
+	}
+
+	return &TokenRunner{client: client}, nil
+}
+
+// TokenRunner wraps the logic to manage API tokens.
+type TokenRunner struct {
+	client client.ClientWithResponsesInterface
+}
+
+// TokenRunnerCreateOptions are all options expected by [TokenRunner.Create].
+type TokenRunnerCreateOptions struct {
+	// Name of the API token.
+	Name string
+	// OutLog is the writer that [TokenRunner]
+	// writes human-readable log messages to.
+	OutLog io.Writer
+	// OutSecret is the writer that [TokenRunner]
+	// writes the secret of the new token to.
+	OutSecret io.Writer
+	// Scope of the API token.
+	Scope string
+	// Tasks are names or glob patterns of tasks.
+	Tasks []string
+}
+
+// Create creates a new API token and writes its secret to [TokenRunnerCreateOptions.OutSecret].
+func (t *TokenRunner) Create(opts TokenRunnerCreateOptions) error {
+	req := client.CreateApiTokenV1Request{
+		Name:  opts.Name,
+		Scope: client.ApiTokenScopeV1(opts.Scope),
+	}
+	if len(opts.Tasks) > 0 {
+		req.Tasks = ptr.To(opts.Tasks)
+	}
+
+	resp, err := t.client.CreateApiTokenV1WithResponse(ctx, req)
+	if err != nil {
+		return fmt.Errorf("create api token: %w", err)
+	}
+
+	switch {
+	case resp.JSON200 != nil:
+		_, _ = fmt.Fprintf(opts.OutLog, "✅ API token %s with scope %s has been created\n", resp.JSON200.ApiToken.Name, resp.JSON200.ApiToken.Scope)
+		_, _ = fmt.Fprintln(opts.OutSecret, resp.JSON200.Secret)
+		return nil
+	case resp.JSON400 != nil:
+		handleTokenApiError(opts.OutLog, "create", resp.JSON400)
+	case resp.JSON401 != nil:
+		handleTokenApiError(opts.OutLog, "create", resp.JSON401)
+	case resp.JSON403 != nil:
+		handleTokenApiError(opts.OutLog, "create", resp.JSON403)
+	default:
+		return fmt.Errorf("unexpected HTTP response with code %d", resp.HTTPResponse.StatusCode)
+	}
+
+	return errTokenRequestFailed
+}
+
+// TokenRunnerListOptions are all options expected by [TokenRunner.List].
+type TokenRunnerListOptions struct {
+	// OutLog is the writer that [TokenRunner]
+	// writes human-readable log messages to.
+	OutLog io.Writer
+	// OutReport is the writer that [TokenRunner]
+	// writes the list of tokens to.
+	OutReport io.Writer
+}
+
+// List writes all API tokens to [TokenRunnerListOptions.OutReport].
+func (t *TokenRunner) List(opts TokenRunnerListOptions) error {
+	tw := tabwriter.NewWriter(opts.OutReport, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "NAME\tSCOPE\tTASKS\tCREATED AT")
+	listOpts := client.ListOptions{
+		Limit: 50,
+		Page:  1,
+	}
+	for {
+		resp, err := t.client.ListApiTokensV1WithResponse(ctx, &client.ListApiTokensV1Params{
+			ListOptions: ptr.To(listOpts),
+		})
+		if err != nil {
+			return fmt.Errorf("list api tokens: %w", err)
+		}
+
+		switch {
+		case resp.JSON200 != nil:
+			for _, token := range resp.JSON200.ApiTokens {
+				_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", token.Name, token.Scope, strings.Join(ptr.FromDef(token.Tasks, []string{}), ","), token.CreatedAt.Format("2006-01-02T15:04:05Z07:00"))
+			}
+		case resp.JSON401 != nil:
+			handleTokenApiError(opts.OutLog, "list", resp.JSON401)
+			return errTokenRequestFailed
+		case resp.JSON403 != nil:
+			handleTokenApiError(opts.OutLog, "list", resp.JSON403)
+			return errTokenRequestFailed
+		default:
+			return fmt.Errorf("unexpected HTTP response with code %d", resp.HTTPResponse.StatusCode)
+		}
+
+		if resp.JSON200.Page.NextPage == 0 {
+			break
+		}
+
+		listOpts.Page = resp.JSON200.Page.NextPage
+	}
+
+	return tw.Flush()
+}
+
+// TokenRunnerRevokeOptions are all options expected by [TokenRunner.Revoke].
+type TokenRunnerRevokeOptions struct {
+	// Name of the API token.
+	Name string
+	// OutLog is the writer that [TokenRunner]
+	// writes human-readable log messages to.
+	OutLog io.Writer
+}
+
+// Revoke deletes an API token.
+func (t *TokenRunner) Revoke(opts TokenRunnerRevokeOptions) error {
+	resp, err := t.client.DeleteApiTokenV1WithResponse(ctx, opts.Name)
+	if err != nil {
+		return fmt.Errorf("revoke api token: %w", err)
+	}
+
+	switch {
+	case resp.JSON200 != nil:
+		_, _ = fmt.Fprintf(opts.OutLog, "✅ API token %s has been revoked\n", opts.Name)
+		return nil
+	case resp.JSON401 != nil:
+		handleTokenApiError(opts.OutLog, "revoke", resp.JSON401)
+	case resp.JSON403 != nil:
+		handleTokenApiError(opts.OutLog, "revoke", resp.JSON403)
+	case resp.JSON404 != nil:
+		handleTokenApiError(opts.OutLog, "revoke", resp.JSON404)
+	default:
+		return fmt.Errorf("unexpected HTTP response with code %d", resp.HTTPResponse.StatusCode)
+	}
+
+	return errTokenRequestFailed
+}
+
+func handleTokenApiError(out io.Writer, op string, apiErr *client.Error) {
+	_, _ = fmt.Fprintf(out, "❌ Failed to %s api token:\n", op)
+	for _, errDetail := range apiErr.Errors {
+		_, _ = fmt.Fprintf(out, "  Error: %s\n", errDetail.Message)
+		if errDetail.Detail != nil {
+			_, _ = fmt.Fprintf(out, "    Detail: %s\n", ptr.From(errDetail.Detail))
+		}
+	}
+}
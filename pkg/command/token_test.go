@@ -0,0 +1,128 @@
+package command_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/require"
+	"github.com/wndhydrnt/saturn-bot/pkg/client"
+	"github.com/wndhydrnt/saturn-bot/pkg/command"
+	"github.com/wndhydrnt/saturn-bot/pkg/ptr"
+)
+
+func TestTokenRunner_Create(t *testing.T) {
+	defer gock.Off()
+	gock.New(testServerUrl).
+		Post("/api/v1/apiTokens").
+		MatchType("json").
+		JSON(client.CreateApiTokenV1Request{Name: "team-a", Scope: client.Scheduler, Tasks: ptr.To([]string{"team-a-*"})}).
+		Reply(200).
+		JSON(client.CreateApiTokenV1Response{
+			ApiToken: client.ApiTokenV1{Name: "team-a", Scope: client.Scheduler},
+			Secret:   "sbt_secret",
+		})
+	logOut := &bytes.Buffer{}
+	secretOut := &bytes.Buffer{}
+
+	runner, err := command.NewTokenRunner(command.NewTokenRunnerOptions{
+		HttpClient: setupClient(),
+		ServerUrl:  testServerUrl,
+	})
+	require.NoError(t, err)
+	err = runner.Create(command.TokenRunnerCreateOptions{
+		Name:      "team-a",
+		OutLog:    logOut,
+		OutSecret: secretOut,
+		Scope:     "scheduler",
+		Tasks:     []string{"team-a-*"},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "✅ API token team-a with scope scheduler has been created\n", logOut.String())
+	require.Equal(t, "sbt_secret\n", secretOut.String())
+	require.True(t, gock.IsDone())
+}
+
+func TestTokenRunner_Create_Invalid(t *testing.T) {
+	defer gock.Off()
+	gock.New(testServerUrl).
+		Post("/api/v1/apiTokens").
+		Reply(400).
+		JSON(client.Error{Errors: []client.ErrorDetail{{Error: 1007, Message: "scope scheduler requires at least one task"}}})
+	logOut := &bytes.Buffer{}
+
+	runner, err := command.NewTokenRunner(command.NewTokenRunnerOptions{
+		HttpClient: setupClient(),
+		ServerUrl:  testServerUrl,
+	})
+	require.NoError(t, err)
+	err = runner.Create(command.TokenRunnerCreateOptions{
+		Name:      "team-a",
+		OutLog:    logOut,
+		OutSecret: &bytes.Buffer{},
+		Scope:     "scheduler",
+	})
+	require.Error(t, err)
+
+	require.Equal(t, "❌ Failed to create api token:\n  Error: scope scheduler requires at least one task\n", logOut.String())
+	require.True(t, gock.IsDone())
+}
+
+func TestTokenRunner_List(t *testing.T) {
+	defer gock.Off()
+	gock.New(testServerUrl).
+		Get("/api/v1/apiTokens").
+		MatchParams(map[string]string{"limit": "50", "page": "1"}).
+		Reply(200).
+		JSON(client.ListApiTokensV1Response{
+			ApiTokens: []client.ApiTokenV1{
+				{CreatedAt: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), Name: "team-a", Scope: client.Scheduler, Tasks: ptr.To([]string{"team-a-*", "shared"})},
+				{CreatedAt: time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC), Name: "workers", Scope: client.Worker},
+			},
+			Page: client.Page{CurrentPage: 1},
+		})
+	reportOut := &bytes.Buffer{}
+
+	runner, err := command.NewTokenRunner(command.NewTokenRunnerOptions{
+		HttpClient: setupClient(),
+		ServerUrl:  testServerUrl,
+	})
+	require.NoError(t, err)
+	err = runner.List(command.TokenRunnerListOptions{
+		OutLog:    &bytes.Buffer{},
+		OutReport: reportOut,
+	})
+	require.NoError(t, err)
+
+	want := `NAME     SCOPE      TASKS            CREATED AT
+team-a   scheduler  team-a-*,shared  2000-01-01T00:00:00Z
+workers  worker                      2000-01-02T00:00:00Z
+`
+	require.Equal(t, want, reportOut.String())
+	require.True(t, gock.IsDone())
+}
+
+func TestTokenRunner_Revoke(t *testing.T) {
+	defer gock.Off()
+	gock.New(testServerUrl).
+		Delete("/api/v1/apiTokens/team-a").
+		Reply(200).
+		JSON(client.DeleteApiTokenV1Response{})
+	logOut := &bytes.Buffer{}
+
+	runner, err := command.NewTokenRunner(command.NewTokenRunnerOptions{
+		HttpClient: setupClient(),
+		ServerUrl:  testServerUrl,
+	})
+	require.NoError(t, err)
+	err = runner.Revoke(command.TokenRunnerRevokeOptions{
+		Name:   "team-a",
+		OutLog: logOut,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "✅ API token team-a has been revoked\n", logOut.String())
+	require.True(t, gock.IsDone())
+}
@@ -2,10 +2,12 @@ package command
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 
+	"github.com/mattn/go-isatty"
 	"github.com/wndhydrnt/saturn-bot/pkg/action"
 	sbcontext "github.com/wndhydrnt/saturn-bot/pkg/context"
 	"github.com/wndhydrnt/saturn-bot/pkg/git"
@@ -15,24 +17,60 @@ import (
 	"github.com/wndhydrnt/saturn-bot/pkg/template"
 )
 
+const (
+	TryColorAlways = "always"
+	TryColorAuto   = "auto"
+	TryColorNever  = "never"
+
+	TryOutputFormatJson = "json"
+	TryOutputFormatText = "text"
+)
+
 type TryRunner struct {
 	ApplyActionsFunc func(actions []action.Action, ctx context.Context, dir string) error
-	GitClient        git.GitClient
-	Hosts            []host.Host
-	Inputs           map[string]string
-	Out              io.Writer
-	Registry         *task.Registry
-	RepositoryName   string
-	TaskFile         string
-	TaskName         string
+	// Color makes the runner colour the diff of the changes.
+	Color bool
+	// DefaultCommitMessage is the commit message to use if a task doesn't define one.
+	DefaultCommitMessage string
+	// DiffStat makes the runner print a summary of the changed files instead of the full diff.
+	DiffStat  bool
+	GitClient git.GitClient
+	Hosts     []host.Host
+	Inputs    map[string]string
+	Out       io.Writer
+	// OutputFormat defines the output format to use.
+	// "json" writes the results to OutReport instead of printing them to Out.
+	OutputFormat string
+	// OutReport is the writer that [TryRunner] writes results to if OutputFormat is "json".
+	OutReport      io.Writer
+	Registry       *task.Registry
+	RepositoryName string
+	TaskFile       string
+	TaskName       string
+}
+
+// TryRunnerOutputOptions defines how a [TryRunner] reports its results.
+type TryRunnerOutputOptions struct {
+	// Color is one of "always", "auto" or "never".
+	// "auto" colours the output if stdout is a terminal and the environment variable NO_COLOR is not set.
+	Color string
+	// DiffStat makes the runner print a summary of the changed files instead of the full diff.
+	DiffStat bool
+	// Format is one of "json" or "text".
+	Format string
 }
 
-func NewTryRunner(opts options.Opts, dataDir string, repositoryName string, taskFile string, taskName string, inputs map[string]string) (*TryRunner, error) {
+func NewTryRunner(opts options.Opts, dataDir string, repositoryName string, taskFile string, taskName string, inputs map[string]string, outputOpts TryRunnerOutputOptions) (*TryRunner, error) {
+	color, err := useTryColor(outputOpts)
+	if err != nil {
+		return nil, err
+	}
+
 	if dataDir != "" {
 		// This code can set its own data dir.
 		opts.Config.DataDir = &dataDir
 	}
-	err := options.Initialize(&opts)
+	err = options.Initialize(&opts)
 	if err != nil {
 		return nil, fmt.Errorf("initialize options: %w", err)
 	}
@@ -42,17 +80,65 @@ func NewTryRunner(opts options.Opts, dataDir string, repositoryName string, task
 		return nil, fmt.Errorf("new git client for try: %w", err)
 	}
 
-	return &TryRunner{
-		ApplyActionsFunc: applyActionsInDirectory,
-		GitClient:        gitClient,
-		Hosts:            opts.Hosts,
-		Inputs:           inputs,
-		Out:              os.Stdout,
-		Registry:         task.NewRegistry(opts),
-		RepositoryName:   repositoryName,
-		TaskFile:         taskFile,
-		TaskName:         taskName,
-	}, nil
+	runner := &TryRunner{
+		ApplyActionsFunc:     applyActionsInDirectory,
+		Color:                color,
+		DefaultCommitMessage: opts.Config.GitCommitMessage,
+		DiffStat:             outputOpts.DiffStat,
+		GitClient:            gitClient,
+		Hosts:                opts.Hosts,
+		Inputs:               inputs,
+		Out:                  os.Stdout,
+		OutputFormat:         outputOpts.Format,
+		OutReport:            os.Stdout,
+		Registry:             task.NewRegistry(opts),
+		RepositoryName:       repositoryName,
+		TaskFile:             taskFile,
+		TaskName:             taskName,
+	}
+	if outputOpts.Format == TryOutputFormatJson {
+		// Keep stdout free for the JSON document.
+		runner.Out = os.Stderr
+	}
+
+	return runner, nil
+}
+
+func useTryColor(outputOpts TryRunnerOutputOptions) (bool, error) {
+	switch outputOpts.Format {
+	case "", TryOutputFormatText:
+	case TryOutputFormatJson:
+		// Escape sequences of colours make no sense in JSON.
+		return false, nil
+	default:
+		return false, fmt.Errorf("unknown output format %s - one of json or text", outputOpts.Format)
+	}
+
+	switch outputOpts.Color {
+	case TryColorAlways:
+		return true, nil
+	case "", TryColorAuto:
+		return os.Getenv("NO_COLOR") == "" && isatty.IsTerminal(os.Stdout.Fd()), nil
+	case TryColorNever:
+		return false, nil
+	default:
+		return false, fmt.Errorf("unknown color mode %s - one of always, auto or never", outputOpts.Color)
+	}
+}
+
+// tryResult describes the pull request that saturn-bot would create for a task.
+type tryResult struct {
+	BranchName    string `json:"branchName"`
+	CommitMessage string `json:"commitMessage"`
+	Diff          string `json:"diff"`
+	PrBody        string `json:"prBody"`
+	PrTitle       string `json:"prTitle"`
+	Repository    string `json:"repository"`
+	Task          string `json:"task"`
+}
+
+type tryResultList struct {
+	Results []tryResult `json:"results"`
 }
 
 func (r *TryRunner) Run() error {
@@ -80,6 +166,7 @@ func (r *TryRunner) Run() error {
 		return nil
 	}
 
+	results := []tryResult{}
 	processed := false
 	for _, task := range tasks {
 		if r.TaskName != "" && task.Name != r.TaskName {
@@ -143,7 +230,7 @@ func (r *TryRunner) Run() error {
 		}
 
 		templateData := template.Data{
-			Run: make(map[string]string),
+			Run: task.RunData(),
 			Repository: template.DataRepository{
 				FullName: repository.FullName(),
 				Host:     repository.Host().Name(),
@@ -184,10 +271,23 @@ func (r *TryRunner) Run() error {
 			continue
 		}
 
-		if result {
-			fmt.Fprintf(r.Out, "😍 Actions modified files - view checkout in %s\n", checkoutPath)
-		} else {
+		if !result {
 			fmt.Fprintf(r.Out, "⚠️  No changes after applying actions - view checkout in %s\n", checkoutPath)
+			continue
+		}
+
+		fmt.Fprintf(r.Out, "😍 Actions modified files - view checkout in %s\n", checkoutPath)
+		tr, err := r.describeChanges(task, branchName, templateData)
+		if err != nil {
+			fmt.Fprintf(r.Out, "⛔️ %s\n", err)
+			continue
+		}
+
+		tr.Repository = repository.FullName()
+		if r.OutputFormat == TryOutputFormatJson {
+			results = append(results, tr)
+		} else {
+			r.printResult(tr)
 		}
 	}
 
@@ -195,5 +295,85 @@ func (r *TryRunner) Run() error {
 		fmt.Fprintf(r.Out, "⛔️ Task %s not found in %s\n", r.TaskName, r.TaskFile)
 	}
 
+	if r.OutputFormat == TryOutputFormatJson {
+		enc := json.NewEncoder(r.OutReport)
+		enc.SetIndent("", "  ")
+		return enc.Encode(tryResultList{Results: results})
+	}
+
 	return nil
 }
+
+// describeChanges renders the pull request that saturn-bot would create for the changes of task.
+func (r *TryRunner) describeChanges(t *task.Task, branchName string, templateData template.Data) (tryResult, error) {
+	diff, err := r.GitClient.Diff(r.DiffStat, r.Color)
+	if err != nil {
+		return tryResult{}, fmt.Errorf("diff changes: %w", err)
+	}
+
+	pr, err := renderPullRequestPreview(t, templateData, r.DefaultCommitMessage)
+	if err != nil {
+		return tryResult{}, err
+	}
+
+	return tryResult{
+		BranchName:    branchName,
+		CommitMessage: pr.CommitMessage,
+		Diff:          diff,
+		PrBody:        pr.Body,
+		PrTitle:       pr.Title,
+		Task:          t.Name,
+	}, nil
+}
+
+// pullRequestPreview is the pull request that saturn-bot would create for a task.
+type pullRequestPreview struct {
+	Body          string
+	CommitMessage string
+	Title         string
+}
+
+// renderPullRequestPreview renders the title, the body and the commit message of the pull request of t.
+func renderPullRequestPreview(t *task.Task, templateData template.Data, defaultCommitMessage string) (pullRequestPreview, error) {
+	title, err := t.RenderPrTitle(templateData)
+	if err != nil {
+		return pullRequestPreview{}, fmt.Errorf("render pull request title: %w", err)
+	}
+
+	prData := host.PullRequestData{
+		AutoMerge:      t.AutoMerge,
+		AutoMergeAfter: t.CalcAutoMergeAfter(),
+		Body:           t.PrBody,
+		Footer:         t.PrFooter,
+		MergeOnce:      t.MergeOnce,
+		TaskName:       t.Name,
+		TemplateData:   templateData,
+	}
+	body, err := prData.GetBody()
+	if err != nil {
+		return pullRequestPreview{}, fmt.Errorf("render pull request body: %w", err)
+	}
+
+	commitMessage := t.CommitMessage
+	if commitMessage == "" {
+		commitMessage = defaultCommitMessage
+	}
+
+	return pullRequestPreview{
+		Body:          body,
+		CommitMessage: commitMessage,
+		Title:         title,
+	}, nil
+}
+
+func (r *TryRunner) printResult(tr tryResult) {
+	fmt.Fprintf(r.Out, "\n🌿 Branch: %s\n", tr.BranchName)
+	fmt.Fprintf(r.Out, "💬 Commit message: %s\n", tr.CommitMessage)
+	fmt.Fprintf(r.Out, "📝 Pull request title: %s\n", tr.PrTitle)
+	fmt.Fprintf(r.Out, "📄 Pull request body:\n\n%s\n\n", tr.PrBody)
+	if r.DiffStat {
+		fmt.Fprintf(r.Out, "🔍 Changed files:\n\n%s\n", tr.Diff)
+	} else {
+		fmt.Fprintf(r.Out, "🔍 Changes:\n\n%s\n", tr.Diff)
+	}
+}
@@ -3,6 +3,7 @@ package command_test
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"testing"
@@ -52,8 +53,12 @@ func TestTryRunner_Run_FilesModified(t *testing.T) {
 	gitcMock.EXPECT().Prepare(repoMock, false).Return("/checkout", nil)
 	gitcMock.EXPECT().UpdateTaskBranch("saturn-bot--unit-test", false, repoMock).Return(false, nil)
 	gitcMock.EXPECT().HasLocalChanges().Return(true, nil)
+	gitcMock.EXPECT().Diff(false, false).Return("diff --git a/test.txt b/test.txt\n", nil)
 	out := &bytes.Buffer{}
 	content := `name: Unit Test
+commitMessage: Update test.txt
+prTitle: "Update in {{.Repository.FullName}}"
+prBody: Body of the pull request
 filters:
   - filter: repository
     params:
@@ -76,6 +81,92 @@ filters:
 	require.NoError(t, err)
 	assert.Contains(t, out.String(), "Filter repository(host=^git.local$,owner=^unit$,name=^test$) of task Unit Test matches")
 	assert.Contains(t, out.String(), "Actions modified files")
+	assert.Contains(t, out.String(), "Branch: saturn-bot--unit-test")
+	assert.Contains(t, out.String(), "Commit message: Update test.txt")
+	assert.Contains(t, out.String(), "Pull request title: Update in git.local/unit/test")
+	assert.Contains(t, out.String(), "Body of the pull request")
+	assert.Contains(t, out.String(), "diff --git a/test.txt b/test.txt")
+}
+
+func TestTryRunner_Run_DiffStat(t *testing.T) {
+	repoName := "git.local/unit/test"
+	ctrl := gomock.NewController(t)
+	repoMock := setupTryRepoMock(ctrl)
+	hostMock := hostmock.NewMockHost(ctrl)
+	hostMock.EXPECT().CreateFromName(repoName).Return(repoMock, nil)
+	registry := task.NewRegistry(tryTestOpts)
+	gitcMock := gitmock.NewMockGitClient(ctrl)
+	gitcMock.EXPECT().Prepare(repoMock, false).Return("/checkout", nil)
+	gitcMock.EXPECT().UpdateTaskBranch("saturn-bot--unit-test", false, repoMock).Return(false, nil)
+	gitcMock.EXPECT().HasLocalChanges().Return(true, nil)
+	gitcMock.EXPECT().Diff(true, true).Return(" test.txt | 1 +\n", nil)
+	out := &bytes.Buffer{}
+	taskFile := createTestTaskFile(createTestTask(repoName))
+
+	underTest := &command.TryRunner{
+		ApplyActionsFunc:     func(actions []action.Action, ctx context.Context, dir string) error { return nil },
+		Color:                true,
+		DefaultCommitMessage: "changes by saturn-bot",
+		DiffStat:             true,
+		GitClient:            gitcMock,
+		Hosts:                []host.Host{hostMock},
+		Out:                  out,
+		Registry:             registry,
+		RepositoryName:       repoName,
+		TaskFile:             taskFile,
+	}
+	err := underTest.Run()
+
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "Commit message: changes by saturn-bot", "uses the default commit message because the task doesn't define one")
+	assert.Contains(t, out.String(), "Changed files:\n\n test.txt | 1 +\n")
+}
+
+func TestTryRunner_Run_OutputJson(t *testing.T) {
+	repoName := "git.local/unit/test"
+	ctrl := gomock.NewController(t)
+	repoMock := setupTryRepoMock(ctrl)
+	hostMock := hostmock.NewMockHost(ctrl)
+	hostMock.EXPECT().CreateFromName(repoName).Return(repoMock, nil)
+	registry := task.NewRegistry(tryTestOpts)
+	gitcMock := gitmock.NewMockGitClient(ctrl)
+	gitcMock.EXPECT().Prepare(repoMock, false).Return("/checkout", nil)
+	gitcMock.EXPECT().UpdateTaskBranch("saturn-bot--unit-test", false, repoMock).Return(false, nil)
+	gitcMock.EXPECT().HasLocalChanges().Return(true, nil)
+	gitcMock.EXPECT().Diff(false, false).Return("diff --git a/test.txt b/test.txt\n", nil)
+	out := &bytes.Buffer{}
+	outReport := &bytes.Buffer{}
+	taskFile := createTestTaskFile(createTestTask(repoName))
+
+	underTest := &command.TryRunner{
+		ApplyActionsFunc:     func(actions []action.Action, ctx context.Context, dir string) error { return nil },
+		DefaultCommitMessage: "changes by saturn-bot",
+		GitClient:            gitcMock,
+		Hosts:                []host.Host{hostMock},
+		Out:                  out,
+		OutputFormat:         "json",
+		OutReport:            outReport,
+		Registry:             registry,
+		RepositoryName:       repoName,
+		TaskFile:             taskFile,
+	}
+	err := underTest.Run()
+
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "Actions modified files")
+	var report struct {
+		Results []map[string]string `json:"results"`
+	}
+	err = json.Unmarshal(outReport.Bytes(), &report)
+	require.NoError(t, err, "writes valid JSON")
+	require.Len(t, report.Results, 1)
+	assert.Equal(t, "saturn-bot--unit-test", report.Results[0]["branchName"])
+	assert.Equal(t, "changes by saturn-bot", report.Results[0]["commitMessage"])
+	assert.Equal(t, "diff --git a/test.txt b/test.txt\n", report.Results[0]["diff"])
+	assert.Equal(t, "saturn-bot: task Unit Test", report.Results[0]["prTitle"])
+	assert.Equal(t, repoName, report.Results[0]["repository"])
+	assert.Equal(t, "Unit Test", report.Results[0]["task"])
+	assert.NotEmpty(t, report.Results[0]["prBody"])
 }
 
 func TestTryRunner_Run_NoChanges(t *testing.T) {
@@ -273,13 +364,15 @@ gitUserName: "unittest"`
 	require.NoError(t, err, "should convert configuration to options successfully")
 	dataDir := filepath.Join(os.TempDir(), "saturn-bot")
 
-	runner, err := command.NewTryRunner(opts, dataDir, "git.local/unit/test", "task.yaml", "Unit Test", map[string]string{})
+	runner, err := command.NewTryRunner(opts, dataDir, "git.local/unit/test", "task.yaml", "Unit Test", map[string]string{}, command.TryRunnerOutputOptions{Color: "never"})
 
 	require.NoError(t, err)
 	assert.NotNil(t, runner.ApplyActionsFunc)
 	assert.Implements(t, (*git.GitClient)(nil), runner.GitClient)
 	assert.IsType(t, []host.Host{}, runner.Hosts)
 	assert.Equal(t, runner.Out, os.Stdout)
+	assert.False(t, runner.Color)
+	assert.Equal(t, "changes by saturn-bot", runner.DefaultCommitMessage)
 	assert.IsType(t, &task.Registry{}, runner.Registry)
 	assert.Equal(t, "git.local/unit/test", runner.RepositoryName)
 	assert.Equal(t, "task.yaml", runner.TaskFile)
@@ -289,3 +382,9 @@ gitUserName: "unittest"`
 	err = os.RemoveAll(dataDir)
 	require.NoError(t, err)
 }
+
+func TestNewTryRunner_UnknownOutputFormat(t *testing.T) {
+	_, err := command.NewTryRunner(options.Opts{}, filepath.Join(t.TempDir(), "saturn-bot"), "git.local/unit/test", "task.yaml", "", map[string]string{}, command.TryRunnerOutputOptions{Format: "yaml"})
+
+	require.EqualError(t, err, "unknown output format yaml - one of json or text")
+}
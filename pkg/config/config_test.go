@@ -11,28 +11,42 @@ import (
 
 // Holds all default values set after a configuration file has been parsed.
 var defaultConfiguration = Configuration{
-	DataDir:                  nil,
-	GitCloneOptions:          []string{"--filter", "blob:none"},
-	GitCommitMessage:         "changes by saturn-bot",
-	GitlabAddress:            "https://gitlab.com",
-	GitLogLevel:              "warn",
-	GitPath:                  "git",
-	GitUrl:                   "https",
-	LogFormat:                "auto",
-	LogLevel:                 "info",
-	JavaPath:                 "java",
-	Labels:                   []string{},
-	PluginLogLevel:           "debug",
-	PythonPath:               "python",
-	RepositoryCacheTtl:       "6h",
-	ServerAddr:               ":3035",
-	ServerBaseUrl:            "http://localhost:3035",
-	ServerCompress:           true,
-	ServerServeUi:            true,
-	ServerShutdownTimeout:    "5m",
-	WorkerLoopInterval:       "10s",
-	WorkerParallelExecutions: 1,
-	WorkerServerAPIBaseURL:   "http://localhost:3035",
+	CiLintRules:                    map[string]string{},
+	DataDir:                        nil,
+	GitCloneOptions:                []string{"--filter", "blob:none"},
+	GitCommitMessage:               "changes by saturn-bot",
+	GitlabAddress:                  "https://gitlab.com",
+	GitLogLevel:                    "warn",
+	GitPath:                        "git",
+	GitUrl:                         "https",
+	LogFormat:                      "auto",
+	LogLevel:                       "info",
+	JavaPath:                       "java",
+	Labels:                         []string{},
+	PluginLogLevel:                 "debug",
+	PythonPath:                     "python",
+	RepositoryCacheTtl:             "6h",
+	ServerAddr:                     ":3035",
+	ServerBaseUrl:                  "http://localhost:3035",
+	ServerCompress:                 true,
+	ServerRepositoryLogMaxSize:     1048576,
+	ServerRepositoryLogRetention:   "720h",
+	ServerServeUi:                  true,
+	ServerShutdownTimeout:          "5m",
+	ServerUiOidcGroupsClaim:        "groups",
+	ServerUiOidcRunGroups:          []string{},
+	ServerUiOidcScopes:             []string{"openid", "profile", "email"},
+	ServerUiOidcViewGroups:         []string{},
+	ServerUiSessionTtl:             "8h",
+	ServerWebhookDeliveryRetention: "720h",
+	ServerWebhookGenericSources:    []WebhookGenericSource{},
+	TasksGitGlob:                   "*.yaml",
+	TasksGitPollInterval:           "5m",
+	TasksGitRef:                    "main",
+	WorkerLoopInterval:             "10s",
+	WorkerParallelExecutions:       1,
+	WorkerRepositoryLogMaxSize:     1048576,
+	WorkerServerAPIBaseURL:         "http://localhost:3035",
 }
 
 func TestReadConfig(t *testing.T) {
@@ -113,3 +127,24 @@ func TestReadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestReadConfig_WebhookGenericSourceEmptySecret(t *testing.T) {
+	in := Configuration{
+		GitlabToken: ptr.To("abc"),
+		ServerWebhookGenericSources: []WebhookGenericSource{
+			{Name: "ci", Secret: "", Verification: WebhookGenericSourceVerificationBearer},
+		},
+	}
+	f, err := os.CreateTemp("", "*config.yaml")
+	require.NoError(t, err)
+	defer f.Close()
+	enc := yaml.NewEncoder(f)
+	err = enc.Encode(in)
+	enc.Close()
+	require.NoError(t, err)
+
+	_, err = Read(f.Name())
+
+	// The message of the error contains the absolute path to the schema file.
+	require.ErrorContains(t, err, "at '/serverWebhookGenericSources/0/secret': minLength: got 0, want 1")
+}
@@ -6,9 +6,15 @@ import "encoding/json"
 import "fmt"
 import yaml "gopkg.in/yaml.v3"
 import "reflect"
+import "regexp"
 
 // Configuration settings of saturn-bot.
 type Configuration struct {
+	// Severity of lint rules applied by the command `ci`. Key is the identifier of a
+	// rule. Value is the severity of the rule. `error` fails the command, `warning`
+	// reports the problem without failing and `off` disables the rule.
+	CiLintRules map[string]string `json:"ciLintRules,omitempty" yaml:"ciLintRules,omitempty" mapstructure:"ciLintRules,omitempty"`
+
 	// Path to directory to store files and repository clones.
 	DataDir *string `json:"dataDir,omitempty" yaml:"dataDir,omitempty" mapstructure:"dataDir,omitempty"`
 
@@ -73,6 +79,11 @@ type Configuration struct {
 	// order to display the logs of a plugin.
 	PluginLogLevel ConfigurationPluginLogLevel `json:"pluginLogLevel,omitempty" yaml:"pluginLogLevel,omitempty" mapstructure:"pluginLogLevel,omitempty"`
 
+	// Template that replaces the footer that saturn-bot appends to the body of every
+	// pull request. Allows an operator of saturn-bot to add text, like compliance
+	// notes, to all pull requests. A task can override it.
+	PrFooter string `json:"prFooter,omitempty" yaml:"prFooter,omitempty" mapstructure:"prFooter,omitempty"`
+
 	// Address of a Prometheus Pushgateway to send metrics to.
 	PrometheusPushgatewayUrl *string `json:"prometheusPushgatewayUrl,omitempty" yaml:"prometheusPushgatewayUrl,omitempty" mapstructure:"prometheusPushgatewayUrl,omitempty"`
 
@@ -91,8 +102,10 @@ type Configuration struct {
 	// Address of the server in the format `<host>:<port>`.
 	ServerAddr string `json:"serverAddr,omitempty" yaml:"serverAddr,omitempty" mapstructure:"serverAddr,omitempty"`
 
-	// Secret required to authenticate at the API. Clients set the key as the value of
-	// the HTTP header X-API-KEY. Required if saturn-bot runs in server mode.
+	// Admin key required to authenticate at the API. Clients set the key as the value
+	// of the HTTP header X-API-KEY. The key grants access to all operations of the
+	// API, including the management of API tokens. Required if saturn-bot runs in
+	// server mode.
 	ServerApiKey string `json:"serverApiKey,omitempty" yaml:"serverApiKey,omitempty" mapstructure:"serverApiKey,omitempty"`
 
 	// URL of the API server. The value is used to populate the `servers` array in the
@@ -110,12 +123,63 @@ type Configuration struct {
 	// `<dataDir>/db/saturn-bot.db`.
 	ServerDatabasePath string `json:"serverDatabasePath,omitempty" yaml:"serverDatabasePath,omitempty" mapstructure:"serverDatabasePath,omitempty"`
 
+	// Maximum size in bytes of a log of a repository that the server stores. The
+	// server truncates longer logs uploaded by workers. Set to `0` to not truncate
+	// logs.
+	ServerRepositoryLogMaxSize int `json:"serverRepositoryLogMaxSize,omitempty" yaml:"serverRepositoryLogMaxSize,omitempty" mapstructure:"serverRepositoryLogMaxSize,omitempty"`
+
+	// Duration for which the server keeps the logs of repositories uploaded by
+	// workers. The server deletes older logs. Set to `0s` to keep logs forever.
+	ServerRepositoryLogRetention string `json:"serverRepositoryLogRetention,omitempty" yaml:"serverRepositoryLogRetention,omitempty" mapstructure:"serverRepositoryLogRetention,omitempty"`
+
 	// If `true`, serves the user interface.
 	ServerServeUi bool `json:"serverServeUi,omitempty" yaml:"serverServeUi,omitempty" mapstructure:"serverServeUi,omitempty"`
 
 	// Duration to wait for active runs to finish before stopping the server.
 	ServerShutdownTimeout string `json:"serverShutdownTimeout,omitempty" yaml:"serverShutdownTimeout,omitempty" mapstructure:"serverShutdownTimeout,omitempty"`
 
+	// ID of the client registered at the OpenID Connect provider.
+	ServerUiOidcClientId string `json:"serverUiOidcClientId,omitempty" yaml:"serverUiOidcClientId,omitempty" mapstructure:"serverUiOidcClientId,omitempty"`
+
+	// Secret of the client registered at the OpenID Connect provider. Leave empty if
+	// the client is public. The login always uses PKCE.
+	ServerUiOidcClientSecret string `json:"serverUiOidcClientSecret,omitempty" yaml:"serverUiOidcClientSecret,omitempty" mapstructure:"serverUiOidcClientSecret,omitempty"`
+
+	// Name of the claim in the ID token that contains the groups of a user.
+	ServerUiOidcGroupsClaim string `json:"serverUiOidcGroupsClaim,omitempty" yaml:"serverUiOidcGroupsClaim,omitempty" mapstructure:"serverUiOidcGroupsClaim,omitempty"`
+
+	// URL of the OpenID Connect provider. Setting it requires users to log in before
+	// they can access the user interface.
+	ServerUiOidcIssuerUrl string `json:"serverUiOidcIssuerUrl,omitempty" yaml:"serverUiOidcIssuerUrl,omitempty" mapstructure:"serverUiOidcIssuerUrl,omitempty"`
+
+	// Groups whose members can schedule runs of tasks in the user interface. Nobody
+	// can schedule runs if empty.
+	ServerUiOidcRunGroups []string `json:"serverUiOidcRunGroups,omitempty" yaml:"serverUiOidcRunGroups,omitempty" mapstructure:"serverUiOidcRunGroups,omitempty"`
+
+	// Scopes to request from the OpenID Connect provider.
+	ServerUiOidcScopes []string `json:"serverUiOidcScopes,omitempty" yaml:"serverUiOidcScopes,omitempty" mapstructure:"serverUiOidcScopes,omitempty"`
+
+	// Groups whose members can access the user interface. Every user who logs in can
+	// access the user interface if empty.
+	ServerUiOidcViewGroups []string `json:"serverUiOidcViewGroups,omitempty" yaml:"serverUiOidcViewGroups,omitempty" mapstructure:"serverUiOidcViewGroups,omitempty"`
+
+	// Secret to sign the session cookies of the user interface. Required if
+	// `serverUiOidcIssuerUrl` is set.
+	ServerUiSessionSecret string `json:"serverUiSessionSecret,omitempty" yaml:"serverUiSessionSecret,omitempty" mapstructure:"serverUiSessionSecret,omitempty"`
+
+	// Duration after which a user needs to log in again.
+	ServerUiSessionTtl string `json:"serverUiSessionTtl,omitempty" yaml:"serverUiSessionTtl,omitempty" mapstructure:"serverUiSessionTtl,omitempty"`
+
+	// Duration for which the server keeps webhooks it has received. The server
+	// deletes older webhooks once it has processed them. Set to `0s` to keep webhooks
+	// forever.
+	ServerWebhookDeliveryRetention string `json:"serverWebhookDeliveryRetention,omitempty" yaml:"serverWebhookDeliveryRetention,omitempty" mapstructure:"serverWebhookDeliveryRetention,omitempty"`
+
+	// Sources that send generic webhooks to the server. Each source sends its
+	// webhooks to `/webhooks/generic/<name>`. Tasks reference a source in
+	// `trigger.webhook.generic`.
+	ServerWebhookGenericSources []WebhookGenericSource `json:"serverWebhookGenericSources,omitempty" yaml:"serverWebhookGenericSources,omitempty" mapstructure:"serverWebhookGenericSources,omitempty"`
+
 	// Secret to authenticate webhook requests sent by GitHub.
 	ServerWebhookSecretGithub string `json:"serverWebhookSecretGithub,omitempty" yaml:"serverWebhookSecretGithub,omitempty" mapstructure:"serverWebhookSecretGithub,omitempty"`
 
@@ -124,12 +188,45 @@ type Configuration struct {
 	// for how to set up the token.
 	ServerWebhookSecretGitlab string `json:"serverWebhookSecretGitlab,omitempty" yaml:"serverWebhookSecretGitlab,omitempty" mapstructure:"serverWebhookSecretGitlab,omitempty"`
 
+	// Glob pattern, relative to the root of the repository set in `tasksGitUrl`, that
+	// matches the task files to read. Supports the syntax of Go's `filepath.Match`,
+	// like `tasks/*/*.yaml`.
+	TasksGitGlob string `json:"tasksGitGlob,omitempty" yaml:"tasksGitGlob,omitempty" mapstructure:"tasksGitGlob,omitempty"`
+
+	// Interval at which the server checks the repository set in `tasksGitUrl` for new
+	// commits. Set to `0s` to turn polling off.
+	TasksGitPollInterval string `json:"tasksGitPollInterval,omitempty" yaml:"tasksGitPollInterval,omitempty" mapstructure:"tasksGitPollInterval,omitempty"`
+
+	// Branch or tag of the repository set in `tasksGitUrl` to read tasks from.
+	TasksGitRef string `json:"tasksGitRef,omitempty" yaml:"tasksGitRef,omitempty" mapstructure:"tasksGitRef,omitempty"`
+
+	// URL of a git repository to read tasks from. If set, the server and the worker
+	// read their tasks from the repository instead of local files. saturn-bot
+	// authenticates with the credentials of `githubToken` or `gitlabToken`.
+	TasksGitUrl string `json:"tasksGitUrl,omitempty" yaml:"tasksGitUrl,omitempty" mapstructure:"tasksGitUrl,omitempty"`
+
+	// Name of a source in `serverWebhookGenericSources`. The server checks the
+	// repository set in `tasksGitUrl` for new commits when the source sends a
+	// webhook.
+	TasksGitWebhookSource string `json:"tasksGitWebhookSource,omitempty" yaml:"tasksGitWebhookSource,omitempty" mapstructure:"tasksGitWebhookSource,omitempty"`
+
+	// Key the worker uses to authenticate at the server API. Set this to the secret
+	// of an API token with scope `worker`. Falls back to `serverApiKey` if empty.
+	WorkerApiKey string `json:"workerApiKey,omitempty" yaml:"workerApiKey,omitempty" mapstructure:"workerApiKey,omitempty"`
+
 	// Interval at which a worker queries the server to receive new tasks to execute.
+	// Also the interval at which a worker sends heartbeats of running tasks to the
+	// server.
 	WorkerLoopInterval string `json:"workerLoopInterval,omitempty" yaml:"workerLoopInterval,omitempty" mapstructure:"workerLoopInterval,omitempty"`
 
 	// Number of parallel executions of tasks per worker.
 	WorkerParallelExecutions int `json:"workerParallelExecutions,omitempty" yaml:"workerParallelExecutions,omitempty" mapstructure:"workerParallelExecutions,omitempty"`
 
+	// Maximum size in bytes of the log that a worker captures for each repository of
+	// a run and uploads to the server. The worker truncates longer logs. Set to `0`
+	// to not capture logs.
+	WorkerRepositoryLogMaxSize int `json:"workerRepositoryLogMaxSize,omitempty" yaml:"workerRepositoryLogMaxSize,omitempty" mapstructure:"workerRepositoryLogMaxSize,omitempty"`
+
 	// Base URL of the server API to query for new tasks to execute.
 	WorkerServerAPIBaseURL string `json:"workerServerAPIBaseURL,omitempty" yaml:"workerServerAPIBaseURL,omitempty" mapstructure:"workerServerAPIBaseURL,omitempty"`
 }
@@ -148,10 +245,10 @@ var enumValues_ConfigurationGitLogLevel = []interface{}{
 	"warn",
 }
 
-// UnmarshalJSON implements json.Unmarshaler.
-func (j *ConfigurationGitLogLevel) UnmarshalJSON(value []byte) error {
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *ConfigurationGitLogLevel) UnmarshalYAML(value *yaml.Node) error {
 	var v string
-	if err := json.Unmarshal(value, &v); err != nil {
+	if err := value.Decode(&v); err != nil {
 		return err
 	}
 	var ok bool
@@ -168,10 +265,10 @@ func (j *ConfigurationGitLogLevel) UnmarshalJSON(value []byte) error {
 	return nil
 }
 
-// UnmarshalYAML implements yaml.Unmarshaler.
-func (j *ConfigurationGitLogLevel) UnmarshalYAML(value *yaml.Node) error {
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *ConfigurationGitLogLevel) UnmarshalJSON(value []byte) error {
 	var v string
-	if err := value.Decode(&v); err != nil {
+	if err := json.Unmarshal(value, &v); err != nil {
 		return err
 	}
 	var ok bool
@@ -198,10 +295,10 @@ var enumValues_ConfigurationGitUrl = []interface{}{
 	"ssh",
 }
 
-// UnmarshalJSON implements json.Unmarshaler.
-func (j *ConfigurationGitUrl) UnmarshalJSON(value []byte) error {
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *ConfigurationGitUrl) UnmarshalYAML(value *yaml.Node) error {
 	var v string
-	if err := json.Unmarshal(value, &v); err != nil {
+	if err := value.Decode(&v); err != nil {
 		return err
 	}
 	var ok bool
@@ -218,10 +315,10 @@ func (j *ConfigurationGitUrl) UnmarshalJSON(value []byte) error {
 	return nil
 }
 
-// UnmarshalYAML implements yaml.Unmarshaler.
-func (j *ConfigurationGitUrl) UnmarshalYAML(value *yaml.Node) error {
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *ConfigurationGitUrl) UnmarshalJSON(value []byte) error {
 	var v string
-	if err := value.Decode(&v); err != nil {
+	if err := json.Unmarshal(value, &v); err != nil {
 		return err
 	}
 	var ok bool
@@ -304,10 +401,10 @@ var enumValues_ConfigurationLogLevel = []interface{}{
 	"warn",
 }
 
-// UnmarshalJSON implements json.Unmarshaler.
-func (j *ConfigurationLogLevel) UnmarshalJSON(value []byte) error {
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *ConfigurationLogLevel) UnmarshalYAML(value *yaml.Node) error {
 	var v string
-	if err := json.Unmarshal(value, &v); err != nil {
+	if err := value.Decode(&v); err != nil {
 		return err
 	}
 	var ok bool
@@ -324,10 +421,10 @@ func (j *ConfigurationLogLevel) UnmarshalJSON(value []byte) error {
 	return nil
 }
 
-// UnmarshalYAML implements yaml.Unmarshaler.
-func (j *ConfigurationLogLevel) UnmarshalYAML(value *yaml.Node) error {
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *ConfigurationLogLevel) UnmarshalJSON(value []byte) error {
 	var v string
-	if err := value.Decode(&v); err != nil {
+	if err := json.Unmarshal(value, &v); err != nil {
 		return err
 	}
 	var ok bool
@@ -358,10 +455,10 @@ var enumValues_ConfigurationPluginLogLevel = []interface{}{
 	"warn",
 }
 
-// UnmarshalJSON implements json.Unmarshaler.
-func (j *ConfigurationPluginLogLevel) UnmarshalJSON(value []byte) error {
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *ConfigurationPluginLogLevel) UnmarshalYAML(value *yaml.Node) error {
 	var v string
-	if err := json.Unmarshal(value, &v); err != nil {
+	if err := value.Decode(&v); err != nil {
 		return err
 	}
 	var ok bool
@@ -378,10 +475,10 @@ func (j *ConfigurationPluginLogLevel) UnmarshalJSON(value []byte) error {
 	return nil
 }
 
-// UnmarshalYAML implements yaml.Unmarshaler.
-func (j *ConfigurationPluginLogLevel) UnmarshalYAML(value *yaml.Node) error {
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *ConfigurationPluginLogLevel) UnmarshalJSON(value []byte) error {
 	var v string
-	if err := value.Decode(&v); err != nil {
+	if err := json.Unmarshal(value, &v); err != nil {
 		return err
 	}
 	var ok bool
@@ -409,6 +506,9 @@ func (j *Configuration) UnmarshalJSON(value []byte) error {
 	if err := json.Unmarshal(value, &plain); err != nil {
 		return err
 	}
+	if v, ok := raw["ciLintRules"]; !ok || v == nil {
+		plain.CiLintRules = map[string]string{}
+	}
 	if v, ok := raw["dryRun"]; !ok || v == nil {
 		plain.DryRun = false
 	}
@@ -457,6 +557,9 @@ func (j *Configuration) UnmarshalJSON(value []byte) error {
 	if v, ok := raw["pluginLogLevel"]; !ok || v == nil {
 		plain.PluginLogLevel = "debug"
 	}
+	if v, ok := raw["prFooter"]; !ok || v == nil {
+		plain.PrFooter = ""
+	}
 	if v, ok := raw["pythonPath"]; !ok || v == nil {
 		plain.PythonPath = "python"
 	}
@@ -484,24 +587,88 @@ func (j *Configuration) UnmarshalJSON(value []byte) error {
 	if v, ok := raw["serverDatabasePath"]; !ok || v == nil {
 		plain.ServerDatabasePath = ""
 	}
+	if v, ok := raw["serverRepositoryLogMaxSize"]; !ok || v == nil {
+		plain.ServerRepositoryLogMaxSize = 1048576.0
+	}
+	if v, ok := raw["serverRepositoryLogRetention"]; !ok || v == nil {
+		plain.ServerRepositoryLogRetention = "720h"
+	}
 	if v, ok := raw["serverServeUi"]; !ok || v == nil {
 		plain.ServerServeUi = true
 	}
 	if v, ok := raw["serverShutdownTimeout"]; !ok || v == nil {
 		plain.ServerShutdownTimeout = "5m"
 	}
+	if v, ok := raw["serverUiOidcClientId"]; !ok || v == nil {
+		plain.ServerUiOidcClientId = ""
+	}
+	if v, ok := raw["serverUiOidcClientSecret"]; !ok || v == nil {
+		plain.ServerUiOidcClientSecret = ""
+	}
+	if v, ok := raw["serverUiOidcGroupsClaim"]; !ok || v == nil {
+		plain.ServerUiOidcGroupsClaim = "groups"
+	}
+	if v, ok := raw["serverUiOidcIssuerUrl"]; !ok || v == nil {
+		plain.ServerUiOidcIssuerUrl = ""
+	}
+	if v, ok := raw["serverUiOidcRunGroups"]; !ok || v == nil {
+		plain.ServerUiOidcRunGroups = []string{}
+	}
+	if v, ok := raw["serverUiOidcScopes"]; !ok || v == nil {
+		plain.ServerUiOidcScopes = []string{
+			"openid",
+			"profile",
+			"email",
+		}
+	}
+	if v, ok := raw["serverUiOidcViewGroups"]; !ok || v == nil {
+		plain.ServerUiOidcViewGroups = []string{}
+	}
+	if v, ok := raw["serverUiSessionSecret"]; !ok || v == nil {
+		plain.ServerUiSessionSecret = ""
+	}
+	if v, ok := raw["serverUiSessionTtl"]; !ok || v == nil {
+		plain.ServerUiSessionTtl = "8h"
+	}
+	if v, ok := raw["serverWebhookDeliveryRetention"]; !ok || v == nil {
+		plain.ServerWebhookDeliveryRetention = "720h"
+	}
+	if v, ok := raw["serverWebhookGenericSources"]; !ok || v == nil {
+		plain.ServerWebhookGenericSources = []WebhookGenericSource{}
+	}
 	if v, ok := raw["serverWebhookSecretGithub"]; !ok || v == nil {
 		plain.ServerWebhookSecretGithub = ""
 	}
 	if v, ok := raw["serverWebhookSecretGitlab"]; !ok || v == nil {
 		plain.ServerWebhookSecretGitlab = ""
 	}
+	if v, ok := raw["tasksGitGlob"]; !ok || v == nil {
+		plain.TasksGitGlob = "*.yaml"
+	}
+	if v, ok := raw["tasksGitPollInterval"]; !ok || v == nil {
+		plain.TasksGitPollInterval = "5m"
+	}
+	if v, ok := raw["tasksGitRef"]; !ok || v == nil {
+		plain.TasksGitRef = "main"
+	}
+	if v, ok := raw["tasksGitUrl"]; !ok || v == nil {
+		plain.TasksGitUrl = ""
+	}
+	if v, ok := raw["tasksGitWebhookSource"]; !ok || v == nil {
+		plain.TasksGitWebhookSource = ""
+	}
+	if v, ok := raw["workerApiKey"]; !ok || v == nil {
+		plain.WorkerApiKey = ""
+	}
 	if v, ok := raw["workerLoopInterval"]; !ok || v == nil {
 		plain.WorkerLoopInterval = "10s"
 	}
 	if v, ok := raw["workerParallelExecutions"]; !ok || v == nil {
 		plain.WorkerParallelExecutions = 1.0
 	}
+	if v, ok := raw["workerRepositoryLogMaxSize"]; !ok || v == nil {
+		plain.WorkerRepositoryLogMaxSize = 1048576.0
+	}
 	if v, ok := raw["workerServerAPIBaseURL"]; !ok || v == nil {
 		plain.WorkerServerAPIBaseURL = "http://localhost:3035"
 	}
@@ -520,6 +687,9 @@ func (j *Configuration) UnmarshalYAML(value *yaml.Node) error {
 	if err := value.Decode(&plain); err != nil {
 		return err
 	}
+	if v, ok := raw["ciLintRules"]; !ok || v == nil {
+		plain.CiLintRules = map[string]string{}
+	}
 	if v, ok := raw["dryRun"]; !ok || v == nil {
 		plain.DryRun = false
 	}
@@ -568,6 +738,9 @@ func (j *Configuration) UnmarshalYAML(value *yaml.Node) error {
 	if v, ok := raw["pluginLogLevel"]; !ok || v == nil {
 		plain.PluginLogLevel = "debug"
 	}
+	if v, ok := raw["prFooter"]; !ok || v == nil {
+		plain.PrFooter = ""
+	}
 	if v, ok := raw["pythonPath"]; !ok || v == nil {
 		plain.PythonPath = "python"
 	}
@@ -595,27 +768,222 @@ func (j *Configuration) UnmarshalYAML(value *yaml.Node) error {
 	if v, ok := raw["serverDatabasePath"]; !ok || v == nil {
 		plain.ServerDatabasePath = ""
 	}
+	if v, ok := raw["serverRepositoryLogMaxSize"]; !ok || v == nil {
+		plain.ServerRepositoryLogMaxSize = 1048576.0
+	}
+	if v, ok := raw["serverRepositoryLogRetention"]; !ok || v == nil {
+		plain.ServerRepositoryLogRetention = "720h"
+	}
 	if v, ok := raw["serverServeUi"]; !ok || v == nil {
 		plain.ServerServeUi = true
 	}
 	if v, ok := raw["serverShutdownTimeout"]; !ok || v == nil {
 		plain.ServerShutdownTimeout = "5m"
 	}
+	if v, ok := raw["serverUiOidcClientId"]; !ok || v == nil {
+		plain.ServerUiOidcClientId = ""
+	}
+	if v, ok := raw["serverUiOidcClientSecret"]; !ok || v == nil {
+		plain.ServerUiOidcClientSecret = ""
+	}
+	if v, ok := raw["serverUiOidcGroupsClaim"]; !ok || v == nil {
+		plain.ServerUiOidcGroupsClaim = "groups"
+	}
+	if v, ok := raw["serverUiOidcIssuerUrl"]; !ok || v == nil {
+		plain.ServerUiOidcIssuerUrl = ""
+	}
+	if v, ok := raw["serverUiOidcRunGroups"]; !ok || v == nil {
+		plain.ServerUiOidcRunGroups = []string{}
+	}
+	if v, ok := raw["serverUiOidcScopes"]; !ok || v == nil {
+		plain.ServerUiOidcScopes = []string{
+			"openid",
+			"profile",
+			"email",
+		}
+	}
+	if v, ok := raw["serverUiOidcViewGroups"]; !ok || v == nil {
+		plain.ServerUiOidcViewGroups = []string{}
+	}
+	if v, ok := raw["serverUiSessionSecret"]; !ok || v == nil {
+		plain.ServerUiSessionSecret = ""
+	}
+	if v, ok := raw["serverUiSessionTtl"]; !ok || v == nil {
+		plain.ServerUiSessionTtl = "8h"
+	}
+	if v, ok := raw["serverWebhookDeliveryRetention"]; !ok || v == nil {
+		plain.ServerWebhookDeliveryRetention = "720h"
+	}
+	if v, ok := raw["serverWebhookGenericSources"]; !ok || v == nil {
+		plain.ServerWebhookGenericSources = []WebhookGenericSource{}
+	}
 	if v, ok := raw["serverWebhookSecretGithub"]; !ok || v == nil {
 		plain.ServerWebhookSecretGithub = ""
 	}
 	if v, ok := raw["serverWebhookSecretGitlab"]; !ok || v == nil {
 		plain.ServerWebhookSecretGitlab = ""
 	}
+	if v, ok := raw["tasksGitGlob"]; !ok || v == nil {
+		plain.TasksGitGlob = "*.yaml"
+	}
+	if v, ok := raw["tasksGitPollInterval"]; !ok || v == nil {
+		plain.TasksGitPollInterval = "5m"
+	}
+	if v, ok := raw["tasksGitRef"]; !ok || v == nil {
+		plain.TasksGitRef = "main"
+	}
+	if v, ok := raw["tasksGitUrl"]; !ok || v == nil {
+		plain.TasksGitUrl = ""
+	}
+	if v, ok := raw["tasksGitWebhookSource"]; !ok || v == nil {
+		plain.TasksGitWebhookSource = ""
+	}
+	if v, ok := raw["workerApiKey"]; !ok || v == nil {
+		plain.WorkerApiKey = ""
+	}
 	if v, ok := raw["workerLoopInterval"]; !ok || v == nil {
 		plain.WorkerLoopInterval = "10s"
 	}
 	if v, ok := raw["workerParallelExecutions"]; !ok || v == nil {
 		plain.WorkerParallelExecutions = 1.0
 	}
+	if v, ok := raw["workerRepositoryLogMaxSize"]; !ok || v == nil {
+		plain.WorkerRepositoryLogMaxSize = 1048576.0
+	}
 	if v, ok := raw["workerServerAPIBaseURL"]; !ok || v == nil {
 		plain.WorkerServerAPIBaseURL = "http://localhost:3035"
 	}
 	*j = Configuration(plain)
 	return nil
 }
+
+type WebhookGenericSource struct {
+	// HTTP header that contains the event of a webhook. Defaults to `X-Event`.
+	EventHeader *string `json:"eventHeader,omitempty" yaml:"eventHeader,omitempty" mapstructure:"eventHeader,omitempty"`
+
+	// Name of the source. Must only contain letters, digits, `-` and `_`.
+	Name string `json:"name" yaml:"name" mapstructure:"name"`
+
+	// Secret shared with the source. Must not be empty.
+	Secret string `json:"secret" yaml:"secret" mapstructure:"secret"`
+
+	// HTTP header that contains the HMAC signature of a webhook if `verification` is
+	// `hmac-sha256`. Defaults to `X-Signature-256`.
+	SignatureHeader *string `json:"signatureHeader,omitempty" yaml:"signatureHeader,omitempty" mapstructure:"signatureHeader,omitempty"`
+
+	// How the server verifies a webhook. `hmac-sha256` expects the hex-encoded
+	// HMAC-SHA256 of the body, signed with the secret, in the header
+	// `signatureHeader`. An optional prefix `sha256=` is allowed. `bearer` expects
+	// the secret in the header `Authorization: Bearer <secret>`.
+	Verification WebhookGenericSourceVerification `json:"verification" yaml:"verification" mapstructure:"verification"`
+}
+
+type WebhookGenericSourceVerification string
+
+const WebhookGenericSourceVerificationBearer WebhookGenericSourceVerification = "bearer"
+const WebhookGenericSourceVerificationHmacSha256 WebhookGenericSourceVerification = "hmac-sha256"
+
+var enumValues_WebhookGenericSourceVerification = []interface{}{
+	"hmac-sha256",
+	"bearer",
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *WebhookGenericSourceVerification) UnmarshalJSON(value []byte) error {
+	var v string
+	if err := json.Unmarshal(value, &v); err != nil {
+		return err
+	}
+	var ok bool
+	for _, expected := range enumValues_WebhookGenericSourceVerification {
+		if reflect.DeepEqual(v, expected) {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return fmt.Errorf("invalid value (expected one of %#v): %#v", enumValues_WebhookGenericSourceVerification, v)
+	}
+	*j = WebhookGenericSourceVerification(v)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *WebhookGenericSourceVerification) UnmarshalYAML(value *yaml.Node) error {
+	var v string
+	if err := value.Decode(&v); err != nil {
+		return err
+	}
+	var ok bool
+	for _, expected := range enumValues_WebhookGenericSourceVerification {
+		if reflect.DeepEqual(v, expected) {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return fmt.Errorf("invalid value (expected one of %#v): %#v", enumValues_WebhookGenericSourceVerification, v)
+	}
+	*j = WebhookGenericSourceVerification(v)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *WebhookGenericSource) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["name"]; raw != nil && !ok {
+		return fmt.Errorf("field name in WebhookGenericSource: required")
+	}
+	if _, ok := raw["secret"]; raw != nil && !ok {
+		return fmt.Errorf("field secret in WebhookGenericSource: required")
+	}
+	if _, ok := raw["verification"]; raw != nil && !ok {
+		return fmt.Errorf("field verification in WebhookGenericSource: required")
+	}
+	type Plain WebhookGenericSource
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if matched, _ := regexp.MatchString(`^[a-zA-Z0-9_-]+$`, string(plain.Name)); !matched {
+		return fmt.Errorf("field %s pattern match: must match %s", "Name", `^[a-zA-Z0-9_-]+$`)
+	}
+	if len(plain.Secret) < 1 {
+		return fmt.Errorf("field %s length: must be >= %d", "secret", 1)
+	}
+	*j = WebhookGenericSource(plain)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *WebhookGenericSource) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["name"]; raw != nil && !ok {
+		return fmt.Errorf("field name in WebhookGenericSource: required")
+	}
+	if _, ok := raw["secret"]; raw != nil && !ok {
+		return fmt.Errorf("field secret in WebhookGenericSource: required")
+	}
+	if _, ok := raw["verification"]; raw != nil && !ok {
+		return fmt.Errorf("field verification in WebhookGenericSource: required")
+	}
+	type Plain WebhookGenericSource
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if matched, _ := regexp.MatchString(`^[a-zA-Z0-9_-]+$`, string(plain.Name)); !matched {
+		return fmt.Errorf("field %s pattern match: must match %s", "Name", `^[a-zA-Z0-9_-]+$`)
+	}
+	if len(plain.Secret) < 1 {
+		return fmt.Errorf("field %s length: must be >= %d", "secret", 1)
+	}
+	*j = WebhookGenericSource(plain)
+	return nil
+}
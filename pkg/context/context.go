@@ -2,6 +2,7 @@ package context
 
 import (
 	"context"
+	"io"
 
 	"github.com/wndhydrnt/saturn-bot/pkg/log"
 	"go.uber.org/zap"
@@ -14,7 +15,35 @@ type runDataKey struct{}
 
 const (
 	RunDataKeyAssignees = "sb.assignees"
-	RunDataKeyReviewers = "sb.reviewers"
+	// RunDataKeyCommand is the command that a user has sent via a comment on a pull request.
+	// One of the Command* constants.
+	RunDataKeyCommand = "sb.command"
+	// RunDataKeyFilterRepositories is "true" if the run applies the filters of the task
+	// to the repositories that the server has scoped the run to.
+	RunDataKeyFilterRepositories = "sb.filterRepositories"
+	// RunDataKeyIgnoredRepositories is a comma-separated list of repositories
+	// to which a user has asked saturn-bot to never apply the task again.
+	RunDataKeyIgnoredRepositories = "sb.ignoredRepositories"
+	RunDataKeyReviewers           = "sb.reviewers"
+	// RunDataKeyRolloutWave is the index of the last wave of the rollout of a task that has started.
+	RunDataKeyRolloutWave = "sb.rolloutWave"
+	// RunDataKeyRunID is the ID of the run of the server that a worker executes.
+	RunDataKeyRunID = "sb.runId"
+	// RunDataKeyServerUrl is the URL of the server that has scheduled the run.
+	RunDataKeyServerUrl = "sb.serverUrl"
+)
+
+const (
+	// CommandIgnore asks saturn-bot to never apply the task to the repository again.
+	CommandIgnore = "ignore"
+	// CommandMerge asks saturn-bot to merge the pull request,
+	// even if the task doesn't merge automatically.
+	CommandMerge = "merge"
+	// CommandRebase asks saturn-bot to rebase the pull request.
+	CommandRebase = "rebase"
+	// CommandRecreate asks saturn-bot to create the pull request again,
+	// even if it has been closed and the task merges only once.
+	CommandRecreate = "recreate"
 )
 
 // RunData reads and returns plugin data from the context.
@@ -62,3 +91,18 @@ func Log(ctx context.Context) *zap.SugaredLogger {
 
 	return v.(*zap.SugaredLogger)
 }
+
+type logCaptureKey struct{}
+
+// WithLogCapture returns a context with w added to it.
+// Components that support capturing of logs write their messages to w.
+func WithLogCapture(parent context.Context, w io.Writer) context.Context {
+	return context.WithValue(parent, logCaptureKey{}, w)
+}
+
+// LogCapture returns the writer that captures logs from the context.
+// It returns nil if the context doesn't contain a writer.
+func LogCapture(ctx context.Context) io.Writer {
+	w, _ := ctx.Value(logCaptureKey{}).(io.Writer)
+	return w
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"net/url"
 	"os"
 	"os/exec"
@@ -37,6 +38,17 @@ func (e EmptyRepositoryError) Error() string {
 	return "empty repository"
 }
 
+// ChangedFile is a file that a branch changes.
+type ChangedFile struct {
+	// Additions is the number of added lines.
+	// 0 if the file is binary.
+	Additions int
+	// Deletions is the number of deleted lines.
+	// 0 if the file is binary.
+	Deletions int
+	Path      string
+}
+
 type GitCommandError struct {
 	err      error
 	exitCode int
@@ -53,14 +65,34 @@ func (e *GitCommandError) Unwrap() error {
 }
 
 type GitClient interface {
+	// ChangedFiles returns the paths of all files that the current branch changes compared to baseBranch.
+	ChangedFiles(baseBranch string) ([]string, error)
+	// ChangedFileStats returns all files that the current branch changes compared to baseBranch,
+	// together with the number of added and deleted lines.
+	ChangedFileStats(baseBranch string) ([]ChangedFile, error)
 	Cleanup(repo host.Repository) error
 	CommitChanges(msg string) error
+	// CountCommitsBehind returns the number of commits in baseBranch that the remote branch branchName doesn't contain.
+	// The remote branch needs to exist.
+	CountCommitsBehind(branchName, baseBranch string) (int, error)
+	// Diff stages all changes in the checkout and returns them as a unified diff.
+	// It returns a summary of the changed files instead if stat is true.
+	// color makes git colour the output.
+	Diff(stat, color bool) (string, error)
 	Execute(arg ...string) (string, string, error)
 	HasLocalChanges() (bool, error)
 	HasRemoteChanges(branchName string) (bool, error)
 	Prepare(repo host.Repository, retry bool) (string, error)
 	Push(branchName string, force bool) error
+	// RemoteBranches returns the names of all branches in the remote repository.
+	RemoteBranches() ([]string, error)
+	// SetLogCapture makes the client write its log messages, the git commands it executes and their output to w.
+	// Passing nil stops the capture.
+	SetLogCapture(w io.Writer)
 	UpdateTaskBranch(branchName string, forceRebase bool, repo host.Repository) (bool, error)
+	// WriteTree stages all changes in the checkout and returns the hash of the resulting tree.
+	// The hash changes whenever the content of the checkout changes.
+	WriteTree() (string, error)
 }
 
 type Git struct {
@@ -69,6 +101,7 @@ type Git struct {
 	MetricCommandsCount *prometheus.CounterVec
 	MetricCommandsSum   *prometheus.CounterVec
 
+	capture          io.Writer
 	clock            clock.Clock
 	cloneOpts        []string
 	checkoutDir      string
@@ -103,6 +136,48 @@ func New(opts options.Opts) (*Git, error) {
 	}, nil
 }
 
+func (g *Git) ChangedFiles(baseBranch string) ([]string, error) {
+	// Three dots compare to the merge base.
+	// Ignores changes in baseBranch that the current branch doesn't contain.
+	stdout, _, err := g.Execute("diff", "--name-only", "origin/"+baseBranch+"...HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("list changed files: %w", err)
+	}
+
+	var files []string
+	for _, line := range strings.Split(stdout, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+
+	return files, nil
+}
+
+// ChangedFileStats implements [GitClient].
+func (g *Git) ChangedFileStats(baseBranch string) ([]ChangedFile, error) {
+	stdout, _, err := g.Execute("diff", "--numstat", "origin/"+baseBranch+"...HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("list stats of changed files: %w", err)
+	}
+
+	var files []ChangedFile
+	for _, line := range strings.Split(stdout, "\n") {
+		// Format is "<additions>\t<deletions>\t<path>".
+		// git prints "-" instead of numbers for binary files.
+		parts := strings.SplitN(strings.TrimSpace(line), "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+
+		additions, _ := strconv.Atoi(parts[0])
+		deletions, _ := strconv.Atoi(parts[1])
+		files = append(files, ChangedFile{Additions: additions, Deletions: deletions, Path: parts[2]})
+	}
+
+	return files, nil
+}
+
 func (g *Git) Cleanup(repo host.Repository) error {
 	checkoutDir := path.Join(g.dataDir, "git", repo.FullName())
 	return os.RemoveAll(checkoutDir)
@@ -126,6 +201,21 @@ func (g *Git) CommitChanges(msg string) error {
 	return nil
 }
 
+// CountCommitsBehind implements [GitClient].
+func (g *Git) CountCommitsBehind(branchName, baseBranch string) (int, error) {
+	stdout, _, err := g.Execute("rev-list", "--count", "origin/"+branchName+".."+baseBranch)
+	if err != nil {
+		return 0, fmt.Errorf("count commits of branch %s behind %s: %w", branchName, baseBranch, err)
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(stdout))
+	if err != nil {
+		return 0, fmt.Errorf("parse count of commits of branch %s behind %s: %w", branchName, baseBranch, err)
+	}
+
+	return count, nil
+}
+
 func (g *Git) Prepare(repo host.Repository, retry bool) (string, error) {
 	checkoutDir := path.Join(g.dataDir, "git", repo.FullName())
 	g.checkoutDir = checkoutDir
@@ -137,7 +227,7 @@ func (g *Git) Prepare(repo host.Repository, retry bool) (string, error) {
 		}
 	}
 
-	logger := log.GitLogger().With("dir", checkoutDir, "repository", repo.FullName())
+	logger := g.logger().With("dir", checkoutDir, "repository", repo.FullName())
 	_, err := os.Stat(checkoutDir)
 	if err != nil {
 		if !os.IsNotExist(err) {
@@ -185,6 +275,32 @@ func (g *Git) Prepare(repo host.Repository, retry bool) (string, error) {
 	return checkoutDir, nil
 }
 
+// Diff implements [GitClient].
+func (g *Git) Diff(stat, color bool) (string, error) {
+	_, _, err := g.Execute("add", "--all")
+	if err != nil {
+		return "", fmt.Errorf("add changes before diff: %w", err)
+	}
+
+	args := []string{"diff", "--cached"}
+	if stat {
+		args = append(args, "--stat")
+	}
+
+	if color {
+		args = append(args, "--color=always")
+	} else {
+		args = append(args, "--color=never")
+	}
+
+	stdout, _, err := g.Execute(args...)
+	if err != nil {
+		return "", fmt.Errorf("diff changes: %w", err)
+	}
+
+	return stdout, nil
+}
+
 func (g *Git) Execute(arg ...string) (string, string, error) {
 	cmd := exec.Command(g.gitPath, arg...) // #nosec G204 -- git executable is checked and arguments are controlled by saturn-bot
 	if len(arg) > 0 {
@@ -209,8 +325,13 @@ func (g *Git) Execute(arg ...string) (string, string, error) {
 	cmd.Stderr = stderr
 	stdout := &bytes.Buffer{}
 	cmd.Stdout = stdout
-	log.GitLogger().Debugf("Executing git - cmd: %v - cwd: %s", arg, g.checkoutDir)
+	logger := g.logger()
+	logger.Debugf("Executing git - cmd: %v - cwd: %s", arg, g.checkoutDir)
 	err := g.CmdExec(cmd)
+	if g.capture != nil {
+		logger.Debugw("Executed git", "stdout", stdout.String(), "stderr", stderr.String())
+	}
+
 	if err != nil {
 		return stdout.String(), stderr.String(), &GitCommandError{err: err, exitCode: cmd.ProcessState.ExitCode(), stderr: stderr.String(), stdout: stdout.String()}
 	}
@@ -250,6 +371,27 @@ func (g *Git) HasRemoteChanges(branchName string) (bool, error) {
 	return strings.TrimSpace(stdout) != "", nil
 }
 
+// RemoteBranches implements [GitClient].
+func (g *Git) RemoteBranches() ([]string, error) {
+	stdout, _, err := g.Execute("branch", "-r", "--format", "%(refname)")
+	if err != nil {
+		return nil, fmt.Errorf("list remote branches: %w", err)
+	}
+
+	var branches []string
+	for _, line := range strings.Split(stdout, "\n") {
+		name, found := strings.CutPrefix(strings.TrimSpace(line), "refs/remotes/origin/")
+		// Skip the symbolic reference to the default branch.
+		if !found || name == "HEAD" {
+			continue
+		}
+
+		branches = append(branches, name)
+	}
+
+	return branches, nil
+}
+
 func (g *Git) Push(branchName string, force bool) error {
 	args := []string{"push", "origin", branchName, "--set-upstream"}
 	if force {
@@ -264,6 +406,11 @@ func (g *Git) Push(branchName string, force bool) error {
 	return nil
 }
 
+// SetLogCapture implements [GitClient].
+func (g *Git) SetLogCapture(w io.Writer) {
+	g.capture = w
+}
+
 func (g *Git) UpdateTaskBranch(branchName string, forceRebase bool, repo host.Repository) (bool, error) {
 	_, _, err := g.Execute("checkout", repo.BaseBranch())
 	if err != nil {
@@ -288,7 +435,7 @@ func (g *Git) UpdateTaskBranch(branchName string, forceRebase bool, repo host.Re
 	}
 
 	if !branchExistsLocal {
-		log.GitLogger().Debug("Creating branch", "branch", branchName)
+		g.logger().Debug("Creating branch", "branch", branchName)
 		if branchExistsRemote {
 			_, _, err := g.Execute("branch", "--track", branchName, "origin/"+branchName)
 			if err != nil {
@@ -307,14 +454,14 @@ func (g *Git) UpdateTaskBranch(branchName string, forceRebase bool, repo host.Re
 		return false, err
 	}
 
-	log.GitLogger().Debug("Checking out work branch", "branch", branchName)
+	g.logger().Debug("Checking out work branch", "branch", branchName)
 	_, _, err = g.Execute("checkout", branchName)
 	if err != nil {
 		return false, fmt.Errorf("checkout git branch %s: %w", branchName, err)
 	}
 
 	if branchExistsRemote {
-		log.GitLogger().Debug("Pulling changes into work branch", "branch", branchName)
+		g.logger().Debug("Pulling changes into work branch", "branch", branchName)
 		// --rebase to end up with a clean history.
 		// "--strategy-option theirs" to always prefer changes from the remote.
 		// Commits by someone else will be preserved with this strategy and there
@@ -342,13 +489,13 @@ func (g *Git) UpdateTaskBranch(branchName string, forceRebase bool, repo host.Re
 		}
 	}
 
-	log.GitLogger().Debug("Resetting to merge base", "branch", branchName)
+	g.logger().Debug("Resetting to merge base", "branch", branchName)
 	_, _, err = g.Execute("reset", "--hard", mergeBase)
 	if err != nil {
 		return false, fmt.Errorf("reset git branch %s to merge base %s: %w", branchName, mergeBase, err)
 	}
 
-	log.GitLogger().Debug("Rebasing onto work branch", "branch", branchName)
+	g.logger().Debug("Rebasing onto work branch", "branch", branchName)
 	_, _, err = g.Execute("rebase", repo.BaseBranch())
 	if err != nil {
 		return false, fmt.Errorf("rebase git branch %s: %w", branchName, err)
@@ -357,6 +504,14 @@ func (g *Git) UpdateTaskBranch(branchName string, forceRebase bool, repo host.Re
 	return hasMergeConflict, nil
 }
 
+func (g *Git) logger() *zap.SugaredLogger {
+	if g.capture == nil {
+		return log.GitLogger()
+	}
+
+	return log.Tee(log.GitLogger(), g.capture)
+}
+
 func (g *Git) author(repo host.Repository) (string, string) {
 	if g.userEmail != "" && g.userName != "" {
 		return g.userName, g.userEmail
@@ -613,3 +768,18 @@ func createGitEnvVars(c config.Configuration) ([]string, error) {
 func execCmd(cmd *exec.Cmd) error {
 	return cmd.Run()
 }
+
+// WriteTree implements [GitClient].
+func (g *Git) WriteTree() (string, error) {
+	_, _, err := g.Execute("add", "--all")
+	if err != nil {
+		return "", fmt.Errorf("add changes before write-tree: %w", err)
+	}
+
+	stdout, _, err := g.Execute("write-tree")
+	if err != nil {
+		return "", fmt.Errorf("write tree: %w", err)
+	}
+
+	return strings.TrimSpace(stdout), nil
+}
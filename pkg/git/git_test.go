@@ -459,6 +459,42 @@ func TestGit_HasLocal_Changes_Error(t *testing.T) {
 	assert.True(t, em.finished())
 }
 
+func TestGit_Diff(t *testing.T) {
+	em := &execMock{t: t}
+	em.withCall("git", "add", "--all")
+	em.withCall("git", "diff", "--cached", "--color=never").withStdout("diff --git a/test.txt b/test.txt\n")
+
+	g, err := git.New(setupOpts(config.Configuration{
+		DataDir: toPtr("/tmp"),
+		GitPath: "git",
+	}))
+	require.NoError(t, err)
+	g.CmdExec = em.exec
+	result, err := g.Diff(false, false)
+
+	require.NoError(t, err)
+	assert.Equal(t, "diff --git a/test.txt b/test.txt\n", result)
+	assert.True(t, em.finished())
+}
+
+func TestGit_Diff_StatColor(t *testing.T) {
+	em := &execMock{t: t}
+	em.withCall("git", "add", "--all")
+	em.withCall("git", "diff", "--cached", "--stat", "--color=always").withStdout(" test.txt | 1 +\n")
+
+	g, err := git.New(setupOpts(config.Configuration{
+		DataDir: toPtr("/tmp"),
+		GitPath: "git",
+	}))
+	require.NoError(t, err)
+	g.CmdExec = em.exec
+	result, err := g.Diff(true, true)
+
+	require.NoError(t, err)
+	assert.Equal(t, " test.txt | 1 +\n", result)
+	assert.True(t, em.finished())
+}
+
 func TestGit_HasRemoteChanges_Changes(t *testing.T) {
 	em := &execMock{t: t}
 	em.withCall("git", "branch", "-r", "--format", "%(refname)").withStdout("refs/remotes/origin/unittest")
@@ -561,6 +597,78 @@ func TestGit_Push_Failure(t *testing.T) {
 	assert.True(t, em.finished())
 }
 
+func TestGit_RemoteBranches(t *testing.T) {
+	em := &execMock{t: t}
+	em.withCall("git", "branch", "-r", "--format", "%(refname)").withStdout("refs/remotes/origin/HEAD\nrefs/remotes/origin/main\nrefs/remotes/origin/release/1.0\n")
+
+	g, err := git.New(setupOpts(config.Configuration{
+		DataDir: toPtr("/tmp"),
+		GitPath: "git",
+	}))
+	require.NoError(t, err)
+	g.CmdExec = em.exec
+	result, err := g.RemoteBranches()
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"main", "release/1.0"}, result)
+	assert.True(t, em.finished())
+}
+
+func TestGit_ChangedFiles(t *testing.T) {
+	em := &execMock{t: t}
+	em.withCall("git", "diff", "--name-only", "origin/main...HEAD").withStdout("docs/index.md\nmain.go\n")
+
+	g, err := git.New(setupOpts(config.Configuration{
+		DataDir: toPtr("/tmp"),
+		GitPath: "git",
+	}))
+	require.NoError(t, err)
+	g.CmdExec = em.exec
+	result, err := g.ChangedFiles("main")
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"docs/index.md", "main.go"}, result)
+	assert.True(t, em.finished())
+}
+
+func TestGit_ChangedFileStats(t *testing.T) {
+	em := &execMock{t: t}
+	em.withCall("git", "diff", "--numstat", "origin/main...HEAD").withStdout("3\t1\tmain.go\n-\t-\tlogo.png\n")
+
+	g, err := git.New(setupOpts(config.Configuration{
+		DataDir: toPtr("/tmp"),
+		GitPath: "git",
+	}))
+	require.NoError(t, err)
+	g.CmdExec = em.exec
+	result, err := g.ChangedFileStats("main")
+
+	require.NoError(t, err)
+	want := []git.ChangedFile{
+		{Additions: 3, Deletions: 1, Path: "main.go"},
+		{Path: "logo.png"},
+	}
+	require.Equal(t, want, result)
+	assert.True(t, em.finished())
+}
+
+func TestGit_CountCommitsBehind(t *testing.T) {
+	em := &execMock{t: t}
+	em.withCall("git", "rev-list", "--count", "origin/unittest..main").withStdout("7\n")
+
+	g, err := git.New(setupOpts(config.Configuration{
+		DataDir: toPtr("/tmp"),
+		GitPath: "git",
+	}))
+	require.NoError(t, err)
+	g.CmdExec = em.exec
+	result, err := g.CountCommitsBehind("unittest", "main")
+
+	require.NoError(t, err)
+	require.Equal(t, 7, result)
+	assert.True(t, em.finished())
+}
+
 func TestGit_UpdateTaskBranch_NewBranch(t *testing.T) {
 	em := &execMock{t: t}
 	em.withCall("git", "checkout", "main")
@@ -735,3 +843,21 @@ func Test_New_EnvVars(t *testing.T) {
 func toPtr[T any](v T) *T {
 	return &v
 }
+
+func TestGit_WriteTree(t *testing.T) {
+	em := &execMock{t: t}
+	em.withCall("git", "add", "--all")
+	em.withCall("git", "write-tree").withStdout("4b825dc642cb6eb9a060e54bf8d69288fbee4904\n")
+
+	g, err := git.New(setupOpts(config.Configuration{
+		DataDir: toPtr("/tmp"),
+		GitPath: "git",
+	}))
+	require.NoError(t, err)
+	g.CmdExec = em.exec
+	result, err := g.WriteTree()
+
+	require.NoError(t, err)
+	require.Equal(t, "4b825dc642cb6eb9a060e54bf8d69288fbee4904", result)
+	assert.True(t, em.finished())
+}
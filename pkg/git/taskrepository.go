@@ -0,0 +1,235 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/wndhydrnt/saturn-bot/pkg/log"
+	"github.com/wndhydrnt/saturn-bot/pkg/options"
+	"go.uber.org/zap"
+)
+
+const (
+	// taskRepositoryMaxRevisions is the number of checkouts of revisions to keep on disk.
+	taskRepositoryMaxRevisions = 10
+)
+
+var (
+	revisionRegex = regexp.MustCompile(`^[0-9a-f]{7,64}$`)
+)
+
+// TaskRepository reads task files from a git repository.
+//
+// It checks out every revision into a directory of its own.
+// A run that reads the files of a revision isn't affected
+// if another revision gets checked out while the run is active.
+// It keeps the checkouts of the most recently used revisions and removes older ones.
+type TaskRepository struct {
+	git          *Git
+	glob         string
+	initialized  bool
+	mu           sync.Mutex
+	ref          string
+	revisionsDir string
+	url          string
+}
+
+// NewTaskRepository returns a new [TaskRepository].
+// It reads the URL, the ref and the glob of task files from the configuration in opts.
+// It authenticates with the same credentials that saturn-bot uses to clone repositories.
+func NewTaskRepository(opts options.Opts) (*TaskRepository, error) {
+	g, err := New(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	baseDir := filepath.Join(opts.DataDir, "tasks-git")
+	g.checkoutDir = filepath.Join(baseDir, "repository")
+	return &TaskRepository{
+		git:          g,
+		glob:         opts.Config.TasksGitGlob,
+		ref:          opts.Config.TasksGitRef,
+		revisionsDir: filepath.Join(baseDir, "revisions"),
+		url:          opts.Config.TasksGitUrl,
+	}, nil
+}
+
+// Checkout makes the files of revision available in a directory.
+// It returns the glob pattern that matches the task files in the directory.
+// It fetches revision from the remote if the local repository doesn't contain it yet.
+func (r *TaskRepository) Checkout(revision string) ([]string, error) {
+	if !revisionRegex.MatchString(revision) {
+		return nil, fmt.Errorf("revision '%s' of task repository is not a commit SHA", revision)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.init(); err != nil {
+		return nil, err
+	}
+
+	revisionDir := filepath.Join(r.revisionsDir, revision)
+	_, err := os.Stat(revisionDir)
+	if err == nil {
+		// Mark as recently used to not remove the checkout during cleanup.
+		now := time.Now()
+		_ = os.Chtimes(revisionDir, now, now)
+		return r.taskPaths(revisionDir), nil
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("check if checkout of revision %s exists: %w", revision, err)
+	}
+
+	if !r.hasCommit(revision) {
+		log.Log().Infof("Fetching revision %s of task repository %s", revision, r.url)
+		_, _, err := r.git.Execute("fetch", "origin", revision)
+		if err != nil {
+			return nil, fmt.Errorf("fetch revision %s of task repository: %w", revision, err)
+		}
+	}
+
+	_, _, err = r.git.Execute("worktree", "add", "--detach", "--force", revisionDir, revision)
+	if err != nil {
+		return nil, fmt.Errorf("check out revision %s of task repository: %w", revision, err)
+	}
+
+	r.cleanup()
+	return r.taskPaths(revisionDir), nil
+}
+
+// Fetch downloads the latest commit of the configured ref from the remote.
+// It returns the SHA of the commit.
+func (r *TaskRepository) Fetch() (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := r.init(); err != nil {
+		return "", err
+	}
+
+	log.Log().Debugf("Fetching ref %s of task repository %s", r.ref, r.url)
+	_, _, err := r.git.Execute("fetch", "origin", r.ref)
+	if err != nil {
+		return "", fmt.Errorf("fetch ref %s of task repository: %w", r.ref, err)
+	}
+
+	stdout, _, err := r.git.Execute("rev-parse", "FETCH_HEAD")
+	if err != nil {
+		return "", fmt.Errorf("read revision of ref %s of task repository: %w", r.ref, err)
+	}
+
+	return strings.TrimSpace(stdout), nil
+}
+
+// Latest fetches the latest commit of the configured ref and checks it out.
+// It returns the SHA of the commit and the glob pattern that matches the task files.
+func (r *TaskRepository) Latest() (string, []string, error) {
+	revision, err := r.Fetch()
+	if err != nil {
+		return "", nil, err
+	}
+
+	taskPaths, err := r.Checkout(revision)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return revision, taskPaths, nil
+}
+
+// cleanup removes the checkouts of revisions that haven't been used recently.
+func (r *TaskRepository) cleanup() {
+	entries, err := os.ReadDir(r.revisionsDir)
+	if err != nil {
+		log.Log().Warnw("Failed to list checkouts of task repository", zap.Error(err))
+		return
+	}
+
+	if len(entries) <= taskRepositoryMaxRevisions {
+		return
+	}
+
+	type checkout struct {
+		modTime time.Time
+		path    string
+	}
+	var checkouts []checkout
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		checkouts = append(checkouts, checkout{modTime: info.ModTime(), path: filepath.Join(r.revisionsDir, entry.Name())})
+	}
+
+	// Most recently used first.
+	slices.SortFunc(checkouts, func(a, b checkout) int {
+		return b.modTime.Compare(a.modTime)
+	})
+	for _, c := range checkouts[min(taskRepositoryMaxRevisions, len(checkouts)):] {
+		log.Log().Debugf("Removing checkout %s of task repository", c.path)
+		if err := os.RemoveAll(c.path); err != nil {
+			log.Log().Warnw("Failed to remove checkout of task repository", zap.Error(err))
+		}
+	}
+
+	_, _, err = r.git.Execute("worktree", "prune")
+	if err != nil {
+		log.Log().Warnw("Failed to prune worktrees of task repository", zap.Error(err))
+	}
+}
+
+func (r *TaskRepository) hasCommit(revision string) bool {
+	_, _, err := r.git.Execute("cat-file", "-e", revision+"^{commit}")
+	return err == nil
+}
+
+// init creates the local repository if it doesn't exist.
+func (r *TaskRepository) init() error {
+	if r.initialized {
+		return nil
+	}
+
+	_, err := os.Stat(r.git.checkoutDir)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("check if directory of task repository exists: %w", err)
+		}
+
+		err := os.MkdirAll(r.git.checkoutDir, 0700)
+		if err != nil {
+			return fmt.Errorf("create directory of task repository: %w", err)
+		}
+
+		_, _, err = r.git.Execute("init", "--bare")
+		if err != nil {
+			return fmt.Errorf("initialize task repository: %w", err)
+		}
+
+		_, _, err = r.git.Execute("remote", "add", "origin", r.url)
+		if err != nil {
+			return fmt.Errorf("add remote to task repository: %w", err)
+		}
+	} else {
+		// The URL might have changed since the last start.
+		_, _, err = r.git.Execute("remote", "set-url", "origin", r.url)
+		if err != nil {
+			return fmt.Errorf("update remote of task repository: %w", err)
+		}
+	}
+
+	r.initialized = true
+	return nil
+}
+
+func (r *TaskRepository) taskPaths(revisionDir string) []string {
+	return []string{filepath.Join(revisionDir, r.glob)}
+}
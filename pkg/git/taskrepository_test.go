@@ -0,0 +1,102 @@
+package git_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wndhydrnt/saturn-bot/pkg/config"
+	"github.com/wndhydrnt/saturn-bot/pkg/git"
+	"github.com/wndhydrnt/saturn-bot/pkg/options"
+)
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=unittest",
+		"GIT_AUTHOR_EMAIL=unittest@example.local",
+		"GIT_COMMITTER_NAME=unittest",
+		"GIT_COMMITTER_EMAIL=unittest@example.local",
+	)
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+	return strings.TrimSpace(string(out))
+}
+
+func commitTaskFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-m", "update "+name)
+	return runGit(t, dir, "rev-parse", "HEAD")
+}
+
+func setupTaskRepository(t *testing.T) (string, *git.TaskRepository) {
+	t.Helper()
+	originDir := t.TempDir()
+	runGit(t, originDir, "init", "--initial-branch", "main")
+	opts := options.Opts{
+		Config: config.Configuration{
+			GitPath:      "git",
+			TasksGitGlob: "*.yaml",
+			TasksGitRef:  "main",
+			TasksGitUrl:  "file://" + originDir,
+		},
+		DataDir: t.TempDir(),
+	}
+	repo, err := git.NewTaskRepository(opts)
+	require.NoError(t, err)
+	return originDir, repo
+}
+
+func TestTaskRepository_Latest(t *testing.T) {
+	originDir, repo := setupTaskRepository(t)
+	revisionFirst := commitTaskFile(t, originDir, "task.yaml", "name: first\n")
+
+	revision, taskPaths, err := repo.Latest()
+	require.NoError(t, err)
+	require.Equal(t, revisionFirst, revision)
+	require.Len(t, taskPaths, 1)
+	content, err := os.ReadFile(strings.Replace(taskPaths[0], "*.yaml", "task.yaml", 1))
+	require.NoError(t, err)
+	require.Equal(t, "name: first\n", string(content))
+
+	revisionSecond := commitTaskFile(t, originDir, "task.yaml", "name: second\n")
+	revision, taskPathsSecond, err := repo.Latest()
+	require.NoError(t, err)
+	require.Equal(t, revisionSecond, revision)
+	content, err = os.ReadFile(strings.Replace(taskPathsSecond[0], "*.yaml", "task.yaml", 1))
+	require.NoError(t, err)
+	require.Equal(t, "name: second\n", string(content))
+
+	// Checkout of the previous revision stays unchanged.
+	content, err = os.ReadFile(strings.Replace(taskPaths[0], "*.yaml", "task.yaml", 1))
+	require.NoError(t, err)
+	require.Equal(t, "name: first\n", string(content))
+}
+
+func TestTaskRepository_Checkout_FetchesUnknownRevision(t *testing.T) {
+	originDir, repo := setupTaskRepository(t)
+	commitTaskFile(t, originDir, "task.yaml", "name: first\n")
+	_, _, err := repo.Latest()
+	require.NoError(t, err)
+
+	revisionSecond := commitTaskFile(t, originDir, "task.yaml", "name: second\n")
+	taskPaths, err := repo.Checkout(revisionSecond)
+	require.NoError(t, err)
+	content, err := os.ReadFile(strings.Replace(taskPaths[0], "*.yaml", "task.yaml", 1))
+	require.NoError(t, err)
+	require.Equal(t, "name: second\n", string(content))
+}
+
+func TestTaskRepository_Checkout_InvalidRevision(t *testing.T) {
+	_, repo := setupTaskRepository(t)
+
+	_, err := repo.Checkout("../../etc")
+	require.EqualError(t, err, "revision '../../etc' of task repository is not a commit SHA")
+}
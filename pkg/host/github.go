@@ -3,6 +3,7 @@ package host
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"iter"
 	"net/http"
@@ -25,6 +26,21 @@ var (
 	ctx = context.Background()
 )
 
+// GithubProject identifies a project on GitHub.
+type GithubProject struct {
+	Number int
+	// Owner is the login of the organization or the user that owns the project.
+	Owner string
+}
+
+// GithubPullRequestData contains settings of a pull request that only GitHub supports.
+type GithubPullRequestData struct {
+	// Milestone is the title of the milestone to assign the pull request to.
+	Milestone string
+	// Projects to add the pull request to.
+	Projects []GithubProject
+}
+
 type GitHubRepository struct {
 	client *github.Client
 	host   *GitHubHost
@@ -86,38 +102,54 @@ func (g *GitHubRepository) CreatePullRequest(branch string, data PullRequestData
 		return nil, err
 	}
 
+	base := g.repo.DefaultBranch
+	if data.TargetBranch != "" {
+		base = github.Ptr(data.TargetBranch)
+	}
+
 	gpr := &github.NewPullRequest{
-		Base:                g.repo.DefaultBranch,
+		Base:                base,
 		Body:                github.Ptr(body),
 		Head:                github.Ptr(branch),
 		MaintainerCanModify: github.Ptr(true),
 		Title:               github.Ptr(data.Title),
 	}
+	if data.Draft {
+		gpr.Draft = github.Ptr(true)
+	}
 	pr, _, err := g.client.PullRequests.Create(ctx, g.repo.GetOwner().GetLogin(), g.repo.GetName(), gpr)
 	if err != nil {
 		return nil, fmt.Errorf("create github pull request: %w", err)
 	}
 
+	// Return the pull request together with any error from here on
+	// to let the caller know that the pull request exists.
 	if len(data.Assignees) > 0 {
 		_, _, err := g.client.Issues.AddAssignees(ctx, g.repo.GetOwner().GetLogin(), g.repo.GetName(), pr.GetNumber(), data.Assignees)
 		if err != nil {
-			return nil, fmt.Errorf("add assignees to pull request: %w", err)
+			return convertGithubPullRequestToPullRequest(pr), fmt.Errorf("add assignees to pull request: %w", err)
 		}
 	}
 
 	if len(data.Reviewers) > 0 {
+		users, teams := splitGithubReviewers(data.Reviewers)
 		_, _, err := g.client.PullRequests.RequestReviewers(
 			ctx,
 			g.repo.GetOwner().GetLogin(),
 			g.repo.GetName(),
 			pr.GetNumber(),
-			github.ReviewersRequest{Reviewers: data.Reviewers},
+			github.ReviewersRequest{Reviewers: users, TeamReviewers: teams},
 		)
 		if err != nil {
-			return nil, fmt.Errorf("request review for pull request: %w", err)
+			return convertGithubPullRequestToPullRequest(pr), fmt.Errorf("request review for pull request: %w", err)
 		}
 	}
 
+	err = g.applyPullRequestData(data.Github, pr)
+	if err != nil {
+		return convertGithubPullRequestToPullRequest(pr), err
+	}
+
 	return convertGithubPullRequestToPullRequest(pr), nil
 }
 
@@ -275,12 +307,12 @@ func (g *GitHubRepository) ListPullRequestComments(pr *PullRequest) ([]PullReque
 	return pullRequestComments, nil
 }
 
-func (g *GitHubRepository) MergePullRequest(deleteBranch bool, pr *PullRequest) error {
+func (g *GitHubRepository) MergePullRequest(opts MergeOptions, pr *PullRequest) error {
 	gpr := pr.Raw.(*github.PullRequest)
-	opts := &github.PullRequestOptions{
-		MergeMethod: g.determineMergeMethod(),
+	mergeOpts := &github.PullRequestOptions{
+		MergeMethod: g.determineMergeMethod(opts.Method),
 	}
-	mergeResult, _, err := g.client.PullRequests.Merge(ctx, g.repo.GetOwner().GetLogin(), g.repo.GetName(), gpr.GetNumber(), "Auto-merge by saturn-bot", opts)
+	mergeResult, _, err := g.client.PullRequests.Merge(ctx, g.repo.GetOwner().GetLogin(), g.repo.GetName(), gpr.GetNumber(), "Auto-merge by saturn-bot", mergeOpts)
 	if err != nil {
 		return fmt.Errorf("merge github pull request %d: %w", gpr.GetNumber(), err)
 	}
@@ -289,14 +321,123 @@ func (g *GitHubRepository) MergePullRequest(deleteBranch bool, pr *PullRequest)
 
 	// Don't delete if DeleteBranchOnMerge == true.
 	// GitHub deletes the branch on its own.
-	if deleteBranch && !g.repo.GetDeleteBranchOnMerge() {
+	if opts.DeleteBranch && !g.repo.GetDeleteBranchOnMerge() {
 		return g.DeleteBranch(pr)
 	}
 
 	return nil
 }
 
-func (g *GitHubRepository) determineMergeMethod() string {
+const githubEnableAutoMergeMutation = `mutation($pullRequestId: ID!, $mergeMethod: PullRequestMergeMethod) {
+  enablePullRequestAutoMerge(input: {pullRequestId: $pullRequestId, mergeMethod: $mergeMethod}) {
+    clientMutationId
+  }
+}`
+
+// EnableAutoMerge implements [Repository].
+// The REST API of GitHub doesn't support auto-merge.
+// The function calls the GraphQL API instead.
+//
+// GitHub ignores opts.DeleteBranch.
+// It deletes the branch after the merge if the setting "Automatically delete head branches" of the repository is active.
+func (g *GitHubRepository) EnableAutoMerge(opts MergeOptions, pr *PullRequest) error {
+	gpr := pr.Raw.(*github.PullRequest)
+	variables := map[string]any{"pullRequestId": gpr.GetNodeID()}
+	mergeMethod := g.determineMergeMethod(opts.Method)
+	if mergeMethod != "" {
+		variables["mergeMethod"] = strings.ToUpper(mergeMethod)
+	}
+
+	err := g.doGraphql(githubEnableAutoMergeMutation, variables, nil)
+	if err != nil {
+		return fmt.Errorf("enable auto-merge of github pull request %d: %w", gpr.GetNumber(), err)
+	}
+
+	gpr.AutoMerge = &github.PullRequestAutoMerge{MergeMethod: github.Ptr(mergeMethod)}
+	pr.AutoMergeEnabled = true
+	return nil
+}
+
+const githubMarkReadyForReviewMutation = `mutation($pullRequestId: ID!) {
+  markPullRequestReadyForReview(input: {pullRequestId: $pullRequestId}) {
+    clientMutationId
+  }
+}`
+
+// MarkPullRequestReady implements [Repository].
+// The REST API of GitHub doesn't support changing the draft status of a pull request.
+// The function calls the GraphQL API instead.
+func (g *GitHubRepository) MarkPullRequestReady(pr *PullRequest) error {
+	gpr := pr.Raw.(*github.PullRequest)
+	err := g.doGraphql(githubMarkReadyForReviewMutation, map[string]any{"pullRequestId": gpr.GetNodeID()}, nil)
+	if err != nil {
+		return fmt.Errorf("mark github pull request %d as ready for review: %w", gpr.GetNumber(), err)
+	}
+
+	gpr.Draft = github.Ptr(false)
+	pr.Draft = false
+	return nil
+}
+
+// doGraphql sends query to the GraphQL API of GitHub.
+// It decodes the data of the response into result if result isn't nil.
+func (g *GitHubRepository) doGraphql(query string, variables map[string]any, result any) error {
+	body := map[string]any{
+		"query":     query,
+		"variables": variables,
+	}
+	req, err := g.client.NewRequest(http.MethodPost, githubGraphqlUrl(g.client.BaseURL), body)
+	if err != nil {
+		return fmt.Errorf("create graphql request: %w", err)
+	}
+
+	var resp struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	_, err = g.client.Do(ctx, req, &resp)
+	if err != nil {
+		return err
+	}
+
+	// The GraphQL API responds with status code 200 and a list of errors.
+	if len(resp.Errors) > 0 {
+		return errors.New(resp.Errors[0].Message)
+	}
+
+	if result != nil {
+		err := json.Unmarshal(resp.Data, result)
+		if err != nil {
+			return fmt.Errorf("decode data of graphql response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// githubGraphqlUrl returns the URL of the GraphQL API.
+// GitHub serves the REST API at "https://api.github.com/" and the GraphQL API at "https://api.github.com/graphql".
+// GitHub Enterprise serves the REST API at "/api/v3/" and the GraphQL API at "/api/graphql".
+func githubGraphqlUrl(baseUrl *url.URL) string {
+	u := *baseUrl
+	if strings.HasSuffix(u.Path, "/api/v3/") {
+		u.Path = strings.TrimSuffix(u.Path, "v3/") + "graphql"
+	} else {
+		u.Path = "/graphql"
+	}
+
+	return u.String()
+}
+
+// determineMergeMethod returns method if it is set.
+// Otherwise, it selects a merge method that the repository allows.
+func (g *GitHubRepository) determineMergeMethod(method string) string {
+	if method != "" {
+		return method
+	}
+
 	if g.repo.GetAllowSquashMerge() {
 		return "squash"
 	}
@@ -320,6 +461,29 @@ func (g *GitHubRepository) Owner() string {
 	return g.repo.GetOwner().GetLogin()
 }
 
+// RequestPullRequestReview implements [Repository].
+// GitHub notifies a user again if the user has already submitted a review.
+func (g *GitHubRepository) RequestPullRequestReview(reviewers []string, pr *PullRequest) error {
+	if len(reviewers) == 0 {
+		return nil
+	}
+
+	gpr := pr.Raw.(*github.PullRequest)
+	users, teams := splitGithubReviewers(reviewers)
+	_, _, err := g.client.PullRequests.RequestReviewers(
+		ctx,
+		g.repo.GetOwner().GetLogin(),
+		g.repo.GetName(),
+		gpr.GetNumber(),
+		github.ReviewersRequest{Reviewers: users, TeamReviewers: teams},
+	)
+	if err != nil {
+		return fmt.Errorf("request review of github pull request %d again: %w", gpr.GetNumber(), err)
+	}
+
+	return nil
+}
+
 func (g *GitHubRepository) UpdatePullRequest(data PullRequestData, pr *PullRequest) error {
 	gpr := pr.Raw.(*github.PullRequest)
 	needsUpdate := false
@@ -373,14 +537,22 @@ func (g *GitHubRepository) UpdatePullRequest(data PullRequestData, pr *PullReque
 			submittedReviewers = append(submittedReviewers, review.User)
 		}
 
-		reviewersToAdd, reviewersToRemove := diffReviewers(gpr.RequestedReviewers, submittedReviewers, data.Reviewers)
-		if len(reviewersToAdd) > 0 {
+		users, teams := splitGithubReviewers(data.Reviewers)
+		reviewersToAdd, reviewersToRemove := diffReviewers(gpr.RequestedReviewers, submittedReviewers, users)
+		// Don't request a review from a team again if a member has submitted a review.
+		// GitHub removes the request of the team once a member has submitted a review.
+		var teamsToAdd []string
+		if len(reviews) == 0 {
+			teamsToAdd = diffTeams(gpr.RequestedTeams, teams)
+		}
+
+		if len(reviewersToAdd) > 0 || len(teamsToAdd) > 0 {
 			_, _, err := g.client.PullRequests.RequestReviewers(
 				ctx,
 				g.repo.GetOwner().GetLogin(),
 				g.repo.GetName(),
 				gpr.GetNumber(),
-				github.ReviewersRequest{Reviewers: reviewersToAdd},
+				github.ReviewersRequest{Reviewers: reviewersToAdd, TeamReviewers: teamsToAdd},
 			)
 			if err != nil {
 				return fmt.Errorf("update to add requested reviewers on pull request %d: %w", gpr.GetNumber(), err)
@@ -401,9 +573,143 @@ func (g *GitHubRepository) UpdatePullRequest(data PullRequestData, pr *PullReque
 		}
 	}
 
+	return g.applyPullRequestData(data.Github, gpr)
+}
+
+// applyPullRequestData assigns the milestone of data to gpr and adds gpr to the projects of data.
+// It doesn't remove gpr from a project if the project has been removed from data.
+func (g *GitHubRepository) applyPullRequestData(data GithubPullRequestData, gpr *github.PullRequest) error {
+	if data.Milestone != "" && gpr.GetMilestone().GetTitle() != data.Milestone {
+		milestone, err := g.findMilestone(data.Milestone)
+		if err != nil {
+			return err
+		}
+
+		_, _, err = g.client.Issues.Edit(
+			ctx,
+			g.repo.GetOwner().GetLogin(),
+			g.repo.GetName(),
+			gpr.GetNumber(),
+			&github.IssueRequest{Milestone: github.Ptr(milestone.GetNumber())},
+		)
+		if err != nil {
+			return fmt.Errorf("assign milestone %s to github pull request %d: %w", data.Milestone, gpr.GetNumber(), err)
+		}
+
+		gpr.Milestone = milestone
+	}
+
+	for _, project := range data.Projects {
+		err := g.addToProject(project, gpr)
+		if err != nil {
+			return fmt.Errorf("add github pull request %d to project %d of %s: %w", gpr.GetNumber(), project.Number, project.Owner, err)
+		}
+	}
+
 	return nil
 }
 
+// findMilestone returns the open milestone of the repository that has the given title.
+func (g *GitHubRepository) findMilestone(title string) (*github.Milestone, error) {
+	opts := &github.MilestoneListOptions{
+		State:       "open",
+		ListOptions: github.ListOptions{Page: 1, PerPage: 100},
+	}
+	for {
+		milestones, resp, err := g.client.Issues.ListMilestones(ctx, g.repo.GetOwner().GetLogin(), g.repo.GetName(), opts)
+		if err != nil {
+			return nil, fmt.Errorf("list milestones of github repository: %w", err)
+		}
+
+		for _, milestone := range milestones {
+			if milestone.GetTitle() == title {
+				return milestone, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			return nil, fmt.Errorf("github repository has no open milestone %s", title)
+		}
+
+		opts.Page = resp.NextPage
+	}
+}
+
+const githubProjectQuery = `query($owner: String!, $number: Int!, $contentId: ID!) {
+  repositoryOwner(login: $owner) {
+    ... on ProjectV2Owner {
+      projectV2(number: $number) {
+        id
+      }
+    }
+  }
+  node(id: $contentId) {
+    ... on PullRequest {
+      projectItems(first: 100) {
+        nodes {
+          project {
+            id
+          }
+        }
+      }
+    }
+  }
+}`
+
+const githubAddProjectItemMutation = `mutation($projectId: ID!, $contentId: ID!) {
+  addProjectV2ItemById(input: {projectId: $projectId, contentId: $contentId}) {
+    item {
+      id
+    }
+  }
+}`
+
+// addToProject adds gpr to a project.
+// The REST API of GitHub doesn't support projects.
+// The function calls the GraphQL API instead.
+// It doesn't add gpr if gpr is already part of the project.
+func (g *GitHubRepository) addToProject(project GithubProject, gpr *github.PullRequest) error {
+	var data struct {
+		RepositoryOwner *struct {
+			ProjectV2 *struct {
+				ID string `json:"id"`
+			} `json:"projectV2"`
+		} `json:"repositoryOwner"`
+		Node *struct {
+			ProjectItems struct {
+				Nodes []struct {
+					Project struct {
+						ID string `json:"id"`
+					} `json:"project"`
+				} `json:"nodes"`
+			} `json:"projectItems"`
+		} `json:"node"`
+	}
+	err := g.doGraphql(githubProjectQuery, map[string]any{"owner": project.Owner, "number": project.Number, "contentId": gpr.GetNodeID()}, &data)
+	if err != nil {
+		return fmt.Errorf("find project: %w", err)
+	}
+
+	if data.RepositoryOwner == nil || data.RepositoryOwner.ProjectV2 == nil {
+		return errors.New("project not found")
+	}
+
+	projectID := data.RepositoryOwner.ProjectV2.ID
+	if data.Node != nil {
+		for _, item := range data.Node.ProjectItems.Nodes {
+			if item.Project.ID == projectID {
+				return nil
+			}
+		}
+	}
+
+	return g.doGraphql(
+		githubAddProjectItemMutation,
+		map[string]any{"projectId": projectID, "contentId": gpr.GetNodeID()},
+		nil,
+	)
+}
+
 func (g *GitHubRepository) WebUrl() string {
 	return g.repo.GetHTMLURL()
 }
@@ -476,6 +782,35 @@ func diffAssignees(current []*github.User, want []string) (toAdd, toRemove []str
 	return slices.Compact(toAdd), slices.Compact(toRemove)
 }
 
+// diffTeams returns the slugs of the teams in want that haven't been requested.
+func diffTeams(requested []*github.Team, want []string) []string {
+	var toAdd []string
+	for _, slug := range want {
+		idx := slices.IndexFunc(requested, func(team *github.Team) bool {
+			return team.GetSlug() == slug
+		})
+		if idx == -1 {
+			toAdd = append(toAdd, slug)
+		}
+	}
+
+	return toAdd
+}
+
+// splitGithubReviewers splits reviewers into users and slugs of teams.
+// GitHub identifies a team by its slug, without the name of the organization.
+func splitGithubReviewers(reviewers []string) (users, teams []string) {
+	for _, reviewer := range reviewers {
+		if isTeam(reviewer) {
+			teams = append(teams, path.Base(reviewer))
+		} else {
+			users = append(users, reviewer)
+		}
+	}
+
+	return users, teams
+}
+
 func diffReviewers(requested, submitted []*github.User, want []string) (toAdd, toRemove []string) {
 	// Normalize the list of requested reviewers by adding the users that have already
 	// submitted a review.
@@ -489,6 +824,12 @@ func diffReviewers(requested, submitted []*github.User, want []string) (toAdd, t
 	return diffAssignees(requested, want)
 }
 
+// GitHubPermissionChecker defines methods to check the permissions of users in GitHub repositories.
+type GitHubPermissionChecker interface {
+	// HasWriteAccess returns true if the user with login has at least the permission write in the repository owner/repo.
+	HasWriteAccess(owner, repo, login string) (bool, error)
+}
+
 type GitHubHost struct {
 	authenticatedUser *UserInfo
 	client            *github.Client
@@ -626,6 +967,21 @@ func (g *GitHubHost) CreateFromName(name string) (Repository, error) {
 	return &GitHubRepository{client: g.client, host: g, repo: repo}, nil
 }
 
+// HasWriteAccess implements [GitHubPermissionChecker].
+func (g *GitHubHost) HasWriteAccess(owner, repo, login string) (bool, error) {
+	level, resp, err := g.client.Repositories.GetPermissionLevel(ctx, owner, repo, login)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("get permission of user %s in repository %s/%s: %w", login, owner, repo, err)
+	}
+
+	// GitHub reports the role "maintain" as "write".
+	return slices.Contains([]string{"admin", "write"}, level.GetPermission()), nil
+}
+
 func (g *GitHubHost) Name() string {
 	if g.client.BaseURL.Host == "api.github.com" {
 		return "github.com"
@@ -785,15 +1141,18 @@ func convertGithubPullRequestToPullRequest(gpr *github.PullRequest) *PullRequest
 	u, _ := url.Parse(gpr.GetHead().GetRepo().GetHTMLURL())
 
 	return &PullRequest{
-		CreatedAt:      gpr.GetCreatedAt().Time,
-		Number:         int64(gpr.GetNumber()),
-		WebURL:         gpr.GetHTMLURL(),
-		State:          mapGithubPrToPullRequestState(gpr),
-		Raw:            gpr,
-		HostName:       u.Host,
-		BranchName:     gpr.GetHead().GetRef(),
-		RepositoryName: fmt.Sprintf("%s%s", u.Host, u.Path),
-		Type:           GitHubType,
+		CreatedAt:        gpr.GetCreatedAt().Time,
+		Number:           int64(gpr.GetNumber()),
+		WebURL:           gpr.GetHTMLURL(),
+		State:            mapGithubPrToPullRequestState(gpr),
+		Raw:              gpr,
+		HostName:         u.Host,
+		BranchName:       gpr.GetHead().GetRef(),
+		TargetBranch:     gpr.GetBase().GetRef(),
+		RepositoryName:   fmt.Sprintf("%s%s", u.Host, u.Path),
+		Type:             GitHubType,
+		AutoMergeEnabled: gpr.AutoMerge != nil,
+		Draft:            gpr.GetDraft(),
 	}
 }
 
@@ -3,6 +3,7 @@ package host
 import (
 	"errors"
 	"net/http"
+	"net/url"
 	"slices"
 	"testing"
 	"time"
@@ -200,6 +201,69 @@ func TestGitHubRepository_CreatePullRequest(t *testing.T) {
 	require.True(t, gock.IsDone())
 }
 
+func TestGitHubRepository_CreatePullRequest_TargetBranch(t *testing.T) {
+	defer gock.Off()
+	gock.New("https://api.github.com").
+		Post("/repos/unit/test/pulls").
+		MatchType("json").
+		JSON(&github.NewPullRequest{
+			Base:                github.Ptr("release/1.0"),
+			Body:                github.Ptr(githubPullRequestBody),
+			Head:                github.Ptr("unittest"),
+			MaintainerCanModify: github.Ptr(true),
+			Title:               github.Ptr("pull request title"),
+		}).
+		Reply(200).
+		JSON(createPullRequestRespBody)
+	prData := PullRequestData{
+		Body:         "pull request body",
+		TargetBranch: "release/1.0",
+		TaskName:     "Unit Test",
+		Title:        "pull request title",
+	}
+
+	repo := &GitHubRepository{
+		client: setupGitHubTestClient(),
+		repo:   setupGitHubRepository(),
+	}
+	_, err := repo.CreatePullRequest("unittest", prData)
+
+	require.NoError(t, err)
+	require.True(t, gock.IsDone())
+}
+
+func TestGitHubRepository_CreatePullRequest_Draft(t *testing.T) {
+	defer gock.Off()
+	gock.New("https://api.github.com").
+		Post("/repos/unit/test/pulls").
+		MatchType("json").
+		JSON(&github.NewPullRequest{
+			Base:                github.Ptr("main"),
+			Body:                github.Ptr(githubPullRequestBody),
+			Draft:               github.Ptr(true),
+			Head:                github.Ptr("unittest"),
+			MaintainerCanModify: github.Ptr(true),
+			Title:               github.Ptr("pull request title"),
+		}).
+		Reply(200).
+		JSON(createPullRequestRespBody)
+	prData := PullRequestData{
+		Body:     "pull request body",
+		Draft:    true,
+		TaskName: "Unit Test",
+		Title:    "pull request title",
+	}
+
+	repo := &GitHubRepository{
+		client: setupGitHubTestClient(),
+		repo:   setupGitHubRepository(),
+	}
+	_, err := repo.CreatePullRequest("unittest", prData)
+
+	require.NoError(t, err)
+	require.True(t, gock.IsDone())
+}
+
 func TestGitHubRepository_CreatePullRequest_WithAssignees(t *testing.T) {
 	defer gock.Off()
 	gock.New("https://api.github.com").
@@ -238,6 +302,123 @@ func TestGitHubRepository_CreatePullRequest_WithAssignees(t *testing.T) {
 	require.True(t, gock.IsDone())
 }
 
+func TestGitHubRepository_CreatePullRequest_WithGithubData(t *testing.T) {
+	defer gock.Off()
+	gock.New("https://api.github.com").
+		Post("/repos/unit/test/pulls").
+		Reply(200).
+		JSON(github.PullRequest{NodeID: github.Ptr("PR_kwDOA"), Number: github.Ptr(1)})
+	gock.New("https://api.github.com").
+		Get("/repos/unit/test/milestones").
+		MatchParam("state", "open").
+		Reply(200).
+		JSON([]*github.Milestone{
+			{Number: github.Ptr(3), Title: github.Ptr("v1.1")},
+			{Number: github.Ptr(4), Title: github.Ptr("v1.2")},
+		})
+	gock.New("https://api.github.com").
+		Patch("/repos/unit/test/issues/1").
+		MatchType("json").
+		JSON(map[string]any{"milestone": 4}).
+		Reply(200).
+		JSON(map[string]any{})
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		MatchType("json").
+		JSON(map[string]any{
+			"query":     githubProjectQuery,
+			"variables": map[string]any{"owner": "unit", "number": 7, "contentId": "PR_kwDOA"},
+		}).
+		Reply(200).
+		JSON(map[string]any{"data": map[string]any{"repositoryOwner": map[string]any{"projectV2": map[string]any{"id": "PVT_kwDOA"}}}})
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		MatchType("json").
+		JSON(map[string]any{
+			"query":     githubAddProjectItemMutation,
+			"variables": map[string]any{"projectId": "PVT_kwDOA", "contentId": "PR_kwDOA"},
+		}).
+		Reply(200).
+		JSON(map[string]any{"data": map[string]any{}})
+	prData := PullRequestData{
+		Body: "pull request body",
+		Github: GithubPullRequestData{
+			Milestone: "v1.2",
+			Projects:  []GithubProject{{Number: 7, Owner: "unit"}},
+		},
+		TaskName: "Unit Test",
+		Title:    "pull request title",
+	}
+
+	repo := &GitHubRepository{
+		client: setupGitHubTestClient(),
+		repo:   setupGitHubRepository(),
+	}
+	_, err := repo.CreatePullRequest("unittest", prData)
+
+	require.NoError(t, err)
+	require.True(t, gock.IsDone())
+}
+
+func TestGitHubRepository_CreatePullRequest_UnknownProject(t *testing.T) {
+	defer gock.Off()
+	gock.New("https://api.github.com").
+		Post("/repos/unit/test/pulls").
+		Reply(200).
+		JSON(github.PullRequest{NodeID: github.Ptr("PR_kwDOA"), Number: github.Ptr(1)})
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		Reply(200).
+		JSON(map[string]any{"data": map[string]any{"repositoryOwner": map[string]any{"projectV2": nil}}})
+	prData := PullRequestData{
+		Body:     "pull request body",
+		Github:   GithubPullRequestData{Projects: []GithubProject{{Number: 7, Owner: "unit"}}},
+		TaskName: "Unit Test",
+		Title:    "pull request title",
+	}
+
+	repo := &GitHubRepository{
+		client: setupGitHubTestClient(),
+		repo:   setupGitHubRepository(),
+	}
+	pr, err := repo.CreatePullRequest("unittest", prData)
+
+	require.EqualError(t, err, "add github pull request 1 to project 7 of unit: project not found")
+	require.NotNil(t, pr, "Returns the pull request that has been created")
+	require.Equal(t, int64(1), pr.Number)
+	require.True(t, gock.IsDone())
+}
+
+func TestGitHubRepository_CreatePullRequest_UnknownMilestone(t *testing.T) {
+	defer gock.Off()
+	gock.New("https://api.github.com").
+		Post("/repos/unit/test/pulls").
+		Reply(200).
+		JSON(github.PullRequest{NodeID: github.Ptr("PR_kwDOA"), Number: github.Ptr(1)})
+	gock.New("https://api.github.com").
+		Get("/repos/unit/test/milestones").
+		MatchParam("state", "open").
+		Reply(200).
+		JSON([]*github.Milestone{{Number: github.Ptr(3), Title: github.Ptr("v1.1")}})
+	prData := PullRequestData{
+		Body:     "pull request body",
+		Github:   GithubPullRequestData{Milestone: "v1.2"},
+		TaskName: "Unit Test",
+		Title:    "pull request title",
+	}
+
+	repo := &GitHubRepository{
+		client: setupGitHubTestClient(),
+		repo:   setupGitHubRepository(),
+	}
+	pr, err := repo.CreatePullRequest("unittest", prData)
+
+	require.EqualError(t, err, "github repository has no open milestone v1.2")
+	require.NotNil(t, pr, "Returns the pull request that has been created")
+	require.Equal(t, int64(1), pr.Number)
+	require.True(t, gock.IsDone())
+}
+
 func TestGitHubRepository_CreatePullRequest_WithReviewers(t *testing.T) {
 	defer gock.Off()
 	gock.New("https://api.github.com").
@@ -277,6 +458,37 @@ func TestGitHubRepository_CreatePullRequest_WithReviewers(t *testing.T) {
 	require.True(t, gock.IsDone())
 }
 
+func TestGitHubRepository_CreatePullRequest_WithTeamReviewers(t *testing.T) {
+	defer gock.Off()
+	gock.New("https://api.github.com").
+		Post("/repos/unit/test/pulls").
+		Reply(200).
+		JSON(createPullRequestRespBody)
+	gock.New("https://api.github.com").
+		Post("/repos/unit/test/pulls/1/requested_reviewers").
+		MatchType("json").
+		JSON(github.ReviewersRequest{Reviewers: []string{"abby"}, TeamReviewers: []string{"backend"}}).
+		Reply(200).
+		JSON(&github.PullRequest{
+			Number: github.Ptr(1),
+		})
+	prData := PullRequestData{
+		Body:      "pull request body",
+		Reviewers: []string{"abby", "unit/backend"},
+		TaskName:  "Unit Test",
+		Title:     "pull request title",
+	}
+
+	repo := &GitHubRepository{
+		client: setupGitHubTestClient(),
+		repo:   setupGitHubRepository(),
+	}
+	_, err := repo.CreatePullRequest("unittest", prData)
+
+	require.NoError(t, err)
+	require.True(t, gock.IsDone())
+}
+
 func TestGitHubRepository_FindPullRequest(t *testing.T) {
 	defer gock.Off()
 	gock.New("https://api.github.com").
@@ -582,7 +794,7 @@ func TestGitHubRepository_MergePullRequest_NoDeleteBranch(t *testing.T) {
 		client: setupGitHubTestClient(),
 		repo:   setupGitHubRepository(),
 	}
-	err := repo.MergePullRequest(false, toSbPr(pr))
+	err := repo.MergePullRequest(MergeOptions{}, toSbPr(pr))
 
 	require.NoError(t, err)
 	assert.True(t, gock.IsDone())
@@ -610,7 +822,7 @@ func TestGitHubRepository_MergePullRequest_DeleteBranch(t *testing.T) {
 		client: setupGitHubTestClient(),
 		repo:   setupGitHubRepository(),
 	}
-	err := repo.MergePullRequest(true, toSbPr(pr))
+	err := repo.MergePullRequest(MergeOptions{DeleteBranch: true}, toSbPr(pr))
 
 	require.NoError(t, err)
 	assert.True(t, gock.IsDone())
@@ -637,7 +849,7 @@ func TestGitHubRepository_MergePullRequest_DeleteBranchByGitHub(t *testing.T) {
 		client: setupGitHubTestClient(),
 		repo:   ghRepo,
 	}
-	err := repo.MergePullRequest(true, toSbPr(pr))
+	err := repo.MergePullRequest(MergeOptions{DeleteBranch: true}, toSbPr(pr))
 
 	require.NoError(t, err)
 	assert.True(t, gock.IsDone())
@@ -694,7 +906,7 @@ func TestGitHubRepository_MergePullRequest_MergeMethods(t *testing.T) {
 				client: setupGitHubTestClient(),
 				repo:   tc.repo,
 			}
-			err := repo.MergePullRequest(false, toSbPr(pr))
+			err := repo.MergePullRequest(MergeOptions{}, toSbPr(pr))
 
 			require.NoError(t, err)
 			assert.True(t, gock.IsDone())
@@ -702,6 +914,139 @@ func TestGitHubRepository_MergePullRequest_MergeMethods(t *testing.T) {
 	}
 }
 
+func TestGitHubRepository_MergePullRequest_MethodFromOptions(t *testing.T) {
+	defer gock.Off()
+	gock.New("https://api.github.com").
+		Put("/repos/unit/test/pulls/987/merge").
+		JSON(map[string]string{
+			"commit_message": "Auto-merge by saturn-bot",
+			"merge_method":   "rebase",
+		}).
+		Reply(200)
+	pr := &github.PullRequest{
+		Number: github.Ptr(987),
+	}
+
+	ghRepo := setupGitHubRepository()
+	ghRepo.AllowSquashMerge = github.Ptr(true)
+	repo := &GitHubRepository{
+		client: setupGitHubTestClient(),
+		repo:   ghRepo,
+	}
+	err := repo.MergePullRequest(MergeOptions{Method: MergeMethodRebase}, toSbPr(pr))
+
+	require.NoError(t, err)
+	assert.True(t, gock.IsDone())
+}
+
+func TestGitHubRepository_EnableAutoMerge(t *testing.T) {
+	defer gock.Off()
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		MatchType("json").
+		JSON(map[string]any{
+			"query": githubEnableAutoMergeMutation,
+			"variables": map[string]any{
+				"mergeMethod":   "SQUASH",
+				"pullRequestId": "PR_kwDOA",
+			},
+		}).
+		Reply(200).
+		JSON(map[string]any{"data": map[string]any{}})
+	pr := &github.PullRequest{
+		NodeID: github.Ptr("PR_kwDOA"),
+		Number: github.Ptr(987),
+	}
+
+	repo := &GitHubRepository{
+		client: setupGitHubTestClient(),
+		repo:   setupGitHubRepository(),
+	}
+	sbPr := toSbPr(pr)
+	err := repo.EnableAutoMerge(MergeOptions{Method: MergeMethodSquash}, sbPr)
+
+	require.NoError(t, err)
+	assert.True(t, sbPr.AutoMergeEnabled)
+	assert.True(t, gock.IsDone())
+}
+
+func TestGitHubRepository_MarkPullRequestReady(t *testing.T) {
+	defer gock.Off()
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		MatchType("json").
+		JSON(map[string]any{
+			"query":     githubMarkReadyForReviewMutation,
+			"variables": map[string]any{"pullRequestId": "PR_kwDOA"},
+		}).
+		Reply(200).
+		JSON(map[string]any{"data": map[string]any{}})
+	pr := &github.PullRequest{
+		Draft:  github.Ptr(true),
+		NodeID: github.Ptr("PR_kwDOA"),
+		Number: github.Ptr(987),
+	}
+
+	repo := &GitHubRepository{
+		client: setupGitHubTestClient(),
+		repo:   setupGitHubRepository(),
+	}
+	sbPr := toSbPr(pr)
+	err := repo.MarkPullRequestReady(sbPr)
+
+	require.NoError(t, err)
+	assert.False(t, sbPr.Draft)
+	assert.True(t, gock.IsDone())
+}
+
+func TestGitHubRepository_RequestPullRequestReview(t *testing.T) {
+	defer gock.Off()
+	gock.New("https://api.github.com").
+		Post("/repos/unit/test/pulls/987/requested_reviewers").
+		JSON(github.ReviewersRequest{Reviewers: []string{"ellie"}, TeamReviewers: []string{"team"}}).
+		Reply(200)
+	pr := &github.PullRequest{Number: github.Ptr(987)}
+
+	repo := &GitHubRepository{
+		client: setupGitHubTestClient(),
+		repo:   setupGitHubRepository(),
+	}
+	err := repo.RequestPullRequestReview([]string{"ellie", "unit/team"}, toSbPr(pr))
+
+	require.NoError(t, err)
+	assert.True(t, gock.IsDone())
+}
+
+func TestGitHubRepository_EnableAutoMerge_Error(t *testing.T) {
+	defer gock.Off()
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		Reply(200).
+		JSON(map[string]any{"errors": []map[string]any{{"message": "Pull request is in clean status"}}})
+	pr := &github.PullRequest{
+		NodeID: github.Ptr("PR_kwDOA"),
+		Number: github.Ptr(987),
+	}
+
+	repo := &GitHubRepository{
+		client: setupGitHubTestClient(),
+		repo:   setupGitHubRepository(),
+	}
+	sbPr := toSbPr(pr)
+	err := repo.EnableAutoMerge(MergeOptions{}, sbPr)
+
+	require.EqualError(t, err, "enable auto-merge of github pull request 987: Pull request is in clean status")
+	assert.False(t, sbPr.AutoMergeEnabled)
+	assert.True(t, gock.IsDone())
+}
+
+func Test_githubGraphqlUrl(t *testing.T) {
+	githubCom, _ := url.Parse("https://api.github.com/")
+	assert.Equal(t, "https://api.github.com/graphql", githubGraphqlUrl(githubCom))
+	enterprise, _ := url.Parse("https://github.example.com/api/v3/")
+	assert.Equal(t, "https://github.example.com/api/graphql", githubGraphqlUrl(enterprise))
+}
+
 func TestGitHubRepository_UpdatePullRequest_Update(t *testing.T) {
 	body := `new body
 
@@ -787,6 +1132,82 @@ _This pull request has been created by [saturn-bot](https://github.com/wndhydrnt
 	assert.True(t, gock.IsDone())
 }
 
+func TestGitHubRepository_UpdatePullRequest_Milestone(t *testing.T) {
+	defer gock.Off()
+	gock.New("https://api.github.com").
+		Get("/repos/unit/test/milestones").
+		Reply(200).
+		JSON([]*github.Milestone{{Number: github.Ptr(4), Title: github.Ptr("v1.2")}})
+	gock.New("https://api.github.com").
+		Patch("/repos/unit/test/issues/987").
+		MatchType("json").
+		JSON(map[string]any{"milestone": 4}).
+		Reply(200).
+		JSON(map[string]any{})
+	pr := &github.PullRequest{
+		Body:      github.Ptr("old body\n\n---\n\n**Auto-merge:** Disabled. Merge this manually.\n\n**Ignore:** This PR will be recreated if closed.\n\n---\n\n- [ ] If you want to rebase this PR, check this box\n\n---\n\n_This pull request has been created by [saturn-bot](https://github.com/wndhydrnt/saturn-bot)_ 🪐🤖.\n"),
+		Milestone: &github.Milestone{Number: github.Ptr(3), Title: github.Ptr("v1.1")},
+		Number:    github.Ptr(987),
+		Title:     github.Ptr("old title"),
+	}
+	prData := PullRequestData{
+		Body:     "old body",
+		Github:   GithubPullRequestData{Milestone: "v1.2"},
+		TaskName: "Unit Test",
+		Title:    "old title",
+	}
+
+	repo := &GitHubRepository{
+		client: setupGitHubTestClient(),
+		repo:   setupGitHubRepository(),
+	}
+	err := repo.UpdatePullRequest(prData, toSbPr(pr))
+
+	require.NoError(t, err)
+	require.Equal(t, "v1.2", pr.GetMilestone().GetTitle())
+	assert.True(t, gock.IsDone())
+}
+
+func TestGitHubRepository_UpdatePullRequest_AlreadyInProject(t *testing.T) {
+	defer gock.Off()
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		MatchType("json").
+		JSON(map[string]any{
+			"query":     githubProjectQuery,
+			"variables": map[string]any{"owner": "unit", "number": 7, "contentId": "PR_kwDOA"},
+		}).
+		Reply(200).
+		JSON(map[string]any{"data": map[string]any{
+			"repositoryOwner": map[string]any{"projectV2": map[string]any{"id": "PVT_kwDOA"}},
+			"node": map[string]any{"projectItems": map[string]any{"nodes": []any{
+				map[string]any{"project": map[string]any{"id": "PVT_other"}},
+				map[string]any{"project": map[string]any{"id": "PVT_kwDOA"}},
+			}}},
+		}})
+	pr := &github.PullRequest{
+		Body:   github.Ptr("old body\n\n---\n\n**Auto-merge:** Disabled. Merge this manually.\n\n**Ignore:** This PR will be recreated if closed.\n\n---\n\n- [ ] If you want to rebase this PR, check this box\n\n---\n\n_This pull request has been created by [saturn-bot](https://github.com/wndhydrnt/saturn-bot)_ 🪐🤖.\n"),
+		NodeID: github.Ptr("PR_kwDOA"),
+		Number: github.Ptr(987),
+		Title:  github.Ptr("old title"),
+	}
+	prData := PullRequestData{
+		Body:     "old body",
+		Github:   GithubPullRequestData{Projects: []GithubProject{{Number: 7, Owner: "unit"}}},
+		TaskName: "Unit Test",
+		Title:    "old title",
+	}
+
+	repo := &GitHubRepository{
+		client: setupGitHubTestClient(),
+		repo:   setupGitHubRepository(),
+	}
+	err := repo.UpdatePullRequest(prData, toSbPr(pr))
+
+	require.NoError(t, err, "Doesn't add the pull request to the project again")
+	assert.True(t, gock.IsDone())
+}
+
 func TestGitHubRepository_UpdatePullRequest_UpdatedAssignees(t *testing.T) {
 	body := `PR body
 
@@ -963,6 +1384,38 @@ func TestGitHubHost_CreateFromName(t *testing.T) {
 	}
 }
 
+func TestGitHubHost_HasWriteAccess(t *testing.T) {
+	testCases := []struct {
+		name       string
+		statusCode int
+		permission string
+		want       bool
+	}{
+		{name: "Admin", statusCode: 200, permission: "admin", want: true},
+		{name: "Write", statusCode: 200, permission: "write", want: true},
+		{name: "Read", statusCode: 200, permission: "read", want: false},
+		{name: "None", statusCode: 200, permission: "none", want: false},
+		{name: "Not found", statusCode: 404, want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer gock.Off()
+			gock.New("https://api.github.com").
+				Get("/repos/unit/test/collaborators/ellie/permission").
+				Reply(tc.statusCode).
+				JSON(&github.RepositoryPermissionLevel{Permission: github.Ptr(tc.permission)})
+
+			gh := &GitHubHost{client: setupGitHubTestClient()}
+			result, err := gh.HasWriteAccess("unit", "test", "ellie")
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, result)
+			assert.True(t, gock.IsDone())
+		})
+	}
+}
+
 func TestGitHubHost_PullRequestIterator_FullUpdate(t *testing.T) {
 	defer gock.Off()
 	gock.New("https://api.github.com").
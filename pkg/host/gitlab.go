@@ -2,10 +2,12 @@ package host
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"iter"
 	"net/url"
 	"path"
+	"regexp"
 	"slices"
 	"strings"
 	"sync"
@@ -19,6 +21,11 @@ import (
 	"go.uber.org/zap"
 )
 
+const gitlabDraftPrefix = "Draft: "
+
+// gitlabDraftRegex matches the prefixes of a title that mark a merge request as draft.
+var gitlabDraftRegex = regexp.MustCompile(`(?i)^\s*(\[draft\]|\(draft\)|draft:)\s*`)
+
 // userCache caches GitLab users.
 // The cache prevents frequent requests for users, for example when finding assignees, from triggering rate limits.
 type userCache struct {
@@ -48,6 +55,12 @@ func (u *userCache) get(name string) (*gitlab.User, error) {
 	return users[0], nil
 }
 
+// GitLabMemberChecker defines methods to check the permissions of members of GitLab projects.
+type GitLabMemberChecker interface {
+	// HasDeveloperAccess returns true if the user with userID has at least the role Developer in the project with projectID.
+	HasDeveloperAccess(projectID, userID int) (bool, error)
+}
+
 // GitLabSearcher defines methods to search GitLab.
 type GitLabSearcher interface {
 	// SearchCode returns a list of GitLab project IDs that match the search query.
@@ -56,6 +69,28 @@ type GitLabSearcher interface {
 	SearchCode(gitlabGroupID any, query string) ([]int64, error)
 }
 
+// GitlabApprovalRule is an approval rule of a merge request.
+type GitlabApprovalRule struct {
+	ApprovalsRequired int
+	// Groups contains the full paths of groups whose members can approve.
+	Groups []string
+	Name   string
+	// Usernames contains the users who can approve.
+	Usernames []string
+}
+
+// GitlabPullRequestData contains settings of a merge request that only GitLab supports.
+type GitlabPullRequestData struct {
+	// ApprovalRules to add to the merge request.
+	ApprovalRules []GitlabApprovalRule
+	// Milestone is the title of the milestone to assign the merge request to.
+	Milestone string
+	// RemoveSourceBranch overrides the setting of the project if not nil.
+	RemoveSourceBranch *bool
+	// Squash overrides the setting of the project if not nil.
+	Squash *bool
+}
+
 type GitLabRepository struct {
 	client    *gitlab.Client
 	fullName  string
@@ -147,6 +182,9 @@ func (g *GitLabRepository) CreatePullRequest(branch string, data PullRequestData
 		TargetBranch:       gitlab.Ptr(g.project.DefaultBranch),
 		RemoveSourceBranch: gitlab.Ptr(g.project.RemoveSourceBranchAfterMerge),
 	}
+	if data.TargetBranch != "" {
+		opts.TargetBranch = gitlab.Ptr(data.TargetBranch)
+	}
 
 	description, err := data.GetBody()
 	if err != nil {
@@ -154,6 +192,11 @@ func (g *GitLabRepository) CreatePullRequest(branch string, data PullRequestData
 	}
 	opts.Description = gitlab.Ptr(description)
 	opts.Title = gitlab.Ptr(data.Title)
+	if data.Draft {
+		// GitLab doesn't support a parameter to create a draft.
+		// It marks a merge request as draft if its title starts with "Draft:".
+		opts.Title = gitlab.Ptr(gitlabDraftPrefix + data.Title)
+	}
 
 	if len(data.Assignees) > 0 {
 		var assigneeIDs []int
@@ -173,7 +216,7 @@ func (g *GitLabRepository) CreatePullRequest(branch string, data PullRequestData
 
 	if len(data.Reviewers) > 0 {
 		var reviewerIDs []int
-		for _, reviewer := range data.Reviewers {
+		for _, reviewer := range g.expandGroups(data.Reviewers) {
 			user, err := g.userCache.get(reviewer)
 			if err != nil {
 				log.Log().Warnf("Cannot find reviewer %s in GitLab to add to new merge request", reviewer)
@@ -195,11 +238,33 @@ func (g *GitLabRepository) CreatePullRequest(branch string, data PullRequestData
 		opts.Squash = gitlab.Ptr(true)
 	}
 
+	if data.Gitlab.Squash != nil {
+		opts.Squash = data.Gitlab.Squash
+	}
+
+	if data.Gitlab.RemoveSourceBranch != nil {
+		opts.RemoveSourceBranch = data.Gitlab.RemoveSourceBranch
+	}
+
+	if data.Gitlab.Milestone != "" {
+		milestone, err := g.findMilestone(data.Gitlab.Milestone)
+		if err != nil {
+			return nil, err
+		}
+
+		opts.MilestoneID = gitlab.Ptr(milestone.ID)
+	}
+
 	mr, _, err := g.client.MergeRequests.CreateMergeRequest(g.project.ID, opts)
 	if err != nil {
 		return nil, fmt.Errorf("create merge request for project %d: %w", g.project.ID, err)
 	}
 
+	err = g.syncApprovalRules(data.Gitlab.ApprovalRules, mr.IID)
+	if err != nil {
+		return nil, err
+	}
+
 	return convertGitlabMergeRequestToPullRequest(&mr.BasicMergeRequest), nil
 }
 
@@ -350,14 +415,14 @@ func (g *GitLabRepository) ListPullRequestComments(pr *PullRequest) ([]PullReque
 	return result, nil
 }
 
-func (g *GitLabRepository) MergePullRequest(deleteBranch bool, pr *PullRequest) error {
+func (g *GitLabRepository) MergePullRequest(opts MergeOptions, pr *PullRequest) error {
 	mr := pr.Raw.(*gitlab.BasicMergeRequest)
 	_, _, err := g.client.MergeRequests.AcceptMergeRequest(
 		g.project.ID,
 		mr.IID,
 		&gitlab.AcceptMergeRequestOptions{
-			ShouldRemoveSourceBranch: gitlab.Ptr(deleteBranch),
-			Squash:                   gitlab.Ptr(mr.Squash),
+			ShouldRemoveSourceBranch: gitlab.Ptr(opts.DeleteBranch),
+			Squash:                   gitlab.Ptr(squashGitLab(opts.Method, mr)),
 		},
 	)
 	if err != nil {
@@ -367,6 +432,65 @@ func (g *GitLabRepository) MergePullRequest(deleteBranch bool, pr *PullRequest)
 	return nil
 }
 
+// EnableAutoMerge implements [Repository].
+// It sets the merge request to "merge when pipeline succeeds".
+func (g *GitLabRepository) EnableAutoMerge(opts MergeOptions, pr *PullRequest) error {
+	mr := pr.Raw.(*gitlab.BasicMergeRequest)
+	_, _, err := g.client.MergeRequests.AcceptMergeRequest(
+		g.project.ID,
+		mr.IID,
+		&gitlab.AcceptMergeRequestOptions{
+			AutoMerge:                gitlab.Ptr(true),
+			ShouldRemoveSourceBranch: gitlab.Ptr(opts.DeleteBranch),
+			Squash:                   gitlab.Ptr(squashGitLab(opts.Method, mr)),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("enable auto-merge of merge request %d: %w", mr.IID, err)
+	}
+
+	mr.MergeWhenPipelineSucceeds = true
+	pr.AutoMergeEnabled = true
+	return nil
+}
+
+// squashGitLab returns true if GitLab should squash the commits of mr.
+// GitLab configures the merge method per project.
+// A merge request can only decide whether to squash its commits.
+func squashGitLab(method string, mr *gitlab.BasicMergeRequest) bool {
+	switch method {
+	case MergeMethodSquash:
+		return true
+	case MergeMethodMerge:
+		return false
+	case MergeMethodRebase:
+		log.Log().Warnw("GitLab cannot rebase a single merge request - merging with the merge method of the project", "mergeRequest", mr.WebURL)
+		return false
+	default:
+		return mr.Squash
+	}
+}
+
+// MarkPullRequestReady implements [Repository].
+// It removes the draft prefix from the title of the merge request.
+func (g *GitLabRepository) MarkPullRequestReady(pr *PullRequest) error {
+	mr := pr.Raw.(*gitlab.BasicMergeRequest)
+	title := gitlabDraftRegex.ReplaceAllString(mr.Title, "")
+	updated, _, err := g.client.MergeRequests.UpdateMergeRequest(
+		g.project.ID,
+		mr.IID,
+		&gitlab.UpdateMergeRequestOptions{Title: gitlab.Ptr(title)},
+	)
+	if err != nil {
+		return fmt.Errorf("mark merge request %d as ready: %w", mr.IID, err)
+	}
+
+	mr.Draft = updated.Draft
+	mr.Title = updated.Title
+	pr.Draft = updated.Draft
+	return nil
+}
+
 func (g *GitLabRepository) Name() string {
 	return g.project.Name
 }
@@ -384,12 +508,56 @@ func (g *GitLabRepository) PullRequest(pr any) *PullRequest {
 	return convertGitlabMergeRequestToPullRequest(mr)
 }
 
+// RequestPullRequestReview implements [Repository].
+// The REST API of GitLab doesn't support requesting a review again.
+// The function removes all reviewers and adds them again, which notifies them.
+func (g *GitLabRepository) RequestPullRequestReview(reviewers []string, pr *PullRequest) error {
+	if len(reviewers) == 0 {
+		return nil
+	}
+
+	mr := pr.Raw.(*gitlab.BasicMergeRequest)
+	var reviewerIDs []int
+	for _, reviewer := range g.expandGroups(reviewers) {
+		user, err := g.userCache.get(reviewer)
+		if err != nil {
+			log.Log().Warnw("Failed to find reviewer in GitLab to request a review from", "reviewer", reviewer, zap.Error(err))
+			continue
+		}
+
+		reviewerIDs = append(reviewerIDs, user.ID)
+	}
+
+	if len(reviewerIDs) == 0 {
+		return nil
+	}
+
+	for _, ids := range [][]int{{}, reviewerIDs} {
+		_, _, err := g.client.MergeRequests.UpdateMergeRequest(
+			g.project.ID,
+			mr.IID,
+			&gitlab.UpdateMergeRequestOptions{ReviewerIDs: gitlab.Ptr(ids)},
+		)
+		if err != nil {
+			return fmt.Errorf("request review of merge request %d again: %w", mr.IID, err)
+		}
+	}
+
+	return nil
+}
+
 func (g *GitLabRepository) UpdatePullRequest(data PullRequestData, pr *PullRequest) error {
 	needsUpdate := false
 	opts := &gitlab.UpdateMergeRequestOptions{}
 	mr := pr.Raw.(*gitlab.BasicMergeRequest)
-	if mr.Title != data.Title {
-		opts.Title = gitlab.Ptr(data.Title)
+	title := data.Title
+	if mr.Draft {
+		// Keep the draft status.
+		title = gitlabDraftPrefix + data.Title
+	}
+
+	if mr.Title != title {
+		opts.Title = gitlab.Ptr(title)
 		needsUpdate = true
 	}
 
@@ -412,13 +580,33 @@ func (g *GitLabRepository) UpdatePullRequest(data PullRequestData, pr *PullReque
 	}
 
 	if len(data.Reviewers) > 0 {
-		reviewerIDs, hasChanges := g.diffUsers(mr.Reviewers, data.Reviewers)
+		reviewerIDs, hasChanges := g.diffUsers(mr.Reviewers, g.expandGroups(data.Reviewers))
 		if hasChanges {
 			opts.ReviewerIDs = gitlab.Ptr(reviewerIDs)
 			needsUpdate = true
 		}
 	}
 
+	if data.Gitlab.Squash != nil && mr.Squash != *data.Gitlab.Squash {
+		opts.Squash = data.Gitlab.Squash
+		needsUpdate = true
+	}
+
+	if data.Gitlab.RemoveSourceBranch != nil && mr.ForceRemoveSourceBranch != *data.Gitlab.RemoveSourceBranch {
+		opts.RemoveSourceBranch = data.Gitlab.RemoveSourceBranch
+		needsUpdate = true
+	}
+
+	if data.Gitlab.Milestone != "" && (mr.Milestone == nil || mr.Milestone.Title != data.Gitlab.Milestone) {
+		milestone, err := g.findMilestone(data.Gitlab.Milestone)
+		if err != nil {
+			return err
+		}
+
+		opts.MilestoneID = gitlab.Ptr(milestone.ID)
+		needsUpdate = true
+	}
+
 	if needsUpdate {
 		_, _, err = g.client.MergeRequests.UpdateMergeRequest(
 			g.project.ID,
@@ -430,9 +618,130 @@ func (g *GitLabRepository) UpdatePullRequest(data PullRequestData, pr *PullReque
 		}
 	}
 
+	return g.syncApprovalRules(data.Gitlab.ApprovalRules, mr.IID)
+}
+
+// findMilestone returns the active milestone with the given title.
+// The milestone can belong to the project or one of its groups.
+func (g *GitLabRepository) findMilestone(title string) (*gitlab.Milestone, error) {
+	milestones, _, err := g.client.Milestones.ListMilestones(g.project.ID, &gitlab.ListMilestonesOptions{
+		IncludeAncestors: gitlab.Ptr(true),
+		State:            gitlab.Ptr("active"),
+		Title:            gitlab.Ptr(title),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list milestones of gitlab project %d: %w", g.project.ID, err)
+	}
+
+	if len(milestones) == 0 {
+		return nil, fmt.Errorf("gitlab project %d has no active milestone %s", g.project.ID, title)
+	}
+
+	return milestones[0], nil
+}
+
+// syncApprovalRules creates each rule in rules that the merge request doesn't have
+// and updates each rule that differs from its definition in rules.
+// It identifies a rule by its name and leaves rules of the merge request that aren't in rules untouched.
+func (g *GitLabRepository) syncApprovalRules(rules []GitlabApprovalRule, mrIID int) error {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	existingRules, _, err := g.client.MergeRequestApprovals.GetApprovalRules(g.project.ID, mrIID)
+	if err != nil {
+		return fmt.Errorf("get approval rules of gitlab merge request %d: %w", mrIID, err)
+	}
+
+	for _, rule := range rules {
+		userIDs, groupIDs, err := g.resolveApprovers(rule)
+		if err != nil {
+			return err
+		}
+
+		idx := slices.IndexFunc(existingRules, func(r *gitlab.MergeRequestApprovalRule) bool { return r.Name == rule.Name })
+		if idx == -1 {
+			_, _, err := g.client.MergeRequestApprovals.CreateApprovalRule(g.project.ID, mrIID, &gitlab.CreateMergeRequestApprovalRuleOptions{
+				ApprovalsRequired: gitlab.Ptr(rule.ApprovalsRequired),
+				GroupIDs:          gitlab.Ptr(groupIDs),
+				Name:              gitlab.Ptr(rule.Name),
+				UserIDs:           gitlab.Ptr(userIDs),
+			})
+			if err != nil {
+				return fmt.Errorf("create approval rule %s of gitlab merge request %d: %w", rule.Name, mrIID, err)
+			}
+
+			continue
+		}
+
+		existing := existingRules[idx]
+		if !approvalRuleChanged(existing, rule.ApprovalsRequired, userIDs, groupIDs) {
+			continue
+		}
+
+		_, _, err = g.client.MergeRequestApprovals.UpdateApprovalRule(g.project.ID, mrIID, existing.ID, &gitlab.UpdateMergeRequestApprovalRuleOptions{
+			ApprovalsRequired: gitlab.Ptr(rule.ApprovalsRequired),
+			GroupIDs:          gitlab.Ptr(groupIDs),
+			UserIDs:           gitlab.Ptr(userIDs),
+		})
+		if err != nil {
+			return fmt.Errorf("update approval rule %s of gitlab merge request %d: %w", rule.Name, mrIID, err)
+		}
+	}
+
 	return nil
 }
 
+// resolveApprovers returns the IDs of the users and groups of rule.
+func (g *GitLabRepository) resolveApprovers(rule GitlabApprovalRule) ([]int, []int, error) {
+	userIDs := []int{}
+	for _, username := range rule.Usernames {
+		user, err := g.userCache.get(username)
+		if err != nil {
+			return nil, nil, fmt.Errorf("find user %s of approval rule %s: %w", username, rule.Name, err)
+		}
+
+		userIDs = append(userIDs, user.ID)
+	}
+
+	groupIDs := []int{}
+	for _, path := range rule.Groups {
+		group, _, err := g.client.Groups.GetGroup(path, &gitlab.GetGroupOptions{WithProjects: gitlab.Ptr(false)})
+		if err != nil {
+			return nil, nil, fmt.Errorf("find group %s of approval rule %s: %w", path, rule.Name, err)
+		}
+
+		groupIDs = append(groupIDs, group.ID)
+	}
+
+	return userIDs, groupIDs, nil
+}
+
+func approvalRuleChanged(rule *gitlab.MergeRequestApprovalRule, approvalsRequired int, userIDs, groupIDs []int) bool {
+	if rule.ApprovalsRequired != approvalsRequired {
+		return true
+	}
+
+	var currentUserIDs []int
+	for _, user := range rule.Users {
+		currentUserIDs = append(currentUserIDs, user.ID)
+	}
+
+	var currentGroupIDs []int
+	for _, group := range rule.Groups {
+		currentGroupIDs = append(currentGroupIDs, group.ID)
+	}
+
+	return !sameIDs(currentUserIDs, userIDs) || !sameIDs(currentGroupIDs, groupIDs)
+}
+
+// sameIDs returns true if a and b contain the same IDs, regardless of their order.
+func sameIDs(a, b []int) bool {
+	a = slices.Sorted(slices.Values(a))
+	b = slices.Sorted(slices.Values(b))
+	return slices.Equal(a, b)
+}
+
 // IsArchived implements [Repository].
 func (g *GitLabRepository) IsArchived() bool {
 	return g.project.Archived
@@ -466,6 +775,70 @@ func (g *GitLabRepository) diffUsers(assigned []*gitlab.BasicUser, in []string)
 	return ids, needsUpdate
 }
 
+// maxGroupReviewers is the maximum number of members of a group that become reviewers of a merge request.
+const maxGroupReviewers = 10
+
+// expandGroups replaces each group in names with the usernames of the members of the group.
+// GitLab doesn't support groups as reviewers of a merge request.
+// It adds at most maxGroupReviewers active members of each group.
+func (g *GitLabRepository) expandGroups(names []string) []string {
+	var usernames []string
+	for _, name := range names {
+		if !isTeam(name) {
+			if !slices.Contains(usernames, name) {
+				usernames = append(usernames, name)
+			}
+
+			continue
+		}
+
+		members, err := g.listActiveGroupMembers(name, maxGroupReviewers)
+		if err != nil {
+			log.Log().Warnw("Failed to list members of group in GitLab", "group", name, zap.Error(err))
+		}
+
+		for _, member := range members {
+			if !slices.Contains(usernames, member) {
+				usernames = append(usernames, member)
+			}
+		}
+	}
+
+	return usernames
+}
+
+// listActiveGroupMembers returns the usernames of up to limit active members of group.
+// It pages through the members until it has found enough active members.
+func (g *GitLabRepository) listActiveGroupMembers(group string, limit int) ([]string, error) {
+	var usernames []string
+	opts := &gitlab.ListGroupMembersOptions{
+		ListOptions: gitlab.ListOptions{PerPage: 100},
+	}
+	for {
+		members, resp, err := g.client.Groups.ListGroupMembers(group, opts)
+		if err != nil {
+			return usernames, err
+		}
+
+		for _, member := range members {
+			if member.State != "active" {
+				continue
+			}
+
+			usernames = append(usernames, member.Username)
+			if len(usernames) == limit {
+				return usernames, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			return usernames, nil
+		}
+
+		opts.Page = resp.NextPage
+	}
+}
+
 // Raw implements [Repository].
 func (g *GitLabRepository) Raw() any {
 	return g.project
@@ -575,6 +948,21 @@ func (g *GitLabHost) Name() string {
 	return g.client.BaseURL().Host
 }
 
+// HasDeveloperAccess implements [GitLabMemberChecker].
+// It considers memberships inherited from groups.
+func (g *GitLabHost) HasDeveloperAccess(projectID, userID int) (bool, error) {
+	member, _, err := g.client.ProjectMembers.GetInheritedProjectMember(projectID, userID)
+	if err != nil {
+		if errors.Is(err, gitlab.ErrNotFound) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("get member %d of project %d: %w", userID, projectID, err)
+	}
+
+	return member.AccessLevel >= gitlab.DeveloperPermissions, nil
+}
+
 // SearchCode implements [GitLabSearcher].
 // It returns a list of unique IDs of all projects returned by the search query.
 // The IDs are sorted in ascending order.
@@ -756,15 +1144,18 @@ func convertGitlabMergeRequestToPullRequest(mr *gitlab.BasicMergeRequest) *PullR
 	}
 
 	return &PullRequest{
-		CreatedAt:      createdAt,
-		Number:         int64(mr.IID),
-		WebURL:         mr.WebURL,
-		State:          mapToPullRequestStateGitLab(mr),
-		Raw:            mr,
-		HostName:       u.Host,
-		BranchName:     mr.SourceBranch,
-		RepositoryName: u.Host + "" + parts[0],
-		Type:           GitLabType,
+		CreatedAt:        createdAt,
+		Number:           int64(mr.IID),
+		WebURL:           mr.WebURL,
+		State:            mapToPullRequestStateGitLab(mr),
+		Raw:              mr,
+		HostName:         u.Host,
+		BranchName:       mr.SourceBranch,
+		TargetBranch:     mr.TargetBranch,
+		RepositoryName:   u.Host + "" + parts[0],
+		Type:             GitLabType,
+		AutoMergeEnabled: mr.MergeWhenPipelineSucceeds,
+		Draft:            mr.Draft,
 	}
 }
 
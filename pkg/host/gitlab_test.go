@@ -2,6 +2,7 @@ package host
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"slices"
 	"testing"
@@ -154,6 +155,36 @@ func TestGitLabRepository_CreatePullRequest(t *testing.T) {
 	require.True(t, gock.IsDone())
 }
 
+func TestGitLabRepository_CreatePullRequest_TargetBranch(t *testing.T) {
+	defer gock.Off()
+	gock.New("http://gitlab.local").
+		Post("/api/v4/projects/123/merge_requests").
+		MatchType("json").
+		JSON(gitlab.CreateMergeRequestOptions{
+			Title:              gitlab.Ptr("Unit Test Title"),
+			Description:        gitlab.Ptr("Unit Test Body\n\n---\n\n**Auto-merge:** Disabled. Merge this manually.\n\n**Ignore:** This PR will be recreated if closed.\n\n---\n\n- [ ] If you want to rebase this PR, check this box\n\n---\n\n_This pull request has been created by [saturn-bot](https://github.com/wndhydrnt/saturn-bot)_ 🪐🤖.\n"),
+			SourceBranch:       gitlab.Ptr("saturn-bot--unit-test--release-1-0"),
+			TargetBranch:       gitlab.Ptr("release/1.0"),
+			RemoveSourceBranch: gitlab.Ptr(false),
+		}).
+		Reply(200).
+		JSON(gitlab.BasicMergeRequest{
+			CreatedAt:    ptr.To(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)),
+			IID:          1,
+			SourceBranch: "saturn-bot--unit-test--release-1-0",
+			State:        "opened",
+			WebURL:       "http://gitlab.local/unit/test/-/merge_requests/1",
+		})
+	project := &gitlab.Project{DefaultBranch: "main", ID: 123}
+	prData := PullRequestData{Body: "Unit Test Body", TargetBranch: "release/1.0", Title: "Unit Test Title"}
+
+	underTest := &GitLabRepository{client: setupClient(), project: project}
+	_, err := underTest.CreatePullRequest("saturn-bot--unit-test--release-1-0", prData)
+
+	require.NoError(t, err)
+	require.True(t, gock.IsDone())
+}
+
 func TestGitLabRepository_CreatePullRequest_WithAssigneesReviewers(t *testing.T) {
 	defer gock.Off()
 	gock.New("http://gitlab.local").
@@ -211,6 +242,165 @@ func TestGitLabRepository_CreatePullRequest_WithAssigneesReviewers(t *testing.T)
 	require.True(t, gock.IsDone())
 }
 
+func TestGitLabRepository_CreatePullRequest_WithGitlabData(t *testing.T) {
+	defer gock.Off()
+	gock.New("http://gitlab.local").
+		Get("/api/v4/projects/123/milestones").
+		MatchParam("include_ancestors", "true").
+		MatchParam("state", "active").
+		MatchParam("title", "v1.2").
+		Reply(200).
+		JSON([]*gitlab.Milestone{{ID: 42, Title: "v1.2"}})
+	gock.New("http://gitlab.local").
+		Post("/api/v4/projects/123/merge_requests").
+		MatchType("json").
+		JSON(gitlab.CreateMergeRequestOptions{
+			Title:              gitlab.Ptr("Unit Test Title"),
+			Description:        gitlab.Ptr("Unit Test Body\n\n---\n\n**Auto-merge:** Disabled. Merge this manually.\n\n**Ignore:** This PR will be recreated if closed.\n\n---\n\n- [ ] If you want to rebase this PR, check this box\n\n---\n\n_This pull request has been created by [saturn-bot](https://github.com/wndhydrnt/saturn-bot)_ 🪐🤖.\n"),
+			SourceBranch:       gitlab.Ptr("saturn-bot--unit-test"),
+			TargetBranch:       gitlab.Ptr("main"),
+			MilestoneID:        gitlab.Ptr(42),
+			RemoveSourceBranch: gitlab.Ptr(true),
+			Squash:             gitlab.Ptr(false),
+		}).
+		Reply(200).
+		JSON(gitlab.BasicMergeRequest{IID: 1, State: "opened"})
+	gock.New("http://gitlab.local").
+		Get("/api/v4/projects/123/merge_requests/1/approval_rules").
+		Reply(200).
+		JSON([]*gitlab.MergeRequestApprovalRule{})
+	gock.New("http://gitlab.local").
+		Get("/api/v4/users").
+		MatchParam("username", "abby").
+		Reply(200).
+		JSON([]*gitlab.User{{ID: 975}})
+	gock.New("http://gitlab.local").
+		Get("/api/v4/groups/org/security").
+		Reply(200).
+		JSON(gitlab.Group{ID: 33})
+	gock.New("http://gitlab.local").
+		Post("/api/v4/projects/123/merge_requests/1/approval_rules").
+		MatchType("json").
+		JSON(gitlab.CreateMergeRequestApprovalRuleOptions{
+			ApprovalsRequired: gitlab.Ptr(1),
+			GroupIDs:          gitlab.Ptr([]int{33}),
+			Name:              gitlab.Ptr("security"),
+			UserIDs:           gitlab.Ptr([]int{975}),
+		}).
+		Reply(201).
+		JSON(gitlab.MergeRequestApprovalRule{ID: 5})
+	project := &gitlab.Project{DefaultBranch: "main", ID: 123, SquashOption: gitlab.SquashOptionDefaultOn}
+	prData := PullRequestData{
+		Body: "Unit Test Body",
+		Gitlab: GitlabPullRequestData{
+			ApprovalRules: []GitlabApprovalRule{
+				{ApprovalsRequired: 1, Groups: []string{"org/security"}, Name: "security", Usernames: []string{"abby"}},
+			},
+			Milestone:          "v1.2",
+			RemoveSourceBranch: ptr.To(true),
+			Squash:             ptr.To(false),
+		},
+		Title: "Unit Test Title",
+	}
+
+	client := setupClient()
+	uc := &userCache{
+		client: client,
+		data:   map[string]*gitlab.User{},
+	}
+	underTest := &GitLabRepository{client: client, project: project, userCache: uc}
+	_, err := underTest.CreatePullRequest("saturn-bot--unit-test", prData)
+
+	require.NoError(t, err)
+	require.True(t, gock.IsDone())
+}
+
+func TestGitLabRepository_CreatePullRequest_WithGroupReviewers(t *testing.T) {
+	defer gock.Off()
+	gock.New("http://gitlab.local").
+		Get("/api/v4/groups/org/backend/members").
+		Reply(200).
+		JSON([]*gitlab.GroupMember{
+			{ID: 357, State: "active", Username: "joel"},
+			{ID: 246, State: "blocked", Username: "tommy"},
+		})
+	gock.New("http://gitlab.local").
+		Get("/api/v4/users").
+		MatchParam("username", "ellie").
+		Reply(200).
+		JSON([]*gitlab.User{
+			{ID: 642},
+		})
+	gock.New("http://gitlab.local").
+		Get("/api/v4/users").
+		MatchParam("username", "joel").
+		Reply(200).
+		JSON([]*gitlab.User{
+			{ID: 357},
+		})
+	gock.New("http://gitlab.local").
+		Post("/api/v4/projects/123/merge_requests").
+		MatchType("json").
+		JSON(gitlab.CreateMergeRequestOptions{
+			Title:              gitlab.Ptr("Unit Test Title"),
+			Description:        gitlab.Ptr("Unit Test Body\n\n---\n\n**Auto-merge:** Disabled. Merge this manually.\n\n**Ignore:** This PR will be recreated if closed.\n\n---\n\n- [ ] If you want to rebase this PR, check this box\n\n---\n\n_This pull request has been created by [saturn-bot](https://github.com/wndhydrnt/saturn-bot)_ 🪐🤖.\n"),
+			SourceBranch:       gitlab.Ptr("saturn-bot--unit-test"),
+			TargetBranch:       gitlab.Ptr("main"),
+			RemoveSourceBranch: gitlab.Ptr(false),
+			ReviewerIDs:        gitlab.Ptr([]int{642, 357}),
+		}).
+		Reply(200).
+		JSON(map[string]string{})
+	project := &gitlab.Project{DefaultBranch: "main", ID: 123}
+	prData := PullRequestData{
+		Body:      "Unit Test Body",
+		Reviewers: []string{"ellie", "org/backend"},
+		Title:     "Unit Test Title",
+	}
+
+	client := setupClient()
+	uc := &userCache{
+		client: client,
+		data:   map[string]*gitlab.User{},
+	}
+	underTest := &GitLabRepository{client: client, project: project, userCache: uc}
+	_, err := underTest.CreatePullRequest("saturn-bot--unit-test", prData)
+
+	require.NoError(t, err)
+	require.True(t, gock.IsDone())
+}
+
+func TestGitLabRepository_expandGroups(t *testing.T) {
+	defer gock.Off()
+	var firstPage []*gitlab.GroupMember
+	for i := range 8 {
+		firstPage = append(firstPage, &gitlab.GroupMember{State: "active", Username: fmt.Sprintf("user%d", i)})
+	}
+	firstPage = append(firstPage, &gitlab.GroupMember{State: "blocked", Username: "blocked"})
+	gock.New("http://gitlab.local").
+		Get("/api/v4/groups/org/backend/members").
+		MatchParam("per_page", "100").
+		Reply(200).
+		SetHeader("X-Next-Page", "2").
+		JSON(firstPage)
+	gock.New("http://gitlab.local").
+		Get("/api/v4/groups/org/backend/members").
+		MatchParams(map[string]string{"page": "2", "per_page": "100"}).
+		Reply(200).
+		SetHeader("X-Next-Page", "3").
+		JSON([]*gitlab.GroupMember{
+			{State: "active", Username: "user8"},
+			{State: "active", Username: "user9"},
+			{State: "active", Username: "user10"},
+		})
+
+	underTest := &GitLabRepository{client: setupClient()}
+	result := underTest.expandGroups([]string{"ellie", "org/backend"})
+
+	assert.Equal(t, []string{"ellie", "user0", "user1", "user2", "user3", "user4", "user5", "user6", "user7", "user8", "user9"}, result)
+	assert.True(t, gock.IsDone())
+}
+
 func TestGitLabRepository_CreatePullRequest_WithLabels(t *testing.T) {
 	defer gock.Off()
 	gock.New("http://gitlab.local").
@@ -597,7 +787,102 @@ func TestGitLabRepository_MergePullRequest(t *testing.T) {
 	mr := &gitlab.BasicMergeRequest{IID: 987}
 
 	underTest := &GitLabRepository{client: setupClient(), project: project}
-	err := underTest.MergePullRequest(true, toSbPr(mr))
+	err := underTest.MergePullRequest(MergeOptions{DeleteBranch: true}, toSbPr(mr))
+
+	require.NoError(t, err)
+	require.True(t, gock.IsDone())
+}
+
+func TestGitLabRepository_MergePullRequest_Squash(t *testing.T) {
+	defer gock.Off()
+	gock.New("http://gitlab.local").
+		Put("/api/v4/projects/123/merge_requests/987/merge").
+		MatchType("json").
+		JSON(map[string]interface{}{"should_remove_source_branch": false, "squash": true}).
+		Reply(200).
+		JSON(map[string]string{})
+	project := &gitlab.Project{ID: 123}
+	mr := &gitlab.BasicMergeRequest{IID: 987}
+
+	underTest := &GitLabRepository{client: setupClient(), project: project}
+	err := underTest.MergePullRequest(MergeOptions{Method: MergeMethodSquash}, toSbPr(mr))
+
+	require.NoError(t, err)
+	require.True(t, gock.IsDone())
+}
+
+func TestGitLabRepository_EnableAutoMerge(t *testing.T) {
+	defer gock.Off()
+	gock.New("http://gitlab.local").
+		Put("/api/v4/projects/123/merge_requests/987/merge").
+		MatchType("json").
+		JSON(map[string]interface{}{"auto_merge": true, "should_remove_source_branch": true, "squash": false}).
+		Reply(200).
+		JSON(map[string]string{})
+	project := &gitlab.Project{ID: 123}
+	mr := &gitlab.BasicMergeRequest{IID: 987}
+
+	underTest := &GitLabRepository{client: setupClient(), project: project}
+	pr := toSbPr(mr)
+	err := underTest.EnableAutoMerge(MergeOptions{DeleteBranch: true, Method: MergeMethodMerge}, pr)
+
+	require.NoError(t, err)
+	require.True(t, pr.AutoMergeEnabled)
+	require.True(t, gock.IsDone())
+}
+
+func TestGitLabRepository_MarkPullRequestReady(t *testing.T) {
+	defer gock.Off()
+	gock.New("http://gitlab.local").
+		Put("/api/v4/projects/123/merge_requests/987").
+		MatchType("json").
+		JSON(map[string]any{"title": "PR Title"}).
+		Reply(200).
+		JSON(map[string]any{"draft": false, "iid": 987, "title": "PR Title"})
+	project := &gitlab.Project{ID: 123}
+	mr := &gitlab.BasicMergeRequest{Draft: true, IID: 987, Title: "Draft: PR Title"}
+
+	underTest := &GitLabRepository{client: setupClient(), project: project}
+	pr := toSbPr(mr)
+	err := underTest.MarkPullRequestReady(pr)
+
+	require.NoError(t, err)
+	require.False(t, pr.Draft)
+	require.Equal(t, "PR Title", mr.Title)
+	require.True(t, gock.IsDone())
+}
+
+func TestGitLabRepository_RequestPullRequestReview(t *testing.T) {
+	defer gock.Off()
+	gock.New("http://gitlab.local").
+		Get("/api/v4/users").
+		MatchParam("username", "ellie").
+		Reply(200).
+		JSON([]*gitlab.User{
+			{ID: 24},
+		})
+	gock.New("http://gitlab.local").
+		Put("/api/v4/projects/123/merge_requests/987").
+		MatchType("json").
+		JSON(map[string]any{"reviewer_ids": []int{}}).
+		Reply(200).
+		JSON(map[string]string{})
+	gock.New("http://gitlab.local").
+		Put("/api/v4/projects/123/merge_requests/987").
+		MatchType("json").
+		JSON(map[string]any{"reviewer_ids": []int{24}}).
+		Reply(200).
+		JSON(map[string]string{})
+	project := &gitlab.Project{ID: 123}
+	mr := &gitlab.BasicMergeRequest{IID: 987}
+	client := setupClient()
+	uc := &userCache{
+		client: client,
+		data:   map[string]*gitlab.User{},
+	}
+
+	underTest := &GitLabRepository{client: client, project: project, userCache: uc}
+	err := underTest.RequestPullRequestReview([]string{"ellie"}, toSbPr(mr))
 
 	require.NoError(t, err)
 	require.True(t, gock.IsDone())
@@ -628,6 +913,72 @@ func TestGitLabRepository_UpdatePullRequest(t *testing.T) {
 	require.True(t, gock.IsDone())
 }
 
+func TestGitLabRepository_UpdatePullRequest_GitlabData(t *testing.T) {
+	defer gock.Off()
+	gock.New("http://gitlab.local").
+		Get("/api/v4/projects/123/milestones").
+		MatchParam("title", "v1.2").
+		Reply(200).
+		JSON([]*gitlab.Milestone{{ID: 42, Title: "v1.2"}})
+	gock.New("http://gitlab.local").
+		Put("/api/v4/projects/123/merge_requests/987").
+		MatchType("json").
+		JSON(map[string]any{
+			"milestone_id": 42,
+			"squash":       true,
+		}).
+		Reply(200).
+		JSON(map[string]string{})
+	gock.New("http://gitlab.local").
+		Get("/api/v4/projects/123/merge_requests/987/approval_rules").
+		Reply(200).
+		JSON([]*gitlab.MergeRequestApprovalRule{
+			{ID: 5, Name: "security", ApprovalsRequired: 1, Users: []*gitlab.BasicUser{{ID: 975}}},
+			{ID: 6, Name: "qa", ApprovalsRequired: 1, Users: []*gitlab.BasicUser{{ID: 975}}},
+		})
+	gock.New("http://gitlab.local").
+		Put("/api/v4/projects/123/merge_requests/987/approval_rules/5").
+		MatchType("json").
+		JSON(gitlab.UpdateMergeRequestApprovalRuleOptions{
+			ApprovalsRequired: gitlab.Ptr(2),
+			GroupIDs:          gitlab.Ptr([]int{}),
+			UserIDs:           gitlab.Ptr([]int{975}),
+		}).
+		Reply(200).
+		JSON(gitlab.MergeRequestApprovalRule{ID: 5})
+	prData := PullRequestData{
+		Body: "PR Body",
+		Gitlab: GitlabPullRequestData{
+			ApprovalRules: []GitlabApprovalRule{
+				{ApprovalsRequired: 2, Name: "security", Usernames: []string{"abby"}},
+			},
+			Milestone:          "v1.2",
+			RemoveSourceBranch: ptr.To(true),
+			Squash:             ptr.To(true),
+		},
+		Title: "PR Title",
+	}
+	project := &gitlab.Project{ID: 123}
+	mr := &gitlab.BasicMergeRequest{
+		Description:             "PR Body\n\n---\n\n**Auto-merge:** Disabled. Merge this manually.\n\n**Ignore:** This PR will be recreated if closed.\n\n---\n\n- [ ] If you want to rebase this PR, check this box\n\n---\n\n_This pull request has been created by [saturn-bot](https://github.com/wndhydrnt/saturn-bot)_ 🪐🤖.\n",
+		ForceRemoveSourceBranch: true,
+		IID:                     987,
+		Milestone:               &gitlab.Milestone{ID: 41, Title: "v1.1"},
+		Title:                   "PR Title",
+	}
+
+	client := setupClient()
+	uc := &userCache{
+		client: client,
+		data:   map[string]*gitlab.User{"abby": {ID: 975}},
+	}
+	underTest := &GitLabRepository{client: client, project: project, userCache: uc}
+	err := underTest.UpdatePullRequest(prData, toSbPr(mr))
+
+	require.NoError(t, err)
+	require.True(t, gock.IsDone())
+}
+
 func TestGitLabRepository_UpdatePullRequest_NoUpdateRequired(t *testing.T) {
 	defer gock.Off()
 	prData := PullRequestData{
@@ -648,6 +999,27 @@ func TestGitLabRepository_UpdatePullRequest_NoUpdateRequired(t *testing.T) {
 	require.True(t, gock.IsDone())
 }
 
+func TestGitLabRepository_UpdatePullRequest_KeepDraft(t *testing.T) {
+	defer gock.Off()
+	prData := PullRequestData{
+		Body:  "PR Body",
+		Title: "PR Title",
+	}
+	project := &gitlab.Project{ID: 123}
+	mr := &gitlab.BasicMergeRequest{
+		Description: "PR Body\n\n---\n\n**Auto-merge:** Disabled. Merge this manually.\n\n**Ignore:** This PR will be recreated if closed.\n\n---\n\n- [ ] If you want to rebase this PR, check this box\n\n---\n\n_This pull request has been created by [saturn-bot](https://github.com/wndhydrnt/saturn-bot)_ 🪐🤖.\n",
+		Draft:       true,
+		IID:         987,
+		Title:       "Draft: PR Title",
+	}
+
+	underTest := &GitLabRepository{client: setupClient(), project: project}
+	err := underTest.UpdatePullRequest(prData, toSbPr(mr))
+
+	require.NoError(t, err)
+	require.True(t, gock.IsDone())
+}
+
 func TestGitLabRepository_UpdatePullRequest_UpdatedAssigneesReviewers(t *testing.T) {
 	defer gock.Off()
 	gock.New("http://gitlab.local").
@@ -755,6 +1127,37 @@ func TestGitLabHost_CreateFromName(t *testing.T) {
 	}
 }
 
+func TestGitLabHost_HasDeveloperAccess(t *testing.T) {
+	testCases := []struct {
+		name       string
+		statusCode int
+		member     *gitlab.ProjectMember
+		want       bool
+	}{
+		{name: "Developer", statusCode: 200, member: &gitlab.ProjectMember{AccessLevel: gitlab.DeveloperPermissions}, want: true},
+		{name: "Maintainer", statusCode: 200, member: &gitlab.ProjectMember{AccessLevel: gitlab.MaintainerPermissions}, want: true},
+		{name: "Reporter", statusCode: 200, member: &gitlab.ProjectMember{AccessLevel: gitlab.ReporterPermissions}, want: false},
+		{name: "Not a member", statusCode: 404, want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer gock.Off()
+			gock.New("http://gitlab.local").
+				Get("/api/v4/projects/123/members/all/456").
+				Reply(tc.statusCode).
+				JSON(tc.member)
+
+			underTest := &GitLabHost{client: setupClient()}
+			result, err := underTest.HasDeveloperAccess(123, 456)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, result)
+			assert.True(t, gock.IsDone())
+		})
+	}
+}
+
 func TestGitLabHost_PullRequestIterator_FullUpdate(t *testing.T) {
 	defer gock.Off()
 	gock.New("http://gitlab.local").
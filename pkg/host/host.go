@@ -51,10 +51,31 @@ type PullRequest struct {
 	HostName string
 	// BranchName is the name of the source branch.
 	BranchName string
+	// TargetBranch is the name of the branch that the pull request targets.
+	TargetBranch string
 	// RepositoryName is the full name of the repository for which the pull request has been created.
 	RepositoryName string
 	// Type indicates the type of host this pull request belongs to.
 	Type Type
+	// AutoMergeEnabled is true if the host merges the pull request once all checks have passed.
+	AutoMergeEnabled bool
+	// Draft is true if the pull request is a draft and not ready for review.
+	Draft bool
+}
+
+const (
+	MergeMethodMerge  = "merge"
+	MergeMethodRebase = "rebase"
+	MergeMethodSquash = "squash"
+)
+
+// MergeOptions configures how a [Repository] merges a pull request.
+type MergeOptions struct {
+	// DeleteBranch deletes the source branch of the pull request after it has been merged.
+	DeleteBranch bool
+	// Method is one of MergeMethodMerge, MergeMethodRebase or MergeMethodSquash.
+	// The host decides if empty.
+	Method string
 }
 
 type PullRequestComment struct {
@@ -66,13 +87,28 @@ type PullRequestData struct {
 	Assignees      []string
 	AutoMerge      bool
 	AutoMergeAfter time.Duration
-	Body           string
-	Labels         []string
-	MergeOnce      bool
-	Reviewers      []string
-	TaskName       string
-	TemplateData   template.Data
-	Title          string
+	// AutoMergeNative is true if the host merges the pull request.
+	AutoMergeNative bool
+	Body            string
+	// Draft creates the pull request as a draft.
+	Draft bool
+	// Footer is a template that replaces the default footer of the body.
+	// Empty to use the default footer.
+	Footer string
+	// Github contains settings that only GitHub supports.
+	Github GithubPullRequestData
+	// Gitlab contains settings that only GitLab supports.
+	Gitlab    GitlabPullRequestData
+	Labels    []string
+	MergeOnce bool
+	// Reviewers contains usernames and teams, like "org/team".
+	Reviewers []string
+	// TargetBranch is the branch that the pull request targets.
+	// Hosts create the pull request for the default branch of the repository if empty.
+	TargetBranch string
+	TaskName     string
+	TemplateData template.Data
+	Title        string
 }
 
 func (prd PullRequestData) GetBody() (string, error) {
@@ -95,7 +131,9 @@ func (prd PullRequestData) GetBody() (string, error) {
 
 	var autoMergeText string
 	if prd.AutoMerge {
-		if prd.AutoMergeAfter == 0 {
+		if prd.AutoMergeNative {
+			autoMergeText = "Enabled. The git host merges this automatically once all checks have passed."
+		} else if prd.AutoMergeAfter == 0 {
 			autoMergeText = "Enabled. Saturn merges this automatically on its next run and if all checks have passed."
 		} else {
 			autoMergeText = fmt.Sprintf("Enabled. Saturn automatically merges this in %s and if all checks have passed.", prd.AutoMergeAfter.String())
@@ -114,6 +152,8 @@ func (prd PullRequestData) GetBody() (string, error) {
 	content, err := template.RenderPullRequestDescription(template.PullRequestDescriptionInput{
 		AutoMergeText: autoMergeText,
 		Body:          buf.String(),
+		Data:          prd.TemplateData,
+		Footer:        prd.Footer,
 		IgnoreText:    ignoreText,
 	})
 	if err != nil {
@@ -130,9 +170,15 @@ type Repository interface {
 	CloneUrlSsh() string
 	ClosePullRequest(msg string, pr *PullRequest) (*PullRequest, error)
 	CreatePullRequestComment(body string, pr *PullRequest) error
+	// CreatePullRequest creates a pull request for branch.
+	// It returns the pull request together with the error
+	// if it has created the pull request but a subsequent update of the pull request failed.
 	CreatePullRequest(branch string, data PullRequestData) (*PullRequest, error)
 	DeleteBranch(pr *PullRequest) error
 	DeletePullRequestComment(comment PullRequestComment, pr *PullRequest) error
+	// EnableAutoMerge enables the auto-merge feature of the host for pr.
+	// The host merges pr once all checks have passed.
+	EnableAutoMerge(opts MergeOptions, pr *PullRequest) error
 	FindPullRequest(branch string) (*PullRequest, error)
 	FullName() string
 	GetPullRequestBody(pr *PullRequest) string
@@ -143,9 +189,14 @@ type Repository interface {
 	// IsArchived returns true if the repository has been archived on the host.
 	IsArchived() bool
 	ListPullRequestComments(pr *PullRequest) ([]PullRequestComment, error)
-	MergePullRequest(deleteBranch bool, pr *PullRequest) error
+	// MarkPullRequestReady marks the draft pr as ready for review.
+	MarkPullRequestReady(pr *PullRequest) error
+	MergePullRequest(opts MergeOptions, pr *PullRequest) error
 	Name() string
 	Owner() string
+	// RequestPullRequestReview requests a review of pr from reviewers again,
+	// even if they have already submitted a review.
+	RequestPullRequestReview(reviewers []string, pr *PullRequest) error
 	UpdatePullRequest(data PullRequestData, pr *PullRequest) error
 	WebUrl() string
 	// Raw returns the underlying data structure of the Repository struct.
@@ -228,7 +279,7 @@ func CreatePullRequestCommentWithIdentifier(body string, identifier string, pr *
 		return err
 	}
 
-	prefix := fmt.Sprintf("<!-- saturn-bot::{%s} -->", identifier)
+	prefix := commentIdentifierPrefix(identifier)
 	for _, comment := range comments {
 		if strings.HasPrefix(comment.Body, prefix) {
 			return nil
@@ -249,7 +300,7 @@ func DeletePullRequestCommentByIdentifier(identifier string, pr *PullRequest, re
 		return err
 	}
 
-	prefix := fmt.Sprintf("<!-- saturn-bot::{%s} -->", identifier)
+	prefix := commentIdentifierPrefix(identifier)
 	for _, comment := range comments {
 		if strings.HasPrefix(comment.Body, prefix) {
 			log.Log().Debugf("Delete comment on pull request of repository %s with identifier %s", repo.FullName(), identifier)
@@ -265,6 +316,38 @@ func DeletePullRequestCommentByIdentifier(identifier string, pr *PullRequest, re
 	return nil
 }
 
+// HasPullRequestCommentWithIdentifier returns true if pr contains a comment
+// that [CreatePullRequestCommentWithIdentifier] has created with identifier.
+func HasPullRequestCommentWithIdentifier(identifier string, pr *PullRequest, repo Repository) (bool, error) {
+	if identifier == "" {
+		return false, errors.New("identifier is empty")
+	}
+
+	comments, err := repo.ListPullRequestComments(pr)
+	if err != nil {
+		return false, err
+	}
+
+	prefix := commentIdentifierPrefix(identifier)
+	for _, comment := range comments {
+		if strings.HasPrefix(comment.Body, prefix) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func commentIdentifierPrefix(identifier string) string {
+	return fmt.Sprintf("<!-- saturn-bot::{%s} -->", identifier)
+}
+
+// isTeam returns true if name identifies a team on GitHub or a group on GitLab, like "org/team".
+// Usernames can't contain a slash.
+func isTeam(name string) bool {
+	return strings.Contains(name, "/")
+}
+
 // NewRepositoryFromName create a new [Repository] by finding the [Host] that serves
 // the repository.
 //
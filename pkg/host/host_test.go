@@ -0,0 +1,40 @@
+package host
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wndhydrnt/saturn-bot/pkg/template"
+)
+
+func TestPullRequestData_GetBody_ChangesAndFooter(t *testing.T) {
+	prd := PullRequestData{
+		Body:     "{{range .Changes.Files}}- {{.Path}} (+{{.Additions}} -{{.Deletions}})\n{{end}}Actions: {{range .Changes.Actions}}{{.}} {{end}}",
+		Footer:   "Run [{{.Data.RunID}}]({{.Data.RunUrl}}) - {{.IgnoreText}}",
+		TaskName: "unittest",
+		TemplateData: template.Data{
+			Changes: template.DataChanges{
+				Actions: []string{"fileCreate"},
+				Files: []template.DataChangedFile{
+					{Additions: 3, Deletions: 1, Path: "main.go"},
+				},
+			},
+			RunID:  "7",
+			RunUrl: "http://saturn-bot.local/ui/runs/7",
+		},
+	}
+
+	body, err := prd.GetBody()
+
+	require.NoError(t, err)
+	want := "- main.go (&#43;3 -1)\nActions: fileCreate \n\nRun [7](http://saturn-bot.local/ui/runs/7) - This PR will be recreated if closed."
+	require.Equal(t, want, body)
+}
+
+func TestPullRequestData_GetBody_InvalidFooter(t *testing.T) {
+	prd := PullRequestData{Footer: "{{.Data", TaskName: "unittest"}
+
+	_, err := prd.GetBody()
+
+	require.ErrorContains(t, err, "parse pull request footer template")
+}
@@ -34,6 +34,8 @@ type PullRequestCache interface {
 	// Get reads the data from the cache, identified by branchName and repo.
 	// It returns nil if the cache doesn't contain data.
 	Get(branchName, repoName string) *PullRequest
+	// ListByRepository returns all pull requests in the cache that belong to the repository identified by repoName.
+	ListByRepository(repoName string) []*PullRequest
 	// Set writes pr to the cache, identified by branchName and repoName.
 	Set(branchName, repoName string, pr *PullRequest)
 	// LastUpdatedAtFor returns the last time at which the cache was updated for host.
@@ -83,8 +85,31 @@ func (c *pullRequestCache) Get(branchName, repoName string) *PullRequest {
 		return nil
 	}
 
+	return c.decode(data)
+}
+
+// ListByRepository implements [PullRequestCache].
+func (c *pullRequestCache) ListByRepository(repoName string) []*PullRequest {
+	values, err := c.cache.GetAllByTag(createRepositoryTag(repoName))
+	if err != nil {
+		return nil
+	}
+
+	var prs []*PullRequest
+	for _, data := range values {
+		if pr := c.decode(data); pr != nil {
+			prs = append(prs, pr)
+		}
+	}
+
+	return prs
+}
+
+// decode unmarshals data into a [PullRequest].
+// It returns nil if data can't be unmarshalled.
+func (c *pullRequestCache) decode(data []byte) *PullRequest {
 	pt := &typePeek{}
-	err = json.Unmarshal(data, pt)
+	err := json.Unmarshal(data, pt)
 	if err != nil {
 		return nil
 	}
@@ -116,7 +141,8 @@ func (c *pullRequestCache) Set(branchName, repoName string, pr *PullRequest) {
 		return
 	}
 
-	_ = c.cache.Set(createKey(branchName, repoName), data)
+	// Tag the pull request to be able to list all pull requests of a repository.
+	_ = c.cache.SetWithTags(createKey(branchName, repoName), data, createRepositoryTag(repoName))
 }
 
 // LastUpdatedAtFor implements [PullRequestCache].
@@ -191,3 +217,7 @@ func UpdatePullRequestCache(clock clock.Clock, hosts []Host, prCache PullRequest
 func createKey(branchName string, repoName string) string {
 	return repoName + "_" + branchName
 }
+
+func createRepositoryTag(repoName string) string {
+	return "pr_repo_" + repoName
+}
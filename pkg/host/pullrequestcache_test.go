@@ -146,3 +146,22 @@ func TestPullRequestCache_Get_Unknown(t *testing.T) {
 
 	require.Nil(t, underTest.Get("branch", "repo"), "cache is empty")
 }
+
+func TestPullRequestCache_ListByRepository(t *testing.T) {
+	cacher, err := cache.New(filepath.Join(t.TempDir(), "cache.db"))
+	require.NoError(t, err, "creates the cache db")
+
+	underTest := NewPullRequestCacheFromHosts(cacher, []Host{&hostMock{}})
+	underTest.Set("branch-a", "repo", &PullRequest{BranchName: "branch-a", Number: 1, TargetBranch: "release/1", Type: "mock"})
+	underTest.Set("branch-b", "repo", &PullRequest{BranchName: "branch-b", Number: 2, TargetBranch: "release/2", Type: "mock"})
+	underTest.Set("branch-a", "other", &PullRequest{BranchName: "branch-a", Number: 3, Type: "mock"})
+	// Updates an existing pull request.
+	underTest.Set("branch-b", "repo", &PullRequest{BranchName: "branch-b", Number: 4, TargetBranch: "release/2", Type: "mock"})
+	underTest.Delete("branch-a", "repo")
+
+	prs := underTest.ListByRepository("repo")
+
+	require.Len(t, prs, 1)
+	require.Equal(t, int64(4), prs[0].Number)
+	require.Equal(t, "release/2", prs[0].TargetBranch)
+}
@@ -0,0 +1,85 @@
+package log
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const captureTruncatedMsg = "\n[log truncated]\n"
+
+// Capture records log messages in memory.
+// It stops recording once it holds maxBytes and appends a note that the log has been truncated.
+// It is safe for concurrent use.
+type Capture struct {
+	buf       bytes.Buffer
+	maxBytes  int
+	mu        sync.Mutex
+	truncated bool
+}
+
+// NewCapture returns a [Capture] that records up to maxBytes bytes.
+func NewCapture(maxBytes int) *Capture {
+	return &Capture{maxBytes: maxBytes}
+}
+
+// Write implements [io.Writer].
+// It never returns an error to not let a full log interrupt the work that gets logged.
+func (c *Capture) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.truncated {
+		return len(p), nil
+	}
+
+	remaining := c.maxBytes - c.buf.Len()
+	if len(p) > remaining {
+		c.buf.Write(p[:max(remaining, 0)])
+		c.buf.WriteString(captureTruncatedMsg)
+		c.truncated = true
+		return len(p), nil
+	}
+
+	c.buf.Write(p)
+	return len(p), nil
+}
+
+// String returns all recorded messages.
+func (c *Capture) String() string {
+	if c == nil {
+		return ""
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.buf.String()
+}
+
+// Truncate shortens content to maxBytes bytes and appends the same note as [Capture]
+// if content is longer than maxBytes.
+func Truncate(content string, maxBytes int) string {
+	if len(content) <= maxBytes {
+		return content
+	}
+
+	return content[:max(maxBytes, 0)] + captureTruncatedMsg
+}
+
+// Tee returns a copy of logger that also writes every message to w.
+// Messages of all levels get written to w, independent of the level of logger.
+func Tee(logger *zap.SugaredLogger, w io.Writer) *zap.SugaredLogger {
+	encoderCfg := zap.NewProductionEncoderConfig()
+	encoderCfg.EncodeTime = zapcore.ISO8601TimeEncoder
+	encoderCfg.EncodeLevel = zapcore.CapitalLevelEncoder
+	captureCore := zapcore.NewCore(
+		zapcore.NewConsoleEncoder(encoderCfg),
+		zapcore.AddSync(w),
+		zapcore.DebugLevel,
+	)
+	return logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(core, captureCore)
+	}))
+}
@@ -0,0 +1,26 @@
+package log_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wndhydrnt/saturn-bot/pkg/log"
+)
+
+func TestCapture_Write(t *testing.T) {
+	c := log.NewCapture(10)
+
+	_, err := c.Write([]byte("12345"))
+	require.NoError(t, err)
+	_, err = c.Write([]byte("67890abc"))
+	require.NoError(t, err)
+	_, err = c.Write([]byte("def"))
+	require.NoError(t, err)
+
+	require.Equal(t, "1234567890\n[log truncated]\n", c.String())
+}
+
+func TestTruncate(t *testing.T) {
+	require.Equal(t, "1234567890", log.Truncate("1234567890", 10))
+	require.Equal(t, "1234567890\n[log truncated]\n", log.Truncate("1234567890abc", 10))
+}
@@ -63,6 +63,9 @@ type Opts struct {
 	PrometheusGatherer   prometheus.Gatherer
 	PrometheusRegisterer prometheus.Registerer
 	RepositoryCacheTtl   time.Duration
+	// RepositoryLogMaxSize is the maximum size in bytes of the log that a run captures for each repository.
+	// A run doesn't capture logs if it is 0.
+	RepositoryLogMaxSize int
 	// ServerShutdownCheckInterval is the interval at which the API server checks if all conditions
 	// have been met before shutting down gracefully.
 	// This option isn't exposed as a configuration item because it's used by tests only.
@@ -70,7 +73,18 @@ type Opts struct {
 	// ServerShutdownTimeout is the maximum duration the API server waits before
 	// it abandons a graceful shutdown and exits.
 	ServerShutdownTimeout time.Duration
-	WorkerLoopInterval    time.Duration
+	// ServerRepositoryLogRetention is the duration for which the server keeps logs of repositories.
+	// The server keeps logs forever if it is 0.
+	ServerRepositoryLogRetention time.Duration
+	// ServerUiSessionTtl is the duration after which a session of the UI expires.
+	ServerUiSessionTtl time.Duration
+	// ServerWebhookDeliveryRetention is the duration for which the server keeps received webhooks.
+	// The server keeps webhooks forever if it is 0.
+	ServerWebhookDeliveryRetention time.Duration
+	// TasksGitPollInterval is the interval at which the server checks the git repository of tasks for new commits.
+	// The server doesn't poll if it is 0.
+	TasksGitPollInterval time.Duration
+	WorkerLoopInterval   time.Duration
 }
 
 func (o *Opts) SetPrometheusRegistry(reg *prometheus.Registry) {
@@ -195,5 +209,29 @@ func Initialize(opts *Opts) error {
 	}
 	opts.ServerShutdownTimeout = shutdownTimeout
 
+	sessionTtl, err := time.ParseDuration(opts.Config.ServerUiSessionTtl)
+	if err != nil {
+		return fmt.Errorf("setting serverUiSessionTtl '%s' is not a Go duration: %w", opts.Config.ServerUiSessionTtl, err)
+	}
+	opts.ServerUiSessionTtl = sessionTtl
+
+	repositoryLogRetention, err := time.ParseDuration(opts.Config.ServerRepositoryLogRetention)
+	if err != nil {
+		return fmt.Errorf("setting serverRepositoryLogRetention '%s' is not a Go duration: %w", opts.Config.ServerRepositoryLogRetention, err)
+	}
+	opts.ServerRepositoryLogRetention = repositoryLogRetention
+
+	webhookDeliveryRetention, err := time.ParseDuration(opts.Config.ServerWebhookDeliveryRetention)
+	if err != nil {
+		return fmt.Errorf("setting serverWebhookDeliveryRetention '%s' is not a Go duration: %w", opts.Config.ServerWebhookDeliveryRetention, err)
+	}
+	opts.ServerWebhookDeliveryRetention = webhookDeliveryRetention
+
+	tasksGitPollInterval, err := time.ParseDuration(opts.Config.TasksGitPollInterval)
+	if err != nil {
+		return fmt.Errorf("setting tasksGitPollInterval '%s' is not a Go duration: %w", opts.Config.TasksGitPollInterval, err)
+	}
+	opts.TasksGitPollInterval = tasksGitPollInterval
+
 	return nil
 }
@@ -53,10 +53,13 @@ type Plugin struct {
 
 // Apply implements action.Action
 func (p *Plugin) Apply(ctx context.Context) error {
+	defer p.captureLogs(ctx)()
 	path := ctx.Value(sbcontext.CheckoutPath{}).(string)
-	reply, err := p.ExecuteActions(&protoV1.ExecuteActionsRequest{
-		Path:    path,
-		Context: NewContext(ctx),
+	reply, err := callWithContext(ctx, func() (*protoV1.ExecuteActionsResponse, error) {
+		return p.ExecuteActions(&protoV1.ExecuteActionsRequest{
+			Path:    path,
+			Context: NewContext(ctx),
+		})
 	})
 	if err != nil {
 		return fmt.Errorf("execute action: %w", err)
@@ -68,8 +71,11 @@ func (p *Plugin) Apply(ctx context.Context) error {
 
 // Do implements filter.Filter.
 func (p *Plugin) Do(ctx context.Context) (bool, error) {
-	reply, err := p.ExecuteFilters(&protoV1.ExecuteFiltersRequest{
-		Context: NewContext(ctx),
+	defer p.captureLogs(ctx)()
+	reply, err := callWithContext(ctx, func() (*protoV1.ExecuteFiltersResponse, error) {
+		return p.ExecuteFilters(&protoV1.ExecuteFiltersRequest{
+			Context: NewContext(ctx),
+		})
 	})
 	if err != nil {
 		return false, fmt.Errorf("execute filter: %w", err)
@@ -79,6 +85,50 @@ func (p *Plugin) Do(ctx context.Context) (bool, error) {
 	return reply.GetMatch(), nil
 }
 
+// captureLogs writes the data that the plugin sends to stdout and stderr
+// to the log capture of ctx until the returned function gets called.
+func (p *Plugin) captureLogs(ctx context.Context) func() {
+	capture := sbcontext.LogCapture(ctx)
+	if capture == nil || p.stderrAdapter == nil || p.stdoutAdapter == nil {
+		return func() {}
+	}
+
+	logger := log.Tee(zap.NewNop().Sugar(), capture)
+	p.stderrAdapter.setCapture(NewStderrHandler(zapcore.DebugLevel, logger))
+	p.stdoutAdapter.setCapture(NewStdoutHandler(zapcore.DebugLevel, logger))
+	return func() {
+		p.stderrAdapter.setCapture(nil)
+		p.stdoutAdapter.setCapture(nil)
+	}
+}
+
+// callWithContext calls f and returns early with the error of ctx if ctx is done.
+// The protocol of plugins doesn't support cancellation.
+// The plugin keeps processing the call in the background.
+func callWithContext[T any](ctx context.Context, f func() (T, error)) (T, error) {
+	var zero T
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+
+	type reply struct {
+		value T
+		err   error
+	}
+	replyChan := make(chan reply, 1)
+	go func() {
+		value, err := f()
+		replyChan <- reply{value: value, err: err}
+	}()
+
+	select {
+	case r := <-replyChan:
+		return r.value, r.err
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
 func (p *Plugin) ExecuteActions(req *protoV1.ExecuteActionsRequest) (*protoV1.ExecuteActionsResponse, error) {
 	reply, err := p.Provider.ExecuteActions(req)
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	protoV1 "github.com/wndhydrnt/saturn-bot-go/protocol/v1"
@@ -113,6 +114,29 @@ func TestPlugin_Apply_Error(t *testing.T) {
 	require.ErrorContains(t, err, "execute action: exception in plugin")
 }
 
+func TestPlugin_Apply_Cancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo, _ := setupRepoPluginTest(ctrl)
+	release := make(chan struct{})
+	defer close(release)
+	provider := pluginmock.NewMockProvider(ctrl)
+	provider.EXPECT().
+		ExecuteActions(gomock.Any()).
+		DoAndReturn(func(_ *protoV1.ExecuteActionsRequest) (*protoV1.ExecuteActionsResponse, error) {
+			<-release
+			return &protoV1.ExecuteActionsResponse{}, nil
+		})
+	ctx, cancel := context.WithCancel(context.Background())
+	ctx = context.WithValue(ctx, sbcontext.CheckoutPath{}, "/tmp")
+	ctx = context.WithValue(ctx, sbcontext.RepositoryKey{}, repo)
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	pa := &plugin.Plugin{Provider: provider}
+	err := pa.Apply(ctx)
+
+	require.ErrorIs(t, err, context.Canceled)
+}
+
 func TestPlugin_Do(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	repo, payload := setupRepoPluginTest(ctrl)
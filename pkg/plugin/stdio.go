@@ -2,19 +2,42 @@ package plugin
 
 import (
 	"bytes"
+	"sync"
 )
 
 type stdioAdapter struct {
 	name      string
 	onMessage StdioHandler
+
+	mu        sync.Mutex
+	onCapture StdioHandler
 }
 
 // Write implements io.Writer
 // It trims space characters at the begging and end of the received data.
 func (s *stdioAdapter) Write(d []byte) (int, error) {
-	if s.onMessage != nil && len(bytes.TrimSpace(d)) != 0 {
-		s.onMessage(s.name, bytes.TrimSpace(d))
+	msg := bytes.TrimSpace(d)
+	if len(msg) == 0 {
+		return len(d), nil
+	}
+
+	if s.onMessage != nil {
+		s.onMessage(s.name, msg)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.onCapture != nil {
+		s.onCapture(s.name, msg)
 	}
 
 	return len(d), nil
 }
+
+// setCapture sets the handler that receives the data in addition to the regular handler.
+// Passing nil removes the handler.
+func (s *stdioAdapter) setCapture(h StdioHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onCapture = h
+}
@@ -4,17 +4,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
+	"path"
 	"slices"
 	"strings"
 	"time"
 
 	"github.com/wndhydrnt/saturn-bot/pkg/action"
+	"github.com/wndhydrnt/saturn-bot/pkg/codeowners"
 	sbcontext "github.com/wndhydrnt/saturn-bot/pkg/context"
 	"github.com/wndhydrnt/saturn-bot/pkg/filter"
 	"github.com/wndhydrnt/saturn-bot/pkg/git"
 	"github.com/wndhydrnt/saturn-bot/pkg/host"
 	"github.com/wndhydrnt/saturn-bot/pkg/log"
+	"github.com/wndhydrnt/saturn-bot/pkg/ptr"
 	"github.com/wndhydrnt/saturn-bot/pkg/task"
 	"github.com/wndhydrnt/saturn-bot/pkg/template"
 	"go.uber.org/zap"
@@ -40,13 +44,29 @@ const (
 	ResultSkip
 	ResultPushedDefaultBranch
 	ResultArchived
+	// ResultMergePending indicates that the git host merges the pull request once all checks have passed.
+	ResultMergePending
+	// ResultPrNudged indicates that saturn-bot has reminded the reviewers of a stale pull request.
+	ResultPrNudged
+	// ResultReviewRerequested indicates that saturn-bot has requested a review of a stale pull request again.
+	ResultReviewRerequested
+	// ResultPrRebased indicates that saturn-bot has rebased a pull request because its base branch has moved on.
+	ResultPrRebased
+	// ResultDependencyPending indicates that a pull request that the task depends on hasn't been merged yet.
+	ResultDependencyPending
 )
 
 type ProcessResult struct {
 	Error       error
 	PullRequest *host.PullRequest
 	Result      Result
-	Task        *task.Task
+	// RolloutWave is the index of the wave of the rollout of the task that contains the repository.
+	// Nil if the task doesn't define a rollout.
+	RolloutWave *int
+	// TargetBranch is the branch that the pull request targets.
+	// Empty if the task doesn't define target branches.
+	TargetBranch string
+	Task         *task.Task
 }
 
 type Processor struct {
@@ -56,57 +76,120 @@ type Processor struct {
 }
 
 type RepositoryTaskProcessor interface {
-	Process(dryRun bool, repo host.Repository, tasks []*task.Task, doFilter bool) []ProcessResult
+	Process(ctx context.Context, dryRun bool, repo host.Repository, tasks []*task.Task, doFilter bool) []ProcessResult
 }
 
-func (p *Processor) Process(dryRun bool, repo host.Repository, tasks []*task.Task, doFilter bool) []ProcessResult {
-	ctx := context.WithValue(context.Background(), sbcontext.RepositoryKey{}, repo)
-	logger := log.Log().
+// Process applies tasks to repo.
+// It stops once ctx is done and returns the results of the tasks that it has processed so far.
+func (p *Processor) Process(ctx context.Context, dryRun bool, repo host.Repository, tasks []*task.Task, doFilter bool) []ProcessResult {
+	ctx = context.WithValue(ctx, sbcontext.RepositoryKey{}, repo)
+	logger := log.Log()
+	if capture := sbcontext.LogCapture(ctx); capture != nil {
+		logger = log.Tee(logger, capture)
+		p.Git.SetLogCapture(capture)
+		defer p.Git.SetLogCapture(nil)
+	}
+
+	logger = logger.
 		WithOptions(zap.Fields(
 			log.FieldDryRun(dryRun),
 			log.FieldRepo(repo.FullName()),
 		))
 	var results []ProcessResult
 	var tasksAfterPreCloneFilters []*task.Task
+	// rolloutWaves stores the wave of the rollout that contains repo by task.
+	rolloutWaves := map[*task.Task]int{}
 	for _, t := range tasks {
-		if !doFilter {
-			tasksAfterPreCloneFilters = append(tasksAfterPreCloneFilters, t)
-			continue
+		if ctx.Err() != nil {
+			return results
 		}
 
 		taskLogger := logger.With(log.FieldTask(t.Name))
 		taskCtx := sbcontext.WithLog(ctx, taskLogger)
 		taskCtx = sbcontext.WithRunData(taskCtx, t.RunData())
-		match, preCloneResult, err := p.filterPreClone(taskCtx, t, repo)
 		result := ProcessResult{
 			Task: t,
 		}
-		if err != nil {
-			result.Error = err
-			result.Result = preCloneResult
+		// Check before filtering to not touch the repository at all.
+		if isRepositoryIgnored(taskCtx, repo) {
+			taskLogger.Debug("Skipping task because a user has asked to ignore the repository")
+			result.Result = ResultSkip
 			results = append(results, result)
-			taskLogger.Errorw("Task failed", "error", result.Error)
 			continue
 		}
 
-		if !match {
-			pr, err := p.handleFilteredRepository(taskCtx, t, repo, preCloneResult)
+		if doFilter {
+			match, preCloneResult, err := p.filterPreClone(taskCtx, t, repo)
+			if err != nil {
+				result.Error = err
+				result.Result = preCloneResult
+				results = append(results, result)
+				taskLogger.Errorw("Task failed", "error", result.Error)
+				continue
+			}
+
+			if !match {
+				prResults, err := p.handleFilteredRepository(taskCtx, t, repo, preCloneResult)
+				if err != nil {
+					taskLogger.Errorw("Failed to handle filtered task in prefilter", zap.Error(err))
+				}
+
+				if len(prResults) == 0 {
+					result.Result = preCloneResult
+					results = append(results, result)
+				} else {
+					results = append(results, prResults...)
+				}
+
+				continue
+			}
+		}
+
+		// Check the rollout even if the run doesn't filter repositories,
+		// for example because a webhook names them.
+		// Otherwise, the task would get applied to repositories of waves that haven't started yet.
+		if t.Rollout != nil {
+			wave, started, err := matchRolloutWave(taskCtx, t, repo)
 			if err != nil {
-				taskLogger.Errorw("Failed to handle filtered task in prefilter", zap.Error(err))
+				result.Error = err
+				result.Result = ResultUnknown
+				results = append(results, result)
+				taskLogger.Errorw("Task failed", "error", result.Error)
+				continue
 			}
 
-			if pr != nil {
-				result.PullRequest = pr
+			if !started {
+				// Keep pull requests open. The repository might have been part of the wave
+				// before the task changed.
+				result.Result = ResultSkip
+				results = append(results, result)
+				continue
 			}
 
-			result.Result = preCloneResult
-			results = append(results, result)
-		} else {
-			tasksAfterPreCloneFilters = append(tasksAfterPreCloneFilters, t)
+			rolloutWaves[t] = wave
 		}
+
+		if len(t.Dependencies()) > 0 {
+			merged, err := p.dependenciesMerged(taskCtx, t, repo)
+			if err != nil {
+				result.Error = err
+				result.Result = ResultUnknown
+				results = append(results, result)
+				taskLogger.Errorw("Task failed", "error", result.Error)
+				continue
+			}
+
+			if !merged {
+				result.Result = ResultDependencyPending
+				results = append(results, result)
+				continue
+			}
+		}
+
+		tasksAfterPreCloneFilters = append(tasksAfterPreCloneFilters, t)
 	}
 
-	if len(tasksAfterPreCloneFilters) == 0 {
+	if len(tasksAfterPreCloneFilters) == 0 || ctx.Err() != nil {
 		return results
 	}
 
@@ -115,10 +198,10 @@ func (p *Processor) Process(dryRun bool, repo host.Repository, tasks []*task.Tas
 		// An error during the preparation of the git repository is the best indicator that
 		// the repository has been deleted.
 		// Log a warning, clean up and consider the repository as "not matching".
-		log.Log().Warnf("Failed to clone or pull repository '%s' - cleaning up the repository", repo.FullName())
+		logger.Warnf("Failed to clone or pull repository '%s' - cleaning up the repository", repo.FullName())
 		err := p.Git.Cleanup(repo)
 		if err != nil {
-			log.Log().Errorf("Failed to clean up repository '%s'", repo.FullName())
+			logger.Errorf("Failed to clean up repository '%s'", repo.FullName())
 		}
 
 		for _, t := range tasksAfterPreCloneFilters {
@@ -133,25 +216,27 @@ func (p *Processor) Process(dryRun bool, repo host.Repository, tasks []*task.Tas
 
 	ctx = context.WithValue(ctx, sbcontext.CheckoutPath{}, checkoutDir)
 	for _, t := range tasksAfterPreCloneFilters {
+		if ctx.Err() != nil {
+			break
+		}
+
 		taskLogger := logger.
 			WithOptions(zap.Fields(
 				log.FieldTask(t.Name),
 			))
 		taskCtx := sbcontext.WithLog(ctx, taskLogger)
 		taskCtx = sbcontext.WithRunData(taskCtx, t.RunData())
-		resultId, pr, err := p.processPostClone(taskCtx, repo, t, doFilter, dryRun)
-		result := ProcessResult{
-			PullRequest: pr,
-			Result:      resultId,
-			Task:        t,
-		}
-		if err != nil {
-			result.Error = err
-			taskLogger.Errorw("Task failed", "error", result.Error)
-		}
+		for _, result := range p.processPostClone(taskCtx, repo, t, doFilter, dryRun) {
+			if result.Error != nil {
+				taskLogger.Errorw("Task failed", "error", result.Error)
+			}
 
-		_ = p.updatePrCache(taskCtx, t, repo, pr)
-		results = append(results, result)
+			if wave, ok := rolloutWaves[t]; ok {
+				result.RolloutWave = ptr.To(wave)
+			}
+
+			results = append(results, result)
+		}
 	}
 
 	return results
@@ -192,11 +277,75 @@ func (p *Processor) filterPreClone(ctx context.Context, task *task.Task, repo ho
 	return true, 0, nil
 }
 
-func (p *Processor) processPostClone(ctx context.Context, repo host.Repository, task *task.Task, doFilter, dryRun bool) (Result, *host.PullRequest, error) {
+// matchRolloutWave returns the index of the wave of the rollout of t that contains repo.
+// It returns false if no wave contains repo or if the wave hasn't started yet.
+func matchRolloutWave(ctx context.Context, t *task.Task, repo host.Repository) (int, bool, error) {
+	logger := sbcontext.Log(ctx)
+	wave, err := t.RolloutWave(ctx, repo.FullName())
+	if err != nil {
+		return 0, false, err
+	}
+
+	if wave == -1 {
+		logger.Debug("Skipping task because no wave of the rollout contains the repository")
+		return 0, false, nil
+	}
+
+	current, err := t.CurrentRolloutWave()
+	if err != nil {
+		return 0, false, err
+	}
+
+	if wave > current {
+		logger.Debugf("Skipping task because wave %s of the rollout hasn't started yet", t.Rollout.Waves[wave].Name)
+		return 0, false, nil
+	}
+
+	return wave, true, nil
+}
+
+// dependenciesMerged returns true if the pull requests of all dependencies of t have been merged.
+// It reads the pull requests from the cache because they can be part of other repositories.
+func (p *Processor) dependenciesMerged(ctx context.Context, t *task.Task, repo host.Repository) (bool, error) {
+	if p.PullRequestCache == nil {
+		return false, errors.New("check dependencies: no pull request cache")
+	}
+
+	logger := sbcontext.Log(ctx)
+	data := template.FromContext(updateTemplateVars(ctx, repo, t))
+	for _, dep := range t.Dependencies() {
+		repoName := dep.RepositoryName
+		if repoName == "" {
+			repoName = repo.FullName()
+		}
+
+		// The repository that contains the pull request doesn't wait for itself.
+		if dep.Task == t && repoName == repo.FullName() {
+			continue
+		}
+
+		branchName, err := dep.Task.RenderBranchNameForRepository(repoName, data)
+		if err != nil {
+			return false, fmt.Errorf("render branch name of dependency: %w", err)
+		}
+
+		pr := p.PullRequestCache.Get(branchName, repoName)
+		if pr == nil || pr.State != host.PullRequestStateMerged {
+			logger.Debugf("Skipping task because pull request of task %s in repository %s hasn't been merged", dep.Task.Name, repoName)
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// processPostClone applies task to every target branch of repo.
+// It returns one result per target branch.
+func (p *Processor) processPostClone(ctx context.Context, repo host.Repository, task *task.Task, doFilter, dryRun bool) []ProcessResult {
 	lck := &locker{}
 	err := lck.lock(p.DataDir, repo)
 	if err != nil {
-		return ResultUnknown, nil, fmt.Errorf("lock of repository '%s' failed: %w", repo.FullName(), err)
+		return []ProcessResult{{Error: fmt.Errorf("lock of repository '%s' failed: %w", repo.FullName(), err), Task: task}}
 	}
 
 	logger := sbcontext.Log(ctx)
@@ -210,49 +359,237 @@ func (p *Processor) processPostClone(ctx context.Context, repo host.Repository,
 	if doFilter {
 		match, err := matchTaskToRepository(ctx, task.FiltersPostClone(), logger)
 		if err != nil {
-			return ResultUnknown, nil, err
+			return []ProcessResult{{Error: err, Task: task}}
 		}
 
 		if !match {
 			result := ResultNoMatch
-			pr, err := p.handleFilteredRepository(ctx, task, repo, result)
+			prResults, err := p.handleFilteredRepository(ctx, task, repo, result)
 			if err != nil {
 				logger.Errorw("Failed to handle filtered task in postfilter", zap.Error(err))
 			}
 
-			return result, pr, nil
+			if len(prResults) == 0 {
+				return []ProcessResult{{Result: result, Task: task}}
+			}
+
+			for _, prResult := range prResults {
+				_ = p.updatePrCache(withTargetBranch(ctx, prResult.TargetBranch), task, repo, prResult.PullRequest)
+			}
+
+			return prResults
 		}
 	}
 
 	logger.Info("Task matches repository")
+	targetBranches, err := findTargetBranches(ctx, p.Git, repo, task)
+	if err != nil {
+		return []ProcessResult{{Error: fmt.Errorf("find target branches: %w", err), Task: task}}
+	}
+
+	if len(targetBranches) == 0 {
+		logger.Debug("No branch of repository matches target branches of task")
+		return []ProcessResult{{Result: ResultNoMatch, Task: task}}
+	}
+
+	var results []ProcessResult
+	for _, targetBranch := range targetBranches {
+		if ctx.Err() != nil {
+			break
+		}
+
+		results = append(results, p.processTargetBranch(ctx, repo, task, targetBranch, dryRun))
+	}
+
+	return results
+}
+
+// processTargetBranch applies task to repo and creates a pull request for targetBranch.
+// An empty targetBranch targets the base branch of repo.
+func (p *Processor) processTargetBranch(ctx context.Context, repo host.Repository, task *task.Task, targetBranch string, dryRun bool) ProcessResult {
+	result := ProcessResult{TargetBranch: targetBranch, Task: task}
+	logger := sbcontext.Log(ctx)
+	if targetBranch != "" {
+		logger = logger.With("targetBranch", targetBranch)
+		ctx = sbcontext.WithLog(withTargetBranch(ctx, targetBranch), logger)
+		// Reset the local branch to the remote branch because a previous run can have left it in an outdated state.
+		_, _, err := p.Git.Execute("checkout", "-B", targetBranch, "origin/"+targetBranch)
+		if err != nil {
+			result.Error = fmt.Errorf("checkout target branch %s: %w", targetBranch, err)
+			return result
+		}
+
+		repo = &targetRepository{Repository: repo, targetBranch: targetBranch}
+	}
+
 	checkoutDir := ctx.Value(sbcontext.CheckoutPath{}).(string)
-	result, prDetail, err := p.applyTaskToRepository(ctx, dryRun, p.Git, logger, repo, task, checkoutDir)
+	resultId, prDetail, err := p.applyTaskToRepository(ctx, dryRun, p.Git, logger, repo, task, checkoutDir)
+	result.PullRequest = prDetail
+	_ = p.updatePrCache(ctx, task, repo, prDetail)
 	if err != nil {
-		return ResultUnknown, prDetail, fmt.Errorf("task failed: %w", err)
+		if targetBranch != "" {
+			result.Error = fmt.Errorf("task failed for target branch %s: %w", targetBranch, err)
+		} else {
+			result.Error = fmt.Errorf("task failed: %w", err)
+		}
+
+		return result
 	}
 
-	if IsPrOpen(result) {
+	result.Result = resultId
+	if IsPrOpen(resultId) {
 		task.IncOpenPRsCount()
 	}
 
-	if result == ResultPrCreated || result == ResultPrMerged || result == ResultPushedDefaultBranch {
+	if resultId == ResultPrCreated || resultId == ResultPrMerged || resultId == ResultPushedDefaultBranch {
 		task.IncChangeLimitCount()
 	}
 
-	return result, prDetail, nil
+	return result
+}
+
+// withTargetBranch sets targetBranch in the template data of ctx.
+// It returns ctx unchanged if targetBranch is empty.
+func withTargetBranch(ctx context.Context, targetBranch string) context.Context {
+	if targetBranch == "" {
+		return ctx
+	}
+
+	data := template.FromContext(ctx)
+	data.TargetBranch = targetBranch
+	return template.UpdateContext(ctx, data)
+}
+
+// findTargetBranches returns the branches of repo that pull requests of task target.
+// It returns one empty string if task doesn't define target branches,
+// which makes the pull request target the base branch of repo.
+func findTargetBranches(ctx context.Context, gitc git.GitClient, repo host.Repository, task *task.Task) ([]string, error) {
+	if !task.HasTargetBranches() {
+		return []string{""}, nil
+	}
+
+	data := template.FromContext(updateTemplateVars(ctx, repo, task))
+	patterns, err := task.RenderTargetBranches(data)
+	if err != nil {
+		return nil, err
+	}
+
+	remoteBranches, err := gitc.RemoteBranches()
+	if err != nil {
+		return nil, err
+	}
+
+	var queriedBranches []string
+	if task.TargetBranchesQuery != nil {
+		queriedBranches, err = task.QueryTargetBranches(data, repo.BaseBranch(), remoteBranches)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var targetBranches []string
+	// Detect target branches that would share the same branch and pull request.
+	branchNames := map[string]string{}
+	for _, remoteBranch := range remoteBranches {
+		if !slices.Contains(queriedBranches, remoteBranch) && !slices.ContainsFunc(patterns, func(pattern string) bool {
+			match, _ := path.Match(pattern, remoteBranch)
+			return match
+		}) {
+			continue
+		}
+
+		data.TargetBranch = remoteBranch
+		branchName, err := task.RenderBranchName(data)
+		if err != nil {
+			return nil, err
+		}
+
+		if other, exists := branchNames[branchName]; exists {
+			return nil, fmt.Errorf("target branches %s and %s render the same branch name %s - use {{.TargetBranch}} in branchName", other, remoteBranch, branchName)
+		}
+
+		branchNames[branchName] = remoteBranch
+		targetBranches = append(targetBranches, remoteBranch)
+	}
+
+	return targetBranches, nil
+}
+
+// targetRepository replaces the base branch of a repository with a target branch of a task.
+type targetRepository struct {
+	host.Repository
+	targetBranch string
+}
+
+// BaseBranch implements [host.Repository].
+func (r *targetRepository) BaseBranch() string {
+	return r.targetBranch
 }
 
 // handleFilteredRepository wraps other functions that should be executed when a repository doesn't match the filters of the current task.
 //
-// It returns the [host.PullRequest], if it exists in the cache, for further processing.
+// It returns one [ProcessResult] per [host.PullRequest] of the task that exists in the cache.
+// A task that defines target branches can have one pull request per target branch.
 //
 // It only acts if the [host.PullRequestCache] contains the PR. It is a no-op if the cache is empty.
 // Callers of the [Processor] need to ensure that the cache is up-to-date.
-func (p *Processor) handleFilteredRepository(ctx context.Context, t *task.Task, repo host.Repository, result Result) (*host.PullRequest, error) {
+func (p *Processor) handleFilteredRepository(ctx context.Context, t *task.Task, repo host.Repository, result Result) ([]ProcessResult, error) {
 	if p.PullRequestCache == nil {
 		return nil, nil
 	}
 
+	targetBranches := []string{""}
+	if t.HasTargetBranches() {
+		var err error
+		targetBranches, err = p.findCachedTargetBranches(ctx, t, repo)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var errs []error
+	var results []ProcessResult
+	for _, targetBranch := range targetBranches {
+		pr, err := p.handleFilteredPullRequest(withTargetBranch(ctx, targetBranch), t, repo, result)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if pr != nil {
+			results = append(results, ProcessResult{PullRequest: pr, Result: result, TargetBranch: targetBranch, Task: t})
+		}
+	}
+
+	return results, errors.Join(errs...)
+}
+
+// findCachedTargetBranches returns the target branches of all pull requests of t in repo that exist in the cache.
+// It doesn't read the branches of repo because the repository might not have been cloned.
+func (p *Processor) findCachedTargetBranches(ctx context.Context, t *task.Task, repo host.Repository) ([]string, error) {
+	data := template.FromContext(ctx)
+	var targetBranches []string
+	for _, pr := range p.PullRequestCache.ListByRepository(repo.FullName()) {
+		if pr.TargetBranch == "" || slices.Contains(targetBranches, pr.TargetBranch) {
+			continue
+		}
+
+		data.TargetBranch = pr.TargetBranch
+		branchName, err := t.RenderBranchName(data)
+		if err != nil {
+			return nil, err
+		}
+
+		if branchName == pr.BranchName {
+			targetBranches = append(targetBranches, pr.TargetBranch)
+		}
+	}
+
+	return targetBranches, nil
+}
+
+// handleFilteredPullRequest closes the pull request of t in repo if the cache contains it.
+func (p *Processor) handleFilteredPullRequest(ctx context.Context, t *task.Task, repo host.Repository, result Result) (*host.PullRequest, error) {
 	branchName, err := t.RenderBranchName(template.FromContext(ctx))
 	if err != nil {
 		return nil, err
@@ -397,8 +734,13 @@ func (p *Processor) applyTaskToRepository(ctx context.Context, dryRun bool, gitc
 		return ResultUnknown, nil, fmt.Errorf("find pull request: %w", err)
 	}
 
+	command := sbcontext.RunData(ctx)[sbcontext.RunDataKeyCommand]
+	if command != "" {
+		logger.Infof("Executing command %s requested by a user", command)
+	}
+
 	if prID != nil && prID.State == host.PullRequestStateClosed {
-		if task.MergeOnce {
+		if task.MergeOnce && command != sbcontext.CommandRecreate {
 			logger.Info("Existing PR has been closed")
 			return ResultPrClosedBefore, prID, nil
 		} else {
@@ -407,7 +749,19 @@ func (p *Processor) applyTaskToRepository(ctx context.Context, dryRun bool, gitc
 		}
 	}
 
-	if prID != nil && prID.State == host.PullRequestStateMerged && task.MergeOnce {
+	// The host has merged the pull request since the previous run because saturn-bot enabled native auto-merge.
+	if prID != nil && prID.State == host.PullRequestStateMerged && prID.AutoMergeEnabled {
+		logger.Info("Host has merged pull request")
+		err := task.OnPrMerged(context.WithValue(ctx, sbcontext.PullRequestKey{}, *prID))
+		if err != nil {
+			return ResultUnknown, prID, fmt.Errorf("pr merged event failed: %w", err)
+		}
+
+		// The cache stores the pull request, which prevents the next run from sending the event again.
+		prID.AutoMergeEnabled = false
+	}
+
+	if prID != nil && prID.State == host.PullRequestStateMerged && task.MergeOnce && command != sbcontext.CommandRecreate {
 		logger.Info("Existing PR has been merged")
 		return ResultPrMergedBefore, prID, nil
 	}
@@ -437,7 +791,7 @@ func (p *Processor) applyTaskToRepository(ctx context.Context, dryRun bool, gitc
 		}
 	}
 
-	forceRebase := prID != nil && needsRebaseByUser(repo, prID)
+	forceRebase := prID != nil && (needsRebaseByUser(repo, prID) || command == sbcontext.CommandRebase || command == sbcontext.CommandRecreate)
 	if forceRebase {
 		// Do not keep the comment around when the user wants to rebase
 		logger.Debug("Deleting pull request comment because user requested a force-rebase")
@@ -489,7 +843,19 @@ func (p *Processor) applyTaskToRepository(ctx context.Context, dryRun bool, gitc
 		return ResultUnknown, prID, fmt.Errorf("update of git branch of task failed: %w", err)
 	}
 
-	err = applyActionsInDirectory(task.Actions(), ctx, workDir)
+	rebased := false
+	if prID != nil && prID.State == host.PullRequestStateOpen && !forceRebase && !hasConflict {
+		rebased, err = applyRebasePolicy(gitc, task, branchName, repo)
+		if err != nil {
+			return ResultUnknown, prID, err
+		}
+
+		if rebased {
+			logger.Info("Rebasing pull request because its base branch has moved on")
+		}
+	}
+
+	changedBy, err := applyActionsAndTrackChanges(ctx, gitc, task.Actions(), workDir)
 	if err != nil {
 		return ResultUnknown, prID, err
 	}
@@ -507,7 +873,8 @@ func (p *Processor) applyTaskToRepository(ctx context.Context, dryRun bool, gitc
 		}
 	}
 
-	hasChangesInRemoteDefaultBranch, err := gitc.HasRemoteChanges(repo.BaseBranch())
+	baseBranch := repo.BaseBranch()
+	hasChangesInRemoteDefaultBranch, err := gitc.HasRemoteChanges(baseBranch)
 	if err != nil {
 		return ResultUnknown, prID, fmt.Errorf("check for remote changes in default branch failed: %w", err)
 	}
@@ -542,7 +909,7 @@ func (p *Processor) applyTaskToRepository(ctx context.Context, dryRun bool, gitc
 		return ResultUnknown, prID, fmt.Errorf("check for remote changes failed: %w", err)
 	}
 
-	hasChanges := (hasLocalChanges && hasRemoteChanges) || hasConflict
+	hasChanges := (hasLocalChanges && hasRemoteChanges) || hasConflict || rebased
 	if hasChanges {
 		logger.Debug("Pushing changes")
 		if !dryRun {
@@ -561,17 +928,34 @@ func (p *Processor) applyTaskToRepository(ctx context.Context, dryRun bool, gitc
 		return ResultUnknown, prID, err
 	}
 
+	bodyData := template.FromContext(ctx)
+	bodyData.Changes = describeChanges(gitc, logger, baseBranch, changedBy)
+	codeOwners := getCodeOwners(gitc, logger, repo, task, workDir)
 	prData := host.PullRequestData{
-		Assignees:      getAssignees(ctx, task),
-		AutoMerge:      task.AutoMerge,
-		AutoMergeAfter: task.CalcAutoMergeAfter(),
-		Body:           task.PrBody,
-		Labels:         task.Labels,
-		MergeOnce:      task.MergeOnce,
-		Reviewers:      getReviewers(ctx, task),
-		TaskName:       task.Name,
-		TemplateData:   template.FromContext(ctx),
-		Title:          prTitle,
+		Assignees:       getAssignees(ctx, task),
+		AutoMerge:       task.AutoMerge,
+		AutoMergeAfter:  task.CalcAutoMergeAfter(),
+		AutoMergeNative: task.AutoMergeNative,
+		Body:            task.PrBody,
+		Draft:           task.Draft,
+		Footer:          task.PrFooter,
+		Github:          task.GithubPullRequestData(),
+		Gitlab:          task.GitlabPullRequestData(),
+		Labels:          task.Labels,
+		MergeOnce:       task.MergeOnce,
+		Reviewers:       getReviewers(ctx, task, codeOwners),
+		TargetBranch:    template.FromContext(ctx).TargetBranch,
+		TaskName:        task.Name,
+		TemplateData:    bodyData,
+		Title:           prTitle,
+	}
+
+	// Don't notify assignees and reviewers while the pull request is a draft.
+	// They get added once saturn-bot has promoted the pull request.
+	promoteDraft := task.Draft && task.PromoteDraftWhenGreen
+	if promoteDraft && (prID == nil || prID.State != host.PullRequestStateOpen || prID.Draft) {
+		prData.Assignees = nil
+		prData.Reviewers = nil
 	}
 
 	// Always create if branch of task contains changes compared to default branch and no PR has been created yet.
@@ -590,11 +974,78 @@ func (p *Processor) applyTaskToRepository(ctx context.Context, dryRun bool, gitc
 			return ResultUnknown, prID, fmt.Errorf("pr created event failed: %w", err)
 		}
 
+		// Hosts don't enable auto-merge of a draft.
+		if task.AutoMerge && task.AutoMergeNative && task.CalcAutoMergeAfter() == 0 && !task.Draft {
+			logger.Info("Enabling auto-merge of pull request")
+			if !dryRun {
+				err := repo.EnableAutoMerge(getMergeOptions(task), prID)
+				if err != nil {
+					// Not critical because the next run tries again.
+					logger.Warnw("Failed to enable auto-merge of pull request", zap.Error(err))
+				}
+			}
+		}
+
 		return ResultPrCreated, prID, nil
 	}
 
+	// Mark a draft as ready for review if no new changes have been detected and all checks have passed
+	if promoteDraft && !hasChanges && prID != nil && prID.State == host.PullRequestStateOpen && prID.Draft {
+		success, err := repo.HasSuccessfulPullRequestBuild(prID)
+		if err != nil {
+			return ResultUnknown, prID, fmt.Errorf("check for successful pull request build failed: %w", err)
+		}
+
+		if success {
+			logger.Info("Marking draft pull request as ready for review")
+			prData.Assignees = getAssignees(ctx, task)
+			prData.Reviewers = getReviewers(ctx, task, codeOwners)
+			if !dryRun {
+				err := repo.MarkPullRequestReady(prID)
+				if err != nil {
+					return ResultUnknown, prID, fmt.Errorf("mark pull request as ready for review: %w", err)
+				}
+
+				err = repo.UpdatePullRequest(prData, prID)
+				if err != nil {
+					return ResultUnknown, prID, fmt.Errorf("failed to update pull request: %w", err)
+				}
+			}
+
+			// Give reviewers the chance to review before merging on a subsequent run.
+			return ResultPrOpen, prID, nil
+		}
+	}
+
+	// A draft can't be merged.
+	isDraft := prID != nil && prID.Draft
+
+	// A user can ask to merge a pull request even if the task doesn't merge automatically.
+	mergeRequested := command == sbcontext.CommandMerge
+
+	// Let the git host merge if native auto-merge is enabled, no new changes have been detected and the pull request is open
+	mergePending := false
+	if task.AutoMerge && task.AutoMergeNative && !mergeRequested && !hasChanges && !isDraft && prID != nil && prID.State == host.PullRequestStateOpen {
+		if !canMergeAfter(prID.CreatedAt, task.CalcAutoMergeAfter()) {
+			logger.Info("Too early to enable auto-merge of pull request")
+			return ResultAutoMergeTooEarly, prID, nil
+		}
+
+		if !prID.AutoMergeEnabled {
+			logger.Info("Enabling auto-merge of pull request")
+			if !dryRun {
+				err := repo.EnableAutoMerge(getMergeOptions(task), prID)
+				if err != nil {
+					return ResultUnknown, prID, fmt.Errorf("enable auto-merge of pull request: %w", err)
+				}
+			}
+		}
+
+		mergePending = true
+	}
+
 	// Try to merge if auto-merge is enabled, no new changes have been detected and the pull request is open
-	if task.AutoMerge && !hasChanges && prID != nil && prID.State == host.PullRequestStateOpen {
+	if (task.AutoMerge || mergeRequested) && !mergePending && !hasChanges && !isDraft && prID != nil && prID.State == host.PullRequestStateOpen {
 		success, err := repo.HasSuccessfulPullRequestBuild(prID)
 		if err != nil {
 			return ResultUnknown, prID, fmt.Errorf("check for successful pull request build failed: %w", err)
@@ -604,7 +1055,7 @@ func (p *Processor) applyTaskToRepository(ctx context.Context, dryRun bool, gitc
 			return ResultChecksFailed, prID, nil
 		}
 
-		if !canMergeAfter(prID.CreatedAt, task.CalcAutoMergeAfter()) {
+		if !mergeRequested && !canMergeAfter(prID.CreatedAt, task.CalcAutoMergeAfter()) {
 			logger.Info("Too early to merge pull request")
 			return ResultAutoMergeTooEarly, prID, nil
 		}
@@ -621,7 +1072,7 @@ func (p *Processor) applyTaskToRepository(ctx context.Context, dryRun bool, gitc
 
 		logger.Info("Merging pull request")
 		if !dryRun {
-			err := repo.MergePullRequest(!task.KeepBranchAfterMerge, prID)
+			err := repo.MergePullRequest(getMergeOptions(task), prID)
 			if err != nil {
 				return ResultUnknown, prID, fmt.Errorf("failed to merge pull request: %w", err)
 			}
@@ -645,7 +1096,16 @@ func (p *Processor) applyTaskToRepository(ctx context.Context, dryRun bool, gitc
 			}
 		}
 
-		return ResultPrOpen, prID, nil
+		if rebased {
+			return ResultPrRebased, prID, nil
+		}
+
+		result, prID, err := applyStalePolicy(dryRun, logger, repo, task, prData.Reviewers, prID)
+		if err == nil && result == ResultPrOpen && mergePending {
+			return ResultMergePending, prID, nil
+		}
+
+		return result, prID, err
 	}
 
 	return ResultNoChanges, prID, nil
@@ -663,6 +1123,113 @@ func (p *Processor) findPullRequest(branchName string, repo host.Repository) (*h
 	return repo.FindPullRequest(branchName)
 }
 
+// applyRebasePolicy decides if the open pull request of t needs a rebase.
+// [git.GitClient.UpdateTaskBranch] always rebases the local branch of the task onto the base branch.
+// saturn-bot pushes the rebased branch only if the actions change the pull request
+// or if the stale policy of t defines rebaseAfterCommits and the base branch has moved on far enough.
+func applyRebasePolicy(gitc git.GitClient, t *task.Task, branchName string, repo host.Repository) (bool, error) {
+	policy := t.CalcStalePolicy()
+	if policy == nil || policy.RebaseAfterCommits == 0 {
+		return false, nil
+	}
+
+	behind, err := gitc.CountCommitsBehind(branchName, repo.BaseBranch())
+	if err != nil {
+		return false, err
+	}
+
+	return behind > policy.RebaseAfterCommits, nil
+}
+
+const (
+	commentIdentifierNudge           = "stale-nudge"
+	commentIdentifierReviewRerequest = "stale-review-rerequest"
+)
+
+// applyStalePolicy applies the first due step of the stale policy of t to the open pull request pr.
+// Each step happens at most once because saturn-bot records it in a comment on pr.
+// It returns ResultPrOpen if no step is due.
+func applyStalePolicy(dryRun bool, logger *zap.SugaredLogger, repo host.Repository, t *task.Task, reviewers []string, pr *host.PullRequest) (Result, *host.PullRequest, error) {
+	policy := t.CalcStalePolicy()
+	// Reviewers of a draft don't need to review yet.
+	if policy == nil || pr.Draft || pr.CreatedAt.IsZero() {
+		return ResultPrOpen, pr, nil
+	}
+
+	openFor := time.Since(pr.CreatedAt)
+	if policy.NudgeAfter > 0 && openFor > policy.NudgeAfter {
+		done, err := host.HasPullRequestCommentWithIdentifier(commentIdentifierNudge, pr, repo)
+		if err != nil {
+			return ResultUnknown, pr, fmt.Errorf("check for nudge comment: %w", err)
+		}
+
+		if !done {
+			logger.Info("Nudging reviewers of stale pull request")
+			if !dryRun {
+				body := fmt.Sprintf(":wave: This pull request has been open for more than %s.%s", policy.NudgeAfter.String(), mentionReviewers(reviewers))
+				err := host.CreatePullRequestCommentWithIdentifier(body, commentIdentifierNudge, pr, repo)
+				if err != nil {
+					return ResultUnknown, pr, fmt.Errorf("create nudge comment: %w", err)
+				}
+			}
+
+			return ResultPrNudged, pr, nil
+		}
+	}
+
+	if policy.RerequestReviewAfter > 0 && openFor > policy.RerequestReviewAfter && len(reviewers) > 0 {
+		done, err := host.HasPullRequestCommentWithIdentifier(commentIdentifierReviewRerequest, pr, repo)
+		if err != nil {
+			return ResultUnknown, pr, fmt.Errorf("check for review re-request comment: %w", err)
+		}
+
+		if !done {
+			logger.Info("Requesting review of stale pull request again")
+			if !dryRun {
+				err := repo.RequestPullRequestReview(reviewers, pr)
+				if err != nil {
+					return ResultUnknown, pr, err
+				}
+
+				body := fmt.Sprintf(":bell: This pull request has been open for more than %s. saturn-bot has requested a review again.%s", policy.RerequestReviewAfter.String(), mentionReviewers(reviewers))
+				err = host.CreatePullRequestCommentWithIdentifier(body, commentIdentifierReviewRerequest, pr, repo)
+				if err != nil {
+					return ResultUnknown, pr, fmt.Errorf("create review re-request comment: %w", err)
+				}
+			}
+
+			return ResultReviewRerequested, pr, nil
+		}
+	}
+
+	return ResultPrOpen, pr, nil
+}
+
+// mentionReviewers returns a sentence that mentions all reviewers.
+func mentionReviewers(reviewers []string) string {
+	if len(reviewers) == 0 {
+		return ""
+	}
+
+	mentions := make([]string, 0, len(reviewers))
+	for _, reviewer := range reviewers {
+		mentions = append(mentions, "@"+reviewer)
+	}
+
+	return " " + strings.Join(mentions, " ") + " please take a look."
+}
+
+// isRepositoryIgnored returns true if a user has asked to never apply the task to repo again.
+// The server sets the ignored repositories in the run data.
+func isRepositoryIgnored(ctx context.Context, repo host.Repository) bool {
+	value := sbcontext.RunData(ctx)[sbcontext.RunDataKeyIgnoredRepositories]
+	if value == "" {
+		return false
+	}
+
+	return slices.Contains(strings.Split(value, ","), repo.FullName())
+}
+
 func needsRebaseByUser(repo host.Repository, pr *host.PullRequest) bool {
 	body := repo.GetPullRequestBody(pr)
 	return strings.Contains(body, "[x] If you want to rebase this PR")
@@ -687,6 +1254,67 @@ func applyActionsInDirectory(actions []action.Action, ctx context.Context, dir s
 	})
 }
 
+// applyActionsAndTrackChanges applies actions in dir, like [applyActionsInDirectory].
+// It returns the actions that have changed the content of dir.
+func applyActionsAndTrackChanges(ctx context.Context, gitc git.GitClient, actions []action.Action, dir string) ([]string, error) {
+	if len(actions) == 0 {
+		return nil, nil
+	}
+
+	var changedBy []string
+	err := inDirectory(dir, func() error {
+		tree, err := gitc.WriteTree()
+		if err != nil {
+			return err
+		}
+
+		for _, a := range actions {
+			err := a.Apply(ctx)
+			if err != nil {
+				return fmt.Errorf("action %s failed: %w", a.String(), err)
+			}
+
+			nextTree, err := gitc.WriteTree()
+			if err != nil {
+				return err
+			}
+
+			if nextTree != tree {
+				changedBy = append(changedBy, a.String())
+			}
+
+			tree = nextTree
+		}
+
+		return nil
+	})
+
+	return changedBy, err
+}
+
+// describeChanges collects the changes of the pull request for the templates of the body.
+func describeChanges(gitc git.GitClient, logger *zap.SugaredLogger, baseBranch string, changedBy []string) template.DataChanges {
+	changes := template.DataChanges{Actions: changedBy}
+	files, err := gitc.ChangedFileStats(baseBranch)
+	if err != nil {
+		// Not critical because the pull request can exist without the stats.
+		logger.Warnw("Failed to list changed files", zap.Error(err))
+		return changes
+	}
+
+	for _, f := range files {
+		changes.Additions += f.Additions
+		changes.Deletions += f.Deletions
+		changes.Files = append(changes.Files, template.DataChangedFile{
+			Additions: f.Additions,
+			Deletions: f.Deletions,
+			Path:      f.Path,
+		})
+	}
+
+	return changes
+}
+
 func inDirectory(dir string, f func() error) error {
 	currentDir, err := os.Getwd()
 	if err != nil {
@@ -723,17 +1351,85 @@ func updateTemplateVars(ctx context.Context, repo host.Repository, tk *task.Task
 		data.TaskName = tk.Name
 	}
 
+	data.RunID = runData[sbcontext.RunDataKeyRunID]
+	if serverUrl := strings.TrimSuffix(runData[sbcontext.RunDataKeyServerUrl], "/"); serverUrl != "" {
+		if data.RunID != "" {
+			data.RunUrl = serverUrl + "/ui/runs/" + data.RunID
+		}
+
+		if tk != nil {
+			data.TaskFileUrl = serverUrl + "/ui/tasks/" + url.PathEscape(tk.Name) + "/file"
+		}
+	}
+
 	return template.UpdateContext(ctx, data)
 }
 
+// getMergeOptions returns the options to merge a pull request of t.
+func getMergeOptions(t *task.Task) host.MergeOptions {
+	return host.MergeOptions{
+		DeleteBranch: !t.KeepBranchAfterMerge,
+		Method:       string(t.MergeMethod),
+	}
+}
+
 // getAssignees merges static assignees from a task with dynamic assignees from run data.
 func getAssignees(ctx context.Context, t *task.Task) []string {
 	return mergeUsers(ctx, sbcontext.RunDataKeyAssignees, t.Assignees)
 }
 
-// getReviewers merges static reviewers from a task with dynamic reviewers from run data.
-func getReviewers(ctx context.Context, t *task.Task) []string {
-	return mergeUsers(ctx, sbcontext.RunDataKeyReviewers, t.Reviewers)
+// getReviewers merges static reviewers from a task with dynamic reviewers from run data and code owners.
+func getReviewers(ctx context.Context, t *task.Task, codeOwners []string) []string {
+	reviewers := mergeUsers(ctx, sbcontext.RunDataKeyReviewers, t.Reviewers)
+	if len(codeOwners) == 0 {
+		return reviewers
+	}
+
+	reviewers = slices.Concat(reviewers, codeOwners)
+	slices.Sort(reviewers)
+	return slices.Compact(reviewers)
+}
+
+// getCodeOwners returns the owners of the files that the task changes.
+// It returns the fallback reviewers of the task if the repository doesn't define owners of the files.
+func getCodeOwners(gitc git.GitClient, logger *zap.SugaredLogger, repo host.Repository, t *task.Task, workDir string) []string {
+	if t.CodeOwners == nil {
+		return nil
+	}
+
+	owners, err := findCodeOwners(gitc, repo, workDir)
+	if err != nil {
+		// Not critical because the pull request can exist without code owners.
+		logger.Warnw("Failed to find code owners", zap.Error(err))
+	}
+
+	if len(owners) == 0 {
+		return t.CodeOwners.Fallback
+	}
+
+	if t.CodeOwners.MaxReviewers > 0 && len(owners) > t.CodeOwners.MaxReviewers {
+		owners = owners[:t.CodeOwners.MaxReviewers]
+	}
+
+	return owners
+}
+
+func findCodeOwners(gitc git.GitClient, repo host.Repository, workDir string) ([]string, error) {
+	file, err := codeowners.Find(workDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if file == nil {
+		return nil, nil
+	}
+
+	changedFiles, err := gitc.ChangedFiles(repo.BaseBranch())
+	if err != nil {
+		return nil, err
+	}
+
+	return file.Rank(changedFiles), nil
 }
 
 func mergeUsers(ctx context.Context, key string, static []string) []string {
@@ -766,6 +1462,14 @@ func IsPrOpen(result Result) bool {
 		return true
 	case ResultConflict:
 		return true
+	case ResultMergePending:
+		return true
+	case ResultPrNudged:
+		return true
+	case ResultReviewRerequested:
+		return true
+	case ResultPrRebased:
+		return true
 	default:
 		return false
 	}
@@ -2,16 +2,23 @@ package processor_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/wndhydrnt/saturn-bot/pkg/action"
+	sbcontext "github.com/wndhydrnt/saturn-bot/pkg/context"
+	"github.com/wndhydrnt/saturn-bot/pkg/filter"
 	"github.com/wndhydrnt/saturn-bot/pkg/git"
 	"github.com/wndhydrnt/saturn-bot/pkg/host"
+	"github.com/wndhydrnt/saturn-bot/pkg/options"
 	"github.com/wndhydrnt/saturn-bot/pkg/processor"
+	"github.com/wndhydrnt/saturn-bot/pkg/ptr"
 	"github.com/wndhydrnt/saturn-bot/pkg/task"
 	"github.com/wndhydrnt/saturn-bot/pkg/task/schema"
 	"github.com/wndhydrnt/saturn-bot/pkg/template"
@@ -75,6 +82,7 @@ func TestProcessor_Process_CreatePullRequestLocalChanges(t *testing.T) {
 	gitc.EXPECT().CommitChanges("commit test").Return(nil)
 	gitc.EXPECT().HasRemoteChanges("main").Return(false, nil)
 	gitc.EXPECT().HasRemoteChanges("saturn-bot--unittest").Return(true, nil)
+	gitc.EXPECT().ChangedFileStats("main").Return(nil, nil)
 	gitc.EXPECT().Push("saturn-bot--unittest", true).Return(nil)
 	tw := &task.Task{Task: schema.Task{CommitMessage: "commit test", Name: "unittest", ChangeLimit: 1}}
 	tw.AddPreCloneFilters(&trueFilter{})
@@ -86,7 +94,7 @@ func TestProcessor_Process_CreatePullRequestLocalChanges(t *testing.T) {
 		Git:              gitc,
 		PullRequestCache: prCache,
 	}
-	results := p.Process(false, repo, []*task.Task{tw}, true)
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
 
 	assert.Len(t, results, 1)
 	assert.Equal(t, processor.ResultPrCreated, results[0].Result)
@@ -95,6 +103,22 @@ func TestProcessor_Process_CreatePullRequestLocalChanges(t *testing.T) {
 	assert.NoError(t, results[0].Error)
 }
 
+func TestProcessor_Process_Cancelled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := setupRepoMock(ctrl)
+	// Expects no calls because the context has been cancelled.
+	gitc := gitmock.NewMockGitClient(ctrl)
+	tw := &task.Task{Task: schema.Task{Name: "unittest"}}
+	tw.AddPreCloneFilters(&trueFilter{})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	p := &processor.Processor{Git: gitc}
+	results := p.Process(ctx, false, repo, []*task.Task{tw}, true)
+
+	assert.Empty(t, results)
+}
+
 func TestProcessor_Process_CreatePullRequestRemoteChanges(t *testing.T) {
 	tempDir := t.TempDir()
 	ctrl := gomock.NewController(t)
@@ -113,6 +137,7 @@ func TestProcessor_Process_CreatePullRequestRemoteChanges(t *testing.T) {
 	gitc.EXPECT().CommitChanges("commit test").Return(nil)
 	gitc.EXPECT().HasRemoteChanges("main").Return(true, nil)
 	gitc.EXPECT().HasRemoteChanges("saturn-bot--unittest").Return(false, nil)
+	gitc.EXPECT().ChangedFileStats("main").Return(nil, nil)
 	prCache := setupPullRequestCache(ctrl)
 	prCache.EXPECT().Get("saturn-bot--unittest", "git.local/unit/test")
 	prCache.EXPECT().Set("saturn-bot--unittest", "git.local/unit/test", prCreate)
@@ -123,7 +148,7 @@ func TestProcessor_Process_CreatePullRequestRemoteChanges(t *testing.T) {
 		Git:              gitc,
 		PullRequestCache: prCache,
 	}
-	results := p.Process(false, repo, []*task.Task{tw}, true)
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
 
 	assert.Len(t, results, 1)
 	assert.NoError(t, results[0].Error)
@@ -154,6 +179,7 @@ func TestProcessor_Process_CreatePullRequestPreviouslyClosed(t *testing.T) {
 	gitc.EXPECT().HasLocalChanges().Return(false, nil)
 	gitc.EXPECT().HasRemoteChanges("main").Return(true, nil)
 	gitc.EXPECT().HasRemoteChanges("saturn-bot--unittest").Return(false, nil)
+	gitc.EXPECT().ChangedFileStats("main").Return(nil, nil)
 	tw := &task.Task{Task: schema.Task{CommitMessage: "commit test", Name: "unittest"}}
 	tw.AddPreCloneFilters(&trueFilter{})
 	prCache := setupPullRequestCache(ctrl)
@@ -164,7 +190,7 @@ func TestProcessor_Process_CreatePullRequestPreviouslyClosed(t *testing.T) {
 		Git:              gitc,
 		PullRequestCache: prCache,
 	}
-	results := p.Process(false, repo, []*task.Task{tw}, true)
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
 
 	assert.Len(t, results, 1)
 	assert.NoError(t, results[0].Error)
@@ -183,7 +209,7 @@ func TestProcessor_Process_PullRequestClosedAndMergeOnceActive(t *testing.T) {
 	tw.AddPreCloneFilters(&trueFilter{})
 
 	p := &processor.Processor{Git: gitc}
-	results := p.Process(false, repo, []*task.Task{tw}, true)
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
 
 	assert.Len(t, results, 1)
 	assert.NoError(t, results[0].Error)
@@ -202,7 +228,7 @@ func TestProcessor_Process_PullRequestMergedAndMergeOnceActive(t *testing.T) {
 	tw.AddPreCloneFilters(&trueFilter{})
 
 	p := &processor.Processor{Git: gitc}
-	results := p.Process(false, repo, []*task.Task{tw}, true)
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
 
 	assert.Len(t, results, 1)
 	assert.NoError(t, results[0].Error)
@@ -221,7 +247,7 @@ func TestProcessor_Process_CreateOnly(t *testing.T) {
 	tw.AddPreCloneFilters(&trueFilter{})
 
 	p := &processor.Processor{Git: gitc}
-	results := p.Process(false, repo, []*task.Task{tw}, true)
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
 
 	assert.Len(t, results, 1)
 	assert.NoError(t, results[0].Error)
@@ -254,7 +280,7 @@ func TestProcessor_Process_ClosePullRequestIfChangesExistInBaseBranch(t *testing
 	tw.AddPreCloneFilters(&trueFilter{})
 
 	p := &processor.Processor{Git: gitc}
-	results := p.Process(false, repo, []*task.Task{tw}, true)
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
 
 	assert.Len(t, results, 1)
 	assert.NoError(t, results[0].Error)
@@ -282,18 +308,19 @@ func TestProcessor_Process_MergePullRequest(t *testing.T) {
 	repo.EXPECT().BaseBranch().Return("main")
 	repo.EXPECT().HasSuccessfulPullRequestBuild(prID).Return(true, nil)
 	repo.EXPECT().CanMergePullRequest(prID).Return(true, nil)
-	repo.EXPECT().MergePullRequest(true, prID).Return(nil)
+	repo.EXPECT().MergePullRequest(host.MergeOptions{DeleteBranch: true}, prID).Return(nil)
 	gitc := gitmock.NewMockGitClient(ctrl)
 	gitc.EXPECT().Prepare(repo, false).Return(tempDir, nil)
 	gitc.EXPECT().UpdateTaskBranch("saturn-bot--unittest", false, repo)
 	gitc.EXPECT().HasLocalChanges().Return(false, nil)
 	gitc.EXPECT().HasRemoteChanges("main").Return(true, nil)
 	gitc.EXPECT().HasRemoteChanges("saturn-bot--unittest").Return(false, nil)
+	gitc.EXPECT().ChangedFileStats("main").Return(nil, nil)
 	tw := &task.Task{Task: schema.Task{AutoMerge: true, Name: "unittest"}}
 	tw.AddPreCloneFilters(&trueFilter{})
 
 	p := &processor.Processor{Git: gitc}
-	results := p.Process(false, repo, []*task.Task{tw}, true)
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
 
 	assert.Len(t, results, 1)
 	assert.NoError(t, results[0].Error)
@@ -301,6 +328,331 @@ func TestProcessor_Process_MergePullRequest(t *testing.T) {
 	assert.Equal(t, prID, results[0].PullRequest)
 }
 
+func TestProcessor_Process_MergePullRequest_MergeMethod(t *testing.T) {
+	tempDir := t.TempDir()
+	prID := &host.PullRequest{
+		CreatedAt: time.Now().AddDate(0, 0, -1),
+		Number:    579,
+		State:     host.PullRequestStateOpen,
+	}
+	ctrl := gomock.NewController(t)
+	repo := setupRepoMock(ctrl)
+	repo.EXPECT().FindPullRequest("saturn-bot--unittest").Return(prID, nil)
+	repo.EXPECT().GetPullRequestBody(prID).Return("")
+	repo.EXPECT().BaseBranch().Return("main")
+	repo.EXPECT().HasSuccessfulPullRequestBuild(prID).Return(true, nil)
+	repo.EXPECT().CanMergePullRequest(prID).Return(true, nil)
+	repo.EXPECT().MergePullRequest(host.MergeOptions{Method: "squash"}, prID).Return(nil)
+	gitc := gitmock.NewMockGitClient(ctrl)
+	gitc.EXPECT().Prepare(repo, false).Return(tempDir, nil)
+	gitc.EXPECT().UpdateTaskBranch("saturn-bot--unittest", false, repo)
+	gitc.EXPECT().HasLocalChanges().Return(false, nil)
+	gitc.EXPECT().HasRemoteChanges("main").Return(true, nil)
+	gitc.EXPECT().HasRemoteChanges("saturn-bot--unittest").Return(false, nil)
+	gitc.EXPECT().ChangedFileStats("main").Return(nil, nil)
+	tw := &task.Task{Task: schema.Task{AutoMerge: true, KeepBranchAfterMerge: true, MergeMethod: schema.TaskMergeMethodSquash, Name: "unittest"}}
+	tw.AddPreCloneFilters(&trueFilter{})
+
+	p := &processor.Processor{Git: gitc}
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
+
+	assert.Len(t, results, 1)
+	assert.NoError(t, results[0].Error)
+	assert.Equal(t, processor.ResultPrMerged, results[0].Result)
+}
+
+func TestProcessor_Process_AutoMergeNative_EnableOnCreate(t *testing.T) {
+	tempDir := t.TempDir()
+	ctrl := gomock.NewController(t)
+	repo := setupRepoMock(ctrl)
+	repo.EXPECT().FindPullRequest("saturn-bot--unittest").Return(nil, nil)
+	repo.EXPECT().BaseBranch().Return("main")
+	prCreate := &host.PullRequest{Number: 1, State: host.PullRequestStateOpen}
+	repo.EXPECT().
+		CreatePullRequest("saturn-bot--unittest", gomock.Any()).
+		DoAndReturn(func(_ string, data host.PullRequestData) (*host.PullRequest, error) {
+			assert.True(t, data.AutoMergeNative)
+			return prCreate, nil
+		})
+	repo.EXPECT().EnableAutoMerge(host.MergeOptions{DeleteBranch: true, Method: "rebase"}, prCreate).Return(nil)
+	gitc := gitmock.NewMockGitClient(ctrl)
+	gitc.EXPECT().Prepare(repo, false).Return(tempDir, nil)
+	gitc.EXPECT().UpdateTaskBranch("saturn-bot--unittest", false, repo)
+	gitc.EXPECT().HasLocalChanges().Return(true, nil)
+	gitc.EXPECT().CommitChanges("commit test").Return(nil)
+	gitc.EXPECT().HasRemoteChanges("main").Return(true, nil)
+	gitc.EXPECT().HasRemoteChanges("saturn-bot--unittest").Return(true, nil)
+	gitc.EXPECT().ChangedFileStats("main").Return(nil, nil)
+	gitc.EXPECT().Push("saturn-bot--unittest", true).Return(nil)
+	tw := &task.Task{Task: schema.Task{AutoMerge: true, AutoMergeNative: true, CommitMessage: "commit test", MergeMethod: schema.TaskMergeMethodRebase, Name: "unittest"}}
+	tw.AddPreCloneFilters(&trueFilter{})
+
+	p := &processor.Processor{Git: gitc}
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
+
+	assert.Len(t, results, 1)
+	assert.NoError(t, results[0].Error)
+	assert.Equal(t, processor.ResultPrCreated, results[0].Result)
+}
+
+func TestProcessor_Process_AutoMergeNative_MergePending(t *testing.T) {
+	testCases := []struct {
+		name             string
+		autoMergeEnabled bool
+	}{
+		{name: "enables auto-merge if the host has not enabled it", autoMergeEnabled: false},
+		{name: "does not enable auto-merge again", autoMergeEnabled: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			prID := &host.PullRequest{
+				AutoMergeEnabled: tc.autoMergeEnabled,
+				CreatedAt:        time.Now().AddDate(0, 0, -1),
+				Number:           579,
+				State:            host.PullRequestStateOpen,
+			}
+			ctrl := gomock.NewController(t)
+			repo := setupRepoMock(ctrl)
+			repo.EXPECT().FindPullRequest("saturn-bot--unittest").Return(prID, nil)
+			repo.EXPECT().GetPullRequestBody(prID).Return("")
+			repo.EXPECT().BaseBranch().Return("main")
+			if !tc.autoMergeEnabled {
+				repo.EXPECT().EnableAutoMerge(host.MergeOptions{DeleteBranch: true}, prID).Return(nil)
+			}
+			repo.EXPECT().UpdatePullRequest(gomock.Any(), prID).Return(nil)
+
+			gitc := gitmock.NewMockGitClient(ctrl)
+			gitc.EXPECT().Prepare(repo, false).Return(tempDir, nil)
+			gitc.EXPECT().UpdateTaskBranch("saturn-bot--unittest", false, repo)
+			gitc.EXPECT().HasLocalChanges().Return(false, nil)
+			gitc.EXPECT().HasRemoteChanges("main").Return(true, nil)
+			gitc.EXPECT().HasRemoteChanges("saturn-bot--unittest").Return(false, nil)
+			gitc.EXPECT().ChangedFileStats("main").Return(nil, nil)
+			tw := &task.Task{Task: schema.Task{AutoMerge: true, AutoMergeNative: true, Name: "unittest"}}
+			tw.AddPreCloneFilters(&trueFilter{})
+
+			p := &processor.Processor{Git: gitc}
+			results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
+
+			assert.Len(t, results, 1)
+			assert.NoError(t, results[0].Error)
+			assert.Equal(t, processor.ResultMergePending, results[0].Result)
+			assert.Equal(t, prID, results[0].PullRequest)
+		})
+	}
+}
+
+func TestProcessor_Process_AutoMergeNative_MergedByHost(t *testing.T) {
+	prID := &host.PullRequest{AutoMergeEnabled: true, State: host.PullRequestStateMerged}
+	ctrl := gomock.NewController(t)
+	repo := setupRepoMock(ctrl)
+	repo.EXPECT().FindPullRequest("saturn-bot--unittest").Return(prID, nil)
+	gitc := gitmock.NewMockGitClient(ctrl)
+	gitc.EXPECT().Prepare(repo, false).Return("/tmp", nil)
+	tw := &task.Task{Task: schema.Task{AutoMerge: true, AutoMergeNative: true, MergeOnce: true, Name: "unittest"}}
+	tw.AddPreCloneFilters(&trueFilter{})
+
+	p := &processor.Processor{Git: gitc}
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
+
+	assert.Len(t, results, 1)
+	assert.NoError(t, results[0].Error)
+	assert.Equal(t, processor.ResultPrMergedBefore, results[0].Result)
+	assert.False(t, results[0].PullRequest.AutoMergeEnabled, "Resets flag to not send the merged event again")
+}
+
+func TestProcessor_Process_Draft_Create(t *testing.T) {
+	tempDir := t.TempDir()
+	ctrl := gomock.NewController(t)
+	repo := setupRepoMock(ctrl)
+	repo.EXPECT().FindPullRequest("saturn-bot--unittest").Return(nil, nil)
+	repo.EXPECT().GetPullRequestBody(nil).Return("").AnyTimes()
+	repo.EXPECT().BaseBranch().Return("main")
+	prCreate := &host.PullRequest{Draft: true, Number: 1, State: host.PullRequestStateOpen}
+	isDraftWithoutUsers := func(data host.PullRequestData) bool {
+		return data.Draft && data.Assignees == nil && data.Reviewers == nil
+	}
+	repo.EXPECT().
+		CreatePullRequest("saturn-bot--unittest", gomock.Cond(isDraftWithoutUsers)).
+		Return(prCreate, nil)
+	gitc := gitmock.NewMockGitClient(ctrl)
+	gitc.EXPECT().Prepare(repo, false).Return(tempDir, nil)
+	gitc.EXPECT().UpdateTaskBranch("saturn-bot--unittest", false, repo)
+	gitc.EXPECT().HasLocalChanges().Return(true, nil)
+	gitc.EXPECT().CommitChanges("commit test").Return(nil)
+	gitc.EXPECT().HasRemoteChanges("main").Return(true, nil)
+	gitc.EXPECT().HasRemoteChanges("saturn-bot--unittest").Return(true, nil)
+	gitc.EXPECT().ChangedFileStats("main").Return(nil, nil)
+	gitc.EXPECT().Push("saturn-bot--unittest", true).Return(nil)
+	tw := &task.Task{Task: schema.Task{
+		Assignees:             []string{"ellie"},
+		AutoMerge:             true,
+		AutoMergeNative:       true,
+		CommitMessage:         "commit test",
+		Draft:                 true,
+		Name:                  "unittest",
+		PromoteDraftWhenGreen: true,
+		Reviewers:             []string{"joel"},
+	}}
+	tw.AddPreCloneFilters(&trueFilter{})
+
+	p := &processor.Processor{Git: gitc}
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
+
+	assert.Len(t, results, 1)
+	assert.NoError(t, results[0].Error)
+	assert.Equal(t, processor.ResultPrCreated, results[0].Result)
+	assert.Equal(t, prCreate, results[0].PullRequest)
+}
+
+func TestProcessor_Process_Draft_PromoteWhenGreen(t *testing.T) {
+	testCases := []struct {
+		name          string
+		success       bool
+		wantAssignees []string
+		wantReviewers []string
+	}{
+		{name: "marks the pull request as ready and adds users if checks have passed", success: true, wantAssignees: []string{"ellie"}, wantReviewers: []string{"joel"}},
+		{name: "keeps the draft without users if checks have not passed", success: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			prID := &host.PullRequest{
+				CreatedAt: time.Now().AddDate(0, 0, -1),
+				Draft:     true,
+				Number:    579,
+				State:     host.PullRequestStateOpen,
+			}
+			ctrl := gomock.NewController(t)
+			repo := setupRepoMock(ctrl)
+			repo.EXPECT().FindPullRequest("saturn-bot--unittest").Return(prID, nil)
+			repo.EXPECT().GetPullRequestBody(prID).Return("")
+			repo.EXPECT().BaseBranch().Return("main")
+			repo.EXPECT().HasSuccessfulPullRequestBuild(prID).Return(tc.success, nil)
+			if tc.success {
+				repo.EXPECT().MarkPullRequestReady(prID).DoAndReturn(func(pr *host.PullRequest) error {
+					pr.Draft = false
+					return nil
+				})
+			}
+
+			hasUsers := func(data host.PullRequestData) bool {
+				return assert.ObjectsAreEqual(tc.wantAssignees, data.Assignees) && assert.ObjectsAreEqual(tc.wantReviewers, data.Reviewers)
+			}
+			repo.EXPECT().UpdatePullRequest(gomock.Cond(hasUsers), prID).Return(nil)
+
+			gitc := gitmock.NewMockGitClient(ctrl)
+			gitc.EXPECT().Prepare(repo, false).Return(tempDir, nil)
+			gitc.EXPECT().UpdateTaskBranch("saturn-bot--unittest", false, repo)
+			gitc.EXPECT().HasLocalChanges().Return(false, nil)
+			gitc.EXPECT().HasRemoteChanges("main").Return(true, nil)
+			gitc.EXPECT().HasRemoteChanges("saturn-bot--unittest").Return(false, nil)
+			gitc.EXPECT().ChangedFileStats("main").Return(nil, nil)
+			tw := &task.Task{Task: schema.Task{
+				Assignees:             []string{"ellie"},
+				AutoMerge:             true,
+				Draft:                 true,
+				Name:                  "unittest",
+				PromoteDraftWhenGreen: true,
+				Reviewers:             []string{"joel"},
+			}}
+			tw.AddPreCloneFilters(&trueFilter{})
+
+			p := &processor.Processor{Git: gitc}
+			results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
+
+			assert.Len(t, results, 1)
+			assert.NoError(t, results[0].Error)
+			assert.Equal(t, processor.ResultPrOpen, results[0].Result)
+			assert.Equal(t, prID, results[0].PullRequest)
+		})
+	}
+}
+
+func TestProcessor_Process_CodeOwners(t *testing.T) {
+	testCases := []struct {
+		name          string
+		codeOwners    string
+		changedFiles  []string
+		maxReviewers  int
+		wantReviewers []string
+	}{
+		{
+			name:          "requests review from owners of changed files",
+			codeOwners:    "* @ellie\n/docs/ @joel\n*.js @org/frontend\n",
+			changedFiles:  []string{"docs/index.md", "web/app.js"},
+			wantReviewers: []string{"joel", "org/frontend", "tommy"},
+		},
+		{
+			name:          "limits number of code owners",
+			codeOwners:    "/docs/ @joel\n*.md @abby\n/docs/guides/ @dina\n",
+			changedFiles:  []string{"docs/index.md", "docs/setup.md", "docs/guides/start.md"},
+			maxReviewers:  1,
+			wantReviewers: []string{"abby", "tommy"},
+		},
+		{
+			name:          "requests review from fallback if no owner matches",
+			codeOwners:    "/docs/ @joel\n",
+			changedFiles:  []string{"main.go"},
+			wantReviewers: []string{"riley", "tommy"},
+		},
+		{
+			name:          "requests review from fallback if CODEOWNERS does not exist",
+			wantReviewers: []string{"riley", "tommy"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			ctrl := gomock.NewController(t)
+			repo := setupRepoMock(ctrl)
+			repo.EXPECT().FindPullRequest("saturn-bot--unittest").Return(nil, nil)
+			repo.EXPECT().GetPullRequestBody(nil).Return("").AnyTimes()
+			repo.EXPECT().BaseBranch().Return("main").AnyTimes()
+			prCreate := &host.PullRequest{Number: 1, State: host.PullRequestStateOpen}
+			hasReviewers := func(data host.PullRequestData) bool {
+				return assert.ObjectsAreEqual(tc.wantReviewers, data.Reviewers)
+			}
+			repo.EXPECT().
+				CreatePullRequest("saturn-bot--unittest", gomock.Cond(hasReviewers)).
+				Return(prCreate, nil)
+			gitc := gitmock.NewMockGitClient(ctrl)
+			gitc.EXPECT().Prepare(repo, false).Return(tempDir, nil)
+			gitc.EXPECT().UpdateTaskBranch("saturn-bot--unittest", false, repo)
+			gitc.EXPECT().HasLocalChanges().Return(false, nil)
+			gitc.EXPECT().HasRemoteChanges("main").Return(true, nil)
+			gitc.EXPECT().HasRemoteChanges("saturn-bot--unittest").Return(true, nil)
+			gitc.EXPECT().ChangedFileStats("main").Return(nil, nil)
+			if tc.codeOwners != "" {
+				require.NoError(t, os.WriteFile(filepath.Join(tempDir, "CODEOWNERS"), []byte(tc.codeOwners), 0600))
+				gitc.EXPECT().ChangedFiles("main").Return(tc.changedFiles, nil)
+			}
+
+			tw := &task.Task{Task: schema.Task{
+				CodeOwners: &schema.TaskCodeOwners{
+					Fallback:     []string{"riley"},
+					MaxReviewers: tc.maxReviewers,
+				},
+				Name:      "unittest",
+				Reviewers: []string{"tommy"},
+			}}
+			tw.AddPreCloneFilters(&trueFilter{})
+
+			p := &processor.Processor{Git: gitc}
+			results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
+
+			assert.Len(t, results, 1)
+			assert.NoError(t, results[0].Error)
+			assert.Equal(t, processor.ResultPrCreated, results[0].Result)
+		})
+	}
+}
+
 func TestProcessor_Process_MergePullRequest_FailedMergeChecks(t *testing.T) {
 	tempDir, err := os.MkdirTemp("", "")
 	require.NoError(t, err)
@@ -321,11 +673,12 @@ func TestProcessor_Process_MergePullRequest_FailedMergeChecks(t *testing.T) {
 	gitc.EXPECT().HasLocalChanges().Return(false, nil)
 	gitc.EXPECT().HasRemoteChanges("main").Return(true, nil)
 	gitc.EXPECT().HasRemoteChanges("saturn-bot--unittest").Return(false, nil)
+	gitc.EXPECT().ChangedFileStats("main").Return(nil, nil)
 	tw := &task.Task{Task: schema.Task{AutoMerge: true, Name: "unittest"}}
 	tw.AddPreCloneFilters(&trueFilter{})
 
 	p := &processor.Processor{Git: gitc}
-	results := p.Process(false, repo, []*task.Task{tw}, true)
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
 
 	assert.Len(t, results, 1)
 	assert.NoError(t, results[0].Error)
@@ -356,6 +709,7 @@ func TestProcessor_Process_MergePullRequest_AutoMergeAfter(t *testing.T) {
 	gitc.EXPECT().HasLocalChanges().Return(false, nil)
 	gitc.EXPECT().HasRemoteChanges("main").Return(true, nil)
 	gitc.EXPECT().HasRemoteChanges("saturn-bot--unittest").Return(false, nil)
+	gitc.EXPECT().ChangedFileStats("main").Return(nil, nil)
 	tw := &task.Task{Task: schema.Task{
 		AutoMerge:      true,
 		AutoMergeAfter: "48h",
@@ -364,7 +718,7 @@ func TestProcessor_Process_MergePullRequest_AutoMergeAfter(t *testing.T) {
 	tw.AddPreCloneFilters(&trueFilter{})
 
 	p := &processor.Processor{Git: gitc}
-	results := p.Process(false, repo, []*task.Task{tw}, true)
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
 
 	assert.Len(t, results, 1)
 	assert.NoError(t, results[0].Error)
@@ -396,6 +750,7 @@ func TestProcessor_Process_MergePullRequest_MergeConflict(t *testing.T) {
 	gitc.EXPECT().HasLocalChanges().Return(false, nil)
 	gitc.EXPECT().HasRemoteChanges("main").Return(true, nil)
 	gitc.EXPECT().HasRemoteChanges("saturn-bot--unittest").Return(false, nil)
+	gitc.EXPECT().ChangedFileStats("main").Return(nil, nil)
 	tw := &task.Task{Task: schema.Task{
 		AutoMerge: true,
 		Name:      "unittest",
@@ -403,7 +758,7 @@ func TestProcessor_Process_MergePullRequest_MergeConflict(t *testing.T) {
 	tw.AddPreCloneFilters(&trueFilter{})
 
 	p := &processor.Processor{Git: gitc}
-	results := p.Process(false, repo, []*task.Task{tw}, true)
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
 
 	assert.Len(t, results, 1)
 	assert.NoError(t, results[0].Error)
@@ -453,6 +808,7 @@ func TestProcessor_Process_UpdatePullRequest(t *testing.T) {
 	gitc.EXPECT().Push("saturn-bot--unittest", true).Return(nil)
 	gitc.EXPECT().HasRemoteChanges("main").Return(true, nil)
 	gitc.EXPECT().HasRemoteChanges("saturn-bot--unittest").Return(true, nil)
+	gitc.EXPECT().ChangedFileStats("main").Return(nil, nil)
 	tw := &task.Task{Task: schema.Task{
 		Name: "unittest",
 		Inputs: []schema.Input{
@@ -467,7 +823,7 @@ func TestProcessor_Process_UpdatePullRequest(t *testing.T) {
 	require.NoError(t, err)
 
 	p := &processor.Processor{Git: gitc}
-	results := p.Process(false, repo, []*task.Task{tw}, true)
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
 
 	assert.Len(t, results, 1)
 	assert.NoError(t, results[0].Error)
@@ -489,11 +845,12 @@ func TestProcessor_Process_NoChanges(t *testing.T) {
 	gitc.EXPECT().HasLocalChanges().Return(false, nil)
 	gitc.EXPECT().HasRemoteChanges("main").Return(false, nil)
 	gitc.EXPECT().HasRemoteChanges("saturn-bot--unittest").Return(false, nil)
+	gitc.EXPECT().ChangedFileStats("main").Return(nil, nil)
 	tw := &task.Task{Task: schema.Task{Name: "unittest"}}
 	tw.AddPreCloneFilters(&trueFilter{})
 
 	p := &processor.Processor{Git: gitc}
-	results := p.Process(false, repo, []*task.Task{tw}, true)
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
 
 	assert.Len(t, results, 1)
 	assert.NoError(t, results[0].Error)
@@ -543,7 +900,7 @@ The commit(s) that modified the pull request:
 	tw.AddPreCloneFilters(&trueFilter{})
 
 	p := &processor.Processor{Git: gitc}
-	results := p.Process(false, repo, []*task.Task{tw}, true)
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
 
 	assert.Len(t, results, 1)
 	assert.NoError(t, results[0].Error)
@@ -578,11 +935,12 @@ func TestProcessor_Process_ForceRebaseByUser(t *testing.T) {
 	gitc.EXPECT().CommitChanges("").Return(nil)
 	gitc.EXPECT().HasRemoteChanges("main").Return(true, nil)
 	gitc.EXPECT().HasRemoteChanges("saturn-bot--unittest").Return(false, nil)
+	gitc.EXPECT().ChangedFileStats("main").Return(nil, nil)
 	tw := &task.Task{Task: schema.Task{Name: "unittest"}}
 	tw.AddPreCloneFilters(&trueFilter{})
 
 	p := &processor.Processor{Git: gitc}
-	results := p.Process(false, repo, []*task.Task{tw}, true)
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
 
 	assert.Len(t, results, 1)
 	assert.NoError(t, results[0].Error)
@@ -598,7 +956,7 @@ func TestProcessor_Process_ChangeLimit(t *testing.T) {
 	tw.IncChangeLimitCount()
 
 	p := &processor.Processor{Git: gitc}
-	results := p.Process(false, repo, []*task.Task{tw}, true)
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
 
 	assert.Len(t, results, 1)
 	assert.NoError(t, results[0].Error)
@@ -614,7 +972,7 @@ func TestProcessor_Process_MaxOpenPRs(t *testing.T) {
 	tw.IncOpenPRsCount()
 
 	p := &processor.Processor{Git: gitc}
-	results := p.Process(false, repo, []*task.Task{tw}, true)
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
 
 	assert.Len(t, results, 1)
 	assert.NoError(t, results[0].Error)
@@ -630,7 +988,7 @@ func TestProcessor_Process_FilterNotMatching(t *testing.T) {
 	tw.AddPreCloneFilters(&falseFilter{})
 
 	p := &processor.Processor{Git: gitc}
-	results := p.Process(false, repo, []*task.Task{tw}, true)
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
 
 	assert.Len(t, results, 1)
 	assert.NoError(t, results[0].Error)
@@ -645,7 +1003,7 @@ func TestProcessor_Process_NoFilters(t *testing.T) {
 	tw := &task.Task{Task: schema.Task{Name: "unittest"}}
 
 	p := &processor.Processor{Git: gitc}
-	results := p.Process(false, repo, []*task.Task{tw}, true)
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
 
 	assert.Len(t, results, 1)
 	assert.NoError(t, results[0].Error)
@@ -669,7 +1027,7 @@ func TestProcessor_Process_AutoCloseAfter_Close(t *testing.T) {
 	tw.AddPreCloneFilters(&trueFilter{})
 
 	p := &processor.Processor{Git: gitc}
-	results := p.Process(false, repo, []*task.Task{tw}, true)
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
 
 	assert.Len(t, results, 1)
 	assert.NoError(t, results[0].Error)
@@ -694,11 +1052,12 @@ func TestProcessor_Process_AutoCloseAfter_NotTimeYet(t *testing.T) {
 	gitc.EXPECT().HasLocalChanges().Return(false, nil)
 	gitc.EXPECT().HasRemoteChanges("main").Return(true, nil)
 	gitc.EXPECT().HasRemoteChanges("saturn-bot--unittest").Return(false, nil)
+	gitc.EXPECT().ChangedFileStats("main").Return(nil, nil)
 	tw := &task.Task{Task: schema.Task{AutoCloseAfter: 86_400, Name: "unittest"}}
 	tw.AddPreCloneFilters(&trueFilter{})
 
 	p := &processor.Processor{Git: gitc}
-	results := p.Process(false, repo, []*task.Task{tw}, true)
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
 
 	assert.Len(t, results, 1)
 	assert.NoError(t, results[0].Error)
@@ -706,6 +1065,157 @@ func TestProcessor_Process_AutoCloseAfter_NotTimeYet(t *testing.T) {
 	assert.Equal(t, prID, results[0].PullRequest)
 }
 
+func TestProcessor_Process_StalePolicy_Nudge(t *testing.T) {
+	prID := &host.PullRequest{
+		CreatedAt: time.Now().Add(-2 * time.Hour),
+		State:     host.PullRequestStateOpen,
+	}
+	ctrl := gomock.NewController(t)
+	repo := setupRepoMock(ctrl)
+	repo.EXPECT().FindPullRequest("saturn-bot--unittest").Return(prID, nil)
+	repo.EXPECT().GetPullRequestBody(prID).Return("").AnyTimes()
+	repo.EXPECT().BaseBranch().Return("main").AnyTimes()
+	repo.EXPECT().UpdatePullRequest(gomock.Any(), prID).Return(nil)
+	repo.EXPECT().ListPullRequestComments(prID).Return(nil, nil).Times(2)
+	body := "<!-- saturn-bot::{stale-nudge} -->\n:wave: This pull request has been open for more than 1h0m0s. @alice @org/team please take a look."
+	repo.EXPECT().CreatePullRequestComment(body, prID).Return(nil)
+	gitc := gitmock.NewMockGitClient(ctrl)
+	gitc.EXPECT().Prepare(repo, false).Return("/tmp", nil)
+	gitc.EXPECT().UpdateTaskBranch("saturn-bot--unittest", false, repo)
+	gitc.EXPECT().HasLocalChanges().Return(false, nil)
+	gitc.EXPECT().HasRemoteChanges("main").Return(true, nil)
+	gitc.EXPECT().HasRemoteChanges("saturn-bot--unittest").Return(false, nil)
+	gitc.EXPECT().ChangedFileStats("main").Return(nil, nil)
+	tw := &task.Task{Task: schema.Task{
+		Name:      "unittest",
+		Reviewers: []string{"alice", "org/team"},
+		StalePolicy: &schema.TaskStalePolicy{
+			NudgeAfter:           ptr.To("1h"),
+			RerequestReviewAfter: ptr.To("1h"),
+		},
+	}}
+	tw.AddPreCloneFilters(&trueFilter{})
+
+	p := &processor.Processor{Git: gitc}
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
+
+	assert.Len(t, results, 1)
+	assert.NoError(t, results[0].Error)
+	assert.Equal(t, processor.ResultPrNudged, results[0].Result)
+}
+
+func TestProcessor_Process_StalePolicy_RerequestReview(t *testing.T) {
+	prID := &host.PullRequest{
+		CreatedAt: time.Now().Add(-2 * time.Hour),
+		State:     host.PullRequestStateOpen,
+	}
+	comments := []host.PullRequestComment{
+		{Body: "<!-- saturn-bot::{stale-nudge} -->\nnudged", ID: 1},
+	}
+	ctrl := gomock.NewController(t)
+	repo := setupRepoMock(ctrl)
+	repo.EXPECT().FindPullRequest("saturn-bot--unittest").Return(prID, nil)
+	repo.EXPECT().GetPullRequestBody(prID).Return("").AnyTimes()
+	repo.EXPECT().BaseBranch().Return("main").AnyTimes()
+	repo.EXPECT().UpdatePullRequest(gomock.Any(), prID).Return(nil)
+	repo.EXPECT().ListPullRequestComments(prID).Return(comments, nil).Times(3)
+	repo.EXPECT().RequestPullRequestReview([]string{"alice"}, prID).Return(nil)
+	repo.EXPECT().CreatePullRequestComment(gomock.Any(), prID).Return(nil)
+	gitc := gitmock.NewMockGitClient(ctrl)
+	gitc.EXPECT().Prepare(repo, false).Return("/tmp", nil)
+	gitc.EXPECT().UpdateTaskBranch("saturn-bot--unittest", false, repo)
+	gitc.EXPECT().HasLocalChanges().Return(false, nil)
+	gitc.EXPECT().HasRemoteChanges("main").Return(true, nil)
+	gitc.EXPECT().HasRemoteChanges("saturn-bot--unittest").Return(false, nil)
+	gitc.EXPECT().ChangedFileStats("main").Return(nil, nil)
+	tw := &task.Task{Task: schema.Task{
+		Name:      "unittest",
+		Reviewers: []string{"alice"},
+		StalePolicy: &schema.TaskStalePolicy{
+			NudgeAfter:           ptr.To("1h"),
+			RerequestReviewAfter: ptr.To("1h"),
+		},
+	}}
+	tw.AddPreCloneFilters(&trueFilter{})
+
+	p := &processor.Processor{Git: gitc}
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
+
+	assert.Len(t, results, 1)
+	assert.NoError(t, results[0].Error)
+	assert.Equal(t, processor.ResultReviewRerequested, results[0].Result)
+}
+
+func TestProcessor_Process_StalePolicy_Rebase(t *testing.T) {
+	prID := &host.PullRequest{
+		CreatedAt: time.Now(),
+		State:     host.PullRequestStateOpen,
+	}
+	ctrl := gomock.NewController(t)
+	repo := setupRepoMock(ctrl)
+	repo.EXPECT().FindPullRequest("saturn-bot--unittest").Return(prID, nil)
+	repo.EXPECT().GetPullRequestBody(prID).Return("").AnyTimes()
+	repo.EXPECT().BaseBranch().Return("main").AnyTimes()
+	repo.EXPECT().UpdatePullRequest(gomock.Any(), prID).Return(nil)
+	gitc := gitmock.NewMockGitClient(ctrl)
+	gitc.EXPECT().Prepare(repo, false).Return("/tmp", nil)
+	gitc.EXPECT().UpdateTaskBranch("saturn-bot--unittest", false, repo)
+	gitc.EXPECT().CountCommitsBehind("saturn-bot--unittest", "main").Return(6, nil)
+	gitc.EXPECT().HasLocalChanges().Return(true, nil)
+	gitc.EXPECT().CommitChanges("")
+	gitc.EXPECT().HasRemoteChanges("main").Return(true, nil)
+	gitc.EXPECT().HasRemoteChanges("saturn-bot--unittest").Return(true, nil)
+	gitc.EXPECT().ChangedFileStats("main").Return(nil, nil)
+	gitc.EXPECT().Push("saturn-bot--unittest", true)
+	tw := &task.Task{Task: schema.Task{
+		Name:        "unittest",
+		StalePolicy: &schema.TaskStalePolicy{RebaseAfterCommits: 5},
+	}}
+	tw.AddPreCloneFilters(&trueFilter{})
+
+	p := &processor.Processor{Git: gitc}
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
+
+	assert.Len(t, results, 1)
+	assert.NoError(t, results[0].Error)
+	assert.Equal(t, processor.ResultPrRebased, results[0].Result)
+}
+
+func TestProcessor_Process_StalePolicy_RebaseNotDue(t *testing.T) {
+	prID := &host.PullRequest{
+		CreatedAt: time.Now(),
+		State:     host.PullRequestStateOpen,
+	}
+	ctrl := gomock.NewController(t)
+	repo := setupRepoMock(ctrl)
+	repo.EXPECT().FindPullRequest("saturn-bot--unittest").Return(prID, nil)
+	repo.EXPECT().GetPullRequestBody(prID).Return("").AnyTimes()
+	repo.EXPECT().BaseBranch().Return("main").AnyTimes()
+	repo.EXPECT().UpdatePullRequest(gomock.Any(), prID).Return(nil)
+	gitc := gitmock.NewMockGitClient(ctrl)
+	gitc.EXPECT().Prepare(repo, false).Return("/tmp", nil)
+	gitc.EXPECT().UpdateTaskBranch("saturn-bot--unittest", false, repo)
+	gitc.EXPECT().CountCommitsBehind("saturn-bot--unittest", "main").Return(5, nil)
+	gitc.EXPECT().HasLocalChanges().Return(false, nil)
+	gitc.EXPECT().HasRemoteChanges("main").Return(true, nil)
+	// The local branch differs from the remote branch because it has been rebased onto the base branch.
+	gitc.EXPECT().HasRemoteChanges("saturn-bot--unittest").Return(true, nil)
+	gitc.EXPECT().ChangedFileStats("main").Return(nil, nil)
+	// Doesn't expect a call to Push because the rebase isn't due yet.
+	tw := &task.Task{Task: schema.Task{
+		Name:        "unittest",
+		StalePolicy: &schema.TaskStalePolicy{RebaseAfterCommits: 5},
+	}}
+	tw.AddPreCloneFilters(&trueFilter{})
+
+	p := &processor.Processor{Git: gitc}
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
+
+	assert.Len(t, results, 1)
+	assert.NoError(t, results[0].Error)
+	assert.Equal(t, processor.ResultPrOpen, results[0].Result)
+}
+
 func TestProcessor_Process_EmptyRepository(t *testing.T) {
 	tempDir, err := os.MkdirTemp("", "")
 	require.NoError(t, err)
@@ -727,7 +1237,7 @@ func TestProcessor_Process_EmptyRepository(t *testing.T) {
 	tw.AddPreCloneFilters(&trueFilter{})
 
 	p := &processor.Processor{Git: gitc}
-	results := p.Process(false, repo, []*task.Task{tw}, true)
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
 
 	assert.Len(t, results, 1)
 	assert.NoError(t, results[0].Error)
@@ -751,7 +1261,7 @@ func TestProcessor_Process_CleanupOnPrepareError(t *testing.T) {
 	tw.AddPreCloneFilters(&trueFilter{})
 
 	p := &processor.Processor{Git: gitc}
-	results := p.Process(false, repo, []*task.Task{tw}, true)
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
 
 	assert.Len(t, results, 1)
 	assert.NoError(t, results[0].Error)
@@ -774,7 +1284,7 @@ func TestProcessor_Process_PushToDefaultBranch_Changes(t *testing.T) {
 	tw.AddPreCloneFilters(&trueFilter{})
 
 	p := &processor.Processor{Git: gitc}
-	results := p.Process(false, repo, []*task.Task{tw}, true)
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
 
 	assert.Len(t, results, 1)
 	assert.Equal(t, processor.ResultPushedDefaultBranch, results[0].Result)
@@ -796,7 +1306,7 @@ func TestProcessor_Process_PushToDefaultBranch_NoChanges(t *testing.T) {
 	tw.AddPreCloneFilters(&trueFilter{})
 
 	p := &processor.Processor{Git: gitc}
-	results := p.Process(false, repo, []*task.Task{tw}, true)
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
 
 	assert.Len(t, results, 1)
 	assert.Equal(t, processor.ResultNoChanges, results[0].Result)
@@ -827,7 +1337,7 @@ func TestProcessor_Process_CloseOpenPullRequestForFilteredRepository_PreClone(t
 	tt.AddPreCloneFilters(&falseFilter{})
 
 	p := &processor.Processor{Git: gitc, PullRequestCache: prCache}
-	results := p.Process(false, repo, []*task.Task{tt}, true)
+	results := p.Process(context.Background(), false, repo, []*task.Task{tt}, true)
 
 	assert.Len(t, results, 1)
 	expectedPr := &host.PullRequest{
@@ -859,7 +1369,7 @@ func TestProcessor_Process_CloseOpenPullRequestForFilteredRepository_PostClone(t
 	tt.AddPostCloneFilters(&falseFilter{})
 
 	p := &processor.Processor{Git: gitc, PullRequestCache: prCache}
-	results := p.Process(false, repo, []*task.Task{tt}, true)
+	results := p.Process(context.Background(), false, repo, []*task.Task{tt}, true)
 
 	assert.Len(t, results, 1)
 	expectedPr := &host.PullRequest{
@@ -885,7 +1395,7 @@ func TestProcessor_Process_SetResultOpenPrArchivedRepository(t *testing.T) {
 	tt.AddPreCloneFilters(&falseFilter{})
 
 	p := &processor.Processor{Git: gitc, PullRequestCache: prCache}
-	results := p.Process(false, repo, []*task.Task{tt}, true)
+	results := p.Process(context.Background(), false, repo, []*task.Task{tt}, true)
 
 	assert.Len(t, results, 1)
 	expectedPr := &host.PullRequest{
@@ -893,3 +1403,513 @@ func TestProcessor_Process_SetResultOpenPrArchivedRepository(t *testing.T) {
 	}
 	assert.Equal(t, expectedPr, results[0].PullRequest)
 }
+
+func TestProcessor_Process_TargetBranches(t *testing.T) {
+	tempDir := t.TempDir()
+	ctrl := gomock.NewController(t)
+	repo := setupRepoMock(ctrl)
+	repo.EXPECT().GetPullRequestBody(nil).Return("").AnyTimes()
+	gitc := gitmock.NewMockGitClient(ctrl)
+	gitc.EXPECT().Prepare(repo, false).Return(tempDir, nil)
+	gitc.EXPECT().RemoteBranches().Return([]string{"main", "release/1.0", "release/2.0"}, nil)
+	prCache := setupPullRequestCache(ctrl)
+	targets := []struct {
+		branchName string
+		name       string
+		pr         *host.PullRequest
+	}{
+		{branchName: "saturn-bot--unittest--release-1-0", name: "release/1.0", pr: &host.PullRequest{Number: 1, State: host.PullRequestStateOpen}},
+		{branchName: "saturn-bot--unittest--release-2-0", name: "release/2.0", pr: &host.PullRequest{Number: 2, State: host.PullRequestStateOpen}},
+	}
+	for _, tc := range targets {
+		branchName, target, pr := tc.branchName, tc.name, tc.pr
+		repo.EXPECT().FindPullRequest(branchName).Return(nil, nil)
+		repo.EXPECT().
+			CreatePullRequest(branchName, gomock.Any()).
+			DoAndReturn(func(_ string, data host.PullRequestData) (*host.PullRequest, error) {
+				assert.Equal(t, target, data.TargetBranch)
+				assert.Equal(t, target, data.TemplateData.TargetBranch)
+				return pr, nil
+			})
+		gitc.EXPECT().Execute("checkout", "-B", target, "origin/"+target).Return("", "", nil)
+		gitc.EXPECT().UpdateTaskBranch(branchName, false, gomock.Any()).
+			DoAndReturn(func(_ string, _ bool, r host.Repository) (bool, error) {
+				assert.Equal(t, target, r.BaseBranch())
+				return false, nil
+			})
+		gitc.EXPECT().HasLocalChanges().Return(true, nil)
+		gitc.EXPECT().CommitChanges("commit test").Return(nil)
+		gitc.EXPECT().HasRemoteChanges(target).Return(false, nil)
+		gitc.EXPECT().HasRemoteChanges(branchName).Return(true, nil)
+		gitc.EXPECT().ChangedFileStats(target).Return(nil, nil)
+		gitc.EXPECT().Push(branchName, true).Return(nil)
+		prCache.EXPECT().Get(branchName, "git.local/unit/test")
+		prCache.EXPECT().Set(branchName, "git.local/unit/test", pr)
+	}
+
+	tw := &task.Task{Task: schema.Task{CommitMessage: "commit test", Name: "unittest", TargetBranches: []string{"release/*"}}}
+	tw.AddPreCloneFilters(&trueFilter{})
+
+	p := &processor.Processor{
+		Git:              gitc,
+		PullRequestCache: prCache,
+	}
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
+
+	require.Len(t, results, 2)
+	for idx, tc := range targets {
+		assert.NoError(t, results[idx].Error)
+		assert.Equal(t, processor.ResultPrCreated, results[idx].Result)
+		assert.Equal(t, tc.pr, results[idx].PullRequest)
+		assert.Equal(t, tc.name, results[idx].TargetBranch)
+	}
+}
+
+func TestProcessor_Process_TargetBranches_NoMatch(t *testing.T) {
+	tempDir := t.TempDir()
+	ctrl := gomock.NewController(t)
+	repo := setupRepoMock(ctrl)
+	gitc := gitmock.NewMockGitClient(ctrl)
+	gitc.EXPECT().Prepare(repo, false).Return(tempDir, nil)
+	gitc.EXPECT().RemoteBranches().Return([]string{"main"}, nil)
+	tw := &task.Task{Task: schema.Task{Name: "unittest", TargetBranches: []string{"release/*"}}}
+	tw.AddPreCloneFilters(&trueFilter{})
+
+	p := &processor.Processor{Git: gitc}
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
+
+	require.Len(t, results, 1)
+	assert.NoError(t, results[0].Error)
+	assert.Equal(t, processor.ResultNoMatch, results[0].Result)
+}
+
+func TestProcessor_Process_TargetBranches_Template(t *testing.T) {
+	tempDir := t.TempDir()
+	ctrl := gomock.NewController(t)
+	repo := setupRepoMock(ctrl)
+	gitc := gitmock.NewMockGitClient(ctrl)
+	gitc.EXPECT().Prepare(repo, false).Return(tempDir, nil)
+	gitc.EXPECT().RemoteBranches().Return([]string{"main", "stable-test"}, nil)
+	gitc.EXPECT().Execute("checkout", "-B", "stable-test", "origin/stable-test").Return("", "", errors.New("checkout failed"))
+	tw := &task.Task{Task: schema.Task{Name: "unittest", TargetBranches: []string{"stable-{{.Repository.Name}}"}}}
+	tw.AddPreCloneFilters(&trueFilter{})
+
+	p := &processor.Processor{Git: gitc}
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
+
+	require.Len(t, results, 1)
+	assert.Equal(t, "stable-test", results[0].TargetBranch)
+	assert.EqualError(t, results[0].Error, "checkout target branch stable-test: checkout failed")
+}
+
+func TestProcessor_Process_TargetBranches_DuplicateBranchName(t *testing.T) {
+	tempDir := t.TempDir()
+	ctrl := gomock.NewController(t)
+	repo := setupRepoMock(ctrl)
+	gitc := gitmock.NewMockGitClient(ctrl)
+	gitc.EXPECT().Prepare(repo, false).Return(tempDir, nil)
+	gitc.EXPECT().RemoteBranches().Return([]string{"release/1.0", "release/2.0"}, nil)
+	tw := &task.Task{Task: schema.Task{BranchName: "backport", Name: "unittest", TargetBranches: []string{"release/*"}}}
+	tw.AddPreCloneFilters(&trueFilter{})
+
+	p := &processor.Processor{Git: gitc}
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
+
+	require.Len(t, results, 1)
+	assert.EqualError(t, results[0].Error, "find target branches: target branches release/1.0 and release/2.0 render the same branch name backport - use {{.TargetBranch}} in branchName")
+}
+
+func TestProcessor_Process_TargetBranches_Query(t *testing.T) {
+	tempDir := t.TempDir()
+	ctrl := gomock.NewController(t)
+	repo := setupRepoMock(ctrl)
+	repo.EXPECT().BaseBranch().Return("main")
+	gitc := gitmock.NewMockGitClient(ctrl)
+	gitc.EXPECT().Prepare(repo, false).Return(tempDir, nil)
+	gitc.EXPECT().RemoteBranches().Return([]string{"main", "release/1.0", "release/2.0"}, nil)
+	gitc.EXPECT().Execute("checkout", "-B", "release/2.0", "origin/release/2.0").Return("", "", errors.New("checkout failed"))
+	tw := &task.Task{Task: schema.Task{
+		Name:                "unittest",
+		TargetBranchesQuery: ptr.To(`[.branches[] | select(startswith("release/"))] | sort | last`),
+	}}
+	tw.AddPreCloneFilters(&trueFilter{})
+
+	p := &processor.Processor{Git: gitc}
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
+
+	require.Len(t, results, 1)
+	assert.Equal(t, "release/2.0", results[0].TargetBranch)
+	assert.EqualError(t, results[0].Error, "checkout target branch release/2.0: checkout failed")
+}
+
+func TestProcessor_Process_TargetBranches_CloseOpenPullRequestsForFilteredRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	prRelease1 := &host.PullRequest{BranchName: "saturn-bot--unittest--release-1-0", Number: 1, State: host.PullRequestStateOpen, TargetBranch: "release/1.0"}
+	prRelease2 := &host.PullRequest{BranchName: "saturn-bot--unittest--release-2-0", Number: 2, State: host.PullRequestStateOpen, TargetBranch: "release/2.0"}
+	prOtherTask := &host.PullRequest{BranchName: "saturn-bot--other--release-1-0", Number: 3, State: host.PullRequestStateOpen, TargetBranch: "release/1.0"}
+	prCache := setupPullRequestCache(ctrl)
+	prCache.EXPECT().ListByRepository("git.local/unit/test").Return([]*host.PullRequest{prRelease1, prOtherTask, prRelease2})
+	repo := setupRepoMock(ctrl)
+	for _, pr := range []*host.PullRequest{prRelease1, prRelease2} {
+		prCache.EXPECT().Get(pr.BranchName, "git.local/unit/test").Return(pr)
+		prCache.EXPECT().Delete(pr.BranchName, "git.local/unit/test")
+		repo.EXPECT().ClosePullRequest(closePrMessage, pr).Return(&host.PullRequest{Number: pr.Number, State: host.PullRequestStateClosed}, nil)
+	}
+
+	gitc := gitmock.NewMockGitClient(ctrl)
+	tt := &task.Task{Task: schema.Task{Name: "unittest", TargetBranches: []string{"release/*"}}}
+	tt.AddPreCloneFilters(&falseFilter{})
+
+	p := &processor.Processor{Git: gitc, PullRequestCache: prCache}
+	results := p.Process(context.Background(), false, repo, []*task.Task{tt}, true)
+
+	require.Len(t, results, 2)
+	for idx, target := range []string{"release/1.0", "release/2.0"} {
+		assert.Equal(t, processor.ResultNoMatch, results[idx].Result)
+		assert.Equal(t, target, results[idx].TargetBranch)
+		assert.Equal(t, host.PullRequestStateClosed, results[idx].PullRequest.State)
+	}
+}
+
+const rolloutTaskContent = `name: unittest
+rollout:
+  waves:
+    - name: canary
+      filters:
+        - filter: repository
+          params:
+            host: git.local
+            owner: unit
+            name: canary
+      gate:
+        minMerged: 1
+    - name: everything
+`
+
+func TestProcessor_Process_Rollout(t *testing.T) {
+	testCases := []struct {
+		name        string
+		currentWave string
+		wantResult  processor.Result
+		wantWave    *int
+	}{
+		{name: "skips the repository if its wave hasn't started", currentWave: "0", wantResult: processor.ResultSkip},
+		{name: "applies the task if its wave has started", currentWave: "1", wantResult: processor.ResultNoChanges, wantWave: ptr.To(1)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			taskPath := filepath.Join(tempDir, "task.yaml")
+			require.NoError(t, os.WriteFile(taskPath, []byte(rolloutTaskContent), 0600))
+			registry := task.NewRegistry(options.Opts{FilterFactories: filter.BuiltInFactories})
+			require.NoError(t, registry.ReadAll([]string{taskPath}))
+			tw := registry.GetTasks()[0]
+			tw.AddPreCloneFilters(&trueFilter{})
+			require.NoError(t, tw.SetInputs(map[string]string{sbcontext.RunDataKeyRolloutWave: tc.currentWave}))
+
+			ctrl := gomock.NewController(t)
+			repo := setupRepoMock(ctrl)
+			gitc := gitmock.NewMockGitClient(ctrl)
+			if tc.wantResult != processor.ResultSkip {
+				prID := &host.PullRequest{State: host.PullRequestStateMerged}
+				repo.EXPECT().FindPullRequest("saturn-bot--unittest").Return(prID, nil)
+				repo.EXPECT().GetPullRequestBody(prID).Return("")
+				repo.EXPECT().BaseBranch().Return("main")
+				gitc.EXPECT().Prepare(repo, false).Return(tempDir, nil)
+				gitc.EXPECT().UpdateTaskBranch("saturn-bot--unittest", true, repo)
+				gitc.EXPECT().HasLocalChanges().Return(false, nil)
+				gitc.EXPECT().HasRemoteChanges("main").Return(false, nil)
+				gitc.EXPECT().HasRemoteChanges("saturn-bot--unittest").Return(false, nil)
+				gitc.EXPECT().ChangedFileStats("main").Return(nil, nil)
+			}
+
+			p := &processor.Processor{Git: gitc}
+			results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
+
+			require.Len(t, results, 1)
+			assert.NoError(t, results[0].Error)
+			assert.Equal(t, tc.wantResult, results[0].Result)
+			assert.Equal(t, tc.wantWave, results[0].RolloutWave)
+		})
+	}
+}
+
+const dependencyTaskContent = `name: upstream
+---
+name: unittest
+dependsOn:
+  - task: upstream
+  - repository: git.local/unit/library
+`
+
+func TestProcessor_Process_DependsOn(t *testing.T) {
+	testCases := []struct {
+		name            string
+		upstreamPr      *host.PullRequest
+		libraryPr       *host.PullRequest
+		wantCacheLookup int
+		wantResult      processor.Result
+	}{
+		{
+			name:            "waits if the pull request of the other task doesn't exist",
+			wantCacheLookup: 1,
+			wantResult:      processor.ResultDependencyPending,
+		},
+		{
+			name:            "waits if the pull request of the other repository is open",
+			upstreamPr:      &host.PullRequest{State: host.PullRequestStateMerged},
+			libraryPr:       &host.PullRequest{State: host.PullRequestStateOpen},
+			wantCacheLookup: 2,
+			wantResult:      processor.ResultDependencyPending,
+		},
+		{
+			name:            "applies the task if all pull requests have been merged",
+			upstreamPr:      &host.PullRequest{State: host.PullRequestStateMerged},
+			libraryPr:       &host.PullRequest{State: host.PullRequestStateMerged},
+			wantCacheLookup: 2,
+			wantResult:      processor.ResultNoChanges,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tempDir := t.TempDir()
+			taskPath := filepath.Join(tempDir, "task.yaml")
+			require.NoError(t, os.WriteFile(taskPath, []byte(dependencyTaskContent), 0600))
+			registry := task.NewRegistry(options.Opts{FilterFactories: filter.BuiltInFactories})
+			require.NoError(t, registry.ReadAll([]string{taskPath}))
+			tw := registry.GetTasks()[1]
+			tw.AddPreCloneFilters(&trueFilter{})
+
+			ctrl := gomock.NewController(t)
+			prCache := setupPullRequestCache(ctrl)
+			prCache.EXPECT().Get("saturn-bot--upstream", "git.local/unit/test").Return(tc.upstreamPr)
+			if tc.wantCacheLookup > 1 {
+				prCache.EXPECT().Get("saturn-bot--unittest", "git.local/unit/library").Return(tc.libraryPr)
+			}
+
+			repo := setupRepoMock(ctrl)
+			gitc := gitmock.NewMockGitClient(ctrl)
+			if tc.wantResult != processor.ResultDependencyPending {
+				prCache.EXPECT().Get("saturn-bot--unittest", "git.local/unit/test").Return(nil)
+				repo.EXPECT().FindPullRequest("saturn-bot--unittest").Return(nil, nil)
+				repo.EXPECT().GetPullRequestBody(nil).Return("").AnyTimes()
+				repo.EXPECT().BaseBranch().Return("main")
+				gitc.EXPECT().Prepare(repo, false).Return(tempDir, nil)
+				gitc.EXPECT().UpdateTaskBranch("saturn-bot--unittest", false, repo)
+				gitc.EXPECT().HasLocalChanges().Return(false, nil)
+				gitc.EXPECT().HasRemoteChanges("main").Return(false, nil)
+				gitc.EXPECT().HasRemoteChanges("saturn-bot--unittest").Return(false, nil)
+				gitc.EXPECT().ChangedFileStats("main").Return(nil, nil)
+			}
+
+			p := &processor.Processor{Git: gitc, PullRequestCache: prCache}
+			results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
+
+			require.Len(t, results, 1)
+			assert.NoError(t, results[0].Error)
+			assert.Equal(t, tc.wantResult, results[0].Result)
+		})
+	}
+}
+
+func TestProcessor_Process_DependsOn_NoPullRequestCache(t *testing.T) {
+	tempDir := t.TempDir()
+	taskPath := filepath.Join(tempDir, "task.yaml")
+	require.NoError(t, os.WriteFile(taskPath, []byte(dependencyTaskContent), 0600))
+	registry := task.NewRegistry(options.Opts{FilterFactories: filter.BuiltInFactories})
+	require.NoError(t, registry.ReadAll([]string{taskPath}))
+	tw := registry.GetTasks()[1]
+	tw.AddPreCloneFilters(&trueFilter{})
+
+	ctrl := gomock.NewController(t)
+	repo := setupRepoMock(ctrl)
+	p := &processor.Processor{Git: gitmock.NewMockGitClient(ctrl)}
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
+
+	require.Len(t, results, 1)
+	assert.EqualError(t, results[0].Error, "check dependencies: no pull request cache")
+	assert.Equal(t, processor.ResultUnknown, results[0].Result)
+}
+
+const pullRequestBodyTaskContent = `name: unittest
+commitMessage: commit test
+prFooter: "Compliance: {{.Data.TaskFileUrl}}"
+actions:
+  - action: fileCreate
+    params:
+      content: hello
+      path: hello.txt
+  - action: fileCreate
+    params:
+      content: hello
+      path: hello.txt
+`
+
+func TestProcessor_Process_PullRequestBodyData(t *testing.T) {
+	tempDir := t.TempDir()
+	taskPath := filepath.Join(tempDir, "task.yaml")
+	require.NoError(t, os.WriteFile(taskPath, []byte(pullRequestBodyTaskContent), 0600))
+	registry := task.NewRegistry(options.Opts{ActionFactories: action.BuiltInFactories})
+	require.NoError(t, registry.ReadAll([]string{taskPath}))
+	tw := registry.GetTasks()[0]
+	tw.AddPreCloneFilters(&trueFilter{})
+
+	ctrl := gomock.NewController(t)
+	repo := setupRepoMock(ctrl)
+	repo.EXPECT().FindPullRequest("saturn-bot--unittest").Return(nil, nil)
+	repo.EXPECT().GetPullRequestBody(nil).Return("").AnyTimes()
+	repo.EXPECT().BaseBranch().Return("main")
+	prCreate := &host.PullRequest{Number: 1, State: host.PullRequestStateOpen}
+	repo.EXPECT().
+		CreatePullRequest("saturn-bot--unittest", gomock.Any()).
+		DoAndReturn(func(_ string, data host.PullRequestData) (*host.PullRequest, error) {
+			wantChanges := template.DataChanges{
+				Actions:   []string{"fileCreate(mode=644,overwrite=true,path=hello.txt)"},
+				Additions: 1,
+				Files:     []template.DataChangedFile{{Additions: 1, Path: "hello.txt"}},
+			}
+			assert.Equal(t, wantChanges, data.TemplateData.Changes)
+			assert.Equal(t, "7", data.TemplateData.RunID)
+			assert.Equal(t, "http://saturn-bot.local/ui/runs/7", data.TemplateData.RunUrl)
+			assert.Equal(t, "http://saturn-bot.local/ui/tasks/unittest/file", data.TemplateData.TaskFileUrl)
+			assert.Equal(t, "Compliance: {{.Data.TaskFileUrl}}", data.Footer)
+			return prCreate, nil
+		})
+	gitc := gitmock.NewMockGitClient(ctrl)
+	gitc.EXPECT().Prepare(repo, false).Return(tempDir, nil)
+	gitc.EXPECT().UpdateTaskBranch("saturn-bot--unittest", false, repo)
+	gomock.InOrder(
+		gitc.EXPECT().WriteTree().Return("tree-before", nil),
+		gitc.EXPECT().WriteTree().Return("tree-first-action", nil),
+		gitc.EXPECT().WriteTree().Return("tree-first-action", nil),
+	)
+	gitc.EXPECT().HasLocalChanges().Return(true, nil)
+	gitc.EXPECT().CommitChanges("commit test").Return(nil)
+	gitc.EXPECT().HasRemoteChanges("main").Return(false, nil)
+	gitc.EXPECT().HasRemoteChanges("saturn-bot--unittest").Return(true, nil)
+	gitc.EXPECT().ChangedFileStats("main").Return([]git.ChangedFile{{Additions: 1, Path: "hello.txt"}}, nil)
+	gitc.EXPECT().Push("saturn-bot--unittest", true).Return(nil)
+
+	ctx := sbcontext.WithRunData(context.Background(), map[string]string{
+		sbcontext.RunDataKeyRunID:     "7",
+		sbcontext.RunDataKeyServerUrl: "http://saturn-bot.local/",
+	})
+	p := &processor.Processor{Git: gitc}
+	results := p.Process(ctx, false, repo, []*task.Task{tw}, true)
+
+	require.Len(t, results, 1)
+	assert.NoError(t, results[0].Error)
+	assert.Equal(t, processor.ResultPrCreated, results[0].Result)
+}
+
+func TestProcessor_Process_IgnoredRepository(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := setupRepoMock(ctrl)
+	tw := &task.Task{Task: schema.Task{Name: "unittest"}}
+	tw.AddPreCloneFilters(&trueFilter{})
+	require.NoError(t, tw.SetInputs(map[string]string{sbcontext.RunDataKeyIgnoredRepositories: "git.local/unit/other,git.local/unit/test"}))
+
+	p := &processor.Processor{Git: gitmock.NewMockGitClient(ctrl)}
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, false)
+
+	require.Len(t, results, 1)
+	assert.NoError(t, results[0].Error)
+	assert.Equal(t, processor.ResultSkip, results[0].Result)
+}
+
+func TestProcessor_Process_CommandMerge(t *testing.T) {
+	tempDir := t.TempDir()
+	prID := &host.PullRequest{
+		CreatedAt: time.Now(),
+		Number:    579,
+		State:     host.PullRequestStateOpen,
+	}
+	ctrl := gomock.NewController(t)
+	repo := setupRepoMock(ctrl)
+	repo.EXPECT().FindPullRequest("saturn-bot--unittest").Return(prID, nil)
+	repo.EXPECT().GetPullRequestBody(prID).Return("")
+	repo.EXPECT().BaseBranch().Return("main")
+	repo.EXPECT().HasSuccessfulPullRequestBuild(prID).Return(true, nil)
+	repo.EXPECT().CanMergePullRequest(prID).Return(true, nil)
+	repo.EXPECT().MergePullRequest(host.MergeOptions{DeleteBranch: true}, prID).Return(nil)
+	gitc := gitmock.NewMockGitClient(ctrl)
+	gitc.EXPECT().Prepare(repo, false).Return(tempDir, nil)
+	gitc.EXPECT().UpdateTaskBranch("saturn-bot--unittest", false, repo)
+	gitc.EXPECT().HasLocalChanges().Return(false, nil)
+	gitc.EXPECT().HasRemoteChanges("main").Return(true, nil)
+	gitc.EXPECT().HasRemoteChanges("saturn-bot--unittest").Return(false, nil)
+	gitc.EXPECT().ChangedFileStats("main").Return(nil, nil)
+	// The task doesn't merge automatically and the pull request is too young to be merged.
+	tw := &task.Task{Task: schema.Task{AutoMergeAfter: "48h", Name: "unittest"}}
+	tw.AddPreCloneFilters(&trueFilter{})
+	require.NoError(t, tw.SetInputs(map[string]string{sbcontext.RunDataKeyCommand: sbcontext.CommandMerge}))
+
+	p := &processor.Processor{Git: gitc}
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
+
+	require.Len(t, results, 1)
+	assert.NoError(t, results[0].Error)
+	assert.Equal(t, processor.ResultPrMerged, results[0].Result)
+}
+
+func TestProcessor_Process_CommandRebase(t *testing.T) {
+	tempDir := t.TempDir()
+	prID := &host.PullRequest{State: host.PullRequestStateOpen}
+	ctrl := gomock.NewController(t)
+	repo := setupRepoMock(ctrl)
+	repo.EXPECT().FindPullRequest("saturn-bot--unittest").Return(prID, nil)
+	repo.EXPECT().GetPullRequestBody(prID).Return("")
+	repo.EXPECT().BaseBranch().Return("main")
+	repo.EXPECT().ListPullRequestComments(prID).Return(nil, nil)
+	repo.EXPECT().UpdatePullRequest(gomock.AssignableToTypeOf(host.PullRequestData{}), prID).Return(nil)
+	gitc := gitmock.NewMockGitClient(ctrl)
+	gitc.EXPECT().Prepare(repo, false).Return(tempDir, nil)
+	gitc.EXPECT().UpdateTaskBranch("saturn-bot--unittest", true, repo).Return(false, nil)
+	gitc.EXPECT().HasLocalChanges().Return(false, nil)
+	gitc.EXPECT().HasRemoteChanges("main").Return(true, nil)
+	gitc.EXPECT().HasRemoteChanges("saturn-bot--unittest").Return(false, nil)
+	gitc.EXPECT().ChangedFileStats("main").Return(nil, nil)
+	tw := &task.Task{Task: schema.Task{Name: "unittest"}}
+	tw.AddPreCloneFilters(&trueFilter{})
+	require.NoError(t, tw.SetInputs(map[string]string{sbcontext.RunDataKeyCommand: sbcontext.CommandRebase}))
+
+	p := &processor.Processor{Git: gitc}
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
+
+	require.Len(t, results, 1)
+	assert.NoError(t, results[0].Error)
+	assert.Equal(t, processor.ResultPrOpen, results[0].Result)
+}
+
+func TestProcessor_Process_CommandRecreate(t *testing.T) {
+	tempDir := t.TempDir()
+	prID := &host.PullRequest{State: host.PullRequestStateClosed}
+	ctrl := gomock.NewController(t)
+	repo := setupRepoMock(ctrl)
+	repo.EXPECT().FindPullRequest("saturn-bot--unittest").Return(prID, nil)
+	repo.EXPECT().BaseBranch().Return("main")
+	prCreate := &host.PullRequest{Number: 1, State: host.PullRequestStateOpen}
+	repo.EXPECT().
+		CreatePullRequest("saturn-bot--unittest", gomock.Any()).
+		Return(prCreate, nil)
+	gitc := gitmock.NewMockGitClient(ctrl)
+	gitc.EXPECT().Prepare(repo, false).Return(tempDir, nil)
+	gitc.EXPECT().UpdateTaskBranch("saturn-bot--unittest", false, repo)
+	gitc.EXPECT().HasLocalChanges().Return(false, nil)
+	gitc.EXPECT().HasRemoteChanges("main").Return(true, nil)
+	gitc.EXPECT().HasRemoteChanges("saturn-bot--unittest").Return(false, nil)
+	gitc.EXPECT().ChangedFileStats("main").Return(nil, nil)
+	tw := &task.Task{Task: schema.Task{MergeOnce: true, Name: "unittest"}}
+	tw.AddPreCloneFilters(&trueFilter{})
+	require.NoError(t, tw.SetInputs(map[string]string{sbcontext.RunDataKeyCommand: sbcontext.CommandRecreate}))
+
+	p := &processor.Processor{Git: gitc}
+	results := p.Process(context.Background(), false, repo, []*task.Task{tw}, true)
+
+	require.Len(t, results, 1)
+	assert.NoError(t, results[0].Error)
+	assert.Equal(t, processor.ResultPrCreated, results[0].Result)
+	assert.Equal(t, prCreate, results[0].PullRequest)
+}
@@ -24,11 +24,16 @@ func _() {
 	_ = x[ResultSkip-13]
 	_ = x[ResultPushedDefaultBranch-14]
 	_ = x[ResultArchived-15]
+	_ = x[ResultMergePending-16]
+	_ = x[ResultPrNudged-17]
+	_ = x[ResultReviewRerequested-18]
+	_ = x[ResultPrRebased-19]
+	_ = x[ResultDependencyPending-20]
 }
 
-const _Result_name = "ResultUnknownResultAutoMergeTooEarlyResultBranchModifiedResultChecksFailedResultConflictResultNoChangesResultPrCreatedResultPrClosedBeforeResultPrClosedResultPrMergedBeforeResultPrMergedResultPrOpenResultNoMatchResultSkipResultPushedDefaultBranchResultArchived"
+const _Result_name = "ResultUnknownResultAutoMergeTooEarlyResultBranchModifiedResultChecksFailedResultConflictResultNoChangesResultPrCreatedResultPrClosedBeforeResultPrClosedResultPrMergedBeforeResultPrMergedResultPrOpenResultNoMatchResultSkipResultPushedDefaultBranchResultArchivedResultMergePendingResultPrNudgedResultReviewRerequestedResultPrRebasedResultDependencyPending"
 
-var _Result_index = [...]uint16{0, 13, 36, 56, 74, 88, 103, 118, 138, 152, 172, 186, 198, 211, 221, 246, 260}
+var _Result_index = [...]uint16{0, 13, 36, 56, 74, 88, 103, 118, 138, 152, 172, 186, 198, 211, 221, 246, 260, 278, 292, 315, 330, 353}
 
 func (i Result) String() string {
 	if i < 0 || i >= Result(len(_Result_index)-1) {
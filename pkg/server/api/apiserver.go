@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -19,14 +20,20 @@ import (
 )
 
 var (
+	errForbidden     = errors.New("forbidden")
 	errUnknownApiKey = errors.New("unknown api key")
 )
 
 // APIServer provides the implementation of the OpenAPI endpoints.
 type APIServer struct {
-	Clock         clock.Clock
-	TaskService   *service.TaskService
-	WorkerService *service.WorkerService
+	ApiTokenService      *service.ApiTokenService
+	Clock                clock.Clock
+	RepositoryLogService *service.RepositoryLogService
+	RolloutService       *service.RolloutService
+	TaskReloadService    *service.TaskReloadService
+	TaskService          *service.TaskService
+	WebhookService       *service.WebhookService
+	WorkerService        *service.WorkerService
 }
 
 // Stop gracefully stops the API server.
@@ -68,11 +75,18 @@ func (a *APIServer) Stop(ctx context.Context, checkInterval time.Duration) error
 
 // NewAPIServerOptions are passed to [RegisterAPIServer].
 type NewAPIServerOptions struct {
-	ApiKey        string
-	Clock         clock.Clock
-	Router        chi.Router
-	TaskService   *service.TaskService
-	WorkerService *service.WorkerService
+	// ApiKey is the admin key.
+	// It grants access to all operations.
+	ApiKey               string
+	ApiTokenService      *service.ApiTokenService
+	Clock                clock.Clock
+	RepositoryLogService *service.RepositoryLogService
+	RolloutService       *service.RolloutService
+	Router               chi.Router
+	TaskReloadService    *service.TaskReloadService
+	TaskService          *service.TaskService
+	WebhookService       *service.WebhookService
+	WorkerService        *service.WorkerService
 }
 
 // RegisterAPIServer registers the OpenAPI implementation with the router.
@@ -85,16 +99,21 @@ func RegisterAPIServer(options *NewAPIServerOptions) (http.Handler, *APIServer)
 	}
 
 	apiServer := &APIServer{
-		Clock:         c,
-		TaskService:   options.TaskService,
-		WorkerService: options.WorkerService,
+		ApiTokenService:      options.ApiTokenService,
+		Clock:                c,
+		RepositoryLogService: options.RepositoryLogService,
+		RolloutService:       options.RolloutService,
+		TaskReloadService:    options.TaskReloadService,
+		TaskService:          options.TaskService,
+		WebhookService:       options.WebhookService,
+		WorkerService:        options.WorkerService,
 	}
 
 	handlerOpts := openapi.StrictHTTPServerOptions{
 		RequestErrorHandlerFunc:  handleHttpError,
 		ResponseErrorHandlerFunc: handleHttpError,
 	}
-	middlewares := []openapi.StrictMiddlewareFunc{newApiKeyMiddleware(options.ApiKey)}
+	middlewares := []openapi.StrictMiddlewareFunc{newApiKeyMiddleware(options.ApiKey, options.ApiTokenService)}
 	return openapi.HandlerWithOptions(
 		openapi.NewStrictHandlerWithOptions(apiServer, middlewares, handlerOpts),
 		openapi.ChiServerOptions{
@@ -112,6 +131,13 @@ func handleHttpError(w http.ResponseWriter, _ *http.Request, err error) {
 			Error:   sberror.ClientUnknownApiKey,
 			Message: "unknown api key",
 		})
+	} else if errors.Is(err, errForbidden) {
+		log.Log().Warnw("API key not allowed to execute operation", zap.Error(err))
+		statusCode = http.StatusForbidden
+		apiError.Errors = append(apiError.Errors, openapi.ErrorDetail{
+			Error:   sberror.ClientForbidden,
+			Message: "forbidden",
+		})
 	} else {
 		log.Log().Errorw("Internal Server Error", zap.Error(err))
 		statusCode = http.StatusInternalServerError
@@ -129,14 +155,36 @@ func handleHttpError(w http.ResponseWriter, _ *http.Request, err error) {
 	}
 }
 
-func newApiKeyMiddleware(key string) openapi.StrictMiddlewareFunc {
+func newApiKeyMiddleware(adminKey string, apiTokenService *service.ApiTokenService) openapi.StrictMiddlewareFunc {
 	return func(f nethttp.StrictHTTPHandlerFunc, operationID string) nethttp.StrictHTTPHandlerFunc {
 		return func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (response interface{}, err error) {
-			if r.Header.Get(openapi.HeaderApiKey) != key {
+			key := r.Header.Get(openapi.HeaderApiKey)
+			if key == "" {
+				return nil, errUnknownApiKey
+			}
+
+			if subtle.ConstantTimeCompare([]byte(key), []byte(adminKey)) == 1 {
+				return f(ctx, w, r, request)
+			}
+
+			if apiTokenService == nil {
 				return nil, errUnknownApiKey
 			}
 
-			return f(ctx, w, r, request)
+			token, err := apiTokenService.FindTokenBySecret(key)
+			if err != nil {
+				if errors.Is(err, service.ErrApiTokenUnknown) {
+					return nil, errUnknownApiKey
+				}
+
+				return nil, err
+			}
+
+			if !isOperationAllowed(token.Scope, operationID) {
+				return nil, fmt.Errorf("%w: api token %s with scope %s cannot execute %s", errForbidden, token.Name, token.Scope, operationID)
+			}
+
+			return f(withApiToken(ctx, token), w, r, request)
 		}
 	}
 }
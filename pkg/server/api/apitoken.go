@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/wndhydrnt/saturn-bot/pkg/ptr"
+	"github.com/wndhydrnt/saturn-bot/pkg/server/api/openapi"
+	"github.com/wndhydrnt/saturn-bot/pkg/server/db"
+	sberror "github.com/wndhydrnt/saturn-bot/pkg/server/error"
+	"github.com/wndhydrnt/saturn-bot/pkg/server/service"
+)
+
+// operationScopes maps the ID of each operation to the scopes that are allowed to execute it.
+// Operations that aren't listed can only be executed with the admin key.
+var operationScopes = map[string][]db.ApiTokenScope{
+	"CancelRunV1":                 {db.ApiTokenScopeScheduler},
+	"DeleteRunV1":                 {db.ApiTokenScopeScheduler},
+	"GetRepositoryLogV1":          {db.ApiTokenScopeReadOnly, db.ApiTokenScopeScheduler},
+	"GetRunV1":                    {db.ApiTokenScopeReadOnly, db.ApiTokenScopeScheduler},
+	"GetTaskV1":                   {db.ApiTokenScopeReadOnly, db.ApiTokenScopeScheduler},
+	"GetWorkV1":                   {db.ApiTokenScopeWorker},
+	"HeartbeatWorkV1":             {db.ApiTokenScopeWorker},
+	"ListRunsV1":                  {db.ApiTokenScopeReadOnly, db.ApiTokenScopeScheduler},
+	"ListTaskRecentTaskResultsV1": {db.ApiTokenScopeReadOnly, db.ApiTokenScopeScheduler},
+	"ListTaskResultsV1":           {db.ApiTokenScopeReadOnly, db.ApiTokenScopeScheduler},
+	"ListTasksV1":                 {db.ApiTokenScopeReadOnly, db.ApiTokenScopeScheduler},
+	"ListWebhookDeliveriesV1":     {db.ApiTokenScopeReadOnly, db.ApiTokenScopeScheduler},
+	"ReportWorkV1":                {db.ApiTokenScopeWorker},
+	"ScheduleRunV1":               {db.ApiTokenScopeScheduler},
+	"UpdateTaskRolloutV1":         {db.ApiTokenScopeScheduler},
+}
+
+type apiTokenKey struct{}
+
+// withApiToken adds the API token that authenticated the request to the context.
+func withApiToken(ctx context.Context, token db.ApiToken) context.Context {
+	return context.WithValue(ctx, apiTokenKey{}, token)
+}
+
+// apiTokenFromContext returns the API token that authenticated the request.
+// It returns nil if the request has been authenticated with the admin key.
+func apiTokenFromContext(ctx context.Context) *db.ApiToken {
+	token, ok := ctx.Value(apiTokenKey{}).(db.ApiToken)
+	if !ok {
+		return nil
+	}
+
+	return &token
+}
+
+func isOperationAllowed(scope db.ApiTokenScope, operationID string) bool {
+	scopes, ok := operationScopes[operationID]
+	if !ok {
+		return false
+	}
+
+	return slices.Contains(scopes, scope)
+}
+
+// CreateApiTokenV1 implements [openapi.StrictServerInterface].
+func (a *APIServer) CreateApiTokenV1(_ context.Context, req openapi.CreateApiTokenV1RequestObject) (openapi.CreateApiTokenV1ResponseObject, error) {
+	opts := service.CreateApiTokenOptions{
+		Name:  req.Body.Name,
+		Scope: db.ApiTokenScope(req.Body.Scope),
+	}
+	if req.Body.Tasks != nil {
+		opts.Tasks = ptr.From(req.Body.Tasks)
+	}
+
+	secret, token, err := a.ApiTokenService.CreateToken(opts)
+	if err != nil {
+		var clientErr sberror.Client
+		if errors.As(err, &clientErr) {
+			return openapi.CreateApiTokenV1400JSONResponse(clientErr.ToApiError()), nil
+		}
+
+		return nil, err
+	}
+
+	return openapi.CreateApiTokenV1200JSONResponse{
+		ApiToken: mapApiToken(token),
+		Secret:   secret,
+	}, nil
+}
+
+// DeleteApiTokenV1 implements [openapi.StrictServerInterface].
+func (a *APIServer) DeleteApiTokenV1(_ context.Context, req openapi.DeleteApiTokenV1RequestObject) (openapi.DeleteApiTokenV1ResponseObject, error) {
+	err := a.ApiTokenService.DeleteToken(req.Name)
+	if err != nil {
+		var clientErr sberror.Client
+		if errors.As(err, &clientErr) {
+			return openapi.DeleteApiTokenV1404JSONResponse(clientErr.ToApiError()), nil
+		}
+
+		return nil, err
+	}
+
+	return openapi.DeleteApiTokenV1200JSONResponse{}, nil
+}
+
+// ListApiTokensV1 implements [openapi.StrictServerInterface].
+func (a *APIServer) ListApiTokensV1(_ context.Context, req openapi.ListApiTokensV1RequestObject) (openapi.ListApiTokensV1ResponseObject, error) {
+	listOpts := toListOptions(req.Params.ListOptions)
+	tokens, err := a.ApiTokenService.ListTokens(&listOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := openapi.ListApiTokensV1200JSONResponse{
+		ApiTokens: []openapi.ApiTokenV1{},
+		Page: openapi.Page{
+			PreviousPage: listOpts.Previous(),
+			CurrentPage:  listOpts.Page,
+			NextPage:     listOpts.Next(),
+			ItemsPerPage: listOpts.Limit,
+			TotalItems:   listOpts.TotalItems(),
+			TotalPages:   listOpts.TotalPages(),
+		},
+	}
+	for _, token := range tokens {
+		resp.ApiTokens = append(resp.ApiTokens, mapApiToken(token))
+	}
+
+	return resp, nil
+}
+
+func mapApiToken(token db.ApiToken) openapi.ApiTokenV1 {
+	api := openapi.ApiTokenV1{
+		CreatedAt: token.CreatedAt,
+		Name:      token.Name,
+		Scope:     openapi.ApiTokenScopeV1(token.Scope),
+	}
+	if len(token.Tasks) > 0 {
+		api.Tasks = ptr.To([]string(token.Tasks))
+	}
+
+	return api
+}
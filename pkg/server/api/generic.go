@@ -0,0 +1,148 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/wndhydrnt/saturn-bot/pkg/config"
+	"github.com/wndhydrnt/saturn-bot/pkg/log"
+	"github.com/wndhydrnt/saturn-bot/pkg/ptr"
+	"github.com/wndhydrnt/saturn-bot/pkg/server/service"
+	"go.uber.org/zap"
+)
+
+const (
+	genericWebhookDefaultEventHeader     = "X-Event"
+	genericWebhookDefaultSignatureHeader = "X-Signature-256"
+	genericWebhookDeliveryIDHeader       = "X-Delivery-Id"
+)
+
+// GenericWebhookHandler handles webhooks sent by generic sources,
+// like artifact registries or internal services.
+type GenericWebhookHandler struct {
+	// Sources maps the name of a source to its configuration.
+	Sources           map[string]config.WebhookGenericSource
+	TaskReloadService *service.TaskReloadService
+	// TasksSource is the name of the source whose webhooks make the server
+	// check the git repository of tasks for new commits.
+	TasksSource    string
+	WebhookService *service.WebhookService
+}
+
+// HandleWebhook verifies a webhook sent by a generic source.
+// If the webhook is valid, it sends the webhook on for processing.
+// The source defines how to verify the webhook.
+func (h *GenericWebhookHandler) HandleWebhook(w http.ResponseWriter, r *http.Request) {
+	defer DiscardRequest(r)
+	sourceName := chi.URLParam(r, "source")
+	source, ok := h.Sources[sourceName]
+	if !ok {
+		log.Log().Debugf("Generic webhook received request for unknown source %s", sourceName)
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Log().Warnf("Failed to read payload of generic webhook from source %s", sourceName)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = verifyGenericWebhook(source, r.Header, payload)
+	if err != nil {
+		log.Log().Warnw("Failed to verify generic webhook", "source", sourceName, zap.Error(err))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if !json.Valid(payload) {
+		log.Log().Warnf("Failed to parse generic webhook from source %s", sourceName)
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	eventHeader := ptr.FromDef(source.EventHeader, genericWebhookDefaultEventHeader)
+	err = h.WebhookService.EnqueueGeneric(sourceName, &service.WebhookEnqueueInput{
+		Event:   r.Header.Get(eventHeader),
+		ID:      r.Header.Get(genericWebhookDeliveryIDHeader),
+		Payload: payload,
+	})
+	if err != nil {
+		log.Log().Errorw("Failed to enqueue generic webhook", zap.Error(err))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if h.TasksSource != "" && sourceName == h.TasksSource {
+		log.Log().Debugf("Generic webhook from source %s requests refresh of tasks", sourceName)
+		h.TaskReloadService.RequestRefresh()
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// verifyGenericWebhook checks that the webhook has been sent by source.
+func verifyGenericWebhook(source config.WebhookGenericSource, header http.Header, payload []byte) error {
+	switch source.Verification {
+	case config.WebhookGenericSourceVerificationBearer:
+		token, found := strings.CutPrefix(header.Get("Authorization"), "Bearer ")
+		if !found || subtle.ConstantTimeCompare([]byte(token), []byte(source.Secret)) != 1 {
+			return errors.New("invalid bearer token")
+		}
+
+		return nil
+	case config.WebhookGenericSourceVerificationHmacSha256:
+		signatureHeader := ptr.FromDef(source.SignatureHeader, genericWebhookDefaultSignatureHeader)
+		signatureHex := strings.TrimPrefix(header.Get(signatureHeader), "sha256=")
+		signature, err := hex.DecodeString(signatureHex)
+		if err != nil {
+			return fmt.Errorf("decode signature: %w", err)
+		}
+
+		mac := hmac.New(sha256.New, []byte(source.Secret))
+		_, _ = mac.Write(payload)
+		if !hmac.Equal(signature, mac.Sum(nil)) {
+			return errors.New("invalid signature")
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unknown verification %s", source.Verification)
+	}
+}
+
+// RegisterGenericWebhookHandlerOptions defines the options of [RegisterGenericWebhookHandler].
+type RegisterGenericWebhookHandlerOptions struct {
+	Sources           []config.WebhookGenericSource
+	TaskReloadService *service.TaskReloadService
+	// TasksSource is the name of the source whose webhooks make the server
+	// check the git repository of tasks for new commits.
+	// Empty if no source refreshes tasks.
+	TasksSource    string
+	WebhookService *service.WebhookService
+}
+
+// RegisterGenericWebhookHandler registers the handler with a [github.com/go-chi/chi/v5.Router].
+func RegisterGenericWebhookHandler(router chi.Router, opts RegisterGenericWebhookHandlerOptions) {
+	h := &GenericWebhookHandler{
+		Sources:           make(map[string]config.WebhookGenericSource, len(opts.Sources)),
+		TaskReloadService: opts.TaskReloadService,
+		TasksSource:       opts.TasksSource,
+		WebhookService:    opts.WebhookService,
+	}
+	for _, source := range opts.Sources {
+		h.Sources[source.Name] = source
+	}
+
+	router.Post("/webhooks/generic/{source}", h.HandleWebhook)
+}
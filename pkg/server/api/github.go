@@ -31,20 +31,19 @@ func (h *GithubWebhookHandler) HandleWebhook(w http.ResponseWriter, r *http.Requ
 	whType := github.WebHookType(r)
 	whID := github.DeliveryID(r)
 	log.Log().Debugf("Received GitHub webhook %s of type %s", whID, whType)
-	var content any
-	err = json.Unmarshal(payload, &content)
-	if err != nil {
-		log.Log().Errorw("Failed to unmarshal GitHub webhook", zap.Error(err))
+	if !json.Valid(payload) {
+		log.Log().Error("Failed to unmarshal GitHub webhook")
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
 
 	// Note: GitHub expects a response within 10 seconds.
+	// The webhook service only stores the webhook and processes it asynchronously.
 	log.Log().Debugf("Enqueuing GitHub webhook %s", whID)
 	err = h.WebhookService.EnqueueGithub(&service.WebhookEnqueueInput{
 		Event:   whType,
 		ID:      whID,
-		Payload: content,
+		Payload: payload,
 	})
 	if err != nil {
 		log.Log().Errorw("Failed to enqueue GitHub webhook", zap.Error(err))
@@ -51,10 +51,7 @@ func (gh *GitlabWebhookHandler) HandleWebhook(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	// Use map[string]any because gojq expects it.
-	var event map[string]any
-	err = json.Unmarshal(payload, &event)
-	if err != nil {
+	if !json.Valid(payload) {
 		log.Log().Warn("Failed to parse GitLab webhook")
 		w.WriteHeader(http.StatusBadRequest)
 		return
@@ -63,7 +60,7 @@ func (gh *GitlabWebhookHandler) HandleWebhook(w http.ResponseWriter, r *http.Req
 	err = gh.WebhookService.EnqueueGitlab(&service.WebhookEnqueueInput{
 		Event:   string(eventType),
 		ID:      r.Header.Get(gitlabWebhookEventIDHeader),
-		Payload: event,
+		Payload: payload,
 	})
 	if err != nil {
 		log.Log().Errorw("Failed to enqueue webhook", zap.Error(err))
@@ -19,6 +19,13 @@ const (
 	ApiKeyAuthScopes = "ApiKeyAuth.Scopes"
 )
 
+// Defines values for ApiTokenScopeV1.
+const (
+	ReadOnly  ApiTokenScopeV1 = "read-only"
+	Scheduler ApiTokenScopeV1 = "scheduler"
+	Worker    ApiTokenScopeV1 = "worker"
+)
+
 // Defines values for ReportWorkV1ResponseResult.
 const (
 	Ok ReportWorkV1ResponseResult = "ok"
@@ -26,20 +33,23 @@ const (
 
 // Defines values for RunStatusV1.
 const (
-	Failed   RunStatusV1 = "failed"
-	Finished RunStatusV1 = "finished"
-	Pending  RunStatusV1 = "pending"
-	Running  RunStatusV1 = "running"
+	Cancelled RunStatusV1 = "cancelled"
+	Failed    RunStatusV1 = "failed"
+	Finished  RunStatusV1 = "finished"
+	Pending   RunStatusV1 = "pending"
+	Running   RunStatusV1 = "running"
 )
 
 // Defines values for RunV1Reason.
 const (
-	Changed RunV1Reason = "changed"
-	Cron    RunV1Reason = "cron"
-	Manual  RunV1Reason = "manual"
-	New     RunV1Reason = "new"
-	Next    RunV1Reason = "next"
-	Webhook RunV1Reason = "webhook"
+	Changed    RunV1Reason = "changed"
+	Command    RunV1Reason = "command"
+	Cron       RunV1Reason = "cron"
+	Dependency RunV1Reason = "dependency"
+	Manual     RunV1Reason = "manual"
+	New        RunV1Reason = "new"
+	Next       RunV1Reason = "next"
+	Webhook    RunV1Reason = "webhook"
 )
 
 // Defines values for TaskResultStateV1.
@@ -53,6 +63,84 @@ const (
 	TaskResultStateV1Unknown  TaskResultStateV1 = "unknown"
 )
 
+// Defines values for UpdateTaskRolloutV1RequestAction.
+const (
+	Pause   UpdateTaskRolloutV1RequestAction = "pause"
+	Promote UpdateTaskRolloutV1RequestAction = "promote"
+	Resume  UpdateTaskRolloutV1RequestAction = "resume"
+)
+
+// Defines values for WebhookDeliveryStatusV1.
+const (
+	WebhookDeliveryStatusV1Failed    WebhookDeliveryStatusV1 = "failed"
+	WebhookDeliveryStatusV1Pending   WebhookDeliveryStatusV1 = "pending"
+	WebhookDeliveryStatusV1Processed WebhookDeliveryStatusV1 = "processed"
+)
+
+// Defines values for WebhookDeliveryV1Type.
+const (
+	WebhookDeliveryV1TypeGeneric WebhookDeliveryV1Type = "generic"
+	WebhookDeliveryV1TypeGithub  WebhookDeliveryV1Type = "github"
+	WebhookDeliveryV1TypeGitlab  WebhookDeliveryV1Type = "gitlab"
+)
+
+// ApiTokenScopeV1 Scope of an API token.
+// `read-only` allows reading runs, tasks and task results.
+// `scheduler` allows scheduling runs of the tasks the token lists. Includes the permissions of `read-only`.
+// `worker` allows workers to get and report work.
+type ApiTokenScopeV1 string
+
+// ApiTokenV1 defines model for ApiTokenV1.
+type ApiTokenV1 struct {
+	// CreatedAt Time at which the token has been created.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Name Name of the token.
+	Name string `json:"name"`
+
+	// Scope Scope of an API token.
+	// `read-only` allows reading runs, tasks and task results.
+	// `scheduler` allows scheduling runs of the tasks the token lists. Includes the permissions of `read-only`.
+	// `worker` allows workers to get and report work.
+	Scope ApiTokenScopeV1 `json:"scope"`
+
+	// Tasks Names or glob patterns of tasks the token is allowed to schedule runs for. Only set if scope is `scheduler`.
+	Tasks *[]string `json:"tasks,omitempty"`
+}
+
+// CancelRunV1Response defines model for CancelRunV1Response.
+type CancelRunV1Response struct {
+	// Deleted True if the run was pending and has been deleted.
+	Deleted bool `json:"deleted"`
+}
+
+// CreateApiTokenV1Request defines model for CreateApiTokenV1Request.
+type CreateApiTokenV1Request struct {
+	// Name Unique name of the token.
+	Name string `json:"name"`
+
+	// Scope Scope of an API token.
+	// `read-only` allows reading runs, tasks and task results.
+	// `scheduler` allows scheduling runs of the tasks the token lists. Includes the permissions of `read-only`.
+	// `worker` allows workers to get and report work.
+	Scope ApiTokenScopeV1 `json:"scope"`
+
+	// Tasks Names or glob patterns of tasks the token is allowed to schedule runs for.
+	// Required if scope is `scheduler`.
+	Tasks *[]string `json:"tasks,omitempty"`
+}
+
+// CreateApiTokenV1Response defines model for CreateApiTokenV1Response.
+type CreateApiTokenV1Response struct {
+	ApiToken ApiTokenV1 `json:"apiToken"`
+
+	// Secret Secret of the token. Clients set the secret as the value of the HTTP header X-API-KEY.
+	Secret string `json:"secret"`
+}
+
+// DeleteApiTokenV1Response defines model for DeleteApiTokenV1Response.
+type DeleteApiTokenV1Response = map[string]interface{}
+
 // DeleteRunV1Response defines model for DeleteRunV1Response.
 type DeleteRunV1Response = map[string]interface{}
 
@@ -74,6 +162,21 @@ type ErrorDetail struct {
 	Message string `json:"message"`
 }
 
+// GetRepositoryLogV1Response defines model for GetRepositoryLogV1Response.
+type GetRepositoryLogV1Response struct {
+	// CreatedAt Date and time at which the server received the log.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Log Content of the log.
+	Log string `json:"log"`
+
+	// RepositoryName Name of the repository.
+	RepositoryName string `json:"repositoryName"`
+
+	// RunId Numeric identifier of the run.
+	RunId int `json:"runId"`
+}
+
 // GetRunV1Response defines model for GetRunV1Response.
 type GetRunV1Response struct {
 	Run RunV1 `json:"run"`
@@ -85,6 +188,9 @@ type GetTaskV1Response struct {
 	Hash    string         `json:"hash"`
 	Inputs  *[]TaskV1Input `json:"inputs,omitempty"`
 	Name    string         `json:"name"`
+
+	// Rollout Progress of the rollout of a task.
+	Rollout *TaskRolloutV1 `json:"rollout,omitempty"`
 }
 
 // GetWorkV1Response defines model for GetWorkV1Response.
@@ -102,6 +208,24 @@ type GetWorkV1Response struct {
 	Task WorkTaskV1 `json:"task"`
 }
 
+// HeartbeatWorkV1Request defines model for HeartbeatWorkV1Request.
+type HeartbeatWorkV1Request struct {
+	// RunID Internal identifier of the unit of work.
+	RunID int `json:"runID"`
+}
+
+// HeartbeatWorkV1Response defines model for HeartbeatWorkV1Response.
+type HeartbeatWorkV1Response struct {
+	// Cancelled True if a user has cancelled the run. The worker stops the run.
+	Cancelled bool `json:"cancelled"`
+}
+
+// ListApiTokensV1Response defines model for ListApiTokensV1Response.
+type ListApiTokensV1Response struct {
+	ApiTokens []ApiTokenV1 `json:"apiTokens"`
+	Page      Page         `json:"page"`
+}
+
 // ListOptions defines model for ListOptions.
 type ListOptions struct {
 	Limit int `json:"limit"`
@@ -143,6 +267,14 @@ type ListTasksV1ResponseTask struct {
 	Name     string `json:"name"`
 }
 
+// ListWebhookDeliveriesV1Response defines model for ListWebhookDeliveriesV1Response.
+type ListWebhookDeliveriesV1Response struct {
+	Page Page `json:"page"`
+
+	// Result List of webhook deliveries.
+	Result []WebhookDeliveryV1 `json:"result"`
+}
+
 // Page defines model for Page.
 type Page struct {
 	// CurrentPage Number of the current page.
@@ -166,8 +298,17 @@ type Page struct {
 	TotalPages int `json:"totalPages"`
 }
 
+// ReloadTasksV1Response defines model for ReloadTasksV1Response.
+type ReloadTasksV1Response struct {
+	// Results Tasks known to the server after the reload.
+	Results []ListTasksV1ResponseTask `json:"results"`
+}
+
 // ReportWorkV1Request defines model for ReportWorkV1Request.
 type ReportWorkV1Request struct {
+	// Cancelled True if the worker stopped the run early because a user cancelled it.
+	Cancelled *bool `json:"cancelled,omitempty"`
+
 	// Error General that occurred during the run, if any.
 	Error *string `json:"error,omitempty"`
 
@@ -195,6 +336,10 @@ type ReportWorkV1TaskResult struct {
 	// Error Error encountered during the run, if any.
 	Error *string `json:"error,omitempty"`
 
+	// Log Log captured while the worker applied the task to the repository.
+	// The server stores it compressed and deletes it once it exceeds the configured retention.
+	Log *string `json:"log,omitempty"`
+
 	// PullRequestUrl URL of the pull request for humans to view.
 	PullRequestUrl *string `json:"pullRequestUrl,omitempty"`
 
@@ -204,6 +349,9 @@ type ReportWorkV1TaskResult struct {
 	// Result Identifier of the result.
 	Result int `json:"result"`
 
+	// RolloutWave Index of the wave of the rollout of the task that contains the repository. Not set if the task doesn't define a rollout.
+	RolloutWave *int `json:"rolloutWave,omitempty"`
+
 	// State State of the result.
 	// `archived` indicates that the repository of a pull request has been archived.
 	// `closed` indicates that a pull request existed and has been closed.
@@ -213,6 +361,9 @@ type ReportWorkV1TaskResult struct {
 	// `pushed` indicates that changes were pushed to the default branch.
 	// `unknown` is a fallback value for any unexpected status.
 	State TaskResultStateV1 `json:"state"`
+
+	// TargetBranch Branch that the pull request targets. Not set if the task doesn't define target branches.
+	TargetBranch *string `json:"targetBranch,omitempty"`
 }
 
 // RunStatusV1 defines model for RunStatusV1.
@@ -220,9 +371,15 @@ type RunStatusV1 string
 
 // RunV1 defines model for RunV1.
 type RunV1 struct {
-	Error      *string    `json:"error,omitempty"`
-	FinishedAt *time.Time `json:"finishedAt,omitempty"`
-	Id         uint       `json:"id"`
+	// ApiTokenName Name of the API token that scheduled the run, if any.
+	ApiTokenName *string `json:"apiTokenName,omitempty"`
+
+	// CancelRequestedAt Point in time at which a user requested to cancel the run.
+	// The worker stops the run once it sends the next heartbeat.
+	CancelRequestedAt *time.Time `json:"cancelRequestedAt,omitempty"`
+	Error             *string    `json:"error,omitempty"`
+	FinishedAt        *time.Time `json:"finishedAt,omitempty"`
+	Id                uint       `json:"id"`
 
 	// Reason The reason why a run has been scheduled.
 	// The following reasons are deprecated: changed, new, next
@@ -233,6 +390,10 @@ type RunV1 struct {
 	StartedAt     *time.Time         `json:"startedAt,omitempty"`
 	Status        RunStatusV1        `json:"status"`
 	Task          string             `json:"task"`
+
+	// TaskRevision Commit SHA of the git repository of tasks at the time the run started.
+	// Not set if the server reads tasks from local files.
+	TaskRevision *string `json:"taskRevision,omitempty"`
 }
 
 // RunV1Reason The reason why a run has been scheduled.
@@ -299,6 +460,27 @@ type TaskResultV1 struct {
 	// `pushed` indicates that changes were pushed to the default branch.
 	// `unknown` is a fallback value for any unexpected status.
 	Status TaskResultStateV1 `json:"status"`
+
+	// TargetBranch Branch that the pull request targets. Not set if the task doesn't define target branches.
+	TargetBranch *string `json:"targetBranch,omitempty"`
+}
+
+// TaskRolloutV1 Progress of the rollout of a task.
+type TaskRolloutV1 struct {
+	// CurrentWave Index of the last wave that has started.
+	CurrentWave int `json:"currentWave"`
+
+	// Paused If `true`, the rollout doesn't start the next wave.
+	Paused bool `json:"paused"`
+
+	// PausedReason Reason why the rollout has been paused.
+	PausedReason *string `json:"pausedReason,omitempty"`
+
+	// WaveStartedAt Point in time at which the current wave has started.
+	WaveStartedAt time.Time `json:"waveStartedAt"`
+
+	// Waves Names of all waves of the rollout.
+	Waves []string `json:"waves"`
 }
 
 // TaskV1Input defines model for TaskV1Input.
@@ -319,6 +501,60 @@ type TaskV1Input struct {
 	Validation *string `json:"validation,omitempty"`
 }
 
+// UpdateTaskRolloutV1Request defines model for UpdateTaskRolloutV1Request.
+type UpdateTaskRolloutV1Request struct {
+	// Action Action to apply to the rollout.
+	Action UpdateTaskRolloutV1RequestAction `json:"action"`
+}
+
+// UpdateTaskRolloutV1RequestAction Action to apply to the rollout.
+type UpdateTaskRolloutV1RequestAction string
+
+// WebhookDeliveryStatusV1 `pending` - The server hasn't processed the delivery yet or retries processing it.
+// `processed` - The server matched the delivery against all tasks.
+// `failed` - Processing failed too often. The server doesn't retry processing.
+type WebhookDeliveryStatusV1 string
+
+// WebhookDeliveryV1 defines model for WebhookDeliveryV1.
+type WebhookDeliveryV1 struct {
+	// Attempts Number of times the server tried to process the delivery.
+	Attempts int `json:"attempts"`
+
+	// CreatedAt Date and time at which the server received the delivery.
+	CreatedAt time.Time `json:"createdAt"`
+
+	// DeliveryId ID of the delivery assigned by the sender. Generated by the server if the sender didn't send one.
+	DeliveryId string `json:"deliveryId"`
+
+	// Error Error of the last failed attempt to process the delivery.
+	Error *string `json:"error,omitempty"`
+
+	// Event Event of the webhook.
+	Event string `json:"event"`
+	Id    uint   `json:"id"`
+
+	// MatchedTasks Names of the tasks for which the delivery scheduled a run.
+	MatchedTasks []string `json:"matchedTasks"`
+
+	// NextAttemptAt Date and time after which the server retries to process the delivery. Only set if status is `pending`.
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty"`
+
+	// ProcessedAt Date and time at which the server processed the delivery.
+	ProcessedAt *time.Time `json:"processedAt,omitempty"`
+
+	// Source Name of the source of a generic webhook.
+	Source *string `json:"source,omitempty"`
+
+	// Status `pending` - The server hasn't processed the delivery yet or retries processing it.
+	// `processed` - The server matched the delivery against all tasks.
+	// `failed` - Processing failed too often. The server doesn't retry processing.
+	Status WebhookDeliveryStatusV1 `json:"status"`
+	Type   WebhookDeliveryV1Type   `json:"type"`
+}
+
+// WebhookDeliveryV1Type defines model for WebhookDeliveryV1.Type.
+type WebhookDeliveryV1Type string
+
 // WorkTaskV1 The task to execute.
 type WorkTaskV1 struct {
 	// Hash Hash of the task. Used to detect if server and worker are out of sync.
@@ -326,11 +562,23 @@ type WorkTaskV1 struct {
 
 	// Name Name of the task to execute.
 	Name string `json:"name"`
+
+	// Revision Commit SHA of the git repository of tasks that contains the task.
+	// Not set if the server reads tasks from local files.
+	Revision *string `json:"revision,omitempty"`
 }
 
+// Forbidden defines model for Forbidden.
+type Forbidden = Error
+
 // Unauthorized defines model for Unauthorized.
 type Unauthorized = Error
 
+// ListApiTokensV1Params defines parameters for ListApiTokensV1.
+type ListApiTokensV1Params struct {
+	ListOptions *ListOptions `form:"listOptions,omitempty" json:"listOptions,omitempty"`
+}
+
 // ListRunsV1Params defines parameters for ListRunsV1.
 type ListRunsV1Params struct {
 	// Task Name of the task to filter by.
@@ -339,6 +587,12 @@ type ListRunsV1Params struct {
 	Status      *[]RunStatusV1 `form:"status,omitempty" json:"status,omitempty"`
 }
 
+// GetRepositoryLogV1Params defines parameters for GetRepositoryLogV1.
+type GetRepositoryLogV1Params struct {
+	// RepositoryName Name of the repository.
+	RepositoryName string `form:"repositoryName" json:"repositoryName"`
+}
+
 // ListTaskResultsV1Params defines parameters for ListTaskResultsV1.
 type ListTaskResultsV1Params struct {
 	// RepositoryName Name of a repository to filter by.
@@ -362,14 +616,38 @@ type ListTaskRecentTaskResultsV1Params struct {
 	ListOptions *ListOptions         `form:"listOptions,omitempty" json:"listOptions,omitempty"`
 }
 
+// ListWebhookDeliveriesV1Params defines parameters for ListWebhookDeliveriesV1.
+type ListWebhookDeliveriesV1Params struct {
+	ListOptions *ListOptions               `form:"listOptions,omitempty" json:"listOptions,omitempty"`
+	Status      *[]WebhookDeliveryStatusV1 `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// CreateApiTokenV1JSONRequestBody defines body for CreateApiTokenV1 for application/json ContentType.
+type CreateApiTokenV1JSONRequestBody = CreateApiTokenV1Request
+
 // ScheduleRunV1JSONRequestBody defines body for ScheduleRunV1 for application/json ContentType.
 type ScheduleRunV1JSONRequestBody = ScheduleRunV1Request
 
+// UpdateTaskRolloutV1JSONRequestBody defines body for UpdateTaskRolloutV1 for application/json ContentType.
+type UpdateTaskRolloutV1JSONRequestBody = UpdateTaskRolloutV1Request
+
+// HeartbeatWorkV1JSONRequestBody defines body for HeartbeatWorkV1 for application/json ContentType.
+type HeartbeatWorkV1JSONRequestBody = HeartbeatWorkV1Request
+
 // ReportWorkV1JSONRequestBody defines body for ReportWorkV1 for application/json ContentType.
 type ReportWorkV1JSONRequestBody = ReportWorkV1Request
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// List API tokens.
+	// (GET /api/v1/apiTokens)
+	ListApiTokensV1(w http.ResponseWriter, r *http.Request, params ListApiTokensV1Params)
+	// Create an API token.
+	// (POST /api/v1/apiTokens)
+	CreateApiTokenV1(w http.ResponseWriter, r *http.Request)
+	// Revoke an API token.
+	// (DELETE /api/v1/apiTokens/{name})
+	DeleteApiTokenV1(w http.ResponseWriter, r *http.Request, name string)
 	// List of runs.
 	// (GET /api/v1/runs)
 	ListRunsV1(w http.ResponseWriter, r *http.Request, params ListRunsV1Params)
@@ -382,18 +660,36 @@ type ServerInterface interface {
 	// View data of a run.
 	// (GET /api/v1/runs/{runId})
 	GetRunV1(w http.ResponseWriter, r *http.Request, runId int)
+	// Cancel a run.
+	// (POST /api/v1/runs/{runId}/cancel)
+	CancelRunV1(w http.ResponseWriter, r *http.Request, runId int)
+	// View the log of a repository.
+	// (GET /api/v1/runs/{runId}/logs)
+	GetRepositoryLogV1(w http.ResponseWriter, r *http.Request, runId int, params GetRepositoryLogV1Params)
 	// Task results
 	// (GET /api/v1/taskResults)
 	ListTaskResultsV1(w http.ResponseWriter, r *http.Request, params ListTaskResultsV1Params)
 	// List tasks.
 	// (GET /api/v1/tasks)
 	ListTasksV1(w http.ResponseWriter, r *http.Request, params ListTasksV1Params)
+	// Reload tasks.
+	// (POST /api/v1/tasks/reload)
+	ReloadTasksV1(w http.ResponseWriter, r *http.Request)
 	// Get information about a task.
 	// (GET /api/v1/tasks/{task})
 	GetTaskV1(w http.ResponseWriter, r *http.Request, task string)
 	// List recent run results of a task by repository.
 	// (GET /api/v1/tasks/{task}/results)
 	ListTaskRecentTaskResultsV1(w http.ResponseWriter, r *http.Request, task string, params ListTaskRecentTaskResultsV1Params)
+	// Promote, pause or resume the rollout of a task.
+	// (POST /api/v1/tasks/{task}/rollout)
+	UpdateTaskRolloutV1(w http.ResponseWriter, r *http.Request, task string)
+	// List of webhook deliveries.
+	// (GET /api/v1/webhookDeliveries)
+	ListWebhookDeliveriesV1(w http.ResponseWriter, r *http.Request, params ListWebhookDeliveriesV1Params)
+	// Send a heartbeat for a unit of work.
+	// (POST /api/v1/worker/heartbeat)
+	HeartbeatWorkV1(w http.ResponseWriter, r *http.Request)
 	// Get a unit of work.
 	// (GET /api/v1/worker/work)
 	GetWorkV1(w http.ResponseWriter, r *http.Request)
@@ -406,6 +702,24 @@ type ServerInterface interface {
 
 type Unimplemented struct{}
 
+// List API tokens.
+// (GET /api/v1/apiTokens)
+func (_ Unimplemented) ListApiTokensV1(w http.ResponseWriter, r *http.Request, params ListApiTokensV1Params) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create an API token.
+// (POST /api/v1/apiTokens)
+func (_ Unimplemented) CreateApiTokenV1(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke an API token.
+// (DELETE /api/v1/apiTokens/{name})
+func (_ Unimplemented) DeleteApiTokenV1(w http.ResponseWriter, r *http.Request, name string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // List of runs.
 // (GET /api/v1/runs)
 func (_ Unimplemented) ListRunsV1(w http.ResponseWriter, r *http.Request, params ListRunsV1Params) {
@@ -430,6 +744,18 @@ func (_ Unimplemented) GetRunV1(w http.ResponseWriter, r *http.Request, runId in
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Cancel a run.
+// (POST /api/v1/runs/{runId}/cancel)
+func (_ Unimplemented) CancelRunV1(w http.ResponseWriter, r *http.Request, runId int) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// View the log of a repository.
+// (GET /api/v1/runs/{runId}/logs)
+func (_ Unimplemented) GetRepositoryLogV1(w http.ResponseWriter, r *http.Request, runId int, params GetRepositoryLogV1Params) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Task results
 // (GET /api/v1/taskResults)
 func (_ Unimplemented) ListTaskResultsV1(w http.ResponseWriter, r *http.Request, params ListTaskResultsV1Params) {
@@ -442,6 +768,12 @@ func (_ Unimplemented) ListTasksV1(w http.ResponseWriter, r *http.Request, param
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Reload tasks.
+// (POST /api/v1/tasks/reload)
+func (_ Unimplemented) ReloadTasksV1(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get information about a task.
 // (GET /api/v1/tasks/{task})
 func (_ Unimplemented) GetTaskV1(w http.ResponseWriter, r *http.Request, task string) {
@@ -454,6 +786,24 @@ func (_ Unimplemented) ListTaskRecentTaskResultsV1(w http.ResponseWriter, r *htt
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Promote, pause or resume the rollout of a task.
+// (POST /api/v1/tasks/{task}/rollout)
+func (_ Unimplemented) UpdateTaskRolloutV1(w http.ResponseWriter, r *http.Request, task string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List of webhook deliveries.
+// (GET /api/v1/webhookDeliveries)
+func (_ Unimplemented) ListWebhookDeliveriesV1(w http.ResponseWriter, r *http.Request, params ListWebhookDeliveriesV1Params) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Send a heartbeat for a unit of work.
+// (POST /api/v1/worker/heartbeat)
+func (_ Unimplemented) HeartbeatWorkV1(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get a unit of work.
 // (GET /api/v1/worker/work)
 func (_ Unimplemented) GetWorkV1(w http.ResponseWriter, r *http.Request) {
@@ -475,6 +825,90 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
+// ListApiTokensV1 operation middleware
+func (siw *ServerInterfaceWrapper) ListApiTokensV1(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListApiTokensV1Params
+
+	// ------------- Optional query parameter "listOptions" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "listOptions", r.URL.Query(), &params.ListOptions)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "listOptions", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListApiTokensV1(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// CreateApiTokenV1 operation middleware
+func (siw *ServerInterfaceWrapper) CreateApiTokenV1(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CreateApiTokenV1(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteApiTokenV1 operation middleware
+func (siw *ServerInterfaceWrapper) DeleteApiTokenV1(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "name", chi.URLParam(r, "name"), &name, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteApiTokenV1(w, r, name)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // ListRunsV1 operation middleware
 func (siw *ServerInterfaceWrapper) ListRunsV1(w http.ResponseWriter, r *http.Request) {
 
@@ -606,54 +1040,77 @@ func (siw *ServerInterfaceWrapper) GetRunV1(w http.ResponseWriter, r *http.Reque
 	handler.ServeHTTP(w, r)
 }
 
-// ListTaskResultsV1 operation middleware
-func (siw *ServerInterfaceWrapper) ListTaskResultsV1(w http.ResponseWriter, r *http.Request) {
+// CancelRunV1 operation middleware
+func (siw *ServerInterfaceWrapper) CancelRunV1(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
+	// ------------- Path parameter "runId" -------------
+	var runId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "runId", chi.URLParam(r, "runId"), &runId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "runId", Err: err})
+		return
+	}
+
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
 
 	r = r.WithContext(ctx)
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params ListTaskResultsV1Params
-
-	// ------------- Optional query parameter "repositoryName" -------------
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CancelRunV1(w, r, runId)
+	}))
 
-	err = runtime.BindQueryParameter("form", true, false, "repositoryName", r.URL.Query(), &params.RepositoryName)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repositoryName", Err: err})
-		return
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	// ------------- Optional query parameter "runId" -------------
+	handler.ServeHTTP(w, r)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "runId", r.URL.Query(), &params.RunId)
+// GetRepositoryLogV1 operation middleware
+func (siw *ServerInterfaceWrapper) GetRepositoryLogV1(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "runId" -------------
+	var runId int
+
+	err = runtime.BindStyledParameterWithOptions("simple", "runId", chi.URLParam(r, "runId"), &runId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
 		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "runId", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "status" -------------
+	ctx := r.Context()
 
-	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetRepositoryLogV1Params
+
+	// ------------- Required query parameter "repositoryName" -------------
+
+	if paramValue := r.URL.Query().Get("repositoryName"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "repositoryName"})
 		return
 	}
 
-	// ------------- Optional query parameter "listOptions" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "listOptions", r.URL.Query(), &params.ListOptions)
+	err = runtime.BindQueryParameter("form", true, true, "repositoryName", r.URL.Query(), &params.RepositoryName)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "listOptions", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repositoryName", Err: err})
 		return
 	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.ListTaskResultsV1(w, r, params)
+		siw.Handler.GetRepositoryLogV1(w, r, runId, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -663,8 +1120,8 @@ func (siw *ServerInterfaceWrapper) ListTaskResultsV1(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r)
 }
 
-// ListTasksV1 operation middleware
-func (siw *ServerInterfaceWrapper) ListTasksV1(w http.ResponseWriter, r *http.Request) {
+// ListTaskResultsV1 operation middleware
+func (siw *ServerInterfaceWrapper) ListTaskResultsV1(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
@@ -675,11 +1132,68 @@ func (siw *ServerInterfaceWrapper) ListTasksV1(w http.ResponseWriter, r *http.Re
 	r = r.WithContext(ctx)
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params ListTasksV1Params
+	var params ListTaskResultsV1Params
 
-	// ------------- Optional query parameter "active" -------------
+	// ------------- Optional query parameter "repositoryName" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "active", r.URL.Query(), &params.Active)
+	err = runtime.BindQueryParameter("form", true, false, "repositoryName", r.URL.Query(), &params.RepositoryName)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "repositoryName", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "runId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "runId", r.URL.Query(), &params.RunId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "runId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "listOptions" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "listOptions", r.URL.Query(), &params.ListOptions)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "listOptions", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListTaskResultsV1(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListTasksV1 operation middleware
+func (siw *ServerInterfaceWrapper) ListTasksV1(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListTasksV1Params
+
+	// ------------- Optional query parameter "active" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "active", r.URL.Query(), &params.Active)
 	if err != nil {
 		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "active", Err: err})
 		return
@@ -704,6 +1218,26 @@ func (siw *ServerInterfaceWrapper) ListTasksV1(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r)
 }
 
+// ReloadTasksV1 operation middleware
+func (siw *ServerInterfaceWrapper) ReloadTasksV1(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReloadTasksV1(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetTaskV1 operation middleware
 func (siw *ServerInterfaceWrapper) GetTaskV1(w http.ResponseWriter, r *http.Request) {
 
@@ -785,6 +1319,98 @@ func (siw *ServerInterfaceWrapper) ListTaskRecentTaskResultsV1(w http.ResponseWr
 	handler.ServeHTTP(w, r)
 }
 
+// UpdateTaskRolloutV1 operation middleware
+func (siw *ServerInterfaceWrapper) UpdateTaskRolloutV1(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "task" -------------
+	var task string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "task", chi.URLParam(r, "task"), &task, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "task", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateTaskRolloutV1(w, r, task)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// ListWebhookDeliveriesV1 operation middleware
+func (siw *ServerInterfaceWrapper) ListWebhookDeliveriesV1(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListWebhookDeliveriesV1Params
+
+	// ------------- Optional query parameter "listOptions" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "listOptions", r.URL.Query(), &params.ListOptions)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "listOptions", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ListWebhookDeliveriesV1(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// HeartbeatWorkV1 operation middleware
+func (siw *ServerInterfaceWrapper) HeartbeatWorkV1(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.HeartbeatWorkV1(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
 // GetWorkV1 operation middleware
 func (siw *ServerInterfaceWrapper) GetWorkV1(w http.ResponseWriter, r *http.Request) {
 
@@ -938,6 +1564,15 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/apiTokens", wrapper.ListApiTokensV1)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/apiTokens", wrapper.CreateApiTokenV1)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/api/v1/apiTokens/{name}", wrapper.DeleteApiTokenV1)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/runs", wrapper.ListRunsV1)
 	})
@@ -950,18 +1585,36 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/runs/{runId}", wrapper.GetRunV1)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/runs/{runId}/cancel", wrapper.CancelRunV1)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/runs/{runId}/logs", wrapper.GetRepositoryLogV1)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/taskResults", wrapper.ListTaskResultsV1)
 	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/tasks", wrapper.ListTasksV1)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/tasks/reload", wrapper.ReloadTasksV1)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/tasks/{task}", wrapper.GetTaskV1)
 	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/tasks/{task}/results", wrapper.ListTaskRecentTaskResultsV1)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/tasks/{task}/rollout", wrapper.UpdateTaskRolloutV1)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/api/v1/webhookDeliveries", wrapper.ListWebhookDeliveriesV1)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/api/v1/worker/heartbeat", wrapper.HeartbeatWorkV1)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/api/v1/worker/work", wrapper.GetWorkV1)
 	})
@@ -972,285 +1625,756 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	return r
 }
 
+type ForbiddenJSONResponse Error
+
 type UnauthorizedJSONResponse Error
 
-type ListRunsV1RequestObject struct {
-	Params ListRunsV1Params
+type ListApiTokensV1RequestObject struct {
+	Params ListApiTokensV1Params
 }
 
-type ListRunsV1ResponseObject interface {
-	VisitListRunsV1Response(w http.ResponseWriter) error
+type ListApiTokensV1ResponseObject interface {
+	VisitListApiTokensV1Response(w http.ResponseWriter) error
 }
 
-type ListRunsV1200JSONResponse ListRunsV1Response
+type ListApiTokensV1200JSONResponse ListApiTokensV1Response
 
-func (response ListRunsV1200JSONResponse) VisitListRunsV1Response(w http.ResponseWriter) error {
+func (response ListApiTokensV1200JSONResponse) VisitListApiTokensV1Response(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListRunsV1401JSONResponse struct{ UnauthorizedJSONResponse }
+type ListApiTokensV1401JSONResponse struct{ UnauthorizedJSONResponse }
 
-func (response ListRunsV1401JSONResponse) VisitListRunsV1Response(w http.ResponseWriter) error {
+func (response ListApiTokensV1401JSONResponse) VisitListApiTokensV1Response(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ScheduleRunV1RequestObject struct {
-	Body *ScheduleRunV1JSONRequestBody
+type ListApiTokensV1403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListApiTokensV1403JSONResponse) VisitListApiTokensV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type ScheduleRunV1ResponseObject interface {
-	VisitScheduleRunV1Response(w http.ResponseWriter) error
+type CreateApiTokenV1RequestObject struct {
+	Body *CreateApiTokenV1JSONRequestBody
 }
 
-type ScheduleRunV1200JSONResponse ScheduleRunV1Response
+type CreateApiTokenV1ResponseObject interface {
+	VisitCreateApiTokenV1Response(w http.ResponseWriter) error
+}
 
-func (response ScheduleRunV1200JSONResponse) VisitScheduleRunV1Response(w http.ResponseWriter) error {
+type CreateApiTokenV1200JSONResponse CreateApiTokenV1Response
+
+func (response CreateApiTokenV1200JSONResponse) VisitCreateApiTokenV1Response(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ScheduleRunV1400JSONResponse Error
+type CreateApiTokenV1400JSONResponse Error
 
-func (response ScheduleRunV1400JSONResponse) VisitScheduleRunV1Response(w http.ResponseWriter) error {
+func (response CreateApiTokenV1400JSONResponse) VisitCreateApiTokenV1Response(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ScheduleRunV1401JSONResponse struct{ UnauthorizedJSONResponse }
+type CreateApiTokenV1401JSONResponse struct{ UnauthorizedJSONResponse }
 
-func (response ScheduleRunV1401JSONResponse) VisitScheduleRunV1Response(w http.ResponseWriter) error {
+func (response CreateApiTokenV1401JSONResponse) VisitCreateApiTokenV1Response(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteRunV1RequestObject struct {
-	RunId int `json:"runId"`
+type CreateApiTokenV1403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response CreateApiTokenV1403JSONResponse) VisitCreateApiTokenV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteRunV1ResponseObject interface {
-	VisitDeleteRunV1Response(w http.ResponseWriter) error
+type DeleteApiTokenV1RequestObject struct {
+	Name string `json:"name"`
 }
 
-type DeleteRunV1200JSONResponse DeleteRunV1Response
+type DeleteApiTokenV1ResponseObject interface {
+	VisitDeleteApiTokenV1Response(w http.ResponseWriter) error
+}
 
-func (response DeleteRunV1200JSONResponse) VisitDeleteRunV1Response(w http.ResponseWriter) error {
+type DeleteApiTokenV1200JSONResponse DeleteApiTokenV1Response
+
+func (response DeleteApiTokenV1200JSONResponse) VisitDeleteApiTokenV1Response(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteRunV1400JSONResponse Error
+type DeleteApiTokenV1401JSONResponse struct{ UnauthorizedJSONResponse }
 
-func (response DeleteRunV1400JSONResponse) VisitDeleteRunV1Response(w http.ResponseWriter) error {
+func (response DeleteApiTokenV1401JSONResponse) VisitDeleteApiTokenV1Response(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteRunV1401JSONResponse struct{ UnauthorizedJSONResponse }
+type DeleteApiTokenV1403JSONResponse struct{ ForbiddenJSONResponse }
 
-func (response DeleteRunV1401JSONResponse) VisitDeleteRunV1Response(w http.ResponseWriter) error {
+func (response DeleteApiTokenV1403JSONResponse) VisitDeleteApiTokenV1Response(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type DeleteRunV1404JSONResponse Error
+type DeleteApiTokenV1404JSONResponse Error
 
-func (response DeleteRunV1404JSONResponse) VisitDeleteRunV1Response(w http.ResponseWriter) error {
+func (response DeleteApiTokenV1404JSONResponse) VisitDeleteApiTokenV1Response(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetRunV1RequestObject struct {
-	RunId int `json:"runId"`
+type ListRunsV1RequestObject struct {
+	Params ListRunsV1Params
 }
 
-type GetRunV1ResponseObject interface {
-	VisitGetRunV1Response(w http.ResponseWriter) error
+type ListRunsV1ResponseObject interface {
+	VisitListRunsV1Response(w http.ResponseWriter) error
 }
 
-type GetRunV1200JSONResponse GetRunV1Response
+type ListRunsV1200JSONResponse ListRunsV1Response
 
-func (response GetRunV1200JSONResponse) VisitGetRunV1Response(w http.ResponseWriter) error {
+func (response ListRunsV1200JSONResponse) VisitListRunsV1Response(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetRunV1401JSONResponse struct{ UnauthorizedJSONResponse }
+type ListRunsV1401JSONResponse struct{ UnauthorizedJSONResponse }
 
-func (response GetRunV1401JSONResponse) VisitGetRunV1Response(w http.ResponseWriter) error {
+func (response ListRunsV1401JSONResponse) VisitListRunsV1Response(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetRunV1404JSONResponse Error
+type ListRunsV1403JSONResponse struct{ ForbiddenJSONResponse }
 
-func (response GetRunV1404JSONResponse) VisitGetRunV1Response(w http.ResponseWriter) error {
+func (response ListRunsV1403JSONResponse) VisitListRunsV1Response(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListTaskResultsV1RequestObject struct {
-	Params ListTaskResultsV1Params
+type ScheduleRunV1RequestObject struct {
+	Body *ScheduleRunV1JSONRequestBody
+}
+
+type ScheduleRunV1ResponseObject interface {
+	VisitScheduleRunV1Response(w http.ResponseWriter) error
+}
+
+type ScheduleRunV1200JSONResponse ScheduleRunV1Response
+
+func (response ScheduleRunV1200JSONResponse) VisitScheduleRunV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ScheduleRunV1400JSONResponse Error
+
+func (response ScheduleRunV1400JSONResponse) VisitScheduleRunV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ScheduleRunV1401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ScheduleRunV1401JSONResponse) VisitScheduleRunV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ScheduleRunV1403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ScheduleRunV1403JSONResponse) VisitScheduleRunV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteRunV1RequestObject struct {
+	RunId int `json:"runId"`
+}
+
+type DeleteRunV1ResponseObject interface {
+	VisitDeleteRunV1Response(w http.ResponseWriter) error
+}
+
+type DeleteRunV1200JSONResponse DeleteRunV1Response
+
+func (response DeleteRunV1200JSONResponse) VisitDeleteRunV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteRunV1400JSONResponse Error
+
+func (response DeleteRunV1400JSONResponse) VisitDeleteRunV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteRunV1401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response DeleteRunV1401JSONResponse) VisitDeleteRunV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteRunV1403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response DeleteRunV1403JSONResponse) VisitDeleteRunV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteRunV1404JSONResponse Error
+
+func (response DeleteRunV1404JSONResponse) VisitDeleteRunV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRunV1RequestObject struct {
+	RunId int `json:"runId"`
+}
+
+type GetRunV1ResponseObject interface {
+	VisitGetRunV1Response(w http.ResponseWriter) error
+}
+
+type GetRunV1200JSONResponse GetRunV1Response
+
+func (response GetRunV1200JSONResponse) VisitGetRunV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRunV1401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetRunV1401JSONResponse) VisitGetRunV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRunV1403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetRunV1403JSONResponse) VisitGetRunV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRunV1404JSONResponse Error
+
+func (response GetRunV1404JSONResponse) VisitGetRunV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelRunV1RequestObject struct {
+	RunId int `json:"runId"`
+}
+
+type CancelRunV1ResponseObject interface {
+	VisitCancelRunV1Response(w http.ResponseWriter) error
+}
+
+type CancelRunV1200JSONResponse CancelRunV1Response
+
+func (response CancelRunV1200JSONResponse) VisitCancelRunV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelRunV1400JSONResponse Error
+
+func (response CancelRunV1400JSONResponse) VisitCancelRunV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelRunV1401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response CancelRunV1401JSONResponse) VisitCancelRunV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelRunV1403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response CancelRunV1403JSONResponse) VisitCancelRunV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type CancelRunV1404JSONResponse Error
+
+func (response CancelRunV1404JSONResponse) VisitCancelRunV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRepositoryLogV1RequestObject struct {
+	RunId  int `json:"runId"`
+	Params GetRepositoryLogV1Params
+}
+
+type GetRepositoryLogV1ResponseObject interface {
+	VisitGetRepositoryLogV1Response(w http.ResponseWriter) error
+}
+
+type GetRepositoryLogV1200JSONResponse GetRepositoryLogV1Response
+
+func (response GetRepositoryLogV1200JSONResponse) VisitGetRepositoryLogV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRepositoryLogV1401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetRepositoryLogV1401JSONResponse) VisitGetRepositoryLogV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRepositoryLogV1403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetRepositoryLogV1403JSONResponse) VisitGetRepositoryLogV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetRepositoryLogV1404JSONResponse Error
+
+func (response GetRepositoryLogV1404JSONResponse) VisitGetRepositoryLogV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTaskResultsV1RequestObject struct {
+	Params ListTaskResultsV1Params
+}
+
+type ListTaskResultsV1ResponseObject interface {
+	VisitListTaskResultsV1Response(w http.ResponseWriter) error
+}
+
+type ListTaskResultsV1200JSONResponse ListTaskResultsV1Response
+
+func (response ListTaskResultsV1200JSONResponse) VisitListTaskResultsV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTaskResultsV1401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListTaskResultsV1401JSONResponse) VisitListTaskResultsV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTaskResultsV1403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListTaskResultsV1403JSONResponse) VisitListTaskResultsV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTasksV1RequestObject struct {
+	Params ListTasksV1Params
+}
+
+type ListTasksV1ResponseObject interface {
+	VisitListTasksV1Response(w http.ResponseWriter) error
+}
+
+type ListTasksV1200JSONResponse ListTasksV1Response
+
+func (response ListTasksV1200JSONResponse) VisitListTasksV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTasksV1401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListTasksV1401JSONResponse) VisitListTasksV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTasksV1403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListTasksV1403JSONResponse) VisitListTasksV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReloadTasksV1RequestObject struct {
+}
+
+type ReloadTasksV1ResponseObject interface {
+	VisitReloadTasksV1Response(w http.ResponseWriter) error
+}
+
+type ReloadTasksV1200JSONResponse ReloadTasksV1Response
+
+func (response ReloadTasksV1200JSONResponse) VisitReloadTasksV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReloadTasksV1400JSONResponse Error
+
+func (response ReloadTasksV1400JSONResponse) VisitReloadTasksV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReloadTasksV1401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ReloadTasksV1401JSONResponse) VisitReloadTasksV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ReloadTasksV1403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ReloadTasksV1403JSONResponse) VisitReloadTasksV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTaskV1RequestObject struct {
+	Task string `json:"task"`
+}
+
+type GetTaskV1ResponseObject interface {
+	VisitGetTaskV1Response(w http.ResponseWriter) error
+}
+
+type GetTaskV1200JSONResponse GetTaskV1Response
+
+func (response GetTaskV1200JSONResponse) VisitGetTaskV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTaskV1401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response GetTaskV1401JSONResponse) VisitGetTaskV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTaskV1403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetTaskV1403JSONResponse) VisitGetTaskV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTaskV1404JSONResponse Error
+
+func (response GetTaskV1404JSONResponse) VisitGetTaskV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetTaskV1500JSONResponse Error
+
+func (response GetTaskV1500JSONResponse) VisitGetTaskV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTaskRecentTaskResultsV1RequestObject struct {
+	Task   string `json:"task"`
+	Params ListTaskRecentTaskResultsV1Params
+}
+
+type ListTaskRecentTaskResultsV1ResponseObject interface {
+	VisitListTaskRecentTaskResultsV1Response(w http.ResponseWriter) error
+}
+
+type ListTaskRecentTaskResultsV1200JSONResponse ListTaskRecentTaskResultsV1Response
+
+func (response ListTaskRecentTaskResultsV1200JSONResponse) VisitListTaskRecentTaskResultsV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTaskRecentTaskResultsV1401JSONResponse struct{ UnauthorizedJSONResponse }
+
+func (response ListTaskRecentTaskResultsV1401JSONResponse) VisitListTaskRecentTaskResultsV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTaskRecentTaskResultsV1403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ListTaskRecentTaskResultsV1403JSONResponse) VisitListTaskRecentTaskResultsV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTaskRecentTaskResultsV1404JSONResponse Error
+
+func (response ListTaskRecentTaskResultsV1404JSONResponse) VisitListTaskRecentTaskResultsV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type ListTaskRecentTaskResultsV1500JSONResponse Error
+
+func (response ListTaskRecentTaskResultsV1500JSONResponse) VisitListTaskRecentTaskResultsV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type UpdateTaskRolloutV1RequestObject struct {
+	Task string `json:"task"`
+	Body *UpdateTaskRolloutV1JSONRequestBody
 }
 
-type ListTaskResultsV1ResponseObject interface {
-	VisitListTaskResultsV1Response(w http.ResponseWriter) error
+type UpdateTaskRolloutV1ResponseObject interface {
+	VisitUpdateTaskRolloutV1Response(w http.ResponseWriter) error
 }
 
-type ListTaskResultsV1200JSONResponse ListTaskResultsV1Response
+type UpdateTaskRolloutV1200JSONResponse TaskRolloutV1
 
-func (response ListTaskResultsV1200JSONResponse) VisitListTaskResultsV1Response(w http.ResponseWriter) error {
+func (response UpdateTaskRolloutV1200JSONResponse) VisitUpdateTaskRolloutV1Response(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListTaskResultsV1401JSONResponse struct{ UnauthorizedJSONResponse }
+type UpdateTaskRolloutV1400JSONResponse Error
 
-func (response ListTaskResultsV1401JSONResponse) VisitListTaskResultsV1Response(w http.ResponseWriter) error {
+func (response UpdateTaskRolloutV1400JSONResponse) VisitUpdateTaskRolloutV1Response(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	w.WriteHeader(400)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListTasksV1RequestObject struct {
-	Params ListTasksV1Params
-}
+type UpdateTaskRolloutV1401JSONResponse struct{ UnauthorizedJSONResponse }
 
-type ListTasksV1ResponseObject interface {
-	VisitListTasksV1Response(w http.ResponseWriter) error
+func (response UpdateTaskRolloutV1401JSONResponse) VisitUpdateTaskRolloutV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
 }
 
-type ListTasksV1200JSONResponse ListTasksV1Response
+type UpdateTaskRolloutV1403JSONResponse struct{ ForbiddenJSONResponse }
 
-func (response ListTasksV1200JSONResponse) VisitListTasksV1Response(w http.ResponseWriter) error {
+func (response UpdateTaskRolloutV1403JSONResponse) VisitUpdateTaskRolloutV1Response(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListTasksV1401JSONResponse struct{ UnauthorizedJSONResponse }
+type UpdateTaskRolloutV1404JSONResponse Error
 
-func (response ListTasksV1401JSONResponse) VisitListTasksV1Response(w http.ResponseWriter) error {
+func (response UpdateTaskRolloutV1404JSONResponse) VisitUpdateTaskRolloutV1Response(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(401)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetTaskV1RequestObject struct {
-	Task string `json:"task"`
+type ListWebhookDeliveriesV1RequestObject struct {
+	Params ListWebhookDeliveriesV1Params
 }
 
-type GetTaskV1ResponseObject interface {
-	VisitGetTaskV1Response(w http.ResponseWriter) error
+type ListWebhookDeliveriesV1ResponseObject interface {
+	VisitListWebhookDeliveriesV1Response(w http.ResponseWriter) error
 }
 
-type GetTaskV1200JSONResponse GetTaskV1Response
+type ListWebhookDeliveriesV1200JSONResponse ListWebhookDeliveriesV1Response
 
-func (response GetTaskV1200JSONResponse) VisitGetTaskV1Response(w http.ResponseWriter) error {
+func (response ListWebhookDeliveriesV1200JSONResponse) VisitListWebhookDeliveriesV1Response(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetTaskV1401JSONResponse struct{ UnauthorizedJSONResponse }
+type ListWebhookDeliveriesV1401JSONResponse struct{ UnauthorizedJSONResponse }
 
-func (response GetTaskV1401JSONResponse) VisitGetTaskV1Response(w http.ResponseWriter) error {
+func (response ListWebhookDeliveriesV1401JSONResponse) VisitListWebhookDeliveriesV1Response(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetTaskV1404JSONResponse Error
-
-func (response GetTaskV1404JSONResponse) VisitGetTaskV1Response(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
-
-	return json.NewEncoder(w).Encode(response)
-}
-
-type GetTaskV1500JSONResponse Error
+type ListWebhookDeliveriesV1403JSONResponse struct{ ForbiddenJSONResponse }
 
-func (response GetTaskV1500JSONResponse) VisitGetTaskV1Response(w http.ResponseWriter) error {
+func (response ListWebhookDeliveriesV1403JSONResponse) VisitListWebhookDeliveriesV1Response(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListTaskRecentTaskResultsV1RequestObject struct {
-	Task   string `json:"task"`
-	Params ListTaskRecentTaskResultsV1Params
+type HeartbeatWorkV1RequestObject struct {
+	Body *HeartbeatWorkV1JSONRequestBody
 }
 
-type ListTaskRecentTaskResultsV1ResponseObject interface {
-	VisitListTaskRecentTaskResultsV1Response(w http.ResponseWriter) error
+type HeartbeatWorkV1ResponseObject interface {
+	VisitHeartbeatWorkV1Response(w http.ResponseWriter) error
 }
 
-type ListTaskRecentTaskResultsV1200JSONResponse ListTaskRecentTaskResultsV1Response
+type HeartbeatWorkV1200JSONResponse HeartbeatWorkV1Response
 
-func (response ListTaskRecentTaskResultsV1200JSONResponse) VisitListTaskRecentTaskResultsV1Response(w http.ResponseWriter) error {
+func (response HeartbeatWorkV1200JSONResponse) VisitHeartbeatWorkV1Response(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(200)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListTaskRecentTaskResultsV1401JSONResponse struct{ UnauthorizedJSONResponse }
+type HeartbeatWorkV1401JSONResponse struct{ UnauthorizedJSONResponse }
 
-func (response ListTaskRecentTaskResultsV1401JSONResponse) VisitListTaskRecentTaskResultsV1Response(w http.ResponseWriter) error {
+func (response HeartbeatWorkV1401JSONResponse) VisitHeartbeatWorkV1Response(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(401)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListTaskRecentTaskResultsV1404JSONResponse Error
+type HeartbeatWorkV1403JSONResponse struct{ ForbiddenJSONResponse }
 
-func (response ListTaskRecentTaskResultsV1404JSONResponse) VisitListTaskRecentTaskResultsV1Response(w http.ResponseWriter) error {
+func (response HeartbeatWorkV1403JSONResponse) VisitHeartbeatWorkV1Response(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	w.WriteHeader(403)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type ListTaskRecentTaskResultsV1500JSONResponse Error
+type HeartbeatWorkV1404JSONResponse Error
 
-func (response ListTaskRecentTaskResultsV1500JSONResponse) VisitListTaskRecentTaskResultsV1Response(w http.ResponseWriter) error {
+func (response HeartbeatWorkV1404JSONResponse) VisitHeartbeatWorkV1Response(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	w.WriteHeader(404)
 
 	return json.NewEncoder(w).Encode(response)
 }
@@ -1280,6 +2404,15 @@ func (response GetWorkV1401JSONResponse) VisitGetWorkV1Response(w http.ResponseW
 	return json.NewEncoder(w).Encode(response)
 }
 
+type GetWorkV1403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response GetWorkV1403JSONResponse) VisitGetWorkV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type ReportWorkV1RequestObject struct {
 	Body *ReportWorkV1JSONRequestBody
 }
@@ -1306,8 +2439,26 @@ func (response ReportWorkV1401JSONResponse) VisitReportWorkV1Response(w http.Res
 	return json.NewEncoder(w).Encode(response)
 }
 
+type ReportWorkV1403JSONResponse struct{ ForbiddenJSONResponse }
+
+func (response ReportWorkV1403JSONResponse) VisitReportWorkV1Response(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
+	// List API tokens.
+	// (GET /api/v1/apiTokens)
+	ListApiTokensV1(ctx context.Context, request ListApiTokensV1RequestObject) (ListApiTokensV1ResponseObject, error)
+	// Create an API token.
+	// (POST /api/v1/apiTokens)
+	CreateApiTokenV1(ctx context.Context, request CreateApiTokenV1RequestObject) (CreateApiTokenV1ResponseObject, error)
+	// Revoke an API token.
+	// (DELETE /api/v1/apiTokens/{name})
+	DeleteApiTokenV1(ctx context.Context, request DeleteApiTokenV1RequestObject) (DeleteApiTokenV1ResponseObject, error)
 	// List of runs.
 	// (GET /api/v1/runs)
 	ListRunsV1(ctx context.Context, request ListRunsV1RequestObject) (ListRunsV1ResponseObject, error)
@@ -1320,18 +2471,36 @@ type StrictServerInterface interface {
 	// View data of a run.
 	// (GET /api/v1/runs/{runId})
 	GetRunV1(ctx context.Context, request GetRunV1RequestObject) (GetRunV1ResponseObject, error)
+	// Cancel a run.
+	// (POST /api/v1/runs/{runId}/cancel)
+	CancelRunV1(ctx context.Context, request CancelRunV1RequestObject) (CancelRunV1ResponseObject, error)
+	// View the log of a repository.
+	// (GET /api/v1/runs/{runId}/logs)
+	GetRepositoryLogV1(ctx context.Context, request GetRepositoryLogV1RequestObject) (GetRepositoryLogV1ResponseObject, error)
 	// Task results
 	// (GET /api/v1/taskResults)
 	ListTaskResultsV1(ctx context.Context, request ListTaskResultsV1RequestObject) (ListTaskResultsV1ResponseObject, error)
 	// List tasks.
 	// (GET /api/v1/tasks)
 	ListTasksV1(ctx context.Context, request ListTasksV1RequestObject) (ListTasksV1ResponseObject, error)
+	// Reload tasks.
+	// (POST /api/v1/tasks/reload)
+	ReloadTasksV1(ctx context.Context, request ReloadTasksV1RequestObject) (ReloadTasksV1ResponseObject, error)
 	// Get information about a task.
 	// (GET /api/v1/tasks/{task})
 	GetTaskV1(ctx context.Context, request GetTaskV1RequestObject) (GetTaskV1ResponseObject, error)
 	// List recent run results of a task by repository.
 	// (GET /api/v1/tasks/{task}/results)
 	ListTaskRecentTaskResultsV1(ctx context.Context, request ListTaskRecentTaskResultsV1RequestObject) (ListTaskRecentTaskResultsV1ResponseObject, error)
+	// Promote, pause or resume the rollout of a task.
+	// (POST /api/v1/tasks/{task}/rollout)
+	UpdateTaskRolloutV1(ctx context.Context, request UpdateTaskRolloutV1RequestObject) (UpdateTaskRolloutV1ResponseObject, error)
+	// List of webhook deliveries.
+	// (GET /api/v1/webhookDeliveries)
+	ListWebhookDeliveriesV1(ctx context.Context, request ListWebhookDeliveriesV1RequestObject) (ListWebhookDeliveriesV1ResponseObject, error)
+	// Send a heartbeat for a unit of work.
+	// (POST /api/v1/worker/heartbeat)
+	HeartbeatWorkV1(ctx context.Context, request HeartbeatWorkV1RequestObject) (HeartbeatWorkV1ResponseObject, error)
 	// Get a unit of work.
 	// (GET /api/v1/worker/work)
 	GetWorkV1(ctx context.Context, request GetWorkV1RequestObject) (GetWorkV1ResponseObject, error)
@@ -1369,6 +2538,89 @@ type strictHandler struct {
 	options     StrictHTTPServerOptions
 }
 
+// ListApiTokensV1 operation middleware
+func (sh *strictHandler) ListApiTokensV1(w http.ResponseWriter, r *http.Request, params ListApiTokensV1Params) {
+	var request ListApiTokensV1RequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListApiTokensV1(ctx, request.(ListApiTokensV1RequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListApiTokensV1")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListApiTokensV1ResponseObject); ok {
+		if err := validResponse.VisitListApiTokensV1Response(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// CreateApiTokenV1 operation middleware
+func (sh *strictHandler) CreateApiTokenV1(w http.ResponseWriter, r *http.Request) {
+	var request CreateApiTokenV1RequestObject
+
+	var body CreateApiTokenV1JSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateApiTokenV1(ctx, request.(CreateApiTokenV1RequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateApiTokenV1")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CreateApiTokenV1ResponseObject); ok {
+		if err := validResponse.VisitCreateApiTokenV1Response(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// DeleteApiTokenV1 operation middleware
+func (sh *strictHandler) DeleteApiTokenV1(w http.ResponseWriter, r *http.Request, name string) {
+	var request DeleteApiTokenV1RequestObject
+
+	request.Name = name
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteApiTokenV1(ctx, request.(DeleteApiTokenV1RequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteApiTokenV1")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(DeleteApiTokenV1ResponseObject); ok {
+		if err := validResponse.VisitDeleteApiTokenV1Response(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // ListRunsV1 operation middleware
 func (sh *strictHandler) ListRunsV1(w http.ResponseWriter, r *http.Request, params ListRunsV1Params) {
 	var request ListRunsV1RequestObject
@@ -1478,6 +2730,59 @@ func (sh *strictHandler) GetRunV1(w http.ResponseWriter, r *http.Request, runId
 	}
 }
 
+// CancelRunV1 operation middleware
+func (sh *strictHandler) CancelRunV1(w http.ResponseWriter, r *http.Request, runId int) {
+	var request CancelRunV1RequestObject
+
+	request.RunId = runId
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.CancelRunV1(ctx, request.(CancelRunV1RequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CancelRunV1")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(CancelRunV1ResponseObject); ok {
+		if err := validResponse.VisitCancelRunV1Response(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// GetRepositoryLogV1 operation middleware
+func (sh *strictHandler) GetRepositoryLogV1(w http.ResponseWriter, r *http.Request, runId int, params GetRepositoryLogV1Params) {
+	var request GetRepositoryLogV1RequestObject
+
+	request.RunId = runId
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.GetRepositoryLogV1(ctx, request.(GetRepositoryLogV1RequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetRepositoryLogV1")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(GetRepositoryLogV1ResponseObject); ok {
+		if err := validResponse.VisitGetRepositoryLogV1Response(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // ListTaskResultsV1 operation middleware
 func (sh *strictHandler) ListTaskResultsV1(w http.ResponseWriter, r *http.Request, params ListTaskResultsV1Params) {
 	var request ListTaskResultsV1RequestObject
@@ -1530,6 +2835,30 @@ func (sh *strictHandler) ListTasksV1(w http.ResponseWriter, r *http.Request, par
 	}
 }
 
+// ReloadTasksV1 operation middleware
+func (sh *strictHandler) ReloadTasksV1(w http.ResponseWriter, r *http.Request) {
+	var request ReloadTasksV1RequestObject
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ReloadTasksV1(ctx, request.(ReloadTasksV1RequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ReloadTasksV1")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ReloadTasksV1ResponseObject); ok {
+		if err := validResponse.VisitReloadTasksV1Response(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetTaskV1 operation middleware
 func (sh *strictHandler) GetTaskV1(w http.ResponseWriter, r *http.Request, task string) {
 	var request GetTaskV1RequestObject
@@ -1583,6 +2912,96 @@ func (sh *strictHandler) ListTaskRecentTaskResultsV1(w http.ResponseWriter, r *h
 	}
 }
 
+// UpdateTaskRolloutV1 operation middleware
+func (sh *strictHandler) UpdateTaskRolloutV1(w http.ResponseWriter, r *http.Request, task string) {
+	var request UpdateTaskRolloutV1RequestObject
+
+	request.Task = task
+
+	var body UpdateTaskRolloutV1JSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateTaskRolloutV1(ctx, request.(UpdateTaskRolloutV1RequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateTaskRolloutV1")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(UpdateTaskRolloutV1ResponseObject); ok {
+		if err := validResponse.VisitUpdateTaskRolloutV1Response(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// ListWebhookDeliveriesV1 operation middleware
+func (sh *strictHandler) ListWebhookDeliveriesV1(w http.ResponseWriter, r *http.Request, params ListWebhookDeliveriesV1Params) {
+	var request ListWebhookDeliveriesV1RequestObject
+
+	request.Params = params
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.ListWebhookDeliveriesV1(ctx, request.(ListWebhookDeliveriesV1RequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListWebhookDeliveriesV1")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(ListWebhookDeliveriesV1ResponseObject); ok {
+		if err := validResponse.VisitListWebhookDeliveriesV1Response(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
+// HeartbeatWorkV1 operation middleware
+func (sh *strictHandler) HeartbeatWorkV1(w http.ResponseWriter, r *http.Request) {
+	var request HeartbeatWorkV1RequestObject
+
+	var body HeartbeatWorkV1JSONRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sh.options.RequestErrorHandlerFunc(w, r, fmt.Errorf("can't decode JSON body: %w", err))
+		return
+	}
+	request.Body = &body
+
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, request interface{}) (interface{}, error) {
+		return sh.ssi.HeartbeatWorkV1(ctx, request.(HeartbeatWorkV1RequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "HeartbeatWorkV1")
+	}
+
+	response, err := handler(r.Context(), w, r, request)
+
+	if err != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, err)
+	} else if validResponse, ok := response.(HeartbeatWorkV1ResponseObject); ok {
+		if err := validResponse.VisitHeartbeatWorkV1Response(w); err != nil {
+			sh.options.ResponseErrorHandlerFunc(w, r, err)
+		}
+	} else if response != nil {
+		sh.options.ResponseErrorHandlerFunc(w, r, fmt.Errorf("unexpected response type: %T", response))
+	}
+}
+
 // GetWorkV1 operation middleware
 func (sh *strictHandler) GetWorkV1(w http.ResponseWriter, r *http.Request) {
 	var request GetWorkV1RequestObject
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 
@@ -16,8 +17,41 @@ import (
 	"go.uber.org/zap"
 )
 
+// CancelRunV1 implements [github.com/wndhydrnt/saturn-bot/pkg/server/api/openapi.ServerInterface].
+func (a *APIServer) CancelRunV1(ctx context.Context, req openapi.CancelRunV1RequestObject) (openapi.CancelRunV1ResponseObject, error) {
+	if token := apiTokenFromContext(ctx); token != nil {
+		run, err := a.WorkerService.GetRun(req.RunId)
+		if err == nil && !service.IsTaskAllowed(ptr.From(token), run.TaskName) {
+			return nil, fmt.Errorf("%w: api token %s cannot cancel runs of task %s", errForbidden, token.Name, run.TaskName)
+		}
+	}
+
+	deleted, err := a.WorkerService.CancelRun(req.RunId)
+	var clientErr sberror.Client
+	if errors.As(err, &clientErr) {
+		if clientErr.ErrorID() == sberror.ClientIDRunCannotCancel {
+			return openapi.CancelRunV1400JSONResponse(clientErr.ToApiError()), nil
+		}
+
+		return openapi.CancelRunV1404JSONResponse(clientErr.ToApiError()), nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return openapi.CancelRunV1200JSONResponse{Deleted: deleted}, nil
+}
+
 // DeleteRunV1 implements [github.com/wndhydrnt/saturn-bot/pkg/server/api/openapi.ServerInterface].
-func (a *APIServer) DeleteRunV1(_ context.Context, req openapi.DeleteRunV1RequestObject) (openapi.DeleteRunV1ResponseObject, error) {
+func (a *APIServer) DeleteRunV1(ctx context.Context, req openapi.DeleteRunV1RequestObject) (openapi.DeleteRunV1ResponseObject, error) {
+	if token := apiTokenFromContext(ctx); token != nil {
+		run, err := a.WorkerService.GetRun(req.RunId)
+		if err == nil && !service.IsTaskAllowed(ptr.From(token), run.TaskName) {
+			return nil, fmt.Errorf("%w: api token %s cannot delete runs of task %s", errForbidden, token.Name, run.TaskName)
+		}
+	}
+
 	err := a.WorkerService.DeleteRun(req.RunId)
 	var clientErr sberror.Client
 	if errors.As(err, &clientErr) {
@@ -52,6 +86,26 @@ func (a *APIServer) GetRunV1(_ context.Context, req openapi.GetRunV1RequestObjec
 	}, nil
 }
 
+// GetRepositoryLogV1 implements [github.com/wndhydrnt/saturn-bot/pkg/server/api/openapi.ServerInterface].
+func (a *APIServer) GetRepositoryLogV1(_ context.Context, req openapi.GetRepositoryLogV1RequestObject) (openapi.GetRepositoryLogV1ResponseObject, error) {
+	repositoryLog, content, err := a.RepositoryLogService.GetLog(req.RunId, req.Params.RepositoryName)
+	var clientErr sberror.Client
+	if errors.As(err, &clientErr) {
+		return openapi.GetRepositoryLogV1404JSONResponse(clientErr.ToApiError()), nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return openapi.GetRepositoryLogV1200JSONResponse{
+		CreatedAt:      repositoryLog.CreatedAt,
+		Log:            content,
+		RepositoryName: repositoryLog.RepositoryName,
+		RunId:          int(repositoryLog.RunID),
+	}, nil
+}
+
 // ListRunsV1 implements [github.com/wndhydrnt/saturn-bot/pkg/server/api/openapi.ServerInterface].
 func (a *APIServer) ListRunsV1(ctx context.Context, request openapi.ListRunsV1RequestObject) (openapi.ListRunsV1ResponseObject, error) {
 	listOpts := toListOptions(request.Params.ListOptions)
@@ -93,7 +147,16 @@ func (a *APIServer) ListRunsV1(ctx context.Context, request openapi.ListRunsV1Re
 }
 
 // ScheduleRunV1 implements [github.com/wndhydrnt/saturn-bot/pkg/server/api/openapi.ServerInterface].
-func (a *APIServer) ScheduleRunV1(_ context.Context, req openapi.ScheduleRunV1RequestObject) (openapi.ScheduleRunV1ResponseObject, error) {
+func (a *APIServer) ScheduleRunV1(ctx context.Context, req openapi.ScheduleRunV1RequestObject) (openapi.ScheduleRunV1ResponseObject, error) {
+	var apiTokenName *string
+	if token := apiTokenFromContext(ctx); token != nil {
+		if !service.IsTaskAllowed(ptr.From(token), req.Body.TaskName) {
+			return nil, fmt.Errorf("%w: api token %s cannot schedule runs of task %s", errForbidden, token.Name, req.Body.TaskName)
+		}
+
+		apiTokenName = ptr.To(token.Name)
+	}
+
 	var schedulerAfter time.Time
 	if req.Body.ScheduleAfter == nil {
 		schedulerAfter = a.Clock.Now()
@@ -121,7 +184,14 @@ func (a *APIServer) ScheduleRunV1(_ context.Context, req openapi.ScheduleRunV1Re
 		runData[sbcontext.RunDataKeyReviewers] = strings.Join(ptr.From(req.Body.Reviewers), ",")
 	}
 
-	runID, err := a.WorkerService.ScheduleRun(db.RunReasonManual, repositoryNames, schedulerAfter, req.Body.TaskName, runData, nil)
+	runID, err := a.WorkerService.ScheduleRun(service.ScheduleRunOptions{
+		ApiTokenName:    apiTokenName,
+		Reason:          db.RunReasonManual,
+		RepositoryNames: repositoryNames,
+		RunData:         runData,
+		ScheduleAfter:   schedulerAfter,
+		TaskName:        req.Body.TaskName,
+	}, nil)
 	if err != nil {
 		var clientErr sberror.Client
 		if errors.As(err, &clientErr) {
@@ -10,6 +10,7 @@ import (
 	"github.com/wndhydrnt/saturn-bot/pkg/server/db"
 	sberror "github.com/wndhydrnt/saturn-bot/pkg/server/error"
 	"github.com/wndhydrnt/saturn-bot/pkg/server/service"
+	"github.com/wndhydrnt/saturn-bot/pkg/task"
 	"github.com/wndhydrnt/saturn-bot/pkg/task/schema"
 )
 
@@ -45,6 +46,93 @@ func (a *APIServer) GetTaskV1(_ context.Context, request openapi.GetTaskV1Reques
 		resp.Inputs = ptr.To(inputs)
 	}
 
+	if t.Rollout != nil {
+		rollout, err := a.RolloutService.GetRollout(t, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Rollout = ptr.To(mapRollout(rollout, t))
+	}
+
+	return resp, nil
+}
+
+// UpdateTaskRolloutV1 implements [openapi.ServerInterface].
+func (a *APIServer) UpdateTaskRolloutV1(ctx context.Context, request openapi.UpdateTaskRolloutV1RequestObject) (openapi.UpdateTaskRolloutV1ResponseObject, error) {
+	if token := apiTokenFromContext(ctx); token != nil && !service.IsTaskAllowed(ptr.From(token), request.Task) {
+		return nil, fmt.Errorf("%w: api token %s cannot update the rollout of task %s", errForbidden, token.Name, request.Task)
+	}
+
+	t, err := a.TaskService.GetTask(request.Task)
+	var clientErr sberror.Client
+	if errors.As(err, &clientErr) {
+		return openapi.UpdateTaskRolloutV1404JSONResponse(clientErr.ToApiError()), nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var rollout db.Rollout
+	switch request.Body.Action {
+	case openapi.Pause:
+		rollout, err = a.RolloutService.Pause(t, "paused manually", nil)
+	case openapi.Promote:
+		rollout, err = a.RolloutService.Promote(t, nil)
+	case openapi.Resume:
+		rollout, err = a.RolloutService.Resume(t, nil)
+	default:
+		err = sberror.NewRolloutUnknownActionError(string(request.Body.Action))
+	}
+
+	if errors.As(err, &clientErr) {
+		return openapi.UpdateTaskRolloutV1400JSONResponse(clientErr.ToApiError()), nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Body.Action != openapi.Pause {
+		// Apply the task to the repositories of the new wave
+		// or check the gate of the current wave again.
+		_, err := a.WorkerService.ScheduleRun(service.ScheduleRunOptions{
+			Reason:        db.RunReasonNext,
+			ScheduleAfter: a.Clock.Now(),
+			TaskName:      t.Name,
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return openapi.UpdateTaskRolloutV1200JSONResponse(mapRollout(rollout, t)), nil
+}
+
+// ReloadTasksV1 implements [openapi.ServerInterface].
+func (a *APIServer) ReloadTasksV1(_ context.Context, _ openapi.ReloadTasksV1RequestObject) (openapi.ReloadTasksV1ResponseObject, error) {
+	tasks, err := a.TaskReloadService.Reload()
+	if err != nil {
+		var clientErr sberror.Client
+		if errors.As(err, &clientErr) {
+			return openapi.ReloadTasksV1400JSONResponse(clientErr.ToApiError()), nil
+		}
+
+		return nil, fmt.Errorf("ReloadTasksV1: %w", err)
+	}
+
+	resp := openapi.ReloadTasksV1200JSONResponse{
+		Results: []openapi.ListTasksV1ResponseTask{},
+	}
+	for _, t := range tasks {
+		resp.Results = append(resp.Results, openapi.ListTasksV1ResponseTask{
+			Active:   true,
+			Checksum: t.Checksum(),
+			Name:     t.Name,
+		})
+	}
+
 	return resp, nil
 }
 
@@ -175,6 +263,25 @@ func mapTaskResultFromDbToApi(db db.TaskResult) openapi.TaskResultV1 {
 		api.PullRequestUrl = db.PullRequestUrl
 	}
 
+	if db.TargetBranch != "" {
+		api.TargetBranch = ptr.To(db.TargetBranch)
+	}
+
+	return api
+}
+
+func mapRollout(rollout db.Rollout, t *task.Task) openapi.TaskRolloutV1 {
+	api := openapi.TaskRolloutV1{
+		CurrentWave:   rollout.Wave,
+		Paused:        rollout.Paused,
+		PausedReason:  rollout.PausedReason,
+		WaveStartedAt: rollout.WaveStartedAt,
+		Waves:         []string{},
+	}
+	for _, wave := range t.Rollout.Waves {
+		api.Waves = append(api.Waves, wave.Name)
+	}
+
 	return api
 }
 
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"context"
+
+	"github.com/wndhydrnt/saturn-bot/pkg/log"
+	"github.com/wndhydrnt/saturn-bot/pkg/ptr"
+	"github.com/wndhydrnt/saturn-bot/pkg/server/api/openapi"
+	"github.com/wndhydrnt/saturn-bot/pkg/server/db"
+	"github.com/wndhydrnt/saturn-bot/pkg/server/service"
+	"go.uber.org/zap"
+)
+
+// ListWebhookDeliveriesV1 implements [github.com/wndhydrnt/saturn-bot/pkg/server/api/openapi.ServerInterface].
+func (a *APIServer) ListWebhookDeliveriesV1(_ context.Context, request openapi.ListWebhookDeliveriesV1RequestObject) (openapi.ListWebhookDeliveriesV1ResponseObject, error) {
+	listOpts := toListOptions(request.Params.ListOptions)
+	queryOpts := service.ListWebhookDeliveriesOptions{}
+	if request.Params.Status != nil {
+		for _, apiStatus := range ptr.From(request.Params.Status) {
+			queryOpts.Status = append(queryOpts.Status, db.WebhookDeliveryStatus(apiStatus))
+		}
+	}
+
+	deliveries, err := a.WebhookService.ListDeliveries(queryOpts, &listOpts)
+	if err != nil {
+		log.Log().Errorw("Failed to list webhook deliveries", zap.Error(err))
+		return nil, ErrInternal
+	}
+
+	result := make([]openapi.WebhookDeliveryV1, len(deliveries))
+	for idx, delivery := range deliveries {
+		result[idx] = mapWebhookDelivery(delivery)
+	}
+
+	return openapi.ListWebhookDeliveriesV1200JSONResponse{
+		Page: openapi.Page{
+			PreviousPage: listOpts.Previous(),
+			CurrentPage:  listOpts.Page,
+			NextPage:     listOpts.Next(),
+			ItemsPerPage: listOpts.Limit,
+			TotalItems:   listOpts.TotalItems(),
+			TotalPages:   listOpts.TotalPages(),
+		},
+		Result: result,
+	}, nil
+}
+
+func mapWebhookDelivery(d db.WebhookDelivery) openapi.WebhookDeliveryV1 {
+	delivery := openapi.WebhookDeliveryV1{
+		Attempts:     d.Attempts,
+		CreatedAt:    d.CreatedAt,
+		DeliveryId:   d.DeliveryID,
+		Error:        d.Error,
+		Event:        d.Event,
+		Id:           d.ID,
+		MatchedTasks: []string(d.MatchedTasks),
+		ProcessedAt:  d.ProcessedAt,
+		Status:       openapi.WebhookDeliveryStatusV1(d.Status),
+		Type:         openapi.WebhookDeliveryV1Type(d.Type),
+	}
+	if delivery.MatchedTasks == nil {
+		delivery.MatchedTasks = []string{}
+	}
+
+	if d.Source != "" {
+		delivery.Source = ptr.To(d.Source)
+	}
+
+	if d.Status == db.WebhookDeliveryStatusPending {
+		delivery.NextAttemptAt = ptr.To(d.NextAttemptAt)
+	}
+
+	return delivery
+}
@@ -3,11 +3,16 @@ package api
 import (
 	"context"
 	"errors"
+	"maps"
+	"strconv"
+	"strings"
 
+	sbcontext "github.com/wndhydrnt/saturn-bot/pkg/context"
 	"github.com/wndhydrnt/saturn-bot/pkg/log"
 	"github.com/wndhydrnt/saturn-bot/pkg/ptr"
 	"github.com/wndhydrnt/saturn-bot/pkg/server/api/openapi"
 	"github.com/wndhydrnt/saturn-bot/pkg/server/db"
+	sberror "github.com/wndhydrnt/saturn-bot/pkg/server/error"
 	"github.com/wndhydrnt/saturn-bot/pkg/server/service"
 	"go.uber.org/zap"
 )
@@ -28,12 +33,53 @@ func (a *APIServer) GetWorkV1(ctx context.Context, _ openapi.GetWorkV1RequestObj
 		resp.Repositories = ptr.To([]string(run.RepositoryNames))
 	}
 
-	if len(run.RunData) > 0 {
-		resp.RunData = ptr.To(map[string]string(run.RunData))
+	runData := maps.Clone(map[string]string(run.RunData))
+	if task.Rollout != nil {
+		rollout, err := a.RolloutService.GetRollout(task, nil)
+		if err != nil {
+			log.Log().Errorw("Failed to get rollout of task", zap.Error(err))
+			return resp, ErrInternal
+		}
+
+		if runData == nil {
+			runData = map[string]string{}
+		}
+
+		// Not stored in the run because the rollout can progress while the run is pending.
+		runData[sbcontext.RunDataKeyRolloutWave] = strconv.Itoa(rollout.Wave)
+	}
+
+	ignoredRepositories, err := a.WorkerService.ListIgnoredRepositories(task.Name)
+	if err != nil {
+		log.Log().Errorw("Failed to list ignored repositories of task", zap.Error(err))
+		return resp, ErrInternal
+	}
+
+	if len(ignoredRepositories) > 0 {
+		if runData == nil {
+			runData = map[string]string{}
+		}
+
+		// Not stored in the run because users can ignore repositories while the run is pending.
+		runData[sbcontext.RunDataKeyIgnoredRepositories] = strings.Join(ignoredRepositories, ",")
+	}
+
+	if len(run.RepositoryNames) > 0 && (run.Reason == db.RunReasonWebhook || run.Reason == db.RunReasonDependency) {
+		if runData == nil {
+			runData = map[string]string{}
+		}
+
+		// Repositories extracted from a webhook or unblocked by a dependency
+		// still need to match the filters of the task.
+		runData[sbcontext.RunDataKeyFilterRepositories] = "true"
+	}
+
+	if len(runData) > 0 {
+		resp.RunData = ptr.To(runData)
 	}
 
 	resp.RunID = int(run.ID) // #nosec G115 -- no info by gosec on how to fix this
-	resp.Task = openapi.WorkTaskV1{Hash: task.Checksum(), Name: task.Name}
+	resp.Task = openapi.WorkTaskV1{Hash: task.Checksum(), Name: task.Name, Revision: run.TaskRevision}
 	return resp, nil
 }
 
@@ -48,16 +94,34 @@ func (a *APIServer) ReportWorkV1(_ context.Context, request openapi.ReportWorkV1
 	return resp, nil
 }
 
+// HeartbeatWorkV1 implements [github.com/wndhydrnt/saturn-bot/pkg/server/api/openapi.ServerInterface].
+func (a *APIServer) HeartbeatWorkV1(_ context.Context, request openapi.HeartbeatWorkV1RequestObject) (openapi.HeartbeatWorkV1ResponseObject, error) {
+	cancelled, err := a.WorkerService.HeartbeatRun(request.Body.RunID)
+	var clientErr sberror.Client
+	if errors.As(err, &clientErr) {
+		return openapi.HeartbeatWorkV1404JSONResponse(clientErr.ToApiError()), nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return openapi.HeartbeatWorkV1200JSONResponse{Cancelled: cancelled}, nil
+}
+
 func mapRun(r db.Run) openapi.RunV1 {
 	run := openapi.RunV1{
-		Error:         r.Error,
-		FinishedAt:    r.FinishedAt,
-		Id:            r.ID,
-		Reason:        mapRunReason(r.Reason),
-		ScheduleAfter: r.ScheduleAfter,
-		StartedAt:     r.StartedAt,
-		Status:        mapRunStatus(r.Status),
-		Task:          r.TaskName,
+		ApiTokenName:      r.ApiTokenName,
+		CancelRequestedAt: r.CancelRequestedAt,
+		Error:             r.Error,
+		FinishedAt:        r.FinishedAt,
+		Id:                r.ID,
+		Reason:            mapRunReason(r.Reason),
+		ScheduleAfter:     r.ScheduleAfter,
+		StartedAt:         r.StartedAt,
+		Status:            mapRunStatus(r.Status),
+		Task:              r.TaskName,
+		TaskRevision:      r.TaskRevision,
 	}
 	if len(r.RepositoryNames) > 0 {
 		run.Repositories = ptr.To([]string(r.RepositoryNames))
@@ -82,6 +146,10 @@ func mapRunReason(r db.RunReason) openapi.RunV1Reason {
 		return openapi.Webhook
 	case db.RunReasonCron:
 		return openapi.Cron
+	case db.RunReasonDependency:
+		return openapi.Dependency
+	case db.RunReasonCommand:
+		return openapi.Command
 	default:
 		return openapi.Next
 	}
@@ -89,6 +157,8 @@ func mapRunReason(r db.RunReason) openapi.RunV1Reason {
 
 func mapRunStatus(s db.RunStatus) openapi.RunStatusV1 {
 	switch s {
+	case db.RunStatusCancelled:
+		return openapi.Cancelled
 	case db.RunStatusFailed:
 		return openapi.Failed
 	case db.RunStatusFinished:
@@ -102,6 +172,8 @@ func mapRunStatus(s db.RunStatus) openapi.RunStatusV1 {
 
 func mapRunStatusFromApiToDb(rs openapi.RunStatusV1) db.RunStatus {
 	switch rs {
+	case openapi.Cancelled:
+		return db.RunStatusCancelled
 	case openapi.Failed:
 		return db.RunStatusFailed
 	case openapi.Finished:
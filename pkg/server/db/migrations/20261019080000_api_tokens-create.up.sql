@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS `api_tokens` (
+  `created_at` datetime,
+  `hash` text,
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `name` text,
+  `scope` text,
+  `tasks` text
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_api_tokens_hash` ON `api_tokens` (`hash`);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_api_tokens_name` ON `api_tokens` (`name`);
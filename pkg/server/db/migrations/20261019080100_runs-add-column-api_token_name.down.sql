@@ -0,0 +1 @@
+ALTER TABLE `runs` DROP COLUMN `api_token_name`;
@@ -0,0 +1 @@
+ALTER TABLE `runs` ADD COLUMN `api_token_name` TEXT;
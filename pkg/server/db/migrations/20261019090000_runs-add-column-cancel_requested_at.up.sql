@@ -0,0 +1 @@
+ALTER TABLE `runs` ADD COLUMN `cancel_requested_at` datetime;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS `repository_logs` (
+  `content` blob,
+  `created_at` datetime,
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `repository_name` text,
+  `run_id` integer,
+  `size` integer
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_repository_logs_run_id_repository_name` ON `repository_logs` (`run_id`, `repository_name`);
+CREATE INDEX IF NOT EXISTS `idx_repository_logs_created_at` ON `repository_logs` (`created_at`);
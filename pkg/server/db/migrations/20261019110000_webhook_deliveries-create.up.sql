@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS `webhook_deliveries` (
+  `attempts` integer,
+  `created_at` datetime,
+  `delivery_id` text,
+  `error` text,
+  `event` text,
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `matched_tasks` text,
+  `next_attempt_at` datetime,
+  `payload` blob,
+  `processed_at` datetime,
+  `source` text,
+  `status` text,
+  `type` text
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_webhook_deliveries_type_source_delivery_id` ON `webhook_deliveries` (`type`, `source`, `delivery_id`);
+CREATE INDEX IF NOT EXISTS `idx_webhook_deliveries_status_next_attempt_at` ON `webhook_deliveries` (`status`, `next_attempt_at`);
@@ -0,0 +1 @@
+ALTER TABLE `runs` ADD COLUMN `task_revision` TEXT;
@@ -0,0 +1 @@
+ALTER TABLE `task_results` DROP COLUMN `target_branch`;
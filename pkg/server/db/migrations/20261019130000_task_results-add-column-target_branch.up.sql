@@ -0,0 +1 @@
+ALTER TABLE `task_results` ADD COLUMN `target_branch` TEXT NOT NULL DEFAULT '';
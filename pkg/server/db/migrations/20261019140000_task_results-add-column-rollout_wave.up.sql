@@ -0,0 +1 @@
+ALTER TABLE `task_results` ADD COLUMN `rollout_wave` INTEGER;
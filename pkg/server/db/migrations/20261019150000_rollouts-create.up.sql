@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS `rollouts` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `paused` integer,
+  `paused_reason` text,
+  `resumed_at` datetime,
+  `task_name` text,
+  `updated_at` datetime,
+  `wave` integer,
+  `wave_started_at` datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_rollouts_task_name` ON `rollouts` (`task_name`);
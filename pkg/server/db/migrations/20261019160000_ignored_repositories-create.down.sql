@@ -0,0 +1 @@
+DROP TABLE `ignored_repositories`;
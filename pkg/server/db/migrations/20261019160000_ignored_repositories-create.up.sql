@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS `ignored_repositories` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `created_at` datetime,
+  `pull_request_url` text,
+  `repository_name` text,
+  `task_name` text
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_ignored_repositories_task_name_repository_name` ON `ignored_repositories` (`task_name`, `repository_name`);
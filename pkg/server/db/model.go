@@ -11,6 +11,7 @@ const (
 	RunStatusRunning
 	RunStatusFinished
 	RunStatusFailed
+	RunStatusCancelled
 )
 
 type RunReason uint
@@ -22,19 +23,30 @@ const (
 	RunReasonNext
 	RunReasonWebhook
 	RunReasonCron
+	RunReasonDependency
+	RunReasonCommand
 )
 
 type Run struct {
-	Error           *string
-	ID              uint `gorm:"primarykey"`
-	FinishedAt      *time.Time
-	Reason          RunReason
-	RepositoryNames StringList `gorm:"type:text"`
-	ScheduleAfter   time.Time
-	StartedAt       *time.Time
-	Status          RunStatus
-	TaskName        string
-	RunData         StringMap `gorm:"type:text"`
+	// ApiTokenName is the name of the API token that scheduled the run.
+	// Nil if the run has been scheduled by the server itself or via the admin key.
+	ApiTokenName *string
+	// CancelRequestedAt is the point in time at which a user requested to cancel the run.
+	// Nil if nobody requested to cancel the run.
+	CancelRequestedAt *time.Time
+	Error             *string
+	ID                uint `gorm:"primarykey"`
+	FinishedAt        *time.Time
+	Reason            RunReason
+	RepositoryNames   StringList `gorm:"type:text"`
+	ScheduleAfter     time.Time
+	StartedAt         *time.Time
+	Status            RunStatus
+	TaskName          string
+	// TaskRevision is the commit SHA of the git repository of tasks at the time the run started.
+	// Nil if the server reads tasks from local files or the run hasn't started yet.
+	TaskRevision *string
+	RunData      StringMap `gorm:"type:text"`
 }
 
 type Task struct {
@@ -63,5 +75,123 @@ type TaskResult struct {
 	RepositoryName string
 	Result         int
 	Status         TaskResultStatus
+	// RolloutWave is the index of the wave of the rollout of the task that contains the repository.
+	// Nil if the task doesn't define a rollout.
+	RolloutWave *int
+	RunID       uint
+	// TargetBranch is the branch that the pull request targets.
+	// Empty if the task doesn't define target branches.
+	TargetBranch string
+}
+
+// Rollout tracks the progress of the rollout of a task through its waves.
+type Rollout struct {
+	ID uint `gorm:"primarykey"`
+	// Paused prevents the rollout from starting the next wave.
+	Paused bool
+	// PausedReason describes why the rollout has been paused.
+	PausedReason *string
+	// ResumedAt is the point in time at which a user resumed the rollout.
+	// Pull requests that opened again before this point in time don't pause the rollout.
+	ResumedAt *time.Time
+	TaskName  string
+	UpdatedAt time.Time
+	// Wave is the index of the last wave that has started.
+	Wave          int
+	WaveStartedAt time.Time
+}
+
+// IgnoredRepository is a repository to which a user has asked saturn-bot to never apply a task again.
+type IgnoredRepository struct {
+	CreatedAt time.Time
+	ID        uint `gorm:"primarykey"`
+	// PullRequestUrl is the URL of the pull request on which the user has asked to ignore the repository.
+	PullRequestUrl string
+	RepositoryName string
+	TaskName       string
+}
+
+// ApiTokenScope defines the operations an [ApiToken] is allowed to execute.
+type ApiTokenScope string
+
+const (
+	// ApiTokenScopeReadOnly allows reading runs, tasks and task results.
+	ApiTokenScopeReadOnly ApiTokenScope = "read-only"
+	// ApiTokenScopeScheduler allows scheduling runs of the tasks listed in [ApiToken.Tasks].
+	// Also grants all permissions of [ApiTokenScopeReadOnly].
+	ApiTokenScopeScheduler ApiTokenScope = "scheduler"
+	// ApiTokenScopeWorker allows retrieving and reporting work.
+	ApiTokenScopeWorker ApiTokenScope = "worker"
+)
+
+// ApiToken is a named token that authenticates a client at the API.
+type ApiToken struct {
+	CreatedAt time.Time
+	// Hash is the SHA-256 checksum of the secret of the token.
+	// The secret itself is never stored.
+	Hash  string
+	ID    uint `gorm:"primarykey"`
+	Name  string
+	Scope ApiTokenScope
+	// Tasks is a list of names or glob patterns of tasks.
+	// Only set if Scope is [ApiTokenScopeScheduler].
+	Tasks StringList `gorm:"type:text"`
+}
+
+// RepositoryLog is the log that a worker captured while it applied the task of a run to a repository.
+type RepositoryLog struct {
+	// Content is the log compressed with gzip.
+	Content        []byte
+	CreatedAt      time.Time
+	ID             uint `gorm:"primarykey"`
+	RepositoryName string
 	RunID          uint
+	// Size is the size of the uncompressed log in bytes.
+	Size int
+}
+
+// WebhookDeliveryType is the kind of system that sent a [WebhookDelivery].
+type WebhookDeliveryType string
+
+const (
+	WebhookDeliveryTypeGeneric WebhookDeliveryType = "generic"
+	WebhookDeliveryTypeGithub  WebhookDeliveryType = "github"
+	WebhookDeliveryTypeGitlab  WebhookDeliveryType = "gitlab"
+)
+
+// WebhookDeliveryStatus is the state of processing of a [WebhookDelivery].
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryStatusPending indicates that the delivery waits to be processed.
+	WebhookDeliveryStatusPending WebhookDeliveryStatus = "pending"
+	// WebhookDeliveryStatusProcessed indicates that the delivery has been matched against all tasks.
+	WebhookDeliveryStatusProcessed WebhookDeliveryStatus = "processed"
+	// WebhookDeliveryStatusFailed indicates that processing failed and no more attempts will be made.
+	WebhookDeliveryStatusFailed WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is a webhook that the server received.
+type WebhookDelivery struct {
+	// Attempts is the number of times the server tried to process the delivery.
+	Attempts  int
+	CreatedAt time.Time
+	// DeliveryID is the ID that the sender assigned to the delivery.
+	// Unique per Type and Source.
+	DeliveryID string
+	// Error is the error of the last failed attempt.
+	Error *string
+	Event string
+	ID    uint `gorm:"primarykey"`
+	// MatchedTasks are the names of the tasks for which the delivery scheduled a run.
+	MatchedTasks StringList `gorm:"type:text"`
+	// NextAttemptAt is the point in time after which the server processes the delivery.
+	NextAttemptAt time.Time
+	Payload       []byte
+	ProcessedAt   *time.Time
+	// Source is the name of the source of a generic webhook.
+	// Empty for other types.
+	Source string
+	Status WebhookDeliveryStatus
+	Type   WebhookDeliveryType
 }
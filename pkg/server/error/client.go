@@ -11,6 +11,16 @@ const (
 	ClientIDRunNotFound
 	ClientIDRunCannotDelete
 	ClientUnknownApiKey
+	ClientForbidden
+	ClientIDApiTokenNotFound
+	ClientIDApiTokenInvalid
+	ClientIDRunCannotCancel
+	ClientIDRepositoryLogNotFound
+	ClientIDTaskReloadFailed
+	ClientIDRolloutNotDefined
+	ClientIDRolloutCannotPromote
+	ClientIDRolloutUnknownAction
+	ClientIDWebhookPayloadInvalid
 )
 
 // Client defines an interface for errors caused by invalid inputs sent by a client.
@@ -96,3 +106,49 @@ func NewRunNotFoundError(id int) Client {
 func NewRunCannotDeleteError() Client {
 	return client{ID: ClientIDRunCannotDelete, Message: "cannot delete run"}
 }
+
+// NewApiTokenNotFoundError returns a client error that indicates that the API token identified by name doesn't exist.
+func NewApiTokenNotFoundError(name string) Client {
+	return client{ID: ClientIDApiTokenNotFound, Message: "unknown api token " + name}
+}
+
+// NewApiTokenInvalidError returns a client error that indicates that the data to create an API token is invalid.
+// msg describes the reason.
+func NewApiTokenInvalidError(msg string) Client {
+	return client{ID: ClientIDApiTokenInvalid, Message: msg}
+}
+
+// NewRunCannotCancelError returns a client error that indicates that the run cannot be cancelled.
+func NewRunCannotCancelError() Client {
+	return client{ID: ClientIDRunCannotCancel, Message: "cannot cancel run"}
+}
+
+// NewRepositoryLogNotFoundError returns a client error that indicates that no log of a repository exists for a run.
+func NewRepositoryLogNotFoundError() Client {
+	return client{ID: ClientIDRepositoryLogNotFound, Message: "unknown repository log"}
+}
+
+// NewTaskReloadError returns a client error that indicates that the task files could not be read during a reload.
+func NewTaskReloadError(err error) Client {
+	return client{ID: ClientIDTaskReloadFailed, Message: "reload tasks: " + err.Error()}
+}
+
+// NewRolloutNotDefinedError returns a client error that indicates that a task doesn't define a rollout.
+func NewRolloutNotDefinedError() Client {
+	return client{ID: ClientIDRolloutNotDefined, Message: "task does not define a rollout"}
+}
+
+// NewRolloutCannotPromoteError returns a client error that indicates that the rollout has reached its last wave.
+func NewRolloutCannotPromoteError() Client {
+	return client{ID: ClientIDRolloutCannotPromote, Message: "rollout has reached its last wave"}
+}
+
+// NewRolloutUnknownActionError returns a client error that indicates that an action to update a rollout is not supported.
+func NewRolloutUnknownActionError(action string) Client {
+	return client{ID: ClientIDRolloutUnknownAction, Message: "unknown rollout action " + action}
+}
+
+// NewWebhookPayloadInvalidError returns a client error that indicates that the payload of a webhook isn't valid JSON.
+func NewWebhookPayloadInvalidError(err error) Client {
+	return client{ID: ClientIDWebhookPayloadInvalid, Message: "invalid payload of webhook: " + err.Error()}
+}
@@ -0,0 +1,198 @@
+package integration_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/wndhydrnt/saturn-bot/pkg/ptr"
+	"github.com/wndhydrnt/saturn-bot/pkg/server"
+	"github.com/wndhydrnt/saturn-bot/pkg/server/api/openapi"
+	"github.com/wndhydrnt/saturn-bot/pkg/task/schema"
+)
+
+func createApiToken(e *httpexpect.Expect, req openapi.CreateApiTokenV1Request) string {
+	return e.POST("/api/v1/apiTokens").
+		WithHeader(openapi.HeaderApiKey, testApiKey).
+		WithJSON(req).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("secret").String().Raw()
+}
+
+func Test_API_ApiTokens(t *testing.T) {
+	taskTeamA := schema.Task{Name: "team-a-task"}
+	taskTeamB := schema.Task{Name: "team-b-task"}
+	opts := setupOptions(t, nil, nil)
+	taskFiles := bootstrapTaskFiles(t, taskTeamA, taskTeamB)
+	svr := &server.Server{}
+	err := svr.Start(opts, taskFiles)
+	require.NoError(t, err, "Server starts up")
+	defer func() {
+		err := svr.Stop()
+		require.NoError(t, err, "Server shuts down")
+	}()
+
+	time.Sleep(1 * time.Millisecond)
+	e := httpexpect.Default(t, opts.Config.ServerBaseUrl)
+	schedulerSecret := createApiToken(e, openapi.CreateApiTokenV1Request{
+		Name:  "team-a",
+		Scope: openapi.Scheduler,
+		Tasks: ptr.To([]string{"team-a-*"}),
+	})
+	readOnlySecret := createApiToken(e, openapi.CreateApiTokenV1Request{
+		Name:  "dashboard",
+		Scope: openapi.ReadOnly,
+	})
+	workerSecret := createApiToken(e, openapi.CreateApiTokenV1Request{
+		Name:  "workers",
+		Scope: openapi.Worker,
+	})
+
+	// Token with the same name can't be created twice.
+	assertApiCall(e, apiCall{
+		method:      "POST",
+		path:        "/api/v1/apiTokens",
+		requestBody: openapi.CreateApiTokenV1Request{Name: "workers", Scope: openapi.Worker},
+		statusCode:  http.StatusBadRequest,
+		responseBody: openapi.Error{
+			Errors: []openapi.ErrorDetail{{Error: 1007, Message: "api token with the same name exists"}},
+		},
+	})
+	// Scheduler token schedules a run of a task it lists.
+	assertApiCall(e, apiCall{
+		method:         "POST",
+		path:           "/api/v1/runs",
+		requestHeaders: map[string]string{openapi.HeaderApiKey: schedulerSecret},
+		requestBody:    openapi.ScheduleRunV1Request{TaskName: taskTeamA.Name},
+		statusCode:     http.StatusOK,
+		responseBody:   openapi.ScheduleRunV1Response{RunID: 1},
+	})
+	// Scheduler token can't schedule a run of a task it doesn't list.
+	assertApiCall(e, apiCall{
+		method:         "POST",
+		path:           "/api/v1/runs",
+		requestHeaders: map[string]string{openapi.HeaderApiKey: schedulerSecret},
+		requestBody:    openapi.ScheduleRunV1Request{TaskName: taskTeamB.Name},
+		statusCode:     http.StatusForbidden,
+		responseBody: openapi.Error{
+			Errors: []openapi.ErrorDetail{{Error: 1005, Message: "forbidden"}},
+		},
+	})
+	// Run records the name of the token.
+	assertApiCall(e, apiCall{
+		method:         "GET",
+		path:           "/api/v1/runs/1",
+		requestHeaders: map[string]string{openapi.HeaderApiKey: readOnlySecret},
+		statusCode:     http.StatusOK,
+		responseBody: openapi.GetRunV1Response{
+			Run: openapi.RunV1{
+				ApiTokenName:  ptr.To("team-a"),
+				Id:            1,
+				Reason:        openapi.Manual,
+				ScheduleAfter: testDate(1, 0, 0, 5),
+				Status:        openapi.Pending,
+				Task:          taskTeamA.Name,
+			},
+		},
+	})
+	// Read-only token can't schedule runs.
+	assertApiCall(e, apiCall{
+		method:         "POST",
+		path:           "/api/v1/runs",
+		requestHeaders: map[string]string{openapi.HeaderApiKey: readOnlySecret},
+		requestBody:    openapi.ScheduleRunV1Request{TaskName: taskTeamA.Name},
+		statusCode:     http.StatusForbidden,
+		responseBody: openapi.Error{
+			Errors: []openapi.ErrorDetail{{Error: 1005, Message: "forbidden"}},
+		},
+	})
+	// Scheduler token can't get work.
+	assertApiCall(e, apiCall{
+		method:         "GET",
+		path:           "/api/v1/worker/work",
+		requestHeaders: map[string]string{openapi.HeaderApiKey: schedulerSecret},
+		statusCode:     http.StatusForbidden,
+		responseBody: openapi.Error{
+			Errors: []openapi.ErrorDetail{{Error: 1005, Message: "forbidden"}},
+		},
+	})
+	// Worker token gets work.
+	assertApiCall(e, apiCall{
+		method:         "GET",
+		path:           "/api/v1/worker/work",
+		requestHeaders: map[string]string{openapi.HeaderApiKey: workerSecret},
+		statusCode:     http.StatusOK,
+		responseBody: openapi.GetWorkV1Response{
+			RunID: 1,
+			Task:  openapi.WorkTaskV1{Hash: "27038a477b4c08038126a02f8c2673a2af42f172097fdfdf0f5ead990ff000af", Name: taskTeamA.Name},
+		},
+	})
+	// Worker token can't manage tokens.
+	assertApiCall(e, apiCall{
+		method:         "GET",
+		path:           "/api/v1/apiTokens",
+		requestHeaders: map[string]string{openapi.HeaderApiKey: workerSecret},
+		statusCode:     http.StatusForbidden,
+		responseBody: openapi.Error{
+			Errors: []openapi.ErrorDetail{{Error: 1005, Message: "forbidden"}},
+		},
+	})
+	// Revoke the token.
+	assertApiCall(e, apiCall{
+		method:       "DELETE",
+		path:         "/api/v1/apiTokens/team-a",
+		statusCode:   http.StatusOK,
+		responseBody: openapi.DeleteApiTokenV1Response{},
+	})
+	// Revoked token is unknown.
+	assertApiCall(e, apiCall{
+		method:         "GET",
+		path:           "/api/v1/runs/1",
+		requestHeaders: map[string]string{openapi.HeaderApiKey: schedulerSecret},
+		statusCode:     http.StatusUnauthorized,
+		responseBody: openapi.Error{
+			Errors: []openapi.ErrorDetail{{Error: 1004, Message: "unknown api key"}},
+		},
+	})
+	// Revoking an unknown token fails.
+	assertApiCall(e, apiCall{
+		method:     "DELETE",
+		path:       "/api/v1/apiTokens/team-a",
+		statusCode: http.StatusNotFound,
+		responseBody: openapi.Error{
+			Errors: []openapi.ErrorDetail{{Error: 1006, Message: "unknown api token team-a"}},
+		},
+	})
+}
+
+func Test_API_CreateApiTokenV1(t *testing.T) {
+	testCases := []testCase{
+		{
+			name:  `When the scope is scheduler and no tasks are set then it fails`,
+			tasks: []schema.Task{defaultTask},
+			apiCalls: []apiCall{
+				{
+					method: "POST",
+					path:   "/api/v1/apiTokens",
+					requestBody: openapi.CreateApiTokenV1Request{
+						Name:  "unittest",
+						Scope: openapi.Scheduler,
+					},
+					statusCode: http.StatusBadRequest,
+					responseBody: openapi.Error{
+						Errors: []openapi.ErrorDetail{{Error: 1007, Message: "scope scheduler requires at least one task"}},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			executeTestCase(t, tc)
+		})
+	}
+}
@@ -0,0 +1,444 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/stretchr/testify/require"
+	"github.com/wndhydrnt/saturn-bot/pkg/config"
+	sbcontext "github.com/wndhydrnt/saturn-bot/pkg/context"
+	"github.com/wndhydrnt/saturn-bot/pkg/processor"
+	"github.com/wndhydrnt/saturn-bot/pkg/ptr"
+	"github.com/wndhydrnt/saturn-bot/pkg/server/api/openapi"
+	"github.com/wndhydrnt/saturn-bot/pkg/task/schema"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+const commandTaskHash = "7d4262799e93d4fb6abc2f299a1846921256fc7aa64d80f87d2ad579e5c31306"
+
+// commandReportCalls are the calls that let a worker create a pull request in repositoryName.
+func commandReportCalls(repositoryName, pullRequestUrl string) []apiCall {
+	return []apiCall{
+		{
+			method:       "POST",
+			path:         "/api/v1/runs",
+			requestBody:  openapi.ScheduleRunV1Request{TaskName: "unittest", RunData: ptr.To(map[string]string{"greeting": "hello"})},
+			statusCode:   http.StatusOK,
+			responseBody: openapi.ScheduleRunV1Response{RunID: 1},
+		},
+		{
+			method:     "GET",
+			path:       "/api/v1/worker/work",
+			statusCode: http.StatusOK,
+			responseBody: openapi.GetWorkV1Response{
+				RunID:   1,
+				RunData: ptr.To(map[string]string{"greeting": "hello"}),
+				Task:    openapi.WorkTaskV1{Hash: commandTaskHash, Name: "unittest"},
+			},
+		},
+		{
+			method: "POST",
+			path:   "/api/v1/worker/work",
+			requestBody: openapi.ReportWorkV1Request{
+				RunID: 1,
+				Task:  openapi.WorkTaskV1{Hash: commandTaskHash, Name: "unittest"},
+				TaskResults: []openapi.ReportWorkV1TaskResult{
+					{
+						PullRequestUrl: ptr.To(pullRequestUrl),
+						RepositoryName: repositoryName,
+						Result:         int(processor.ResultPrCreated),
+						State:          openapi.TaskResultStateV1Open,
+					},
+				},
+			},
+			statusCode:   http.StatusCreated,
+			responseBody: openapi.ReportWorkV1Response{Result: "ok"},
+		},
+	}
+}
+
+func TestServer_WebhookGithub_Command(t *testing.T) {
+	event := github.IssueCommentEvent{
+		Action: ptr.To("created"),
+		Comment: &github.IssueComment{
+			AuthorAssociation: ptr.To("MEMBER"),
+			User:              &github.User{Login: ptr.To("ellie")},
+			Body:              ptr.To("Please update.\n/saturn-bot rebase\n"),
+		},
+		Issue: &github.Issue{
+			PullRequestLinks: &github.PullRequestLinks{HTMLURL: ptr.To("https://github.com/unit/test/pull/1")},
+		},
+		Repo: &github.Repository{Name: ptr.To("test"), Owner: &github.User{Login: ptr.To("unit")}},
+	}
+	eventBytes, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	tc := testCase{
+		name:      `When a user comments a command on a pull request then it schedules a run of the task for the repository`,
+		config:    setupGithubConfig(t, "write"),
+		tasks:     []schema.Task{{Name: "unittest"}},
+		fakeClock: &fixedClock{now: testDate(1, 0, 0, 0)},
+		apiCalls: append(
+			commandReportCalls("github.com/unit/test", "https://github.com/unit/test/pull/1"),
+			apiCall{
+				method: "POST",
+				path:   "/webhooks/github",
+				requestHeaders: map[string]string{
+					github.EventTypeHeader:       "issue_comment",
+					github.SHA256SignatureHeader: genGithubWebhookSignature([]byte("secret"), eventBytes),
+				},
+				requestBody: event,
+				statusCode:  http.StatusOK,
+			},
+			apiCall{
+				sleep:      5 * time.Millisecond, // Need to sleep because processing of webhook happens in goroutine
+				method:     "GET",
+				path:       "/api/v1/worker/work",
+				statusCode: http.StatusOK,
+				responseBody: openapi.GetWorkV1Response{
+					Repositories: ptr.To([]string{"github.com/unit/test"}),
+					RunData: ptr.To(map[string]string{
+						"greeting":                  "hello",
+						sbcontext.RunDataKeyCommand: sbcontext.CommandRebase,
+					}),
+					// Run 2 is the next regular run of the task.
+					RunID: 3,
+					Task:  openapi.WorkTaskV1{Hash: commandTaskHash, Name: "unittest"},
+				},
+			},
+		),
+	}
+
+	executeTestCase(t, tc)
+}
+
+func TestServer_WebhookGithub_CommandOnlyOnce(t *testing.T) {
+	event := github.IssueCommentEvent{
+		Action: ptr.To("created"),
+		Comment: &github.IssueComment{
+			AuthorAssociation: ptr.To("MEMBER"),
+			User:              &github.User{Login: ptr.To("ellie")},
+			Body:              ptr.To("/saturn-bot rebase"),
+		},
+		Issue: &github.Issue{
+			PullRequestLinks: &github.PullRequestLinks{HTMLURL: ptr.To("https://github.com/unit/test/pull/1")},
+		},
+		Repo: &github.Repository{Name: ptr.To("test"), Owner: &github.User{Login: ptr.To("unit")}},
+	}
+	eventBytes, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	tc := testCase{
+		name:      `When the run of a command has finished then the next run of the task doesn't execute the command again`,
+		config:    setupGithubConfig(t, "write"),
+		tasks:     []schema.Task{{Name: "unittest"}},
+		fakeClock: &fixedClock{now: testDate(1, 0, 0, 0)},
+		apiCalls: append(
+			commandReportCalls("github.com/unit/test", "https://github.com/unit/test/pull/1"),
+			apiCall{
+				method: "POST",
+				path:   "/webhooks/github",
+				requestHeaders: map[string]string{
+					github.EventTypeHeader:       "issue_comment",
+					github.SHA256SignatureHeader: genGithubWebhookSignature([]byte("secret"), eventBytes),
+				},
+				requestBody: event,
+				statusCode:  http.StatusOK,
+			},
+			apiCall{
+				sleep:      5 * time.Millisecond, // Need to sleep because processing of webhook happens in goroutine
+				method:     "GET",
+				path:       "/api/v1/worker/work",
+				statusCode: http.StatusOK,
+				responseBody: openapi.GetWorkV1Response{
+					Repositories: ptr.To([]string{"github.com/unit/test"}),
+					RunData: ptr.To(map[string]string{
+						"greeting":                  "hello",
+						sbcontext.RunDataKeyCommand: sbcontext.CommandRebase,
+					}),
+					RunID: 3,
+					Task:  openapi.WorkTaskV1{Hash: commandTaskHash, Name: "unittest"},
+				},
+			},
+			apiCall{
+				method: "POST",
+				path:   "/api/v1/worker/work",
+				requestBody: openapi.ReportWorkV1Request{
+					RunID: 3,
+					Task:  openapi.WorkTaskV1{Hash: commandTaskHash, Name: "unittest"},
+					TaskResults: []openapi.ReportWorkV1TaskResult{
+						{
+							PullRequestUrl: ptr.To("https://github.com/unit/test/pull/1"),
+							RepositoryName: "github.com/unit/test",
+							Result:         int(processor.ResultPrRebased),
+							State:          openapi.TaskResultStateV1Open,
+						},
+					},
+				},
+				statusCode:   http.StatusCreated,
+				responseBody: openapi.ReportWorkV1Response{Result: "ok"},
+			},
+			apiCall{
+				method:     "GET",
+				path:       "/api/v1/runs/4",
+				statusCode: http.StatusOK,
+				responseBody: openapi.GetRunV1Response{
+					Run: openapi.RunV1{
+						Id:            4,
+						Reason:        openapi.Command,
+						Repositories:  ptr.To([]string{"github.com/unit/test"}),
+						RunData:       ptr.To(map[string]string{"greeting": "hello"}),
+						ScheduleAfter: testDate(2, 0, 0, 0),
+						Status:        openapi.Pending,
+						Task:          "unittest",
+					},
+				},
+			},
+		),
+	}
+
+	executeTestCase(t, tc)
+}
+
+func TestServer_WebhookGithub_CommandNoWriteAccess(t *testing.T) {
+	event := github.IssueCommentEvent{
+		Action: ptr.To("created"),
+		Comment: &github.IssueComment{
+			AuthorAssociation: ptr.To("NONE"),
+			User:              &github.User{Login: ptr.To("ellie")},
+			Body:              ptr.To("/saturn-bot merge"),
+		},
+		Issue: &github.Issue{
+			PullRequestLinks: &github.PullRequestLinks{HTMLURL: ptr.To("https://github.com/unit/test/pull/1")},
+		},
+		Repo: &github.Repository{Name: ptr.To("test"), Owner: &github.User{Login: ptr.To("unit")}},
+	}
+	eventBytes, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	tc := testCase{
+		name:      `When a user without write access comments a command on a pull request then it doesn't schedule a run`,
+		config:    setupGithubConfig(t, "write"),
+		tasks:     []schema.Task{{Name: "unittest"}},
+		fakeClock: &fixedClock{now: testDate(1, 0, 0, 0)},
+		apiCalls: append(
+			commandReportCalls("github.com/unit/test", "https://github.com/unit/test/pull/1"),
+			apiCall{
+				method: "POST",
+				path:   "/webhooks/github",
+				requestHeaders: map[string]string{
+					github.EventTypeHeader:       "issue_comment",
+					github.SHA256SignatureHeader: genGithubWebhookSignature([]byte("secret"), eventBytes),
+				},
+				requestBody: event,
+				statusCode:  http.StatusOK,
+			},
+			apiCall{
+				sleep:        5 * time.Millisecond, // Need to sleep because processing of webhook happens in goroutine
+				method:       "GET",
+				path:         "/api/v1/worker/work",
+				statusCode:   http.StatusOK,
+				responseBody: openapi.GetWorkV1Response{},
+			},
+		),
+	}
+
+	executeTestCase(t, tc)
+}
+
+func TestServer_WebhookGithub_CommandReadOnlyMember(t *testing.T) {
+	event := github.IssueCommentEvent{
+		Action: ptr.To("created"),
+		Comment: &github.IssueComment{
+			AuthorAssociation: ptr.To("MEMBER"),
+			User:              &github.User{Login: ptr.To("ellie")},
+			Body:              ptr.To("/saturn-bot merge"),
+		},
+		Issue: &github.Issue{
+			PullRequestLinks: &github.PullRequestLinks{HTMLURL: ptr.To("https://github.com/unit/test/pull/1")},
+		},
+		Repo: &github.Repository{Name: ptr.To("test"), Owner: &github.User{Login: ptr.To("unit")}},
+	}
+	eventBytes, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	tc := testCase{
+		name:      `When a member of the organization with read access comments a command on a pull request then it doesn't schedule a run`,
+		config:    setupGithubConfig(t, "read"),
+		tasks:     []schema.Task{{Name: "unittest"}},
+		fakeClock: &fixedClock{now: testDate(1, 0, 0, 0)},
+		apiCalls: append(
+			commandReportCalls("github.com/unit/test", "https://github.com/unit/test/pull/1"),
+			apiCall{
+				method: "POST",
+				path:   "/webhooks/github",
+				requestHeaders: map[string]string{
+					github.EventTypeHeader:       "issue_comment",
+					github.SHA256SignatureHeader: genGithubWebhookSignature([]byte("secret"), eventBytes),
+				},
+				requestBody: event,
+				statusCode:  http.StatusOK,
+			},
+			apiCall{
+				sleep:        5 * time.Millisecond, // Need to sleep because processing of webhook happens in goroutine
+				method:       "GET",
+				path:         "/api/v1/worker/work",
+				statusCode:   http.StatusOK,
+				responseBody: openapi.GetWorkV1Response{},
+			},
+		),
+	}
+
+	executeTestCase(t, tc)
+}
+
+// setupGithubConfig returns a configuration of the server that connects to a fake of GitHub.
+// The fake reports permission as the permission of every user in a repository.
+func setupGithubConfig(t *testing.T, permission string) *config.Configuration {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v3/repos/unit/test/collaborators/{login}/permission", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(github.RepositoryPermissionLevel{Permission: ptr.To(permission)})
+	})
+	githubServer := httptest.NewServer(mux)
+	t.Cleanup(githubServer.Close)
+
+	cfg := defaultServerConfig
+	cfg.GithubAddress = ptr.To(githubServer.URL)
+	return &cfg
+}
+
+// setupGitlabConfig returns a configuration of the server that connects to a fake of GitLab.
+// The fake reports accessLevel as the role of every member of a project.
+func setupGitlabConfig(t *testing.T, accessLevel gitlab.AccessLevelValue) *config.Configuration {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v4/projects/123/members/all/456", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(gitlab.ProjectMember{ID: 456, AccessLevel: accessLevel})
+	})
+	gitlabServer := httptest.NewServer(mux)
+	t.Cleanup(gitlabServer.Close)
+
+	cfg := defaultServerConfig
+	cfg.GitlabAddress = gitlabServer.URL
+	cfg.GitlabToken = ptr.To("unittest")
+	return &cfg
+}
+
+// gitlabCommentEvent returns the payload of a "Note Hook" webhook of GitLab.
+func gitlabCommentEvent(note string) map[string]any {
+	return map[string]any{
+		"merge_request": map[string]any{"url": "https://gitlab.com/unit/test/-/merge_requests/1"},
+		"object_attributes": map[string]any{
+			"author_id":     456,
+			"note":          note,
+			"noteable_type": "MergeRequest",
+		},
+		"project_id": 123,
+	}
+}
+
+func TestServer_WebhookGitlab_CommandIgnore(t *testing.T) {
+	event := gitlabCommentEvent("/saturn-bot ignore")
+	tc := testCase{
+		name:      `When a developer comments the command ignore on a merge request then the server tells workers to ignore the repository`,
+		config:    setupGitlabConfig(t, gitlab.DeveloperPermissions),
+		tasks:     []schema.Task{{Name: "unittest"}},
+		fakeClock: &fixedClock{now: testDate(1, 0, 0, 0)},
+		apiCalls: append(
+			commandReportCalls("gitlab.com/unit/test", "https://gitlab.com/unit/test/-/merge_requests/1"),
+			apiCall{
+				method: "POST",
+				path:   "/webhooks/gitlab",
+				requestHeaders: map[string]string{
+					"X-Gitlab-Event": "Note Hook",
+					"X-Gitlab-Token": "secret",
+				},
+				requestBody: event,
+				statusCode:  http.StatusOK,
+			},
+			apiCall{
+				sleep:        5 * time.Millisecond, // Need to sleep because processing of webhook happens in goroutine
+				method:       "GET",
+				path:         "/api/v1/worker/work",
+				statusCode:   http.StatusOK,
+				responseBody: openapi.GetWorkV1Response{},
+			},
+			apiCall{
+				method:       "POST",
+				path:         "/api/v1/runs",
+				requestBody:  openapi.ScheduleRunV1Request{TaskName: "unittest", RunData: ptr.To(map[string]string{"greeting": "hello"})},
+				statusCode:   http.StatusOK,
+				responseBody: openapi.ScheduleRunV1Response{RunID: 2},
+			},
+			apiCall{
+				method:     "GET",
+				path:       "/api/v1/worker/work",
+				statusCode: http.StatusOK,
+				responseBody: openapi.GetWorkV1Response{
+					RunID: 2,
+					RunData: ptr.To(map[string]string{
+						"greeting":                              "hello",
+						sbcontext.RunDataKeyIgnoredRepositories: "gitlab.com/unit/test",
+					}),
+					Task: openapi.WorkTaskV1{Hash: commandTaskHash, Name: "unittest"},
+				},
+			},
+		),
+	}
+
+	executeTestCase(t, tc)
+}
+
+func TestServer_WebhookGitlab_CommandUnprivileged(t *testing.T) {
+	event := gitlabCommentEvent("/saturn-bot ignore")
+	tc := testCase{
+		name:      `When a user without the role Developer comments the command ignore on a merge request then the server ignores the command`,
+		config:    setupGitlabConfig(t, gitlab.ReporterPermissions),
+		tasks:     []schema.Task{{Name: "unittest"}},
+		fakeClock: &fixedClock{now: testDate(1, 0, 0, 0)},
+		apiCalls: append(
+			commandReportCalls("gitlab.com/unit/test", "https://gitlab.com/unit/test/-/merge_requests/1"),
+			apiCall{
+				method: "POST",
+				path:   "/webhooks/gitlab",
+				requestHeaders: map[string]string{
+					"X-Gitlab-Event": "Note Hook",
+					"X-Gitlab-Token": "secret",
+				},
+				requestBody: event,
+				statusCode:  http.StatusOK,
+			},
+			apiCall{
+				sleep:        5 * time.Millisecond, // Need to sleep because processing of webhook happens in goroutine
+				method:       "GET",
+				path:         "/api/v1/worker/work",
+				statusCode:   http.StatusOK,
+				responseBody: openapi.GetWorkV1Response{},
+			},
+			apiCall{
+				method:       "POST",
+				path:         "/api/v1/runs",
+				requestBody:  openapi.ScheduleRunV1Request{TaskName: "unittest", RunData: ptr.To(map[string]string{"greeting": "hello"})},
+				statusCode:   http.StatusOK,
+				responseBody: openapi.ScheduleRunV1Response{RunID: 2},
+			},
+			apiCall{
+				method:     "GET",
+				path:       "/api/v1/worker/work",
+				statusCode: http.StatusOK,
+				responseBody: openapi.GetWorkV1Response{
+					RunID:   2,
+					RunData: ptr.To(map[string]string{"greeting": "hello"}),
+					Task:    openapi.WorkTaskV1{Hash: commandTaskHash, Name: "unittest"},
+				},
+			},
+		),
+	}
+
+	executeTestCase(t, tc)
+}
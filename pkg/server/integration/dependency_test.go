@@ -0,0 +1,133 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v74/github"
+	"github.com/stretchr/testify/require"
+	sbcontext "github.com/wndhydrnt/saturn-bot/pkg/context"
+	"github.com/wndhydrnt/saturn-bot/pkg/processor"
+	"github.com/wndhydrnt/saturn-bot/pkg/ptr"
+	"github.com/wndhydrnt/saturn-bot/pkg/server/api/openapi"
+	"github.com/wndhydrnt/saturn-bot/pkg/task/schema"
+)
+
+var dependencyTasks = []schema.Task{
+	{Name: "upstream"},
+	{
+		Name:      "downstream",
+		DependsOn: []schema.Dependency{{Task: ptr.To("upstream")}},
+	},
+}
+
+const (
+	dependencyUpstreamHash   = "c17ca5f091d5fc90752abfb3d03fc09b7f9142b81c9b0eb503ece05895f9fbd5"
+	dependencyDownstreamHash = "6bbfae5b0232c0a7840aaf5be64c1c9bb7b556bb650447255d191ddd6f1f6732"
+)
+
+func TestServer_API_Dependency_ReportRun(t *testing.T) {
+	tc := testCase{
+		name:      `When a worker reports the pull request of a task as merged then it schedules a run of the task that depends on it`,
+		tasks:     dependencyTasks,
+		fakeClock: &fixedClock{now: testDate(1, 0, 0, 0)},
+		apiCalls: []apiCall{
+			{
+				method:       "POST",
+				path:         "/api/v1/runs",
+				requestBody:  openapi.ScheduleRunV1Request{TaskName: "upstream"},
+				statusCode:   http.StatusOK,
+				responseBody: openapi.ScheduleRunV1Response{RunID: 1},
+			},
+			{
+				method:     "GET",
+				path:       "/api/v1/worker/work",
+				statusCode: http.StatusOK,
+				responseBody: openapi.GetWorkV1Response{
+					RunID: 1,
+					Task:  openapi.WorkTaskV1{Hash: dependencyUpstreamHash, Name: "upstream"},
+				},
+			},
+			{
+				method: "POST",
+				path:   "/api/v1/worker/work",
+				requestBody: openapi.ReportWorkV1Request{
+					RunID: 1,
+					Task:  openapi.WorkTaskV1{Hash: dependencyUpstreamHash, Name: "upstream"},
+					TaskResults: []openapi.ReportWorkV1TaskResult{
+						{
+							PullRequestUrl: ptr.To("https://git.local/unit/test/pull/1"),
+							RepositoryName: "git.local/unit/test",
+							Result:         int(processor.ResultPrMerged),
+							State:          openapi.TaskResultStateV1Merged,
+						},
+					},
+				},
+				statusCode:   http.StatusCreated,
+				responseBody: openapi.ReportWorkV1Response{Result: "ok"},
+			},
+			{
+				method:     "GET",
+				path:       "/api/v1/worker/work",
+				statusCode: http.StatusOK,
+				responseBody: openapi.GetWorkV1Response{
+					Repositories: ptr.To([]string{"git.local/unit/test"}),
+					RunData:      ptr.To(map[string]string{sbcontext.RunDataKeyFilterRepositories: "true"}),
+					RunID:        2,
+					Task:         openapi.WorkTaskV1{Hash: dependencyDownstreamHash, Name: "downstream"},
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, tc)
+}
+
+func TestServer_WebhookGithub_DependencyMerged(t *testing.T) {
+	event := github.PullRequestEvent{
+		Action: ptr.To("closed"),
+		PullRequest: &github.PullRequest{
+			Base: &github.PullRequestBranch{
+				Repo: &github.Repository{HTMLURL: ptr.To("https://github.com/unit/test")},
+			},
+			Head:   &github.PullRequestBranch{Ref: ptr.To("saturn-bot--upstream")},
+			Merged: ptr.To(true),
+		},
+	}
+	eventBytes, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	tc := testCase{
+		name:      `When GitHub reports the pull request of a task as merged then it schedules a run of the task that depends on it`,
+		tasks:     dependencyTasks,
+		fakeClock: &fixedClock{now: testDate(1, 0, 0, 0)},
+		apiCalls: []apiCall{
+			{
+				method: "POST",
+				path:   "/webhooks/github",
+				requestHeaders: map[string]string{
+					github.EventTypeHeader:       "pull_request",
+					github.SHA256SignatureHeader: genGithubWebhookSignature([]byte("secret"), eventBytes),
+				},
+				requestBody: event,
+				statusCode:  http.StatusOK,
+			},
+			{
+				sleep:      5 * time.Millisecond, // Need to sleep because processing of webhook happens in goroutine
+				method:     "GET",
+				path:       "/api/v1/worker/work",
+				statusCode: http.StatusOK,
+				responseBody: openapi.GetWorkV1Response{
+					Repositories: ptr.To([]string{"github.com/unit/test"}),
+					RunData:      ptr.To(map[string]string{sbcontext.RunDataKeyFilterRepositories: "true"}),
+					RunID:        1,
+					Task:         openapi.WorkTaskV1{Hash: dependencyDownstreamHash, Name: "downstream"},
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, tc)
+}
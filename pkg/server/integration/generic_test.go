@@ -0,0 +1,195 @@
+package integration_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/wndhydrnt/saturn-bot/pkg/config"
+	"github.com/wndhydrnt/saturn-bot/pkg/ptr"
+	"github.com/wndhydrnt/saturn-bot/pkg/server/api/openapi"
+	"github.com/wndhydrnt/saturn-bot/pkg/task/schema"
+)
+
+func genGenericWebhookSignature(secret []byte, content []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(content)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func genericWebhookConfig() *config.Configuration {
+	cfg := defaultServerConfig
+	cfg.ServerWebhookGenericSources = []config.WebhookGenericSource{
+		{Name: "registry", Secret: "registry-secret", Verification: config.WebhookGenericSourceVerificationHmacSha256},
+		{Name: "feed", Secret: "feed-secret", Verification: config.WebhookGenericSourceVerificationBearer},
+	}
+	return &cfg
+}
+
+var genericWebhookTask = schema.Task{
+	Name: "unittest",
+	Trigger: &schema.TaskTrigger{
+		Webhook: &schema.TaskTriggerWebhook{
+			Generic: []schema.GenericTrigger{
+				{
+					Event:   ptr.To("artifact.published"),
+					Filters: []string{`.artifact.name == "saturn-bot"`},
+					RunData: map[string]string{"version": ".artifact.version"},
+					Source:  "registry",
+				},
+				{
+					RunData: map[string]string{"version": ".release"},
+					Source:  "feed",
+				},
+			},
+		},
+	},
+}
+
+const genericWebhookTaskHash = "f6a96806b37bfaeb8c9a83decc77cd569d35923b862ce1e39e2f3471527364e9"
+
+func TestServer_WebhookGeneric(t *testing.T) {
+	registryPayload := `{"artifact":{"name":"saturn-bot","version":"1.2.3"}}`
+	testCases := []testCase{
+		{
+			name:   `When a source signs a webhook with HMAC then it schedules a new run`,
+			config: genericWebhookConfig(),
+			tasks:  []schema.Task{genericWebhookTask},
+			apiCalls: []apiCall{
+				{
+					method: "POST",
+					path:   "/webhooks/generic/registry",
+					requestHeaders: map[string]string{
+						"X-Event":         "artifact.published",
+						"X-Signature-256": "sha256=" + genGenericWebhookSignature([]byte("registry-secret"), []byte(registryPayload)),
+					},
+					requestBody: map[string]any{"artifact": map[string]any{"name": "saturn-bot", "version": "1.2.3"}},
+					statusCode:  http.StatusOK,
+				},
+				{
+					sleep:      5 * time.Millisecond,
+					method:     "GET",
+					path:       "/api/v1/worker/work",
+					statusCode: http.StatusOK,
+					responseBody: openapi.GetWorkV1Response{
+						RunID:   1,
+						RunData: ptr.To(map[string]string{"version": "1.2.3"}),
+						Task: openapi.WorkTaskV1{
+							Hash: genericWebhookTaskHash,
+							Name: "unittest",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   `When a source authenticates a webhook with a bearer token then it schedules a new run`,
+			config: genericWebhookConfig(),
+			tasks:  []schema.Task{genericWebhookTask},
+			apiCalls: []apiCall{
+				{
+					method: "POST",
+					path:   "/webhooks/generic/feed",
+					requestHeaders: map[string]string{
+						"Authorization": "Bearer feed-secret",
+					},
+					requestBody: map[string]any{"release": "v2.0.0"},
+					statusCode:  http.StatusOK,
+				},
+				{
+					sleep:      5 * time.Millisecond,
+					method:     "GET",
+					path:       "/api/v1/worker/work",
+					statusCode: http.StatusOK,
+					responseBody: openapi.GetWorkV1Response{
+						RunID:   1,
+						RunData: ptr.To(map[string]string{"version": "v2.0.0"}),
+						Task: openapi.WorkTaskV1{
+							Hash: genericWebhookTaskHash,
+							Name: "unittest",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   `When the filters of a trigger do not match then it does not schedule a run`,
+			config: genericWebhookConfig(),
+			tasks:  []schema.Task{genericWebhookTask},
+			apiCalls: []apiCall{
+				{
+					method: "POST",
+					path:   "/webhooks/generic/registry",
+					requestHeaders: map[string]string{
+						"X-Event":         "artifact.published",
+						"X-Signature-256": genGenericWebhookSignature([]byte("registry-secret"), []byte(`{"artifact":{"name":"other"}}`)),
+					},
+					requestBody: map[string]any{"artifact": map[string]any{"name": "other"}},
+					statusCode:  http.StatusOK,
+				},
+				{
+					sleep:        5 * time.Millisecond,
+					method:       "GET",
+					path:         "/api/v1/worker/work",
+					statusCode:   http.StatusOK,
+					responseBody: openapi.GetWorkV1Response{},
+				},
+			},
+		},
+		{
+			name:   `When the signature is invalid then it rejects the webhook`,
+			config: genericWebhookConfig(),
+			tasks:  []schema.Task{genericWebhookTask},
+			apiCalls: []apiCall{
+				{
+					method: "POST",
+					path:   "/webhooks/generic/registry",
+					requestHeaders: map[string]string{
+						"X-Event":         "artifact.published",
+						"X-Signature-256": genGenericWebhookSignature([]byte("wrong"), []byte(registryPayload)),
+					},
+					requestBody: map[string]any{"artifact": map[string]any{"name": "saturn-bot", "version": "1.2.3"}},
+					statusCode:  http.StatusUnauthorized,
+				},
+			},
+		},
+		{
+			name:   `When the bearer token is invalid then it rejects the webhook`,
+			config: genericWebhookConfig(),
+			tasks:  []schema.Task{genericWebhookTask},
+			apiCalls: []apiCall{
+				{
+					method: "POST",
+					path:   "/webhooks/generic/feed",
+					requestHeaders: map[string]string{
+						"Authorization": "Bearer registry-secret",
+					},
+					requestBody: map[string]any{"release": "v2.0.0"},
+					statusCode:  http.StatusUnauthorized,
+				},
+			},
+		},
+		{
+			name:   `When the source is unknown then it is not found`,
+			config: genericWebhookConfig(),
+			tasks:  []schema.Task{genericWebhookTask},
+			apiCalls: []apiCall{
+				{
+					method:      "POST",
+					path:        "/webhooks/generic/unknown",
+					requestBody: map[string]any{},
+					statusCode:  http.StatusNotFound,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			executeTestCase(t, tc)
+		})
+	}
+}
@@ -10,9 +10,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gavv/httpexpect/v2"
 	"github.com/google/go-github/v74/github"
 	"github.com/stretchr/testify/require"
+	sbcontext "github.com/wndhydrnt/saturn-bot/pkg/context"
 	"github.com/wndhydrnt/saturn-bot/pkg/ptr"
+	"github.com/wndhydrnt/saturn-bot/pkg/server"
 	"github.com/wndhydrnt/saturn-bot/pkg/server/api/openapi"
 	"github.com/wndhydrnt/saturn-bot/pkg/task/schema"
 )
@@ -164,7 +167,7 @@ func TestServer_WebhookGithub_WithDelay(t *testing.T) {
 						{
 							Id:            1,
 							Reason:        openapi.Webhook,
-							ScheduleAfter: testDate(1, 0, 5, 1),
+							ScheduleAfter: testDate(1, 0, 5, 3),
 							Status:        openapi.Pending,
 							Task:          "unittest",
 						},
@@ -176,3 +179,313 @@ func TestServer_WebhookGithub_WithDelay(t *testing.T) {
 
 	executeTestCase(t, tc)
 }
+
+func TestServer_WebhookGithub_Redelivery(t *testing.T) {
+	tc := testCase{
+		name: `When GitHub sends the same delivery twice then it schedules one run and records one delivery`,
+		tasks: []schema.Task{
+			{
+				Name: "unittest",
+				Trigger: &schema.TaskTrigger{
+					Webhook: &schema.TaskTriggerWebhook{
+						Github: []schema.GithubTrigger{
+							{Event: ptr.To("push")},
+						},
+					},
+				},
+			},
+		},
+		apiCalls: []apiCall{
+			// Send the webhook request
+			{
+				method: "POST",
+				path:   "/webhooks/github",
+				requestHeaders: map[string]string{
+					github.DeliveryIDHeader:      "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+					github.EventTypeHeader:       "push",
+					github.SHA256SignatureHeader: genGithubWebhookSignature([]byte("secret"), []byte("{}")),
+				},
+				requestBody: github.PushEvent{},
+				statusCode:  http.StatusOK,
+			},
+			// Send the same webhook request again
+			{
+				sleep:  5 * time.Millisecond, // Need to sleep because processing of webhook happens in goroutine
+				method: "POST",
+				path:   "/webhooks/github",
+				requestHeaders: map[string]string{
+					github.DeliveryIDHeader:      "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+					github.EventTypeHeader:       "push",
+					github.SHA256SignatureHeader: genGithubWebhookSignature([]byte("secret"), []byte("{}")),
+				},
+				requestBody: github.PushEvent{},
+				statusCode:  http.StatusOK,
+			},
+			// Check that only one run has been scheduled.
+			{
+				sleep:      5 * time.Millisecond,
+				method:     "GET",
+				path:       "/api/v1/runs",
+				statusCode: http.StatusOK,
+				responseBody: openapi.ListRunsV1Response{
+					Page: openapi.Page{CurrentPage: 1, ItemsPerPage: 20, TotalItems: 1, TotalPages: 1},
+					Result: []openapi.RunV1{
+						{
+							Id:            1,
+							Reason:        openapi.Webhook,
+							ScheduleAfter: testDate(1, 0, 0, 3),
+							Status:        openapi.Pending,
+							Task:          "unittest",
+						},
+					},
+				},
+			},
+			// Check that the history contains one delivery.
+			{
+				method:     "GET",
+				path:       "/api/v1/webhookDeliveries",
+				statusCode: http.StatusOK,
+				responseBody: openapi.ListWebhookDeliveriesV1Response{
+					Page: openapi.Page{CurrentPage: 1, ItemsPerPage: 20, TotalItems: 1, TotalPages: 1},
+					Result: []openapi.WebhookDeliveryV1{
+						{
+							Attempts:     1,
+							CreatedAt:    testDate(1, 0, 0, 1),
+							DeliveryId:   "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+							Event:        "push",
+							Id:           1,
+							MatchedTasks: []string{"unittest"},
+							ProcessedAt:  ptr.To(testDate(1, 0, 0, 4)),
+							Status:       openapi.WebhookDeliveryStatusV1Processed,
+							Type:         openapi.WebhookDeliveryV1TypeGithub,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, tc)
+}
+
+func TestServer_WebhookGithub_DeliveryFailed(t *testing.T) {
+	tc := testCase{
+		name: `When scheduling a run fails because of invalid inputs then it records the error, schedules the runs of other tasks and doesn't retry the delivery`,
+		tasks: []schema.Task{
+			{
+				Name: "unittest",
+				Inputs: []schema.Input{
+					{Name: "version"},
+				},
+				Trigger: &schema.TaskTrigger{
+					Webhook: &schema.TaskTriggerWebhook{
+						Github: []schema.GithubTrigger{
+							{Event: ptr.To("push")},
+						},
+					},
+				},
+			},
+			{
+				Name: "other",
+				Trigger: &schema.TaskTrigger{
+					Webhook: &schema.TaskTriggerWebhook{
+						Github: []schema.GithubTrigger{
+							{Event: ptr.To("push")},
+						},
+					},
+				},
+			},
+		},
+		apiCalls: []apiCall{
+			// Send the webhook request
+			{
+				method: "POST",
+				path:   "/webhooks/github",
+				requestHeaders: map[string]string{
+					github.DeliveryIDHeader:      "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+					github.EventTypeHeader:       "push",
+					github.SHA256SignatureHeader: genGithubWebhookSignature([]byte("secret"), []byte("{}")),
+				},
+				requestBody: github.PushEvent{},
+				statusCode:  http.StatusOK,
+			},
+			// Check that the delivery has failed without a retry.
+			{
+				sleep:      5 * time.Millisecond, // Need to sleep because processing of webhook happens in goroutine
+				method:     "GET",
+				path:       "/api/v1/webhookDeliveries",
+				statusCode: http.StatusOK,
+				responseBody: openapi.ListWebhookDeliveriesV1Response{
+					Page: openapi.Page{CurrentPage: 1, ItemsPerPage: 20, TotalItems: 1, TotalPages: 1},
+					Result: []openapi.WebhookDeliveryV1{
+						{
+							Attempts:     1,
+							CreatedAt:    testDate(1, 0, 0, 2),
+							DeliveryId:   "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+							Error:        ptr.To("schedule run of task unittest: missing inputs for task unittest"),
+							Event:        "push",
+							Id:           1,
+							MatchedTasks: []string{"other"},
+							Status:       openapi.WebhookDeliveryStatusV1Failed,
+							Type:         openapi.WebhookDeliveryV1TypeGithub,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, tc)
+}
+
+func TestServer_WebhookGithub_DeliveryRetention(t *testing.T) {
+	opts := setupOptions(t, nil, nil)
+	opts.ServerWebhookDeliveryRetention = time.Second
+	taskFiles := bootstrapTaskFiles(t, schema.Task{Name: "unittest"})
+	svr := &server.Server{}
+	err := svr.Start(opts, taskFiles)
+	require.NoError(t, err, "Server starts up")
+	defer func() {
+		err := svr.Stop()
+		require.NoError(t, err, "Server shuts down")
+	}()
+
+	time.Sleep(1 * time.Millisecond)
+	e := httpexpect.Default(t, opts.Config.ServerBaseUrl)
+	assertApiCall(e, apiCall{
+		method: "POST",
+		path:   "/webhooks/github",
+		requestHeaders: map[string]string{
+			github.EventTypeHeader:       "push",
+			github.SHA256SignatureHeader: genGithubWebhookSignature([]byte("secret"), []byte("{}")),
+		},
+		requestBody: github.PushEvent{},
+		statusCode:  http.StatusOK,
+	})
+	// The server deletes the delivery after it has processed it because the delivery is older than the retention.
+	assertApiCall(e, apiCall{
+		sleep:      5 * time.Millisecond, // Need to sleep because processing of webhook happens in goroutine
+		method:     "GET",
+		path:       "/api/v1/webhookDeliveries",
+		statusCode: http.StatusOK,
+		responseBody: openapi.ListWebhookDeliveriesV1Response{
+			Page:   openapi.Page{CurrentPage: 1, ItemsPerPage: 20, TotalItems: 0, TotalPages: 0},
+			Result: []openapi.WebhookDeliveryV1{},
+		},
+	})
+}
+
+func TestServer_WebhookGithub_Repositories(t *testing.T) {
+	newPushEvent := func(url string) (github.PushEvent, string) {
+		event := github.PushEvent{
+			Repo: &github.PushEventRepository{HTMLURL: ptr.To(url)},
+		}
+		eventBytes, err := json.Marshal(event)
+		require.NoError(t, err)
+		return event, genGithubWebhookSignature([]byte("secret"), eventBytes)
+	}
+	eventA, signatureA := newPushEvent("https://github.com/unit/a")
+	eventB, signatureB := newPushEvent("https://github.com/unit/b")
+
+	tc := testCase{
+		name: `When a task extracts repositories from a GitHub webhook then it schedules runs scoped to these repositories and merges identical runs`,
+		tasks: []schema.Task{
+			{
+				Name: "unittest",
+				Trigger: &schema.TaskTrigger{
+					Webhook: &schema.TaskTriggerWebhook{
+						Github: []schema.GithubTrigger{
+							{
+								Event:        ptr.To("push"),
+								Repositories: ptr.To(".repository.html_url"),
+							},
+						},
+					},
+				},
+			},
+		},
+		apiCalls: []apiCall{
+			{
+				method: "POST",
+				path:   "/webhooks/github",
+				requestHeaders: map[string]string{
+					github.DeliveryIDHeader:      "1",
+					github.EventTypeHeader:       "push",
+					github.SHA256SignatureHeader: signatureA,
+				},
+				requestBody: eventA,
+				statusCode:  http.StatusOK,
+			},
+			// Second push to the same repository gets merged into the pending run.
+			{
+				sleep:  5 * time.Millisecond, // Need to sleep because processing of webhook happens in goroutine
+				method: "POST",
+				path:   "/webhooks/github",
+				requestHeaders: map[string]string{
+					github.DeliveryIDHeader:      "2",
+					github.EventTypeHeader:       "push",
+					github.SHA256SignatureHeader: signatureA,
+				},
+				requestBody: eventA,
+				statusCode:  http.StatusOK,
+			},
+			// Push to another repository schedules a separate run.
+			{
+				sleep:  5 * time.Millisecond,
+				method: "POST",
+				path:   "/webhooks/github",
+				requestHeaders: map[string]string{
+					github.DeliveryIDHeader:      "3",
+					github.EventTypeHeader:       "push",
+					github.SHA256SignatureHeader: signatureB,
+				},
+				requestBody: eventB,
+				statusCode:  http.StatusOK,
+			},
+			{
+				sleep:      5 * time.Millisecond,
+				method:     "GET",
+				path:       "/api/v1/runs",
+				statusCode: http.StatusOK,
+				responseBody: openapi.ListRunsV1Response{
+					Page: openapi.Page{CurrentPage: 1, ItemsPerPage: 20, TotalItems: 2, TotalPages: 1},
+					Result: []openapi.RunV1{
+						{
+							Id:            2,
+							Reason:        openapi.Webhook,
+							Repositories:  ptr.To([]string{"github.com/unit/b"}),
+							ScheduleAfter: testDate(1, 0, 0, 11),
+							Status:        openapi.Pending,
+							Task:          "unittest",
+						},
+						{
+							Id:            1,
+							Reason:        openapi.Webhook,
+							Repositories:  ptr.To([]string{"github.com/unit/a"}),
+							ScheduleAfter: testDate(1, 0, 0, 7),
+							Status:        openapi.Pending,
+							Task:          "unittest",
+						},
+					},
+				},
+			},
+			// Workers apply the filters of the task to the repositories extracted from the webhook.
+			{
+				method:     "GET",
+				path:       "/api/v1/worker/work",
+				statusCode: http.StatusOK,
+				responseBody: openapi.GetWorkV1Response{
+					Repositories: ptr.To([]string{"github.com/unit/a"}),
+					RunData:      ptr.To(map[string]string{sbcontext.RunDataKeyFilterRepositories: "true"}),
+					RunID:        1,
+					Task: openapi.WorkTaskV1{
+						Hash: "55d2370e9e4f5f684f28b6520f7518da447f50e52f491c771a0e11a10d39c088",
+						Name: "unittest",
+					},
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, tc)
+}
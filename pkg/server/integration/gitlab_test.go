@@ -189,7 +189,7 @@ func TestServer_WebhookGitlab_WithDelay(t *testing.T) {
 						{
 							Id:            1,
 							Reason:        openapi.Webhook,
-							ScheduleAfter: testDate(1, 0, 5, 1),
+							ScheduleAfter: testDate(1, 0, 5, 3),
 							Status:        openapi.Pending,
 							Task:          "unittest",
 						},
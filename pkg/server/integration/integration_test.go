@@ -165,7 +165,10 @@ func assertApiCall(e *httpexpect.Expect, call apiCall) {
 		requestHeaders = call.requestHeaders
 	}
 
-	requestHeaders[openapi.HeaderApiKey] = testApiKey
+	if _, ok := requestHeaders[openapi.HeaderApiKey]; !ok {
+		requestHeaders[openapi.HeaderApiKey] = testApiKey
+	}
+
 	req := e.Request(call.method, call.path).
 		WithHeaders(requestHeaders)
 	if call.query != "" {
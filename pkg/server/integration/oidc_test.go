@@ -0,0 +1,174 @@
+package integration_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	mockOidcClientID = "saturn-bot"
+	mockOidcKeyID    = "unittest"
+)
+
+// mockOidcProvider is a minimal OpenID Connect provider.
+// It logs in every user without asking for credentials
+// and issues ID tokens that contain the configured claims.
+type mockOidcProvider struct {
+	// Claims added to each ID token.
+	Claims map[string]any
+
+	codes  map[string]mockOidcAuthRequest
+	key    *rsa.PrivateKey
+	mu     sync.Mutex
+	server *httptest.Server
+}
+
+type mockOidcAuthRequest struct {
+	codeChallenge string
+	nonce         string
+}
+
+func newMockOidcProvider(t *testing.T, claims map[string]any) *mockOidcProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err, "Generates RSA key of mock OIDC provider")
+	p := &mockOidcProvider{
+		Claims: claims,
+		codes:  map[string]mockOidcAuthRequest{},
+		key:    key,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("GET /authorize", p.authorize)
+	mux.HandleFunc("GET /keys", p.keys)
+	mux.HandleFunc("POST /token", p.token)
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+	return p
+}
+
+// URL returns the issuer URL of the provider.
+func (p *mockOidcProvider) URL() string {
+	return p.server.URL
+}
+
+func (p *mockOidcProvider) discovery(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, map[string]any{
+		"authorization_endpoint":                p.server.URL + "/authorize",
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"issuer":                                p.server.URL,
+		"jwks_uri":                              p.server.URL + "/keys",
+		"token_endpoint":                        p.server.URL + "/token",
+	})
+}
+
+func (p *mockOidcProvider) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("client_id") != mockOidcClientID || q.Get("response_type") != "code" || q.Get("code_challenge_method") != "S256" {
+		http.Error(w, "invalid authorization request", http.StatusBadRequest)
+		return
+	}
+
+	code := rand.Text()
+	p.mu.Lock()
+	p.codes[code] = mockOidcAuthRequest{codeChallenge: q.Get("code_challenge"), nonce: q.Get("nonce")}
+	p.mu.Unlock()
+	redirect, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	redirectQuery := redirect.Query()
+	redirectQuery.Set("code", code)
+	redirectQuery.Set("state", q.Get("state"))
+	redirect.RawQuery = redirectQuery.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (p *mockOidcProvider) keys(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, jose.JSONWebKeySet{
+		Keys: []jose.JSONWebKey{
+			{Algorithm: string(jose.RS256), Key: &p.key.PublicKey, KeyID: mockOidcKeyID, Use: "sig"},
+		},
+	})
+}
+
+func (p *mockOidcProvider) token(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	authReq, ok := p.codes[r.PostFormValue("code")]
+	delete(p.codes, r.PostFormValue("code"))
+	p.mu.Unlock()
+	if !ok {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+		return
+	}
+
+	challenge := sha256.Sum256([]byte(r.PostFormValue("code_verifier")))
+	if base64.RawURLEncoding.EncodeToString(challenge[:]) != authReq.codeChallenge {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+		return
+	}
+
+	claims := map[string]any{
+		"aud":   mockOidcClientID,
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"iat":   time.Now().Unix(),
+		"iss":   p.server.URL,
+		"nonce": authReq.nonce,
+		"sub":   "unittest",
+	}
+	for k, v := range p.Claims {
+		claims[k] = v
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: p.key},
+		(&jose.SignerOptions{}).WithType("JWT").WithHeader("kid", mockOidcKeyID),
+	)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	signed, err := signer.Sign(payload)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	idToken, err := signed.CompactSerialize()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, map[string]any{
+		"access_token": rand.Text(),
+		"expires_in":   3600,
+		"id_token":     idToken,
+		"token_type":   "Bearer",
+	})
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
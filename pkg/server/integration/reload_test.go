@@ -0,0 +1,146 @@
+package integration_test
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/google/go-github/v74/github"
+	"github.com/stretchr/testify/require"
+	"github.com/wndhydrnt/saturn-bot/pkg/ptr"
+	"github.com/wndhydrnt/saturn-bot/pkg/server"
+	"github.com/wndhydrnt/saturn-bot/pkg/server/api/openapi"
+	sberror "github.com/wndhydrnt/saturn-bot/pkg/server/error"
+	"github.com/wndhydrnt/saturn-bot/pkg/task/schema"
+)
+
+func TestServer_API_ReloadTasksV1(t *testing.T) {
+	opts := setupOptions(t, nil, nil)
+	taskFiles := bootstrapTaskFiles(t, schema.Task{Name: "unittest"})
+	s := &server.Server{}
+	err := s.Start(opts, taskFiles)
+	require.NoError(t, err, "Server starts up")
+	defer func() {
+		err := s.Stop()
+		require.NoError(t, err, "Server shuts down")
+	}()
+
+	time.Sleep(1 * time.Millisecond)
+	e := httpexpect.Default(t, opts.Config.ServerBaseUrl)
+
+	// Change the task on disk.
+	changedTaskFiles := bootstrapTaskFiles(t, schema.Task{
+		Name: "unittest",
+		Trigger: &schema.TaskTrigger{
+			Cron: ptr.To("3 6 * * *"),
+			Webhook: &schema.TaskTriggerWebhook{
+				Github: []schema.GithubTrigger{{Event: ptr.To("push")}},
+			},
+		},
+	})
+	changedContent, err := os.ReadFile(changedTaskFiles[0])
+	require.NoError(t, err)
+	err = os.WriteFile(taskFiles[0], changedContent, 0600)
+	require.NoError(t, err)
+
+	apiCalls := []apiCall{
+		{
+			method:     "POST",
+			path:       "/api/v1/tasks/reload",
+			statusCode: http.StatusOK,
+			responseBody: openapi.ReloadTasksV1Response{
+				Results: []openapi.ListTasksV1ResponseTask{
+					{Active: true, Checksum: "a2acf484ceb986f02ea8ddeddf28a181e1cec235b29ab2f0b105d89f2a5efab2", Name: "unittest"},
+				},
+			},
+		},
+		// Schedules a run because the task now defines a cron trigger.
+		{
+			method:     "GET",
+			path:       "/api/v1/runs",
+			statusCode: http.StatusOK,
+			responseBody: openapi.ListRunsV1Response{
+				Page: openapi.Page{CurrentPage: 1, ItemsPerPage: 20, TotalItems: 1, TotalPages: 1},
+				Result: []openapi.RunV1{
+					{
+						Id:            1,
+						Reason:        openapi.Cron,
+						ScheduleAfter: testDate(1, 6, 3, 0),
+						Status:        openapi.Pending,
+						Task:          "unittest",
+					},
+				},
+			},
+		},
+		// Triggers the task via a webhook added during the reload.
+		{
+			method: "POST",
+			path:   "/webhooks/github",
+			requestHeaders: map[string]string{
+				github.EventTypeHeader:       "push",
+				github.SHA256SignatureHeader: genGithubWebhookSignature([]byte("secret"), []byte("{}")),
+			},
+			requestBody: github.PushEvent{},
+			statusCode:  http.StatusOK,
+		},
+		{
+			sleep:      5 * time.Millisecond, // Need to sleep because processing of webhook happens in goroutine
+			method:     "GET",
+			path:       "/api/v1/runs",
+			query:      "status=pending",
+			statusCode: http.StatusOK,
+			responseBody: openapi.ListRunsV1Response{
+				Page: openapi.Page{CurrentPage: 1, ItemsPerPage: 20, TotalItems: 2, TotalPages: 1},
+				Result: []openapi.RunV1{
+					{
+						Id:            1,
+						Reason:        openapi.Cron,
+						ScheduleAfter: testDate(1, 6, 3, 0),
+						Status:        openapi.Pending,
+						Task:          "unittest",
+					},
+					{
+						Id:            2,
+						Reason:        openapi.Webhook,
+						ScheduleAfter: testDate(1, 0, 0, 4),
+						Status:        openapi.Pending,
+						Task:          "unittest",
+					},
+				},
+			},
+		},
+	}
+	for _, call := range apiCalls {
+		assertApiCall(e, call)
+	}
+
+	// Doesn't shadow a task named "reload".
+	assertApiCall(e, apiCall{
+		method:     "GET",
+		path:       "/api/v1/tasks/reload",
+		statusCode: http.StatusNotFound,
+		responseBody: openapi.Error{
+			Errors: []openapi.ErrorDetail{{Error: sberror.ClientIDTaskNotFound, Message: "unknown task"}},
+		},
+	})
+
+	// Keeps the current tasks if a task file is invalid.
+	err = os.WriteFile(taskFiles[0], []byte("name: [invalid"), 0600)
+	require.NoError(t, err)
+	assertApiCall(e, apiCall{
+		method:     "POST",
+		path:       "/api/v1/tasks/reload",
+		statusCode: http.StatusBadRequest,
+		responseBody: openapi.Error{
+			Errors: []openapi.ErrorDetail{
+				{
+					Error:   sberror.ClientIDTaskReloadFailed,
+					Message: fmt.Sprintf("reload tasks: failed to read tasks from file %[1]s: decode task from YAML file %[1]s: yaml: line 1: did not find expected ',' or ']'", taskFiles[0]),
+				},
+			},
+		},
+	})
+}
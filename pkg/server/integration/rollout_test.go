@@ -0,0 +1,230 @@
+package integration_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/wndhydrnt/saturn-bot/pkg/processor"
+	"github.com/wndhydrnt/saturn-bot/pkg/ptr"
+	"github.com/wndhydrnt/saturn-bot/pkg/server/api/openapi"
+	sberror "github.com/wndhydrnt/saturn-bot/pkg/server/error"
+	"github.com/wndhydrnt/saturn-bot/pkg/task/schema"
+)
+
+// fixedClock always returns the same point in time.
+type fixedClock struct {
+	now time.Time
+}
+
+func (c *fixedClock) Now() time.Time {
+	return c.now
+}
+
+var rolloutTask = schema.Task{
+	Name: "unittest",
+	Rollout: &schema.TaskRollout{
+		Waves: []schema.RolloutWave{
+			{Name: "canary", Gate: &schema.RolloutWaveGate{MinMerged: 1}},
+			{Name: "everything"},
+		},
+	},
+}
+
+const rolloutTaskHash = "9f1a4285b014427760e4e309ba0f3a5b6abbc1a5a8050d5edfb5303bfca4ca08"
+
+func TestServer_API_Rollout_Promote(t *testing.T) {
+	tc := testCase{
+		name: `When the gate of the current wave passes
+			Then it starts the next wave
+			And schedules a run with the data of the previous run`,
+		tasks:     []schema.Task{rolloutTask},
+		fakeClock: &fixedClock{now: testDate(1, 0, 0, 0)},
+		apiCalls: []apiCall{
+			{
+				method:       "POST",
+				path:         "/api/v1/runs",
+				requestBody:  openapi.ScheduleRunV1Request{RunData: ptr.To(map[string]string{"greeting": "hello"}), TaskName: rolloutTask.Name},
+				statusCode:   http.StatusOK,
+				responseBody: openapi.ScheduleRunV1Response{RunID: 1},
+			},
+			{
+				method:     "GET",
+				path:       "/api/v1/worker/work",
+				statusCode: http.StatusOK,
+				responseBody: openapi.GetWorkV1Response{
+					RunID:   1,
+					RunData: ptr.To(map[string]string{"greeting": "hello", "sb.rolloutWave": "0"}),
+					Task:    openapi.WorkTaskV1{Hash: rolloutTaskHash, Name: rolloutTask.Name},
+				},
+			},
+			{
+				method: "POST",
+				path:   "/api/v1/worker/work",
+				requestBody: openapi.ReportWorkV1Request{
+					RunID: 1,
+					Task:  openapi.WorkTaskV1{Hash: rolloutTaskHash, Name: rolloutTask.Name},
+					TaskResults: []openapi.ReportWorkV1TaskResult{
+						{
+							PullRequestUrl: ptr.To("https://git.local/unit/test/pull/1"),
+							RepositoryName: "git.local/unit/test",
+							Result:         int(processor.ResultPrMerged),
+							RolloutWave:    ptr.To(0),
+							State:          openapi.TaskResultStateV1Merged,
+						},
+					},
+				},
+				statusCode:   http.StatusCreated,
+				responseBody: openapi.ReportWorkV1Response{Result: "ok"},
+			},
+			{
+				method:     "GET",
+				path:       "/api/v1/worker/work",
+				statusCode: http.StatusOK,
+				responseBody: openapi.GetWorkV1Response{
+					RunID:   2,
+					RunData: ptr.To(map[string]string{"greeting": "hello", "sb.rolloutWave": "1"}),
+					Task:    openapi.WorkTaskV1{Hash: rolloutTaskHash, Name: rolloutTask.Name},
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, tc)
+}
+
+func TestServer_API_Rollout_PauseOnReopen(t *testing.T) {
+	task := rolloutTask
+	task.Rollout = &schema.TaskRollout{
+		Waves: []schema.RolloutWave{
+			{Name: "canary", Gate: &schema.RolloutWaveGate{MinMerged: 2}},
+			{Name: "everything"},
+		},
+	}
+	taskHash := "c6c143b163722fc2509918ab0bef0374dc534f3d8b3961622da1996fdefb5f4e"
+	tc := testCase{
+		name: `When a pull request opens again after it had been merged
+			Then it pauses the rollout
+			And a user can resume and promote the rollout`,
+		tasks:     []schema.Task{task},
+		fakeClock: &fixedClock{now: testDate(1, 0, 0, 0)},
+		apiCalls: []apiCall{
+			{
+				method:       "POST",
+				path:         "/api/v1/runs",
+				requestBody:  openapi.ScheduleRunV1Request{TaskName: task.Name},
+				statusCode:   http.StatusOK,
+				responseBody: openapi.ScheduleRunV1Response{RunID: 1},
+			},
+			{
+				method:     "GET",
+				path:       "/api/v1/worker/work",
+				statusCode: http.StatusOK,
+				responseBody: openapi.GetWorkV1Response{
+					RunID:   1,
+					RunData: ptr.To(map[string]string{"sb.rolloutWave": "0"}),
+					Task:    openapi.WorkTaskV1{Hash: taskHash, Name: task.Name},
+				},
+			},
+			{
+				method: "POST",
+				path:   "/api/v1/worker/work",
+				requestBody: openapi.ReportWorkV1Request{
+					RunID: 1,
+					Task:  openapi.WorkTaskV1{Hash: taskHash, Name: task.Name},
+					TaskResults: []openapi.ReportWorkV1TaskResult{
+						{
+							PullRequestUrl: ptr.To("https://git.local/unit/test/pull/1"),
+							RepositoryName: "git.local/unit/test",
+							Result:         int(processor.ResultPrMerged),
+							RolloutWave:    ptr.To(0),
+							State:          openapi.TaskResultStateV1Merged,
+						},
+					},
+				},
+				statusCode:   http.StatusCreated,
+				responseBody: openapi.ReportWorkV1Response{Result: "ok"},
+			},
+			{
+				method:       "POST",
+				path:         "/api/v1/runs",
+				requestBody:  openapi.ScheduleRunV1Request{TaskName: task.Name},
+				statusCode:   http.StatusOK,
+				responseBody: openapi.ScheduleRunV1Response{RunID: 2},
+			},
+			{
+				method:     "GET",
+				path:       "/api/v1/worker/work",
+				statusCode: http.StatusOK,
+				responseBody: openapi.GetWorkV1Response{
+					RunID:   2,
+					RunData: ptr.To(map[string]string{"sb.rolloutWave": "0"}),
+					Task:    openapi.WorkTaskV1{Hash: taskHash, Name: task.Name},
+				},
+			},
+			{
+				method: "POST",
+				path:   "/api/v1/worker/work",
+				requestBody: openapi.ReportWorkV1Request{
+					RunID: 2,
+					Task:  openapi.WorkTaskV1{Hash: taskHash, Name: task.Name},
+					TaskResults: []openapi.ReportWorkV1TaskResult{
+						{
+							PullRequestUrl: ptr.To("https://git.local/unit/test/pull/2"),
+							RepositoryName: "git.local/unit/test",
+							Result:         int(processor.ResultPrCreated),
+							RolloutWave:    ptr.To(0),
+							State:          openapi.TaskResultStateV1Open,
+						},
+					},
+				},
+				statusCode:   http.StatusCreated,
+				responseBody: openapi.ReportWorkV1Response{Result: "ok"},
+			},
+			{
+				method:     "POST",
+				path:       "/api/v1/tasks/unittest/rollout",
+				statusCode: http.StatusOK,
+				requestBody: openapi.UpdateTaskRolloutV1Request{
+					Action: openapi.Promote,
+				},
+				responseBody: openapi.TaskRolloutV1{
+					CurrentWave:   1,
+					Paused:        true,
+					PausedReason:  ptr.To("pull request of repository git.local/unit/test opened again after it had been merged"),
+					WaveStartedAt: testDate(1, 0, 0, 0),
+					Waves:         []string{"canary", "everything"},
+				},
+			},
+			{
+				method:     "POST",
+				path:       "/api/v1/tasks/unittest/rollout",
+				statusCode: http.StatusOK,
+				requestBody: openapi.UpdateTaskRolloutV1Request{
+					Action: openapi.Resume,
+				},
+				responseBody: openapi.TaskRolloutV1{
+					CurrentWave:   1,
+					Paused:        false,
+					WaveStartedAt: testDate(1, 0, 0, 0),
+					Waves:         []string{"canary", "everything"},
+				},
+			},
+			{
+				method:     "POST",
+				path:       "/api/v1/tasks/unittest/rollout",
+				statusCode: http.StatusBadRequest,
+				requestBody: openapi.UpdateTaskRolloutV1Request{
+					Action: openapi.Promote,
+				},
+				responseBody: openapi.Error{
+					Errors: []openapi.ErrorDetail{
+						{Error: sberror.ClientIDRolloutCannotPromote, Message: "rollout has reached its last wave"},
+					},
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, tc)
+}
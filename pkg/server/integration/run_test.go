@@ -3,8 +3,13 @@ package integration_test
 import (
 	"net/http"
 	"testing"
+	"time"
 
+	"github.com/gavv/httpexpect/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/wndhydrnt/saturn-bot/pkg/processor"
 	"github.com/wndhydrnt/saturn-bot/pkg/ptr"
+	"github.com/wndhydrnt/saturn-bot/pkg/server"
 	"github.com/wndhydrnt/saturn-bot/pkg/server/api/openapi"
 	"github.com/wndhydrnt/saturn-bot/pkg/task/schema"
 )
@@ -176,3 +181,375 @@ func Test_API_DeleteRunV1(t *testing.T) {
 		})
 	}
 }
+
+func Test_API_CancelRunV1(t *testing.T) {
+	taskWithInputs := schema.Task{
+		Name: "with-inputs",
+		Inputs: []schema.Input{
+			{Name: "unit"},
+		},
+	}
+	testCases := []testCase{
+		{
+			name:  `deletes a pending run if it has been scheduled manually`,
+			tasks: []schema.Task{defaultTask},
+			apiCalls: []apiCall{
+				{
+					method: "POST",
+					path:   "/api/v1/runs",
+					requestBody: openapi.ScheduleRunV1Request{
+						TaskName: defaultTask.Name,
+					},
+					statusCode: http.StatusOK,
+					responseBody: openapi.ScheduleRunV1Response{
+						RunID: 2,
+					},
+				},
+				{
+					method:       "POST",
+					path:         "/api/v1/runs/2/cancel",
+					statusCode:   http.StatusOK,
+					responseBody: openapi.CancelRunV1Response{Deleted: true},
+				},
+				{
+					method:     "GET",
+					path:       "/api/v1/runs/2",
+					statusCode: http.StatusNotFound,
+					responseBody: openapi.Error{
+						Errors: []openapi.ErrorDetail{
+							{Error: 1002, Message: "unknown run"},
+						},
+					},
+				},
+			},
+		},
+
+		{
+			name:  `does not cancel a pending run that has been scheduled by a trigger`,
+			tasks: []schema.Task{defaultTask},
+			apiCalls: []apiCall{
+				{
+					method:     "POST",
+					path:       "/api/v1/runs/1/cancel",
+					statusCode: http.StatusBadRequest,
+					responseBody: openapi.Error{
+						Errors: []openapi.ErrorDetail{
+							{Error: 1008, Message: "cannot cancel run"},
+						},
+					},
+				},
+			},
+		},
+
+		{
+			name:  `requests the cancellation of a running run`,
+			tasks: []schema.Task{taskWithInputs},
+			apiCalls: []apiCall{
+				{
+					method: "POST",
+					path:   "/api/v1/runs",
+					requestBody: openapi.ScheduleRunV1Request{
+						RunData:  ptr.To(map[string]string{"unit": "test"}),
+						TaskName: taskWithInputs.Name,
+					},
+					statusCode: http.StatusOK,
+					responseBody: openapi.ScheduleRunV1Response{
+						RunID: 1,
+					},
+				},
+				// Process the run.
+				{
+					method:     "GET",
+					path:       "/api/v1/worker/work",
+					statusCode: http.StatusOK,
+					responseBody: openapi.GetWorkV1Response{
+						RunData: ptr.To(map[string]string{"unit": "test"}),
+						RunID:   1,
+						Task: openapi.WorkTaskV1{
+							Hash: "2b77e497f5d91796abf103724538734cf8ae737ef0a0b134c7b75ebe26e4e2b8",
+							Name: taskWithInputs.Name,
+						},
+					},
+				},
+				{
+					method:       "POST",
+					path:         "/api/v1/runs/1/cancel",
+					statusCode:   http.StatusOK,
+					responseBody: openapi.CancelRunV1Response{Deleted: false},
+				},
+				// Requesting the cancellation again does not fail.
+				{
+					method:       "POST",
+					path:         "/api/v1/runs/1/cancel",
+					statusCode:   http.StatusOK,
+					responseBody: openapi.CancelRunV1Response{Deleted: false},
+				},
+			},
+		},
+
+		{
+			name:  `does not cancel a run that does not exist`,
+			tasks: []schema.Task{defaultTask},
+			apiCalls: []apiCall{
+				{
+					method:     "POST",
+					path:       "/api/v1/runs/100/cancel",
+					statusCode: http.StatusNotFound,
+					responseBody: openapi.Error{
+						Errors: []openapi.ErrorDetail{
+							{Error: 1002, Message: "unknown run"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			executeTestCase(t, tc)
+		})
+	}
+}
+
+func Test_API_GetRepositoryLogV1(t *testing.T) {
+	task := schema.Task{Name: "unittest"}
+	taskHash := "7d4262799e93d4fb6abc2f299a1846921256fc7aa64d80f87d2ad579e5c31306"
+	tc := testCase{
+		name:  `When a worker reports a log then it returns the log`,
+		tasks: []schema.Task{task},
+		apiCalls: []apiCall{
+			{
+				method: "POST",
+				path:   "/api/v1/runs",
+				requestBody: openapi.ScheduleRunV1Request{
+					TaskName: task.Name,
+				},
+				statusCode: http.StatusOK,
+				responseBody: openapi.ScheduleRunV1Response{
+					RunID: 1,
+				},
+			},
+			{
+				method:     "GET",
+				path:       "/api/v1/worker/work",
+				statusCode: http.StatusOK,
+				responseBody: openapi.GetWorkV1Response{
+					RunID: 1,
+					Task: openapi.WorkTaskV1{
+						Hash: taskHash,
+						Name: task.Name,
+					},
+				},
+			},
+			{
+				method: "POST",
+				path:   "/api/v1/worker/work",
+				requestBody: openapi.ReportWorkV1Request{
+					RunID: 1,
+					Task: openapi.WorkTaskV1{
+						Hash: taskHash,
+						Name: task.Name,
+					},
+					TaskResults: []openapi.ReportWorkV1TaskResult{
+						{
+							Error:          ptr.To("action failed"),
+							Log:            ptr.To("Executing git - cmd: [clone]\nExecuted command stdout=hello\n"),
+							RepositoryName: "git.local/unit/test",
+							Result:         int(processor.ResultUnknown),
+							State:          openapi.TaskResultStateV1Error,
+						},
+						{
+							RepositoryName: "git.local/unit/other",
+							Result:         int(processor.ResultPrCreated),
+							State:          openapi.TaskResultStateV1Open,
+						},
+					},
+				},
+				statusCode: http.StatusCreated,
+				responseBody: openapi.ReportWorkV1Response{
+					Result: "ok",
+				},
+			},
+			{
+				method:     "GET",
+				path:       "/api/v1/runs/1/logs",
+				query:      "repositoryName=git.local/unit/test",
+				statusCode: http.StatusOK,
+				responseBody: openapi.GetRepositoryLogV1Response{
+					CreatedAt:      testDate(1, 0, 0, 5),
+					Log:            "Executing git - cmd: [clone]\nExecuted command stdout=hello\n",
+					RepositoryName: "git.local/unit/test",
+					RunId:          1,
+				},
+			},
+			{
+				method:     "GET",
+				path:       "/api/v1/runs/1/logs",
+				query:      "repositoryName=git.local/unit/other",
+				statusCode: http.StatusNotFound,
+				responseBody: openapi.Error{
+					Errors: []openapi.ErrorDetail{
+						{Error: 1009, Message: "unknown repository log"},
+					},
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, tc)
+}
+
+func Test_API_GetRepositoryLogV1_MaxSizeAndActivity(t *testing.T) {
+	task := schema.Task{Name: "unittest"}
+	taskHash := "7d4262799e93d4fb6abc2f299a1846921256fc7aa64d80f87d2ad579e5c31306"
+	cfg := defaultServerConfig
+	cfg.ServerRepositoryLogMaxSize = 10
+	tc := testCase{
+		name:   `When a worker reports logs then it truncates long logs and doesn't store logs of repositories in which nothing has happened`,
+		config: &cfg,
+		tasks:  []schema.Task{task},
+		apiCalls: []apiCall{
+			{
+				method:       "POST",
+				path:         "/api/v1/runs",
+				requestBody:  openapi.ScheduleRunV1Request{TaskName: task.Name},
+				statusCode:   http.StatusOK,
+				responseBody: openapi.ScheduleRunV1Response{RunID: 1},
+			},
+			{
+				method:       "GET",
+				path:         "/api/v1/worker/work",
+				statusCode:   http.StatusOK,
+				responseBody: openapi.GetWorkV1Response{RunID: 1, Task: openapi.WorkTaskV1{Hash: taskHash, Name: task.Name}},
+			},
+			{
+				method: "POST",
+				path:   "/api/v1/worker/work",
+				requestBody: openapi.ReportWorkV1Request{
+					RunID: 1,
+					Task:  openapi.WorkTaskV1{Hash: taskHash, Name: task.Name},
+					TaskResults: []openapi.ReportWorkV1TaskResult{
+						{
+							Log:            ptr.To("1234567890abc"),
+							PullRequestUrl: ptr.To("https://git.local/unit/test/pull/1"),
+							RepositoryName: "git.local/unit/test",
+							Result:         int(processor.ResultPrCreated),
+							State:          openapi.TaskResultStateV1Open,
+						},
+						{
+							Log:            ptr.To("nothing to do"),
+							PullRequestUrl: ptr.To("https://git.local/unit/other/pull/1"),
+							RepositoryName: "git.local/unit/other",
+							Result:         int(processor.ResultPrOpen),
+							State:          openapi.TaskResultStateV1Open,
+						},
+					},
+				},
+				statusCode:   http.StatusCreated,
+				responseBody: openapi.ReportWorkV1Response{Result: "ok"},
+			},
+			{
+				method:     "GET",
+				path:       "/api/v1/runs/1/logs",
+				query:      "repositoryName=git.local/unit/test",
+				statusCode: http.StatusOK,
+				responseBody: openapi.GetRepositoryLogV1Response{
+					CreatedAt:      testDate(1, 0, 0, 5),
+					Log:            "1234567890\n[log truncated]\n",
+					RepositoryName: "git.local/unit/test",
+					RunId:          1,
+				},
+			},
+			{
+				method:     "GET",
+				path:       "/api/v1/runs/1/logs",
+				query:      "repositoryName=git.local/unit/other",
+				statusCode: http.StatusNotFound,
+				responseBody: openapi.Error{
+					Errors: []openapi.ErrorDetail{
+						{Error: 1009, Message: "unknown repository log"},
+					},
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, tc)
+}
+
+// hourClock advances by one hour on each call to Now().
+type hourClock struct {
+	base time.Time
+}
+
+func (c *hourClock) Now() time.Time {
+	now := c.base
+	c.base = c.base.Add(time.Hour)
+	return now
+}
+
+func Test_API_GetRepositoryLogV1_Retention(t *testing.T) {
+	task := schema.Task{Name: "unittest"}
+	taskHash := "7d4262799e93d4fb6abc2f299a1846921256fc7aa64d80f87d2ad579e5c31306"
+	opts := setupOptions(t, nil, &hourClock{base: testDate(1, 0, 0, 0)})
+	opts.ServerRepositoryLogRetention = 90 * time.Minute
+	taskFiles := bootstrapTaskFiles(t, task)
+	svr := &server.Server{}
+	err := svr.Start(opts, taskFiles)
+	require.NoError(t, err, "Server starts up")
+	defer func() {
+		err := svr.Stop()
+		require.NoError(t, err, "Server shuts down")
+	}()
+
+	time.Sleep(1 * time.Millisecond)
+	e := httpexpect.Default(t, opts.Config.ServerBaseUrl)
+	for runID := 1; runID <= 2; runID++ {
+		assertApiCall(e, apiCall{
+			method:       "POST",
+			path:         "/api/v1/runs",
+			requestBody:  openapi.ScheduleRunV1Request{TaskName: task.Name},
+			statusCode:   http.StatusOK,
+			responseBody: openapi.ScheduleRunV1Response{RunID: runID},
+		})
+		assertApiCall(e, apiCall{
+			method:       "GET",
+			path:         "/api/v1/worker/work",
+			statusCode:   http.StatusOK,
+			responseBody: openapi.GetWorkV1Response{RunID: runID, Task: openapi.WorkTaskV1{Hash: taskHash, Name: task.Name}},
+		})
+		assertApiCall(e, apiCall{
+			method: "POST",
+			path:   "/api/v1/worker/work",
+			requestBody: openapi.ReportWorkV1Request{
+				RunID: runID,
+				Task:  openapi.WorkTaskV1{Hash: taskHash, Name: task.Name},
+				TaskResults: []openapi.ReportWorkV1TaskResult{
+					{
+						Error:          ptr.To("action failed"),
+						Log:            ptr.To("log of run"),
+						RepositoryName: "git.local/unit/test",
+						Result:         int(processor.ResultUnknown),
+						State:          openapi.TaskResultStateV1Error,
+					},
+				},
+			},
+			statusCode:   http.StatusCreated,
+			responseBody: openapi.ReportWorkV1Response{Result: "ok"},
+		})
+	}
+
+	// The report of the second run deletes the log of the first run because it exceeds the retention.
+	e.GET("/api/v1/runs/1/logs").
+		WithHeader(openapi.HeaderApiKey, testApiKey).
+		WithQuery("repositoryName", "git.local/unit/test").
+		Expect().
+		Status(http.StatusNotFound)
+	e.GET("/api/v1/runs/2/logs").
+		WithHeader(openapi.HeaderApiKey, testApiKey).
+		WithQuery("repositoryName", "git.local/unit/test").
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().HasValue("log", "log of run")
+}
@@ -0,0 +1,245 @@
+package integration_test
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/gavv/httpexpect/v2"
+	"github.com/stretchr/testify/require"
+	"github.com/wndhydrnt/saturn-bot/pkg/ptr"
+	"github.com/wndhydrnt/saturn-bot/pkg/server"
+	"github.com/wndhydrnt/saturn-bot/pkg/server/api/openapi"
+	"github.com/wndhydrnt/saturn-bot/pkg/task/schema"
+)
+
+var csrfTokenRegex = regexp.MustCompile(`name="csrf_token" value="([^"]+)"`)
+
+// startUiWithOidc starts a server that requires users to log in via the mock OIDC provider.
+// The provider adds claims to the ID token of each user.
+// The server loads defaultTask if tasks is empty.
+func startUiWithOidc(t *testing.T, claims map[string]any, tasks ...schema.Task) *httpexpect.Expect {
+	provider := newMockOidcProvider(t, claims)
+	cfg := defaultServerConfig
+	cfg.ServerServeUi = true
+	cfg.ServerUiOidcClientId = mockOidcClientID
+	cfg.ServerUiOidcGroupsClaim = "groups"
+	cfg.ServerUiOidcIssuerUrl = provider.URL()
+	cfg.ServerUiOidcRunGroups = []string{"maintainers"}
+	cfg.ServerUiOidcScopes = []string{"openid", "profile"}
+	cfg.ServerUiOidcViewGroups = []string{"developers", "maintainers"}
+	cfg.ServerUiSessionSecret = "secret"
+	opts := setupOptions(t, &cfg, nil)
+	opts.ServerUiSessionTtl = time.Hour
+	if len(tasks) == 0 {
+		tasks = []schema.Task{defaultTask}
+	}
+	taskFiles := bootstrapTaskFiles(t, tasks...)
+	svr := &server.Server{}
+	err := svr.Start(opts, taskFiles)
+	require.NoError(t, err, "Server starts up")
+	t.Cleanup(func() {
+		err := svr.Stop()
+		require.NoError(t, err, "Server shuts down")
+	})
+
+	time.Sleep(1 * time.Millisecond)
+	return httpexpect.Default(t, opts.Config.ServerBaseUrl)
+}
+
+func extractCsrfToken(t *testing.T, body string) string {
+	matches := csrfTokenRegex.FindStringSubmatch(body)
+	require.Len(t, matches, 2, "Page contains CSRF token")
+	return matches[1]
+}
+
+func Test_UI_OIDC_Login(t *testing.T) {
+	e := startUiWithOidc(t, map[string]any{"groups": []string{"maintainers"}, "name": "Jane Doe"})
+
+	// Redirects to the login if the user isn't logged in.
+	e.GET("/ui/tasks").
+		WithRedirectPolicy(httpexpect.DontFollowRedirects).
+		Expect().
+		Status(http.StatusFound).
+		Header("Location").IsEqual("/ui/auth/login?redirect=%2Fui%2Ftasks")
+
+	// Logs in at the provider and returns to the page.
+	body := e.GET("/ui/tasks/unittest/results").
+		Expect().
+		Status(http.StatusOK).
+		Body()
+	body.Contains("Jane Doe")
+	body.Contains(`href="/ui/tasks/unittest/runs/new"`)
+	csrfToken := extractCsrfToken(t, body.Raw())
+
+	// Denies forms without a CSRF token.
+	e.POST("/ui/tasks/unittest/runs").
+		Expect().
+		Status(http.StatusForbidden).
+		Body().Contains("Invalid CSRF token")
+
+	// Schedules a run and redirects to it.
+	// Run 1 has been scheduled by the cron trigger of the task.
+	e.POST("/ui/tasks/unittest/runs").
+		WithFormField("csrf_token", csrfToken).
+		WithRedirectPolicy(httpexpect.DontFollowRedirects).
+		Expect().
+		Status(http.StatusSeeOther).
+		Header("Location").IsEqual("/ui/runs/2")
+	run := e.GET("/api/v1/runs/2").
+		WithHeader(openapi.HeaderApiKey, testApiKey).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("run").Object()
+	run.Value("reason").IsEqual(openapi.Manual)
+	run.Value("task").IsEqual("unittest")
+
+	// Logs the user out.
+	e.POST("/ui/auth/logout").
+		WithFormField("csrf_token", csrfToken).
+		Expect().
+		Status(http.StatusOK).
+		Body().Contains("You have been logged out")
+	e.GET("/ui").
+		WithRedirectPolicy(httpexpect.DontFollowRedirects).
+		Expect().
+		Status(http.StatusFound).
+		Header("Location").IsEqual("/ui/auth/login?redirect=%2Fui")
+}
+
+func Test_UI_OIDC_ScheduleRunWithInputs(t *testing.T) {
+	task := schema.Task{
+		Name: "with-inputs",
+		Inputs: []schema.Input{
+			{Name: "environment", Options: []string{"staging", "production"}, Default: ptr.To("staging")},
+			{Name: "version", Description: ptr.To("Version to deploy"), Validation: ptr.To("^v[0-9]+$")},
+		},
+	}
+	e := startUiWithOidc(t, map[string]any{"groups": []string{"maintainers"}}, task)
+
+	body := e.GET("/ui/tasks/with-inputs/runs/new").
+		Expect().
+		Status(http.StatusOK).
+		Body()
+	body.Contains(`<option value="staging" selected>staging</option>`)
+	body.Contains(`<option value="production">production</option>`)
+	body.Contains(`name="input.version"`)
+	body.Contains("Version to deploy")
+	body.Contains("<code>^v[0-9]")
+	csrfToken := extractCsrfToken(t, body.Raw())
+
+	// Renders the form again if an input is invalid and keeps the values.
+	body = e.POST("/ui/tasks/with-inputs/runs").
+		WithFormField("csrf_token", csrfToken).
+		WithFormField("input.environment", "production").
+		WithFormField("input.version", "latest").
+		WithFormField("repositoryNames", "git.local/unit/test").
+		Expect().
+		Status(http.StatusBadRequest).
+		Body()
+	body.Contains("does not match regular expression")
+	body.Contains(`<option value="production" selected>production</option>`)
+	body.Contains(`value="latest"`)
+	body.Contains("git.local/unit/test")
+
+	e.POST("/ui/tasks/with-inputs/runs").
+		WithFormField("csrf_token", csrfToken).
+		WithFormField("input.environment", "production").
+		WithFormField("input.version", "v2").
+		WithFormField("repositoryNames", "git.local/unit/test\ngit.local/unit/other").
+		WithRedirectPolicy(httpexpect.DontFollowRedirects).
+		Expect().
+		Status(http.StatusSeeOther).
+		Header("Location").IsEqual("/ui/runs/1")
+	run := e.GET("/api/v1/runs/1").
+		WithHeader(openapi.HeaderApiKey, testApiKey).
+		Expect().
+		Status(http.StatusOK).
+		JSON().Object().Value("run").Object()
+	run.Value("repositories").IsEqual([]string{"git.local/unit/test", "git.local/unit/other"})
+	run.Value("runData").IsEqual(map[string]string{"environment": "production", "version": "v2"})
+
+	// Cancels the run. It gets deleted because no worker has picked it up yet.
+	e.GET("/ui/runs/1").
+		Expect().
+		Status(http.StatusOK).
+		Body().Contains("Cancel run")
+	e.POST("/ui/runs/1/cancel").
+		WithFormField("csrf_token", csrfToken).
+		WithRedirectPolicy(httpexpect.DontFollowRedirects).
+		Expect().
+		Status(http.StatusSeeOther).
+		Header("Location").IsEqual("/ui/runs?task=with-inputs")
+	e.GET("/api/v1/runs/1").
+		WithHeader(openapi.HeaderApiKey, testApiKey).
+		Expect().
+		Status(http.StatusNotFound)
+}
+
+func Test_UI_OIDC_UserNotInRunGroups(t *testing.T) {
+	e := startUiWithOidc(t, map[string]any{"groups": []string{"developers"}, "preferred_username": "jdoe"})
+
+	body := e.GET("/ui/tasks/unittest/results").
+		Expect().
+		Status(http.StatusOK).
+		Body()
+	body.Contains("jdoe")
+	body.NotContains(`href="/ui/tasks/unittest/runs/new"`)
+	csrfToken := extractCsrfToken(t, body.Raw())
+
+	e.GET("/ui/tasks/unittest/runs/new").
+		Expect().
+		Status(http.StatusForbidden).
+		Body().Contains("Not allowed to schedule runs")
+
+	e.POST("/ui/tasks/unittest/runs").
+		WithFormField("csrf_token", csrfToken).
+		Expect().
+		Status(http.StatusForbidden).
+		Body().Contains("Not allowed to schedule runs")
+}
+
+func Test_UI_OIDC_UserNotInViewGroups(t *testing.T) {
+	e := startUiWithOidc(t, map[string]any{"groups": "guests"})
+
+	e.GET("/ui").
+		Expect().
+		Status(http.StatusForbidden).
+		Body().Contains("Access denied")
+}
+
+func Test_UI_OIDC_Disabled(t *testing.T) {
+	cfg := defaultServerConfig
+	cfg.ServerServeUi = true
+	opts := setupOptions(t, &cfg, nil)
+	taskFiles := bootstrapTaskFiles(t, schema.Task{Name: "unittest"})
+	svr := &server.Server{}
+	err := svr.Start(opts, taskFiles)
+	require.NoError(t, err, "Server starts up")
+	defer func() {
+		err := svr.Stop()
+		require.NoError(t, err, "Server shuts down")
+	}()
+
+	time.Sleep(1 * time.Millisecond)
+	e := httpexpect.Default(t, opts.Config.ServerBaseUrl)
+	// The UI is read-only if login via OpenID Connect is disabled.
+	e.GET("/ui/tasks/unittest/results").
+		Expect().
+		Status(http.StatusOK).
+		Body().NotContains(`href="/ui/tasks/unittest/runs/new"`)
+	e.GET("/ui/tasks/unittest/runs/new").
+		Expect().
+		Status(http.StatusForbidden)
+	e.POST("/ui/tasks/unittest/runs").
+		Expect().
+		Status(http.StatusForbidden)
+	e.POST("/ui/tasks/unittest/rollout").
+		WithFormField("action", "pause").
+		Expect().
+		Status(http.StatusForbidden)
+	e.POST("/ui/runs/1/cancel").
+		Expect().
+		Status(http.StatusForbidden)
+}
@@ -956,3 +956,213 @@ func TestServer_API_ReportWorkV1_ErrorChange(t *testing.T) {
 
 	executeTestCase(t, tc)
 }
+
+func TestServer_API_ReportWorkV1_TargetBranches(t *testing.T) {
+	task := schema.Task{Name: "unittest"}
+	taskHash := "7d4262799e93d4fb6abc2f299a1846921256fc7aa64d80f87d2ad579e5c31306"
+
+	tc := testCase{
+		name:  `When a worker reports results of the same repository for different target branches then it stores a result for each target branch`,
+		tasks: []schema.Task{task},
+		apiCalls: []apiCall{
+			{
+				method: "POST",
+				path:   "/api/v1/runs",
+				requestBody: openapi.ScheduleRunV1Request{
+					TaskName: task.Name,
+				},
+				statusCode: http.StatusOK,
+				responseBody: openapi.ScheduleRunV1Response{
+					RunID: 1,
+				},
+			},
+			{
+				method:     "GET",
+				path:       "/api/v1/worker/work",
+				statusCode: http.StatusOK,
+				responseBody: openapi.GetWorkV1Response{
+					RunID: 1,
+					Task: openapi.WorkTaskV1{
+						Hash: taskHash,
+						Name: task.Name,
+					},
+				},
+			},
+			{
+				method: "POST",
+				path:   "/api/v1/worker/work",
+				requestBody: openapi.ReportWorkV1Request{
+					RunID: 1,
+					Task: openapi.WorkTaskV1{
+						Hash: taskHash,
+						Name: task.Name,
+					},
+					TaskResults: []openapi.ReportWorkV1TaskResult{
+						{
+							PullRequestUrl: ptr.To("https://git.local/unit/test/pull/1"),
+							RepositoryName: "git.local/unit/test",
+							Result:         int(processor.ResultPrCreated),
+							State:          openapi.TaskResultStateV1Open,
+							TargetBranch:   ptr.To("release/1.0"),
+						},
+						{
+							PullRequestUrl: ptr.To("https://git.local/unit/test/pull/2"),
+							RepositoryName: "git.local/unit/test",
+							Result:         int(processor.ResultPrCreated),
+							State:          openapi.TaskResultStateV1Open,
+							TargetBranch:   ptr.To("release/2.0"),
+						},
+					},
+				},
+				statusCode: http.StatusCreated,
+				responseBody: openapi.ReportWorkV1Response{
+					Result: "ok",
+				},
+			},
+			{
+				method:     "GET",
+				path:       fmt.Sprintf("/api/v1/tasks/%s/results", task.Name),
+				statusCode: http.StatusOK,
+				responseBody: openapi.ListTaskRecentTaskResultsV1Response{
+					Page: openapi.Page{CurrentPage: 1, ItemsPerPage: 20, TotalItems: 2, TotalPages: 1},
+					TaskResults: []openapi.TaskResultV1{
+						{
+							PullRequestUrl: ptr.To("https://git.local/unit/test/pull/2"),
+							RepositoryName: "git.local/unit/test",
+							RunId:          1,
+							Status:         openapi.TaskResultStateV1Open,
+							TargetBranch:   ptr.To("release/2.0"),
+						},
+						{
+							PullRequestUrl: ptr.To("https://git.local/unit/test/pull/1"),
+							RepositoryName: "git.local/unit/test",
+							RunId:          1,
+							Status:         openapi.TaskResultStateV1Open,
+							TargetBranch:   ptr.To("release/1.0"),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	executeTestCase(t, tc)
+}
+
+func TestServer_API_HeartbeatWorkV1(t *testing.T) {
+	testCases := []testCase{
+		{
+			name: `Given a running run
+							When a user cancels the run
+							Then the next heartbeat tells the worker to stop
+							And the run is cancelled after the worker reports it`,
+			tasks: []schema.Task{defaultTask},
+			apiCalls: []apiCall{
+				// Schedule a new run.
+				{
+					method: "POST",
+					path:   "/api/v1/runs",
+					requestBody: openapi.ScheduleRunV1Request{
+						TaskName: defaultTask.Name,
+					},
+					statusCode: http.StatusOK,
+					responseBody: openapi.ScheduleRunV1Response{
+						RunID: 2,
+					},
+				},
+				// Process the run.
+				{
+					method:     "GET",
+					path:       "/api/v1/worker/work",
+					statusCode: http.StatusOK,
+					responseBody: openapi.GetWorkV1Response{
+						RunID: 2,
+						Task: openapi.WorkTaskV1{
+							Hash: defaultTaskHash,
+							Name: defaultTask.Name,
+						},
+					},
+				},
+				{
+					method:       "POST",
+					path:         "/api/v1/worker/heartbeat",
+					requestBody:  openapi.HeartbeatWorkV1Request{RunID: 2},
+					statusCode:   http.StatusOK,
+					responseBody: openapi.HeartbeatWorkV1Response{Cancelled: false},
+				},
+				// Cancel the run.
+				{
+					method:       "POST",
+					path:         "/api/v1/runs/2/cancel",
+					statusCode:   http.StatusOK,
+					responseBody: openapi.CancelRunV1Response{Deleted: false},
+				},
+				{
+					method:       "POST",
+					path:         "/api/v1/worker/heartbeat",
+					requestBody:  openapi.HeartbeatWorkV1Request{RunID: 2},
+					statusCode:   http.StatusOK,
+					responseBody: openapi.HeartbeatWorkV1Response{Cancelled: true},
+				},
+				// Report that the run stopped early.
+				{
+					method: "POST",
+					path:   "/api/v1/worker/work",
+					requestBody: openapi.ReportWorkV1Request{
+						Cancelled: ptr.To(true),
+						RunID:     2,
+						Task: openapi.WorkTaskV1{
+							Name: defaultTask.Name,
+						},
+						TaskResults: []openapi.ReportWorkV1TaskResult{},
+					},
+					statusCode: http.StatusCreated,
+					responseBody: openapi.ReportWorkV1Response{
+						Result: "ok",
+					},
+				},
+				{
+					method:     "GET",
+					path:       "/api/v1/runs/2",
+					statusCode: http.StatusOK,
+					responseBody: openapi.GetRunV1Response{
+						Run: openapi.RunV1{
+							CancelRequestedAt: ptr.To(testDate(1, 0, 0, 4)),
+							FinishedAt:        ptr.To(testDate(1, 0, 0, 5)),
+							Id:                2,
+							Reason:            openapi.Manual,
+							ScheduleAfter:     testDate(1, 0, 0, 1),
+							StartedAt:         ptr.To(testDate(1, 0, 0, 3)),
+							Status:            openapi.Cancelled,
+							Task:              defaultTask.Name,
+						},
+					},
+				},
+			},
+		},
+
+		{
+			name:  `When the run does not exist then it is not found`,
+			tasks: []schema.Task{defaultTask},
+			apiCalls: []apiCall{
+				{
+					method:      "POST",
+					path:        "/api/v1/worker/heartbeat",
+					requestBody: openapi.HeartbeatWorkV1Request{RunID: 100},
+					statusCode:  http.StatusNotFound,
+					responseBody: openapi.Error{
+						Errors: []openapi.ErrorDetail{
+							{Error: 1002, Message: "unknown run"},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			executeTestCase(t, tc)
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"slices"
 	"syscall"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	chiprometheus "github.com/toshi0607/chi-prometheus"
 	"github.com/wndhydrnt/saturn-bot/pkg/config"
 	sbdb "github.com/wndhydrnt/saturn-bot/pkg/db"
+	"github.com/wndhydrnt/saturn-bot/pkg/git"
 	"github.com/wndhydrnt/saturn-bot/pkg/log"
 	"github.com/wndhydrnt/saturn-bot/pkg/options"
 	"github.com/wndhydrnt/saturn-bot/pkg/server/api"
@@ -33,6 +35,8 @@ type Server struct {
 	httpServer            *http.Server
 	shutdownCheckInterval time.Duration
 	shutdownTimeout       time.Duration
+	taskReloadService     *service.TaskReloadService
+	webhookService        *service.WebhookService
 }
 
 func (s *Server) Start(opts options.Opts, taskPaths []string) error {
@@ -47,7 +51,7 @@ func (s *Server) Start(opts options.Opts, taskPaths []string) error {
 		FilterFactories: opts.FilterFactories,
 		SkipPlugins:     true,
 	})
-	err := taskRegistry.ReadAll(taskPaths)
+	taskRepository, revision, err := loadTasks(opts, taskPaths, taskRegistry)
 	if err != nil {
 		return fmt.Errorf("load tasks on server start: %w", err)
 	}
@@ -62,9 +66,12 @@ func (s *Server) Start(opts options.Opts, taskPaths []string) error {
 		return fmt.Errorf("initialize database: %w", err)
 	}
 
+	apiTokenService := service.NewApiTokenService(opts.Clock, database)
 	dbInfoService := service.NewDbInfo(database)
 	taskService := service.NewTaskService(opts.Clock, database, taskRegistry)
-	workerService := service.NewWorkerService(opts.Clock, database, taskService)
+	repositoryLogService := service.NewRepositoryLogService(opts.Clock, database, opts.Config.ServerRepositoryLogMaxSize, opts.ServerRepositoryLogRetention)
+	rolloutService := service.NewRolloutService(opts.Clock, database)
+	workerService := service.NewWorkerService(opts.Clock, database, repositoryLogService, rolloutService, taskService)
 	syncService := service.NewSync(opts.Clock, database, taskService, workerService)
 	if err := syncService.SyncTasksInDatabase(); err != nil {
 		return err
@@ -73,10 +80,20 @@ func (s *Server) Start(opts options.Opts, taskPaths []string) error {
 	metrics.Init(opts.PrometheusRegisterer, dbInfoService, taskService, workerService)
 
 	router := newRouter(opts)
-	webhookService, err := service.NewWebhookService(opts.Clock, taskRegistry, workerService)
+	webhookService, err := service.NewWebhookService(opts.Clock, database, opts.Hosts, opts.ServerWebhookDeliveryRetention, taskRegistry, workerService)
 	if err != nil {
 		return fmt.Errorf("create webhook service: %w", err)
 	}
+	webhookService.Start()
+	s.webhookService = webhookService
+	s.taskReloadService = service.NewTaskReloadService(syncService, taskPaths, taskRegistry, taskRepository, revision, webhookService)
+	s.taskReloadService.Start(opts.TasksGitPollInterval)
+	api.RegisterGenericWebhookHandler(router, api.RegisterGenericWebhookHandlerOptions{
+		Sources:           opts.Config.ServerWebhookGenericSources,
+		TaskReloadService: s.taskReloadService,
+		TasksSource:       opts.Config.TasksGitWebhookSource,
+		WebhookService:    webhookService,
+	})
 	api.RegisterGithubWebhookHandler(router, []byte(opts.Config.ServerWebhookSecretGithub), webhookService)
 	api.RegisterGitlabWebhookHandler(router, opts.Config.ServerWebhookSecretGitlab, webhookService)
 	err = api.RegisterOpenAPIDefinitionRoute(opts.Config.ServerBaseUrl, router)
@@ -95,16 +112,47 @@ func (s *Server) Start(opts options.Opts, taskPaths []string) error {
 	}
 
 	handler, apiServer := api.RegisterAPIServer(&api.NewAPIServerOptions{
-		ApiKey:        opts.Config.ServerApiKey,
-		Clock:         opts.Clock,
-		Router:        router,
-		TaskService:   taskService,
-		WorkerService: workerService,
+		ApiKey:               opts.Config.ServerApiKey,
+		ApiTokenService:      apiTokenService,
+		Clock:                opts.Clock,
+		RepositoryLogService: repositoryLogService,
+		RolloutService:       rolloutService,
+		Router:               router,
+		TaskReloadService:    s.taskReloadService,
+		TaskService:          taskService,
+		WebhookService:       webhookService,
+		WorkerService:        workerService,
 	})
 	s.apiServer = apiServer
 	if opts.Config.ServerServeUi {
 		log.Log().Info("Registering UI routes")
-		ui.RegisterUiRoutes(router, apiServer)
+		uiOpts := ui.RegisterUiRoutesOptions{
+			APIServer: apiServer,
+			Router:    router,
+		}
+		if opts.Config.ServerUiOidcIssuerUrl != "" {
+			log.Log().Info("Enabling login to UI via OpenID Connect")
+			uiOpts.Auth = &ui.AuthOptions{
+				BaseUrl:       opts.Config.ServerBaseUrl,
+				ClientID:      opts.Config.ServerUiOidcClientId,
+				ClientSecret:  opts.Config.ServerUiOidcClientSecret,
+				Clock:         opts.Clock,
+				GroupsClaim:   opts.Config.ServerUiOidcGroupsClaim,
+				IssuerUrl:     opts.Config.ServerUiOidcIssuerUrl,
+				RunGroups:     opts.Config.ServerUiOidcRunGroups,
+				Scopes:        opts.Config.ServerUiOidcScopes,
+				SessionSecret: opts.Config.ServerUiSessionSecret,
+				SessionTtl:    opts.ServerUiSessionTtl,
+				ViewGroups:    opts.Config.ServerUiOidcViewGroups,
+			}
+		}
+
+		discoverCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		err := ui.RegisterUiRoutes(discoverCtx, uiOpts)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("register ui routes: %w", err)
+		}
 	}
 
 	s.httpServer = &http.Server{
@@ -123,10 +171,18 @@ func (s *Server) Start(opts options.Opts, taskPaths []string) error {
 	return nil
 }
 
+// ReloadTasks reads all tasks from their files and replaces the tasks known to the server.
+func (s *Server) ReloadTasks() error {
+	_, err := s.taskReloadService.Reload()
+	return err
+}
+
 // Stop initiates a graceful shutdown of the server.
 func (s *Server) Stop() error {
 	apiErr := s.stopApiServer()
 	httpErr := s.stopHttpServer()
+	s.stopWebhookService()
+	s.stopTaskReloadService()
 	return errors.Join(apiErr, httpErr)
 }
 
@@ -173,6 +229,68 @@ func (s *Server) stopHttpServer() error {
 	return nil
 }
 
+func (s *Server) stopWebhookService() {
+	if s.webhookService == nil {
+		return
+	}
+
+	log.Log().Debug("Shutting down processing of webhooks")
+	s.webhookService.Stop()
+	log.Log().Debug("Shutdown of processing of webhooks finished")
+}
+
+func (s *Server) stopTaskReloadService() {
+	if s.taskReloadService == nil {
+		return
+	}
+
+	log.Log().Debug("Shutting down refresh of tasks")
+	s.taskReloadService.Stop()
+	log.Log().Debug("Shutdown of refresh of tasks finished")
+}
+
+// loadTasks reads the tasks of the server into taskRegistry.
+// It reads tasks from the git repository set in the configuration if the setting tasksGitUrl is set
+// and from taskPaths otherwise.
+// It returns the git repository and the revision from which it read the tasks, if any.
+func loadTasks(opts options.Opts, taskPaths []string, taskRegistry *task.Registry) (*git.TaskRepository, string, error) {
+	if opts.Config.TasksGitUrl == "" {
+		if len(taskPaths) == 0 {
+			return nil, "", fmt.Errorf("no task files passed and setting tasksGitUrl not configured")
+		}
+
+		return nil, "", taskRegistry.ReadAll(taskPaths)
+	}
+
+	if len(taskPaths) > 0 {
+		return nil, "", fmt.Errorf("task files passed and setting tasksGitUrl configured - use one or the other")
+	}
+
+	if opts.Config.TasksGitWebhookSource != "" && !slices.ContainsFunc(opts.Config.ServerWebhookGenericSources, func(source config.WebhookGenericSource) bool {
+		return source.Name == opts.Config.TasksGitWebhookSource
+	}) {
+		return nil, "", fmt.Errorf("setting tasksGitWebhookSource references unknown generic webhook source '%s'", opts.Config.TasksGitWebhookSource)
+	}
+
+	taskRepository, err := git.NewTaskRepository(opts)
+	if err != nil {
+		return nil, "", fmt.Errorf("create git repository of tasks: %w", err)
+	}
+
+	revision, gitTaskPaths, err := taskRepository.Latest()
+	if err != nil {
+		return nil, "", err
+	}
+
+	log.Log().Infof("Reading tasks from revision %s of %s", revision, opts.Config.TasksGitUrl)
+	err = taskRegistry.Reload(gitTaskPaths, revision)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return taskRepository, revision, nil
+}
+
 func Run(configPath string, taskPaths []string) error {
 	cfg, err := config.Read(configPath)
 	if err != nil {
@@ -191,6 +309,8 @@ func Run(configPath string, taskPaths []string) error {
 
 	sigs := make(chan os.Signal, 1)
 	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	reloadSigs := make(chan os.Signal, 1)
+	signal.Notify(reloadSigs, syscall.SIGHUP)
 	s := &Server{}
 	err = s.Start(opts, taskPaths)
 	if err != nil {
@@ -198,7 +318,17 @@ func Run(configPath string, taskPaths []string) error {
 	}
 
 	log.Log().Infof("Server started %s", version.String())
-	sig := <-sigs
+	var sig os.Signal
+	for sig == nil {
+		select {
+		case <-reloadSigs:
+			if err := s.ReloadTasks(); err != nil {
+				log.Log().Errorw("Failed to reload tasks", zap.Error(err))
+			}
+		case sig = <-sigs:
+		}
+	}
+
 	log.Log().Infof("Caught signal %s - shutting down", sig.String())
 	err = s.Stop()
 	if err == nil {
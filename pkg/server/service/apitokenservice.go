@@ -0,0 +1,200 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+
+	"github.com/wndhydrnt/saturn-bot/pkg/clock"
+	"github.com/wndhydrnt/saturn-bot/pkg/server/db"
+	sberror "github.com/wndhydrnt/saturn-bot/pkg/server/error"
+	"gorm.io/gorm"
+)
+
+const (
+	apiTokenPrefix = "sbt_"
+)
+
+var (
+	apiTokenNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+	// ErrApiTokenUnknown indicates that no API token matches a secret.
+	ErrApiTokenUnknown = errors.New("unknown api token")
+)
+
+// ApiTokenService manages named API tokens.
+type ApiTokenService struct {
+	clock clock.Clock
+	db    *gorm.DB
+}
+
+// NewApiTokenService returns a new ApiTokenService.
+func NewApiTokenService(clock clock.Clock, db *gorm.DB) *ApiTokenService {
+	return &ApiTokenService{
+		clock: clock,
+		db:    db,
+	}
+}
+
+// CreateApiTokenOptions defines the data of a new API token.
+type CreateApiTokenOptions struct {
+	Name  string
+	Scope db.ApiTokenScope
+	Tasks []string
+}
+
+// CreateToken creates a new API token.
+//
+// It returns the secret of the token.
+// The secret isn't stored and can't be retrieved later.
+func (s *ApiTokenService) CreateToken(opts CreateApiTokenOptions) (string, db.ApiToken, error) {
+	var token db.ApiToken
+	if err := validateCreateApiTokenOptions(opts); err != nil {
+		return "", token, err
+	}
+
+	var count int64
+	result := s.db.Model(&db.ApiToken{}).Where("name = ?", opts.Name).Count(&count)
+	if result.Error != nil {
+		return "", token, fmt.Errorf("check if api token exists: %w", result.Error)
+	}
+
+	if count > 0 {
+		return "", token, sberror.NewApiTokenInvalidError("api token with the same name exists")
+	}
+
+	secret, err := generateApiTokenSecret()
+	if err != nil {
+		return "", token, err
+	}
+
+	token = db.ApiToken{
+		CreatedAt: s.clock.Now(),
+		Hash:      hashApiTokenSecret(secret),
+		Name:      opts.Name,
+		Scope:     opts.Scope,
+		Tasks:     db.StringList(opts.Tasks),
+	}
+	if err := s.db.Save(&token).Error; err != nil {
+		return "", token, fmt.Errorf("save api token: %w", err)
+	}
+
+	return secret, token, nil
+}
+
+// DeleteToken deletes the API token identified by name.
+// Clients can't authenticate with the token after it has been deleted.
+func (s *ApiTokenService) DeleteToken(name string) error {
+	result := s.db.Where("name = ?", name).Delete(&db.ApiToken{})
+	if result.Error != nil {
+		return fmt.Errorf("delete api token '%s': %w", name, result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return sberror.NewApiTokenNotFoundError(name)
+	}
+
+	return nil
+}
+
+// FindTokenBySecret returns the API token that matches secret.
+//
+// It returns [ErrApiTokenUnknown] if no API token matches.
+func (s *ApiTokenService) FindTokenBySecret(secret string) (db.ApiToken, error) {
+	var token db.ApiToken
+	result := s.db.Where("hash = ?", hashApiTokenSecret(secret)).First(&token)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return token, ErrApiTokenUnknown
+		}
+
+		return token, fmt.Errorf("find api token: %w", result.Error)
+	}
+
+	return token, nil
+}
+
+// ListTokens returns a list of [db.ApiToken] ordered by name.
+//
+// Supports pagination via [ListOptions].
+func (s *ApiTokenService) ListTokens(listOpts *ListOptions) ([]db.ApiToken, error) {
+	var tokens []db.ApiToken
+	result := s.db.
+		Offset(listOpts.Offset()).
+		Limit(listOpts.Limit).
+		Order("name ASC").
+		Find(&tokens)
+	if result.Error != nil {
+		return nil, fmt.Errorf("list api tokens: %w", result.Error)
+	}
+
+	var count int64
+	countResult := s.db.Model(&db.ApiToken{}).Count(&count)
+	if countResult.Error != nil {
+		return nil, fmt.Errorf("count api tokens: %w", countResult.Error)
+	}
+
+	listOpts.SetTotalItems(int(count))
+	return tokens, nil
+}
+
+// IsTaskAllowed returns true if token permits scheduling runs of the task identified by taskName.
+func IsTaskAllowed(token db.ApiToken, taskName string) bool {
+	if token.Scope != db.ApiTokenScopeScheduler {
+		return false
+	}
+
+	for _, pattern := range token.Tasks {
+		match, err := path.Match(pattern, taskName)
+		if err == nil && match {
+			return true
+		}
+	}
+
+	return false
+}
+
+func validateCreateApiTokenOptions(opts CreateApiTokenOptions) error {
+	if !apiTokenNameRegex.MatchString(opts.Name) {
+		return sberror.NewApiTokenInvalidError("name of api token must match " + apiTokenNameRegex.String())
+	}
+
+	switch opts.Scope {
+	case db.ApiTokenScopeReadOnly, db.ApiTokenScopeWorker:
+		if len(opts.Tasks) > 0 {
+			return sberror.NewApiTokenInvalidError("tasks can only be set if scope is scheduler")
+		}
+	case db.ApiTokenScopeScheduler:
+		if len(opts.Tasks) == 0 {
+			return sberror.NewApiTokenInvalidError("scope scheduler requires at least one task")
+		}
+
+		for _, pattern := range opts.Tasks {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return sberror.NewApiTokenInvalidError(fmt.Sprintf("invalid task pattern '%s'", pattern))
+			}
+		}
+	default:
+		return sberror.NewApiTokenInvalidError(fmt.Sprintf("unknown scope '%s'", opts.Scope))
+	}
+
+	return nil
+}
+
+func generateApiTokenSecret() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", fmt.Errorf("generate secret of api token: %w", err)
+	}
+
+	return apiTokenPrefix + hex.EncodeToString(b), nil
+}
+
+func hashApiTokenSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
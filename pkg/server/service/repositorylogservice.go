@@ -0,0 +1,150 @@
+package service
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/wndhydrnt/saturn-bot/pkg/clock"
+	"github.com/wndhydrnt/saturn-bot/pkg/log"
+	"github.com/wndhydrnt/saturn-bot/pkg/server/db"
+	sberror "github.com/wndhydrnt/saturn-bot/pkg/server/error"
+	"gorm.io/gorm"
+)
+
+// RepositoryLogService stores the logs of repositories that workers capture during a run.
+type RepositoryLogService struct {
+	clock     clock.Clock
+	db        *gorm.DB
+	maxSize   int
+	retention time.Duration
+}
+
+// NewRepositoryLogService returns a new [RepositoryLogService].
+// It truncates logs longer than maxSize bytes.
+// Logs aren't truncated if maxSize is 0.
+// It deletes logs older than retention.
+// Logs are kept forever if retention is 0.
+func NewRepositoryLogService(clock clock.Clock, db *gorm.DB, maxSize int, retention time.Duration) *RepositoryLogService {
+	return &RepositoryLogService{
+		clock:     clock,
+		db:        db,
+		maxSize:   maxSize,
+		retention: retention,
+	}
+}
+
+// SaveLog compresses and stores the log of the repository identified by repositoryName.
+// It replaces a log that already exists for the same run and repository.
+func (s *RepositoryLogService) SaveLog(runID uint, repositoryName, content string, tx *gorm.DB) error {
+	if tx == nil {
+		tx = s.db
+	}
+
+	if s.maxSize > 0 {
+		// Workers truncate logs too, but the server can't rely on the configuration of every worker.
+		content = log.Truncate(content, s.maxSize)
+	}
+
+	compressed, err := compressLog(content)
+	if err != nil {
+		return fmt.Errorf("compress log of repository %s: %w", repositoryName, err)
+	}
+
+	err = tx.
+		Where("run_id = ?", runID).
+		Where("repository_name = ?", repositoryName).
+		Delete(&db.RepositoryLog{}).Error
+	if err != nil {
+		return fmt.Errorf("delete previous log of repository %s: %w", repositoryName, err)
+	}
+
+	repositoryLog := db.RepositoryLog{
+		Content:        compressed,
+		CreatedAt:      s.clock.Now(),
+		RepositoryName: repositoryName,
+		RunID:          runID,
+		Size:           len(content),
+	}
+	if err := tx.Save(&repositoryLog).Error; err != nil {
+		return fmt.Errorf("save log of repository %s: %w", repositoryName, err)
+	}
+
+	return nil
+}
+
+// GetLog returns the uncompressed log of the repository identified by repositoryName
+// captured during the run identified by runID.
+//
+// It returns an error if no log exists.
+func (s *RepositoryLogService) GetLog(runID int, repositoryName string) (db.RepositoryLog, string, error) {
+	var repositoryLog db.RepositoryLog
+	result := s.db.
+		Where("run_id = ?", runID).
+		Where("repository_name = ?", repositoryName).
+		First(&repositoryLog)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return repositoryLog, "", sberror.NewRepositoryLogNotFoundError()
+		}
+
+		return repositoryLog, "", fmt.Errorf("get log of repository: %w", result.Error)
+	}
+
+	content, err := decompressLog(repositoryLog.Content)
+	if err != nil {
+		return repositoryLog, "", fmt.Errorf("decompress log of repository %s: %w", repositoryName, err)
+	}
+
+	return repositoryLog, content, nil
+}
+
+// DeleteExpiredLogs deletes all logs that are older than the retention.
+func (s *RepositoryLogService) DeleteExpiredLogs(tx *gorm.DB) error {
+	if s.retention <= 0 {
+		return nil
+	}
+
+	if tx == nil {
+		tx = s.db
+	}
+
+	expiredBefore := s.clock.Now().Add(-s.retention)
+	if err := tx.Where("created_at < ?", expiredBefore).Delete(&db.RepositoryLog{}).Error; err != nil {
+		return fmt.Errorf("delete expired logs of repositories: %w", err)
+	}
+
+	return nil
+}
+
+func compressLog(content string) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	w := gzip.NewWriter(buf)
+	if _, err := io.WriteString(w, content); err != nil {
+		return nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func decompressLog(compressed []byte) (string, error) {
+	r, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return "", err
+	}
+
+	defer r.Close()
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
@@ -0,0 +1,287 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/wndhydrnt/saturn-bot/pkg/clock"
+	"github.com/wndhydrnt/saturn-bot/pkg/log"
+	"github.com/wndhydrnt/saturn-bot/pkg/ptr"
+	"github.com/wndhydrnt/saturn-bot/pkg/server/db"
+	sberror "github.com/wndhydrnt/saturn-bot/pkg/server/error"
+	"github.com/wndhydrnt/saturn-bot/pkg/task"
+	"gorm.io/gorm"
+)
+
+// RolloutService tracks the progress of the rollouts of tasks.
+type RolloutService struct {
+	clock clock.Clock
+	db    *gorm.DB
+}
+
+func NewRolloutService(clock clock.Clock, db *gorm.DB) *RolloutService {
+	return &RolloutService{
+		clock: clock,
+		db:    db,
+	}
+}
+
+// GetRollout returns the progress of the rollout of t.
+// It returns the first wave if the rollout hasn't started yet.
+// It doesn't write to the database. [RolloutService.Start] starts the rollout.
+//
+// It returns an error if t doesn't define a rollout.
+func (rs *RolloutService) GetRollout(t *task.Task, tx *gorm.DB) (db.Rollout, error) {
+	rollout, found, err := rs.readRollout(t, tx)
+	if err != nil {
+		return rollout, err
+	}
+
+	if !found {
+		return db.Rollout{TaskName: t.Name}, nil
+	}
+
+	return rollout, nil
+}
+
+// Start starts the first wave of the rollout of t if the rollout hasn't started yet.
+// It returns the progress of the rollout.
+//
+// It returns an error if t doesn't define a rollout.
+func (rs *RolloutService) Start(t *task.Task, tx *gorm.DB) (db.Rollout, error) {
+	rollout, found, err := rs.readRollout(t, tx)
+	if err != nil {
+		return rollout, err
+	}
+
+	if !found {
+		return rs.startWave(db.Rollout{TaskName: t.Name}, 0, t, tx)
+	}
+
+	return rollout, nil
+}
+
+// readRollout reads the rollout of t from the database.
+// It returns false if the rollout hasn't started yet.
+func (rs *RolloutService) readRollout(t *task.Task, tx *gorm.DB) (db.Rollout, bool, error) {
+	var rollout db.Rollout
+	if t.Rollout == nil {
+		return rollout, false, sberror.NewRolloutNotDefinedError()
+	}
+
+	if tx == nil {
+		tx = rs.db
+	}
+
+	result := tx.Where("task_name = ?", t.Name).First(&rollout)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return rollout, false, nil
+	}
+
+	if result.Error != nil {
+		return rollout, false, fmt.Errorf("read rollout of task %s: %w", t.Name, result.Error)
+	}
+
+	// The task might define fewer waves after it has been changed.
+	if last := len(t.Rollout.Waves) - 1; rollout.Wave > last {
+		rollout.Wave = last
+	}
+
+	return rollout, true, nil
+}
+
+// Promote starts the next wave of the rollout of t without checking the gate of the current wave.
+//
+// It returns an error if the rollout has reached its last wave.
+func (rs *RolloutService) Promote(t *task.Task, tx *gorm.DB) (db.Rollout, error) {
+	rollout, err := rs.Start(t, tx)
+	if err != nil {
+		return rollout, err
+	}
+
+	if rollout.Wave >= len(t.Rollout.Waves)-1 {
+		return rollout, sberror.NewRolloutCannotPromoteError()
+	}
+
+	return rs.startWave(rollout, rollout.Wave+1, t, tx)
+}
+
+// Pause prevents the rollout of t from starting the next wave.
+// reason describes why the rollout has been paused.
+func (rs *RolloutService) Pause(t *task.Task, reason string, tx *gorm.DB) (db.Rollout, error) {
+	rollout, err := rs.Start(t, tx)
+	if err != nil {
+		return rollout, err
+	}
+
+	if tx == nil {
+		tx = rs.db
+	}
+
+	rollout.Paused = true
+	rollout.PausedReason = ptr.To(reason)
+	if err := tx.Save(&rollout).Error; err != nil {
+		return rollout, fmt.Errorf("pause rollout of task %s: %w", t.Name, err)
+	}
+
+	log.Log().Infof("Paused rollout of task %s: %s", t.Name, reason)
+	return rollout, nil
+}
+
+// Resume lets the rollout of t start the next wave once the gate of the current wave has passed.
+func (rs *RolloutService) Resume(t *task.Task, tx *gorm.DB) (db.Rollout, error) {
+	rollout, err := rs.Start(t, tx)
+	if err != nil {
+		return rollout, err
+	}
+
+	if tx == nil {
+		tx = rs.db
+	}
+
+	rollout.Paused = false
+	rollout.PausedReason = nil
+	rollout.ResumedAt = ptr.To(rs.clock.Now())
+	if err := tx.Save(&rollout).Error; err != nil {
+		return rollout, fmt.Errorf("resume rollout of task %s: %w", t.Name, err)
+	}
+
+	log.Log().Infof("Resumed rollout of task %s", t.Name)
+	return rollout, nil
+}
+
+// RolloutEvaluation is the outcome of [RolloutService.Evaluate].
+type RolloutEvaluation struct {
+	// EvaluateAt is the point in time at which the soak time of the current wave ends.
+	// Nil if the gate doesn't wait for the soak time.
+	EvaluateAt *time.Time
+	// Promoted is true if the next wave has started.
+	Promoted bool
+}
+
+// Evaluate checks the gate of the current wave of the rollout of t.
+// It starts the next wave if the gate has passed.
+// It pauses the rollout if a pull request opened again for a repository after it had been merged,
+// which indicates that somebody reverted the changes of the task.
+func (rs *RolloutService) Evaluate(t *task.Task, tx *gorm.DB) (RolloutEvaluation, error) {
+	var eval RolloutEvaluation
+	rollout, err := rs.Start(t, tx)
+	if err != nil {
+		return eval, err
+	}
+
+	if rollout.Paused {
+		return eval, nil
+	}
+
+	if tx == nil {
+		tx = rs.db
+	}
+
+	var results []db.TaskResult
+	err = tx.Select("task_results.*").
+		Joins("INNER JOIN runs ON task_results.run_id = runs.id").
+		Where("runs.task_name = ?", t.Name).
+		Where("task_results.rollout_wave IS NOT NULL").
+		Order("task_results.created_at ASC").
+		Order("task_results.id ASC").
+		Find(&results).Error
+	if err != nil {
+		return eval, fmt.Errorf("read task results of rollout of task %s: %w", t.Name, err)
+	}
+
+	if repositoryName := findReopenedRepository(results, rollout.ResumedAt); repositoryName != "" {
+		_, err := rs.Pause(t, fmt.Sprintf("pull request of repository %s opened again after it had been merged", repositoryName), tx)
+		return eval, err
+	}
+
+	if rollout.Wave >= len(t.Rollout.Waves)-1 {
+		return eval, nil
+	}
+
+	gate := t.Rollout.Waves[rollout.Wave].Gate
+	mergedAt := listMergedAt(results, rollout.Wave)
+	if len(mergedAt) < gate.MinMerged {
+		return eval, nil
+	}
+
+	reachedAt := rollout.WaveStartedAt
+	if gate.MinMerged > 0 {
+		reachedAt = mergedAt[gate.MinMerged-1]
+	}
+
+	soakEnd := reachedAt.Add(t.RolloutSoakTime(rollout.Wave))
+	if soakEnd.After(rs.clock.Now()) {
+		eval.EvaluateAt = ptr.To(soakEnd)
+		return eval, nil
+	}
+
+	if _, err := rs.startWave(rollout, rollout.Wave+1, t, tx); err != nil {
+		return eval, err
+	}
+
+	eval.Promoted = true
+	return eval, nil
+}
+
+func (rs *RolloutService) startWave(rollout db.Rollout, wave int, t *task.Task, tx *gorm.DB) (db.Rollout, error) {
+	if tx == nil {
+		tx = rs.db
+	}
+
+	rollout.Wave = wave
+	rollout.WaveStartedAt = rs.clock.Now()
+	if err := tx.Save(&rollout).Error; err != nil {
+		return rollout, fmt.Errorf("start wave %d of rollout of task %s: %w", wave, t.Name, err)
+	}
+
+	log.Log().Infof("Started wave %s of rollout of task %s", t.Rollout.Waves[wave].Name, t.Name)
+	return rollout, nil
+}
+
+// findReopenedRepository returns the name of the first repository
+// for which a pull request opened again after it had been merged.
+// It ignores pull requests that opened again before resumedAt.
+// results need to be ordered by the time they have been created.
+func findReopenedRepository(results []db.TaskResult, resumedAt *time.Time) string {
+	previous := map[string]db.TaskResultStatus{}
+	for _, result := range results {
+		key := result.RepositoryName + "|" + result.TargetBranch
+		if previous[key] == db.TaskResultStatusMerged && result.Status == db.TaskResultStatusOpen {
+			if resumedAt == nil || result.CreatedAt.After(ptr.From(resumedAt)) {
+				return result.RepositoryName
+			}
+		}
+
+		previous[key] = result.Status
+	}
+
+	return ""
+}
+
+// listMergedAt returns the points in time at which the pull requests of a wave have been merged.
+// It only considers the latest result of each repository.
+func listMergedAt(results []db.TaskResult, wave int) []time.Time {
+	latest := map[string]db.TaskResult{}
+	for _, result := range results {
+		if ptr.From(result.RolloutWave) != wave {
+			continue
+		}
+
+		latest[result.RepositoryName+"|"+result.TargetBranch] = result
+	}
+
+	var mergedAt []time.Time
+	for _, result := range latest {
+		if result.Status == db.TaskResultStatusMerged {
+			mergedAt = append(mergedAt, result.CreatedAt)
+		}
+	}
+
+	slices.SortFunc(mergedAt, func(a, b time.Time) int {
+		return a.Compare(b)
+	})
+	return mergedAt
+}
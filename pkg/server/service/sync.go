@@ -13,7 +13,7 @@ import (
 	"gorm.io/gorm"
 )
 
-// Sync synchronizes tasks on startup.
+// Sync synchronizes tasks on startup and when tasks get reloaded.
 type Sync struct {
 	clock         clock.Clock
 	db            *gorm.DB
@@ -92,7 +92,12 @@ func (s *Sync) createTask(t *task.Task) error {
 
 		cronTime := calcNextCronTime(s.clock.Now(), t)
 		if cronTime != nil {
-			_, err := s.workerService.ScheduleRun(db.RunReasonCron, nil, ptr.From(cronTime), t.Name, map[string]string{}, tx)
+			_, err := s.workerService.ScheduleRun(ScheduleRunOptions{
+				Reason:        db.RunReasonCron,
+				RunData:       map[string]string{},
+				ScheduleAfter: ptr.From(cronTime),
+				TaskName:      t.Name,
+			}, tx)
 			if handleScheduleRunError(err) != nil {
 				return fmt.Errorf("schedule run for new task '%s' in db: %w", t.Name, err)
 			}
@@ -125,12 +130,29 @@ func (s *Sync) updateTask(t *task.Task, taskDB db.Task) error {
 	return s.db.Transaction(func(tx *gorm.DB) error {
 		cronTime := calcNextCronTime(s.clock.Now(), t)
 		if t.Active && cronTime != nil {
-			_, err := s.workerService.ScheduleRun(db.RunReasonCron, nil, ptr.From(cronTime), t.Name, map[string]string{}, tx)
+			_, err := s.workerService.ScheduleRun(ScheduleRunOptions{
+				Reason:        db.RunReasonCron,
+				RunData:       map[string]string{},
+				ScheduleAfter: ptr.From(cronTime),
+				TaskName:      t.Name,
+			}, tx)
 			if handleScheduleRunError(err) != nil {
 				return fmt.Errorf("schedule run for updated task '%s' in db: %w", t.Name, err)
 			}
 		}
 
+		if cronTime == nil {
+			// The task doesn't define a cron trigger (anymore).
+			err := tx.
+				Where("task_name = ?", t.Name).
+				Where("status = ?", db.RunStatusPending).
+				Where("reason = ?", db.RunReasonCron).
+				Delete(&db.Run{}).Error
+			if err != nil {
+				return fmt.Errorf("delete pending cron runs of updated task '%s': %w", t.Name, err)
+			}
+		}
+
 		taskDB.Active = true
 		taskDB.Hash = t.Checksum()
 		if err := tx.Save(&taskDB).Error; err != nil {
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/wndhydrnt/saturn-bot/pkg/git"
+	"github.com/wndhydrnt/saturn-bot/pkg/log"
+	sberror "github.com/wndhydrnt/saturn-bot/pkg/server/error"
+	"github.com/wndhydrnt/saturn-bot/pkg/task"
+	"go.uber.org/zap"
+)
+
+// TaskReloadService reads tasks from their files while the server is running.
+type TaskReloadService struct {
+	done   chan struct{}
+	mu     sync.Mutex
+	notify chan struct{}
+	// revision is the commit SHA of the git repository of tasks that the server currently knows.
+	// Empty if the server reads tasks from local files.
+	revision       string
+	stop           chan struct{}
+	stopOnce       sync.Once
+	syncService    *Sync
+	taskPaths      []string
+	taskRegistry   *task.Registry
+	taskRepository *git.TaskRepository
+	webhookService *WebhookService
+}
+
+// NewTaskReloadService returns a new [TaskReloadService].
+// taskPaths are the paths or glob patterns of task files to read on reload.
+// taskRepository is the git repository to read tasks from instead of taskPaths.
+// It is nil if the server reads tasks from local files.
+// revision is the commit SHA of taskRepository from which taskRegistry has read its tasks.
+func NewTaskReloadService(syncService *Sync, taskPaths []string, taskRegistry *task.Registry, taskRepository *git.TaskRepository, revision string, webhookService *WebhookService) *TaskReloadService {
+	return &TaskReloadService{
+		done:           make(chan struct{}),
+		notify:         make(chan struct{}, 1),
+		revision:       revision,
+		stop:           make(chan struct{}),
+		syncService:    syncService,
+		taskPaths:      taskPaths,
+		taskRegistry:   taskRegistry,
+		taskRepository: taskRepository,
+		webhookService: webhookService,
+	}
+}
+
+// Reload reads all tasks from their files and replaces the tasks known to the server.
+// It fetches the latest commit first if the server reads tasks from a git repository.
+// It synchronizes the tasks in the database, which schedules runs of changed tasks
+// and removes pending runs of deleted tasks, and updates the triggers of webhooks.
+//
+// Runs that a worker currently executes are not affected.
+// The server keeps the current tasks if reading a task file fails.
+func (s *TaskReloadService) Reload() ([]*task.Task, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.reload(false)
+}
+
+// Refresh reloads all tasks if the git repository of tasks contains a new commit.
+// It does nothing if the server reads tasks from local files.
+func (s *TaskReloadService) Refresh() error {
+	if s.taskRepository == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := s.reload(true)
+	return err
+}
+
+// RequestRefresh makes the service call [TaskReloadService.Refresh] in the background.
+// It doesn't wait for the refresh to finish.
+func (s *TaskReloadService) RequestRefresh() {
+	select {
+	case s.notify <- struct{}{}:
+	default:
+		// A refresh is already pending.
+	}
+}
+
+// Start checks the git repository of tasks for new commits in the background.
+// It checks every pollInterval and whenever [TaskReloadService.RequestRefresh] is called.
+// It doesn't poll if pollInterval is 0.
+func (s *TaskReloadService) Start(pollInterval time.Duration) {
+	go func() {
+		defer close(s.done)
+		var tick <-chan time.Time
+		if pollInterval > 0 && s.taskRepository != nil {
+			ticker := time.NewTicker(pollInterval)
+			defer ticker.Stop()
+			tick = ticker.C
+		}
+
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-s.notify:
+			case <-tick:
+			}
+
+			if err := s.Refresh(); err != nil {
+				log.Log().Errorw("Failed to refresh tasks from git repository", zap.Error(err))
+			}
+		}
+	}()
+}
+
+// Stop stops checking the git repository of tasks for new commits.
+// It waits until a refresh that is currently running has finished.
+func (s *TaskReloadService) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+	<-s.done
+}
+
+func (s *TaskReloadService) reload(onlyIfChanged bool) ([]*task.Task, error) {
+	taskPaths := s.taskPaths
+	revision := ""
+	if s.taskRepository != nil {
+		var err error
+		revision, err = s.taskRepository.Fetch()
+		if err != nil {
+			return nil, fmt.Errorf("fetch git repository of tasks: %w", err)
+		}
+
+		if onlyIfChanged && revision == s.revision {
+			log.Log().Debugf("Git repository of tasks unchanged at revision %s", revision)
+			return nil, nil
+		}
+
+		taskPaths, err = s.taskRepository.Checkout(revision)
+		if err != nil {
+			return nil, fmt.Errorf("check out git repository of tasks: %w", err)
+		}
+
+		log.Log().Infof("Reloading tasks from revision %s", revision)
+	} else {
+		log.Log().Info("Reloading tasks")
+	}
+
+	if err := s.taskRegistry.Reload(taskPaths, revision); err != nil {
+		return nil, sberror.NewTaskReloadError(err)
+	}
+
+	s.revision = revision
+	if err := s.syncService.SyncTasksInDatabase(); err != nil {
+		return nil, fmt.Errorf("sync reloaded tasks in database: %w", err)
+	}
+
+	if err := s.webhookService.ReloadTriggers(); err != nil {
+		return nil, fmt.Errorf("reload triggers of webhooks: %w", err)
+	}
+
+	tasks := s.taskRegistry.GetTasks()
+	log.Log().Infof("Reloaded %d tasks", len(tasks))
+	return tasks, nil
+}
@@ -109,10 +109,11 @@ func (ts *TaskService) ListRecentTaskResultsByTask(opts ListRecentTaskResultsByT
 	// This sub-query returns the latest entry for each repository
 	subQ := ts.db.
 		Table("task_results").
-		Select("MAX(task_results.created_at), task_results.error, task_results.id, task_results.repository_name, task_results.result, task_results.run_id, task_results.status, task_results.pull_request_url").
+		Select("MAX(task_results.created_at), task_results.error, task_results.id, task_results.repository_name, task_results.result, task_results.run_id, task_results.status, task_results.pull_request_url, task_results.target_branch").
 		Joins("INNER JOIN runs ON task_results.run_id = runs.id").
 		Where("runs.task_name = ?", opts.TaskName).
 		Group("task_results.repository_name").
+		Group("task_results.target_branch").
 		Group("runs.run_data").
 		Order("task_results.created_at DESC")
 	// Prepare query. Used by both other queries that return data and count rows.
@@ -1,52 +1,140 @@
 package service
 
 import (
+	"crypto/rand"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/itchyny/gojq"
+	"github.com/ncruces/go-sqlite3"
 	"github.com/wndhydrnt/saturn-bot/pkg/clock"
+	sbcontext "github.com/wndhydrnt/saturn-bot/pkg/context"
+	"github.com/wndhydrnt/saturn-bot/pkg/host"
 	"github.com/wndhydrnt/saturn-bot/pkg/log"
 	"github.com/wndhydrnt/saturn-bot/pkg/ptr"
 	"github.com/wndhydrnt/saturn-bot/pkg/server/db"
+	sberror "github.com/wndhydrnt/saturn-bot/pkg/server/error"
 	"github.com/wndhydrnt/saturn-bot/pkg/task"
 	"github.com/wndhydrnt/saturn-bot/pkg/task/schema"
+	"github.com/wndhydrnt/saturn-bot/pkg/template"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
 )
 
-type webhookType string
-
 const (
-	webhookTypeGithub webhookType = "GitHub"
-	webhookTypeGitlab webhookType = "GitLab"
+	// webhookDeliveryBatchSize is the number of deliveries to read from the database at once.
+	webhookDeliveryBatchSize = 50
+	// webhookDeliveryMaxAttempts is the number of attempts after which processing of a delivery fails.
+	webhookDeliveryMaxAttempts = 5
+	// webhookDeliveryPollInterval is the interval at which the service checks for deliveries to retry.
+	webhookDeliveryPollInterval = 10 * time.Second
+	// webhookDeliveryRetryDelay is the delay before the first retry.
+	// The delay doubles with every further attempt.
+	webhookDeliveryRetryDelay = 30 * time.Second
+)
+
+var (
+	// githubMergedPullRequestQuery extracts the repository and the source branch of a merged pull request
+	// from a webhook of the event "pull_request".
+	githubMergedPullRequestQuery = mustParseJq(`select(.action == "closed" and .pull_request.merged == true) | [.pull_request.base.repo.html_url, .pull_request.head.ref]`)
+	// gitlabMergedPullRequestQuery extracts the repository and the source branch of a merged merge request
+	// from a webhook of the event "Merge Request Hook".
+	gitlabMergedPullRequestQuery = mustParseJq(`select(.object_attributes.action == "merge") | [.project.web_url, .object_attributes.source_branch]`)
+	// githubCommentQuery extracts the URL of the pull request and the body of a new comment
+	// from a webhook of the event "issue_comment".
+	// It skips comments of users who aren't associated with the repository.
+	// The association doesn't tell if a user has write access. See githubCommentAuthorQuery.
+	githubCommentQuery = mustParseJq(`select(.action == "created" and .issue.pull_request != null and (.comment.author_association | IN("OWNER", "MEMBER", "COLLABORATOR"))) | [.issue.pull_request.html_url, .comment.body]`)
+	// githubCommentAuthorQuery extracts the owner and the name of the repository and the login of the author of a comment
+	// from a webhook of the event "issue_comment".
+	githubCommentAuthorQuery = mustParseJq(`[.repository.owner.login, .repository.name, .comment.user.login]`)
+	// gitlabCommentQuery extracts the URL of the merge request and the body of a new comment
+	// from a webhook of the event "Note Hook".
+	// It doesn't consider the permissions of the author. See gitlabCommentAuthorQuery.
+	gitlabCommentQuery = mustParseJq(`select(.object_attributes.noteable_type == "MergeRequest" and (.object_attributes.action // "create") == "create") | [.merge_request.url, .object_attributes.note]`)
+	// gitlabCommentAuthorQuery extracts the ID of the project and the ID of the author of a comment
+	// from a webhook of the event "Note Hook".
+	gitlabCommentAuthorQuery = mustParseJq(`[.project_id, .object_attributes.author_id]`)
+	// pullRequestCommands are the commands that users can send via comments on pull requests.
+	pullRequestCommands = []string{sbcontext.CommandIgnore, sbcontext.CommandMerge, sbcontext.CommandRebase, sbcontext.CommandRecreate}
 )
 
+// pullRequestCommandPrefix starts a line of a comment that contains a command.
+const pullRequestCommandPrefix = "/saturn-bot"
+
 type cacheEntry struct {
-	delay   time.Duration
-	event   string
-	filters []*gojq.Code
+	// anyEvent is true if the trigger matches webhooks of all events.
+	anyEvent bool
+	delay    time.Duration
+	event    string
+	filters  []*gojq.Code
+	// repositoriesExtractor is the jq expression that extracts the names of repositories from the payload of a webhook.
+	// Nil if the run processes all repositories.
+	repositoriesExtractor *gojq.Code
 	// Key is the key to set in the run data map.
 	// Value is the jq expression that extracts data from the payload of a webhook.
 	runDataExtractors map[string]*gojq.Code
+	// source is the name of the source of a generic webhook.
+	source string
 }
 
 // WebhookService handles scheduling new runs when a webhook is received.
+// It stores every webhook in the database first and processes it asynchronously.
 type WebhookService struct {
-	clock              clock.Clock
+	clock clock.Clock
+	db    *gorm.DB
+	done  chan struct{}
+	// cacheMu guards the trigger caches.
+	cacheMu             sync.RWMutex
+	genericTriggerCache map[string][]cacheEntry
+	// githubPermissions checks if the author of a comment on a pull request is allowed to send commands.
+	// Nil if no GitHub host is configured.
+	githubPermissions  host.GitHubPermissionChecker
 	githubTriggerCache map[string][]cacheEntry
+	// gitlabMembers checks if the author of a comment on a merge request is allowed to send commands.
+	// Nil if no GitLab host is configured.
+	gitlabMembers      host.GitLabMemberChecker
 	gitlabTriggerCache map[string][]cacheEntry
-	taskRegistry       *task.Registry
-	workerService      *WorkerService
+	notify             chan struct{}
+	// retention is the duration for which the service keeps processed deliveries.
+	retention     time.Duration
+	stop          chan struct{}
+	stopOnce      sync.Once
+	taskRegistry  *task.Registry
+	workerService *WorkerService
 }
 
 // NewWebhookService returns a new instance of [WebhookService].
 // It parses the triggers defined by tasks and caches them.
-func NewWebhookService(clock clock.Clock, taskRegistry *task.Registry, workerService *WorkerService) (*WebhookService, error) {
+// It deletes processed deliveries older than retention.
+// Deliveries are kept forever if retention is 0.
+// It uses the GitLab host in hosts, if any, to check the permissions of users who send commands via comments.
+func NewWebhookService(clock clock.Clock, db *gorm.DB, hosts []host.Host, retention time.Duration, taskRegistry *task.Registry, workerService *WorkerService) (*WebhookService, error) {
 	s := &WebhookService{
 		clock:         clock,
+		db:            db,
+		done:          make(chan struct{}),
+		notify:        make(chan struct{}, 1),
+		retention:     retention,
+		stop:          make(chan struct{}),
 		taskRegistry:  taskRegistry,
 		workerService: workerService,
 	}
+	for _, h := range hosts {
+		if checker, ok := h.(host.GitHubPermissionChecker); ok && s.githubPermissions == nil {
+			s.githubPermissions = checker
+		}
+
+		if checker, ok := h.(host.GitLabMemberChecker); ok && s.gitlabMembers == nil {
+			s.gitlabMembers = checker
+		}
+	}
+
 	err := s.populateCaches()
 	if err != nil {
 		return nil, fmt.Errorf("populate filter caches: %w", err)
@@ -55,112 +143,620 @@ func NewWebhookService(clock clock.Clock, taskRegistry *task.Registry, workerSer
 	return s, nil
 }
 
+// WebhookEnqueueInput is a webhook received by the server.
 type WebhookEnqueueInput struct {
-	Event   string
-	ID      string
-	Payload any
+	Event string
+	// ID is the ID of the delivery assigned by the sender.
+	// The service generates an ID if empty.
+	ID string
+	// Payload is the JSON-encoded body of the webhook.
+	Payload []byte
 }
 
-// EnqueueGithub enqueues new runs when a webhook from GitHub is received by the server.
-// It visits every task that configures triggers for GitHub webhooks.
+// ListWebhookDeliveriesOptions defines filters for [WebhookService.ListDeliveries].
+type ListWebhookDeliveriesOptions struct {
+	Status []db.WebhookDeliveryStatus
+}
+
+// EnqueueGeneric stores a generic webhook received by the server.
+// Processing visits every task that configures triggers for generic webhooks of source.
+func (s *WebhookService) EnqueueGeneric(source string, in *WebhookEnqueueInput) error {
+	return s.storeDelivery(db.WebhookDeliveryTypeGeneric, source, in)
+}
+
+// EnqueueGithub stores a webhook from GitHub received by the server.
+// Processing visits every task that configures triggers for GitHub webhooks.
 func (s *WebhookService) EnqueueGithub(in *WebhookEnqueueInput) error {
-	return s.enqueue(in, s.githubTriggerCache, webhookTypeGithub)
+	return s.storeDelivery(db.WebhookDeliveryTypeGithub, "", in)
 }
 
-// EnqueueGitlab enqueues new runs when a webhook from GitLab is received by the server.
-// It visits every task that configures triggers for GitLab webhooks.
+// EnqueueGitlab stores a webhook from GitLab received by the server.
+// Processing visits every task that configures triggers for GitLab webhooks.
 func (s *WebhookService) EnqueueGitlab(in *WebhookEnqueueInput) error {
-	return s.enqueue(in, s.gitlabTriggerCache, webhookTypeGitlab)
+	return s.storeDelivery(db.WebhookDeliveryTypeGitlab, "", in)
+}
+
+// ListDeliveries returns stored deliveries, newest first.
+func (s *WebhookService) ListDeliveries(opts ListWebhookDeliveriesOptions, listOpts *ListOptions) ([]db.WebhookDelivery, error) {
+	query := s.db.Model(&db.WebhookDelivery{})
+	if len(opts.Status) > 0 {
+		query = query.Where("status IN ?", opts.Status)
+	}
+
+	var count int64
+	if err := query.Count(&count).Error; err != nil {
+		return nil, fmt.Errorf("count webhook deliveries: %w", err)
+	}
+
+	var deliveries []db.WebhookDelivery
+	result := query.
+		Offset(listOpts.Offset()).
+		Limit(listOpts.Limit).
+		Order("id DESC").
+		Find(&deliveries)
+	if result.Error != nil {
+		return nil, fmt.Errorf("list webhook deliveries: %w", result.Error)
+	}
+
+	listOpts.SetTotalItems(int(count))
+	return deliveries, nil
 }
 
-func (s *WebhookService) enqueue(in *WebhookEnqueueInput, triggerCache map[string][]cacheEntry, wtype webhookType) error {
+// Start processes stored deliveries in the background until [WebhookService.Stop] is called.
+func (s *WebhookService) Start() {
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(webhookDeliveryPollInterval)
+		defer ticker.Stop()
+		for {
+			s.processDeliveries()
+			if err := s.DeleteExpiredDeliveries(); err != nil {
+				log.Log().Errorw("Failed to delete expired webhook deliveries", zap.Error(err))
+			}
+
+			select {
+			case <-s.stop:
+				return
+			case <-s.notify:
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop stops processing of deliveries.
+// It waits until the delivery that is currently being processed has been processed.
+func (s *WebhookService) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+	<-s.done
+}
+
+// storeDelivery persists a delivery and wakes up processing.
+// It ignores a delivery if a delivery with the same ID has been stored before.
+func (s *WebhookService) storeDelivery(wtype db.WebhookDeliveryType, source string, in *WebhookEnqueueInput) error {
+	deliveryID := in.ID
+	if deliveryID == "" {
+		deliveryID = rand.Text()
+	}
+
+	now := s.clock.Now()
+	delivery := db.WebhookDelivery{
+		CreatedAt:     now,
+		DeliveryID:    deliveryID,
+		Event:         in.Event,
+		NextAttemptAt: now,
+		Payload:       in.Payload,
+		Source:        source,
+		Status:        db.WebhookDeliveryStatusPending,
+		Type:          wtype,
+	}
+	if err := s.db.Save(&delivery).Error; err != nil {
+		// Relies on the unique index to detect duplicates
+		// because the sender can deliver the same webhook concurrently.
+		if errors.Is(err, sqlite3.CONSTRAINT_UNIQUE) {
+			log.Log().Debugf("Ignoring duplicate %s webhook %s", wtype, deliveryID)
+			return nil
+		}
+
+		return fmt.Errorf("save webhook delivery: %w", err)
+	}
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+		// Processing has already been notified.
+	}
+
+	return nil
+}
+
+// DeleteExpiredDeliveries deletes all processed or failed deliveries that are older than the retention.
+// It keeps pending deliveries.
+func (s *WebhookService) DeleteExpiredDeliveries() error {
+	if s.retention <= 0 {
+		return nil
+	}
+
+	expiredBefore := s.clock.Now().Add(-s.retention)
+	err := s.db.
+		Where("status <> ?", db.WebhookDeliveryStatusPending).
+		Where("created_at < ?", expiredBefore).
+		Delete(&db.WebhookDelivery{}).Error
+	if err != nil {
+		return fmt.Errorf("delete expired webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+// processDeliveries processes all pending deliveries that are due.
+func (s *WebhookService) processDeliveries() {
+	for {
+		var deliveries []db.WebhookDelivery
+		result := s.db.
+			Where("status = ?", db.WebhookDeliveryStatusPending).
+			Order("next_attempt_at ASC").
+			Order("id ASC").
+			Limit(webhookDeliveryBatchSize).
+			Find(&deliveries)
+		if result.Error != nil {
+			log.Log().Errorw("Failed to read pending webhook deliveries", zap.Error(result.Error))
+			return
+		}
+
+		if len(deliveries) == 0 {
+			return
+		}
+
+		now := s.clock.Now()
+		for _, delivery := range deliveries {
+			if delivery.NextAttemptAt.After(now) {
+				// Deliveries are sorted by their next attempt.
+				// None of the remaining deliveries is due.
+				return
+			}
+
+			s.processDelivery(delivery)
+		}
+
+		if len(deliveries) < webhookDeliveryBatchSize {
+			return
+		}
+	}
+}
+
+// processDelivery schedules runs of all tasks that match the delivery.
+// It schedules a retry with exponential backoff if processing fails.
+// It doesn't retry if processing failed only because of client errors, like invalid inputs of a task,
+// because a retry would fail again.
+func (s *WebhookService) processDelivery(delivery db.WebhookDelivery) {
+	attempts := delivery.Attempts + 1
+	matchedTasks, err := s.enqueue(delivery)
+	delivery.Attempts = attempts
+	delivery.MatchedTasks = matchedTasks
+	if err == nil {
+		delivery.Error = nil
+		delivery.ProcessedAt = ptr.To(s.clock.Now())
+		delivery.Status = db.WebhookDeliveryStatusProcessed
+		if err := s.db.Save(&delivery).Error; err != nil {
+			log.Log().Errorw("Failed to update webhook delivery", "deliveryId", delivery.DeliveryID, zap.Error(err))
+		}
+
+		return
+	}
+
+	delivery.Error = ptr.To(err.Error())
+	if !isRetryableError(err) {
+		log.Log().Errorw("Failed to process webhook - not retrying", "deliveryId", delivery.DeliveryID, "type", delivery.Type, zap.Error(err))
+		delivery.Status = db.WebhookDeliveryStatusFailed
+	} else if attempts >= webhookDeliveryMaxAttempts {
+		log.Log().Errorw("Failed to process webhook - giving up", "deliveryId", delivery.DeliveryID, "type", delivery.Type, zap.Error(err))
+		delivery.Status = db.WebhookDeliveryStatusFailed
+	} else {
+		log.Log().Warnw("Failed to process webhook - retrying", "deliveryId", delivery.DeliveryID, "type", delivery.Type, zap.Error(err))
+		delivery.NextAttemptAt = s.clock.Now().Add(webhookDeliveryRetryDelay << (attempts - 1))
+	}
+
+	if err := s.db.Save(&delivery).Error; err != nil {
+		log.Log().Errorw("Failed to update webhook delivery", "deliveryId", delivery.DeliveryID, zap.Error(err))
+	}
+}
+
+// triggerCacheFor returns the triggers that apply to delivery.
+func (s *WebhookService) triggerCacheFor(delivery db.WebhookDelivery) map[string][]cacheEntry {
+	s.cacheMu.RLock()
+	defer s.cacheMu.RUnlock()
+	switch delivery.Type {
+	case db.WebhookDeliveryTypeGeneric:
+		triggerCache := map[string][]cacheEntry{}
+		for taskName, triggers := range s.genericTriggerCache {
+			for _, trigger := range triggers {
+				if trigger.source == delivery.Source {
+					triggerCache[taskName] = append(triggerCache[taskName], trigger)
+				}
+			}
+		}
+
+		return triggerCache
+	case db.WebhookDeliveryTypeGithub:
+		return s.githubTriggerCache
+	case db.WebhookDeliveryTypeGitlab:
+		return s.gitlabTriggerCache
+	default:
+		return nil
+	}
+}
+
+// enqueue schedules a run of every task that matches delivery.
+// It schedules the run of each task in a separate transaction
+// to not roll back the runs of other tasks if scheduling of one run fails.
+// It returns the names of the tasks for which it has scheduled a run,
+// including the tasks scheduled by previous attempts.
+func (s *WebhookService) enqueue(delivery db.WebhookDelivery) ([]string, error) {
+	var payload any
+	if err := json.Unmarshal(delivery.Payload, &payload); err != nil {
+		return nil, sberror.NewWebhookPayloadInvalidError(err)
+	}
+
 	var errs []error
-	for taskName, triggers := range triggerCache {
+	matchedTasks := slices.Clone(delivery.MatchedTasks)
+	for taskName, triggers := range s.triggerCacheFor(delivery) {
+		if slices.Contains(delivery.MatchedTasks, taskName) {
+			// A previous attempt has already scheduled the run.
+			continue
+		}
+
 		for _, trigger := range triggers {
-			if match(in.Event, trigger, in.Payload) {
-				log.Log().Debugf("Task %s matches %s webhook %s", taskName, wtype, in.ID)
-				runData := extractRunData(in.Payload, trigger.runDataExtractors)
+			if match(delivery.Event, trigger, payload) {
+				var repositoryNames []string
+				if trigger.repositoriesExtractor != nil {
+					repositoryNames = extractRepositoryNames(payload, trigger.repositoriesExtractor)
+					if len(repositoryNames) == 0 {
+						log.Log().Debugf("Task %s matches %s webhook %s but the webhook contains no repository", taskName, delivery.Type, delivery.DeliveryID)
+						continue
+					}
+				}
+
+				log.Log().Debugf("Task %s matches %s webhook %s", taskName, delivery.Type, delivery.DeliveryID)
+				runData := extractRunData(payload, trigger.runDataExtractors)
 				scheduleAfter := s.clock.Now().Add(trigger.delay)
-				_, err := s.workerService.ScheduleRun(db.RunReasonWebhook, nil, scheduleAfter, taskName, runData, nil)
-				errs = append(errs, err)
+				_, err := s.workerService.ScheduleRun(ScheduleRunOptions{
+					Reason:          db.RunReasonWebhook,
+					RepositoryNames: repositoryNames,
+					RunData:         runData,
+					ScheduleAfter:   scheduleAfter,
+					TaskName:        taskName,
+				}, nil)
+				if err != nil {
+					errs = append(errs, fmt.Errorf("schedule run of task %s: %w", taskName, err))
+				} else {
+					matchedTasks = append(matchedTasks, taskName)
+				}
+
 				break
 			}
 		}
 	}
 
-	return errors.Join(errs...)
+	dependents, err := s.enqueueDependents(delivery, payload)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	matchedTasks = append(matchedTasks, dependents...)
+	commandTask, err := s.enqueueCommand(delivery, payload)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if commandTask != "" {
+		matchedTasks = append(matchedTasks, commandTask)
+	}
+
+	slices.Sort(matchedTasks)
+	return slices.Compact(matchedTasks), errors.Join(errs...)
+}
+
+// enqueueDependents schedules runs of all tasks that wait for the pull request that delivery reports as merged.
+// It returns the names of the tasks for which it has scheduled a run.
+// The runs of the dependents of each task are scheduled in a separate transaction.
+func (s *WebhookService) enqueueDependents(delivery db.WebhookDelivery, payload any) ([]string, error) {
+	repositoryName, branchName, ok := extractMergedPullRequest(delivery, payload)
+	if !ok {
+		return nil, nil
+	}
+
+	tasks := s.taskRegistry.GetTasks()
+	var errs []error
+	var scheduled []string
+	for _, t := range tasks {
+		if !task.HasDependents(tasks, t) {
+			continue
+		}
+
+		taskBranchName, err := t.RenderBranchNameForRepository(repositoryName, template.Data{})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("render branch name of task %s: %w", t.Name, err))
+			continue
+		}
+
+		if taskBranchName != branchName {
+			continue
+		}
+
+		log.Log().Debugf("Pull request of task %s in repository %s merged according to %s webhook %s", t.Name, repositoryName, delivery.Type, delivery.DeliveryID)
+		var taskNames []string
+		err = s.db.Transaction(func(tx *gorm.DB) error {
+			var err error
+			taskNames, err = s.workerService.ScheduleDependents(t.Name, repositoryName, tx)
+			return err
+		})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		scheduled = append(scheduled, taskNames...)
+	}
+
+	return scheduled, errors.Join(errs...)
+}
+
+// enqueueCommand executes the command that a user has sent via the comment that delivery reports.
+// It returns the name of the task that has created the pull request of the comment.
+func (s *WebhookService) enqueueCommand(delivery db.WebhookDelivery, payload any) (string, error) {
+	pullRequestUrl, comment, ok := extractPullRequestComment(delivery, payload)
+	if !ok {
+		return "", nil
+	}
+
+	command, ok := parsePullRequestCommand(comment)
+	if !ok {
+		return "", nil
+	}
+
+	var allowed bool
+	var err error
+	switch delivery.Type {
+	case db.WebhookDeliveryTypeGithub:
+		allowed, err = s.isGithubCommandAllowed(payload)
+	case db.WebhookDeliveryTypeGitlab:
+		allowed, err = s.isGitlabCommandAllowed(payload)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if !allowed {
+		log.Log().Infof("Ignoring command %s for pull request %s because the author of the comment doesn't have write access to the repository", command, pullRequestUrl)
+		return "", nil
+	}
+
+	log.Log().Debugf("Received command %s for pull request %s via %s webhook %s", command, pullRequestUrl, delivery.Type, delivery.DeliveryID)
+	var taskName string
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		taskName, err = s.workerService.ExecuteCommand(pullRequestUrl, command, tx)
+		return err
+	})
+	return taskName, err
+}
+
+// isGithubCommandAllowed returns true if the author of the comment in payload
+// has at least the permission write in the repository of the pull request.
+func (s *WebhookService) isGithubCommandAllowed(payload any) (bool, error) {
+	if s.githubPermissions == nil {
+		log.Log().Warn("Cannot check permissions of author of command because no GitHub host is configured")
+		return false, nil
+	}
+
+	valueRaw, hasNext := githubCommentAuthorQuery.Run(payload).Next()
+	if !hasNext {
+		return false, nil
+	}
+
+	values, isList := valueRaw.([]any)
+	if !isList || len(values) != 3 {
+		return false, nil
+	}
+
+	owner, _ := values[0].(string)
+	repo, _ := values[1].(string)
+	login, _ := values[2].(string)
+	if owner == "" || repo == "" || login == "" {
+		return false, nil
+	}
+
+	allowed, err := s.githubPermissions.HasWriteAccess(owner, repo, login)
+	if err != nil {
+		return false, fmt.Errorf("check permissions of author of command: %w", err)
+	}
+
+	return allowed, nil
+}
+
+// isGitlabCommandAllowed returns true if the author of the comment in payload
+// has at least the role Developer in the project of the merge request.
+// Payloads of GitLab don't describe the permissions of the author, unlike payloads of GitHub.
+func (s *WebhookService) isGitlabCommandAllowed(payload any) (bool, error) {
+	if s.gitlabMembers == nil {
+		log.Log().Warn("Cannot check permissions of author of command because no GitLab host is configured")
+		return false, nil
+	}
+
+	valueRaw, hasNext := gitlabCommentAuthorQuery.Run(payload).Next()
+	if !hasNext {
+		return false, nil
+	}
+
+	values, isList := valueRaw.([]any)
+	if !isList || len(values) != 2 {
+		return false, nil
+	}
+
+	projectID, _ := values[0].(float64)
+	authorID, _ := values[1].(float64)
+	if projectID == 0 || authorID == 0 {
+		return false, nil
+	}
+
+	allowed, err := s.gitlabMembers.HasDeveloperAccess(int(projectID), int(authorID))
+	if err != nil {
+		return false, fmt.Errorf("check permissions of author of command: %w", err)
+	}
+
+	return allowed, nil
+}
+
+// ReloadTriggers parses the triggers of all tasks in the registry again.
+// The service keeps its current triggers if parsing fails.
+func (s *WebhookService) ReloadTriggers() error {
+	return s.populateCaches()
 }
 
 func (s *WebhookService) populateCaches() error {
-	s.githubTriggerCache = map[string][]cacheEntry{}
-	s.gitlabTriggerCache = map[string][]cacheEntry{}
+	genericTriggerCache := map[string][]cacheEntry{}
+	githubTriggerCache := map[string][]cacheEntry{}
+	gitlabTriggerCache := map[string][]cacheEntry{}
 	for _, t := range s.taskRegistry.GetTasks() {
+		if hasGenericWebhookTrigger(t.Trigger) {
+			cacheEntries, err := parseGenericTriggers(t)
+			if err != nil {
+				return err
+			}
+
+			genericTriggerCache[t.Name] = cacheEntries
+		}
+
 		if hasGithubWebhookTrigger(t.Trigger) {
-			err := s.populateGithubCache(t)
+			cacheEntries, err := parseGithubTriggers(t)
 			if err != nil {
 				return err
 			}
+
+			githubTriggerCache[t.Name] = cacheEntries
 		}
 
 		if hasGitlabWebhookTrigger(t.Trigger) {
-			err := s.populateGitlabCache(t)
+			cacheEntries, err := parseGitlabTriggers(t)
 			if err != nil {
 				return err
 			}
+
+			gitlabTriggerCache[t.Name] = cacheEntries
 		}
 	}
 
+	s.cacheMu.Lock()
+	s.genericTriggerCache = genericTriggerCache
+	s.githubTriggerCache = githubTriggerCache
+	s.gitlabTriggerCache = gitlabTriggerCache
+	s.cacheMu.Unlock()
 	return nil
 }
 
-func (s *WebhookService) populateGithubCache(t *task.Task) error {
-	cacheEntries := make([]cacheEntry, len(t.Trigger.Webhook.Github))
-	for idxHook, hook := range t.Trigger.Webhook.Github {
+func parseGenericTriggers(t *task.Task) ([]cacheEntry, error) {
+	cacheEntries := make([]cacheEntry, len(t.Trigger.Webhook.Generic))
+	for idxHook, hook := range t.Trigger.Webhook.Generic {
 		filters, err := parseHookFilters(hook.Filters)
 		if err != nil {
-			return fmt.Errorf("parse filters of GitHub webhook %d: %w", idxHook, err)
+			return nil, fmt.Errorf("parse filters of generic webhook %d: %w", idxHook, err)
 		}
 
 		extractors, err := parseHookExtractors(hook.RunData)
 		if err != nil {
-			return fmt.Errorf("parse runData extractors of GitHub webhook %d: %w", idxHook, err)
+			return nil, fmt.Errorf("parse runData extractors of generic webhook %d: %w", idxHook, err)
 		}
 
 		cacheEntries[idxHook] = cacheEntry{
+			anyEvent:          hook.Event == nil,
 			delay:             time.Duration(t.Trigger.Webhook.Delay) * time.Second,
-			event:             ptr.From(hook.Event),
+			event:             ptr.FromDef(hook.Event, ""),
 			filters:           filters,
 			runDataExtractors: extractors,
+			source:            hook.Source,
 		}
 	}
 
-	s.githubTriggerCache[t.Name] = cacheEntries
-	return nil
+	return cacheEntries, nil
+}
+
+func parseGithubTriggers(t *task.Task) ([]cacheEntry, error) {
+	cacheEntries := make([]cacheEntry, len(t.Trigger.Webhook.Github))
+	for idxHook, hook := range t.Trigger.Webhook.Github {
+		filters, err := parseHookFilters(hook.Filters)
+		if err != nil {
+			return nil, fmt.Errorf("parse filters of GitHub webhook %d: %w", idxHook, err)
+		}
+
+		extractors, err := parseHookExtractors(hook.RunData)
+		if err != nil {
+			return nil, fmt.Errorf("parse runData extractors of GitHub webhook %d: %w", idxHook, err)
+		}
+
+		repositoriesExtractor, err := parseHookRepositoriesExtractor(hook.Repositories)
+		if err != nil {
+			return nil, fmt.Errorf("parse repositories extractor of GitHub webhook %d: %w", idxHook, err)
+		}
+
+		cacheEntries[idxHook] = cacheEntry{
+			delay:                 time.Duration(t.Trigger.Webhook.Delay) * time.Second,
+			event:                 ptr.From(hook.Event),
+			filters:               filters,
+			repositoriesExtractor: repositoriesExtractor,
+			runDataExtractors:     extractors,
+		}
+	}
+
+	return cacheEntries, nil
 }
 
-func (s *WebhookService) populateGitlabCache(t *task.Task) error {
+func parseGitlabTriggers(t *task.Task) ([]cacheEntry, error) {
 	cacheEntries := make([]cacheEntry, len(t.Trigger.Webhook.Gitlab))
 	for idxHook, hook := range t.Trigger.Webhook.Gitlab {
 		filters, err := parseHookFilters(hook.Filters)
 		if err != nil {
-			return fmt.Errorf("parse filters of GitLab webhook %d: %w", idxHook, err)
+			return nil, fmt.Errorf("parse filters of GitLab webhook %d: %w", idxHook, err)
 		}
 
 		extractors, err := parseHookExtractors(hook.RunData)
 		if err != nil {
-			return fmt.Errorf("parse runData extractors of GitLab webhook %d: %w", idxHook, err)
+			return nil, fmt.Errorf("parse runData extractors of GitLab webhook %d: %w", idxHook, err)
+		}
+
+		repositoriesExtractor, err := parseHookRepositoriesExtractor(hook.Repositories)
+		if err != nil {
+			return nil, fmt.Errorf("parse repositories extractor of GitLab webhook %d: %w", idxHook, err)
 		}
 
 		cacheEntries[idxHook] = cacheEntry{
-			delay:             time.Duration(t.Trigger.Webhook.Delay) * time.Second,
-			event:             ptr.From(hook.Event),
-			filters:           filters,
-			runDataExtractors: extractors,
+			delay:                 time.Duration(t.Trigger.Webhook.Delay) * time.Second,
+			event:                 ptr.From(hook.Event),
+			filters:               filters,
+			repositoriesExtractor: repositoriesExtractor,
+			runDataExtractors:     extractors,
 		}
 	}
 
-	s.gitlabTriggerCache[t.Name] = cacheEntries
-	return nil
+	return cacheEntries, nil
+}
+
+func hasGenericWebhookTrigger(trigger *schema.TaskTrigger) bool {
+	if trigger == nil {
+		return false
+	}
+
+	if trigger.Webhook == nil {
+		return false
+	}
+
+	if len(trigger.Webhook.Generic) == 0 {
+		return false
+	}
+
+	return true
 }
 
 func hasGithubWebhookTrigger(trigger *schema.TaskTrigger) bool {
@@ -196,7 +792,7 @@ func hasGitlabWebhookTrigger(trigger *schema.TaskTrigger) bool {
 }
 
 func match(event string, trigger cacheEntry, payload any) bool {
-	if event != trigger.event {
+	if !trigger.anyEvent && event != trigger.event {
 		return false
 	}
 
@@ -284,3 +880,171 @@ func parseHookExtractors(raw map[string]string) (map[string]*gojq.Code, error) {
 
 	return extractors, nil
 }
+
+func parseHookRepositoriesExtractor(expr *string) (*gojq.Code, error) {
+	if expr == nil {
+		return nil, nil
+	}
+
+	query, err := gojq.Parse(*expr)
+	if err != nil {
+		return nil, fmt.Errorf("parse jq expression: %w", err)
+	}
+
+	compiledQuery, err := gojq.Compile(query)
+	if err != nil {
+		return nil, fmt.Errorf("compile jq expression: %w", err)
+	}
+
+	return compiledQuery, nil
+}
+
+// extractRepositoryNames collects the names of repositories returned by code.
+// code can return strings or lists of strings.
+// The result is sorted and free of duplicates to let [WorkerService.ScheduleRun]
+// merge pending runs that process the same repositories.
+func extractRepositoryNames(payload any, code *gojq.Code) []string {
+	var names []string
+	iter := code.Run(payload)
+	for {
+		valueRaw, hasNext := iter.Next()
+		if !hasNext {
+			break
+		}
+
+		switch value := valueRaw.(type) {
+		case string:
+			names = append(names, normalizeRepositoryName(value))
+		case []any:
+			for _, item := range value {
+				if name, ok := item.(string); ok {
+					names = append(names, normalizeRepositoryName(name))
+				}
+			}
+		}
+	}
+
+	names = slices.DeleteFunc(names, func(name string) bool { return name == "" })
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+// extractMergedPullRequest returns the name of the repository and the source branch
+// of the pull request that delivery reports as merged.
+// ok is false if delivery doesn't report a merged pull request.
+func extractMergedPullRequest(delivery db.WebhookDelivery, payload any) (repositoryName, branchName string, ok bool) {
+	var code *gojq.Code
+	switch {
+	case delivery.Type == db.WebhookDeliveryTypeGithub && delivery.Event == "pull_request":
+		code = githubMergedPullRequestQuery
+	case delivery.Type == db.WebhookDeliveryTypeGitlab && delivery.Event == "Merge Request Hook":
+		code = gitlabMergedPullRequestQuery
+	default:
+		return "", "", false
+	}
+
+	valueRaw, hasNext := code.Run(payload).Next()
+	if !hasNext {
+		return "", "", false
+	}
+
+	values, isList := valueRaw.([]any)
+	if !isList || len(values) != 2 {
+		return "", "", false
+	}
+
+	repositoryURL, _ := values[0].(string)
+	branchName, _ = values[1].(string)
+	if repositoryURL == "" || branchName == "" {
+		return "", "", false
+	}
+
+	return normalizeRepositoryName(repositoryURL), branchName, true
+}
+
+// extractPullRequestComment returns the URL of the pull request and the body of the comment
+// that delivery reports as created.
+// ok is false if delivery doesn't report a new comment on a pull request.
+func extractPullRequestComment(delivery db.WebhookDelivery, payload any) (pullRequestUrl, comment string, ok bool) {
+	var code *gojq.Code
+	switch {
+	case delivery.Type == db.WebhookDeliveryTypeGithub && delivery.Event == "issue_comment":
+		code = githubCommentQuery
+	case delivery.Type == db.WebhookDeliveryTypeGitlab && delivery.Event == "Note Hook":
+		code = gitlabCommentQuery
+	default:
+		return "", "", false
+	}
+
+	valueRaw, hasNext := code.Run(payload).Next()
+	if !hasNext {
+		return "", "", false
+	}
+
+	values, isList := valueRaw.([]any)
+	if !isList || len(values) != 2 {
+		return "", "", false
+	}
+
+	pullRequestUrl, _ = values[0].(string)
+	comment, _ = values[1].(string)
+	if pullRequestUrl == "" || comment == "" {
+		return "", "", false
+	}
+
+	return pullRequestUrl, comment, true
+}
+
+// parsePullRequestCommand returns the command in comment.
+// A command is a line of the form "/saturn-bot <command>".
+// ok is false if comment doesn't contain a known command.
+func parsePullRequestCommand(comment string) (command string, ok bool) {
+	for line := range strings.Lines(comment) {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || fields[0] != pullRequestCommandPrefix {
+			continue
+		}
+
+		if slices.Contains(pullRequestCommands, fields[1]) {
+			return fields[1], true
+		}
+	}
+
+	return "", false
+}
+
+// isRetryableError returns false if err consists only of client errors, like invalid inputs of a task.
+// Processing a delivery again doesn't resolve these errors.
+func isRetryableError(err error) bool {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range joined.Unwrap() {
+			if isRetryableError(e) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	var clientErr sberror.Client
+	return !errors.As(err, &clientErr)
+}
+
+// mustParseJq compiles the jq expression expr.
+// It panics if expr is invalid.
+func mustParseJq(expr string) *gojq.Code {
+	code, err := parseHookRepositoriesExtractor(&expr)
+	if err != nil {
+		panic(err)
+	}
+
+	return code
+}
+
+// normalizeRepositoryName turns a URL of a repository, like https://github.com/org/repo.git,
+// into the name of the repository, like github.com/org/repo.
+func normalizeRepositoryName(name string) string {
+	name = strings.TrimPrefix(name, "https://")
+	name = strings.TrimPrefix(name, "http://")
+	return strings.TrimSuffix(name, ".git")
+}
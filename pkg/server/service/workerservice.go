@@ -3,11 +3,13 @@ package service
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"sync/atomic"
 	"time"
 
 	"github.com/adhocore/gronx"
 	"github.com/wndhydrnt/saturn-bot/pkg/clock"
+	sbcontext "github.com/wndhydrnt/saturn-bot/pkg/context"
 	"github.com/wndhydrnt/saturn-bot/pkg/log"
 	"github.com/wndhydrnt/saturn-bot/pkg/processor"
 	"github.com/wndhydrnt/saturn-bot/pkg/ptr"
@@ -33,17 +35,21 @@ func (e ErrorMissingInput) Error() string {
 }
 
 type WorkerService struct {
-	clock       clock.Clock
-	db          *gorm.DB
-	inShutdown  atomic.Bool
-	taskService *TaskService
+	clock                clock.Clock
+	db                   *gorm.DB
+	inShutdown           atomic.Bool
+	repositoryLogService *RepositoryLogService
+	rolloutService       *RolloutService
+	taskService          *TaskService
 }
 
-func NewWorkerService(clock clock.Clock, db *gorm.DB, taskService *TaskService) *WorkerService {
+func NewWorkerService(clock clock.Clock, db *gorm.DB, repositoryLogService *RepositoryLogService, rolloutService *RolloutService, taskService *TaskService) *WorkerService {
 	return &WorkerService{
-		clock:       clock,
-		db:          db,
-		taskService: taskService,
+		clock:                clock,
+		db:                   db,
+		repositoryLogService: repositoryLogService,
+		rolloutService:       rolloutService,
+		taskService:          taskService,
 	}
 }
 
@@ -71,41 +77,54 @@ func (ws *WorkerService) DeletePendingRuns(taskName string, tx *gorm.DB) error {
 	return nil
 }
 
-func (ws *WorkerService) ScheduleRun(
-	reason db.RunReason,
-	repositoryNames []string,
-	scheduleAfter time.Time,
-	taskName string,
-	runData map[string]string,
-	tx *gorm.DB,
-) (uint, error) {
-	t, err := ws.taskService.GetTask(taskName)
+// ScheduleRunOptions defines the run to schedule via [WorkerService.ScheduleRun].
+type ScheduleRunOptions struct {
+	// ApiTokenName is the name of the API token that requested the run, if any.
+	ApiTokenName    *string
+	Reason          db.RunReason
+	RepositoryNames []string
+	RunData         map[string]string
+	ScheduleAfter   time.Time
+	TaskName        string
+}
+
+// ScheduleRun adds a new run to the queue.
+// It updates an existing run if a pending run with the same options already exists.
+func (ws *WorkerService) ScheduleRun(opts ScheduleRunOptions, tx *gorm.DB) (uint, error) {
+	t, err := ws.taskService.GetTask(opts.TaskName)
 	if err != nil {
 		return 0, err
 	}
 
-	errs := task.ValidateInputs(runData, t)
+	errs := task.ValidateInputs(opts.RunData, t)
 	if len(errs) > 0 {
-		return 0, sberror.NewInputError(errs, taskName)
+		return 0, sberror.NewInputError(errs, opts.TaskName)
 	}
 
 	var runDB db.Run
 	if tx == nil {
 		tx = ws.db
 	}
+
+	if t.Rollout != nil {
+		// Workers read the wave of the rollout when they receive the run.
+		if _, err := ws.rolloutService.Start(t, tx); err != nil {
+			return 0, err
+		}
+	}
 	query := tx.
-		Where("task_name = ?", taskName).
+		Where("task_name = ?", opts.TaskName).
 		Where("status = ?", db.RunStatusPending).
-		Where("reason = ?", reason)
+		Where("reason = ?", opts.Reason)
 
-	repositoryNameList := db.StringList(repositoryNames)
+	repositoryNameList := db.StringList(opts.RepositoryNames)
 	if len(repositoryNameList) == 0 {
 		query = query.Where("repository_names is null")
 	} else {
 		query = query.Where("repository_names = ?", repositoryNameList)
 	}
 
-	runDataDb := db.StringMap(runData)
+	runDataDb := db.StringMap(opts.RunData)
 	if len(runDataDb) == 0 {
 		query = query.Where("run_data is null")
 	} else {
@@ -114,18 +133,19 @@ func (ws *WorkerService) ScheduleRun(
 
 	result := query.First(&runDB)
 	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
-		if len(repositoryNames) > 0 {
-			log.Log().Debugf("Scheduling new run of task %s for repositories %v", taskName, repositoryNames)
+		if len(opts.RepositoryNames) > 0 {
+			log.Log().Debugf("Scheduling new run of task %s for repositories %v", opts.TaskName, opts.RepositoryNames)
 		} else {
-			log.Log().Debugf("Scheduling new run of task %s for all repositories", taskName)
+			log.Log().Debugf("Scheduling new run of task %s for all repositories", opts.TaskName)
 		}
 
 		run := db.Run{
-			Reason:          reason,
+			ApiTokenName:    opts.ApiTokenName,
+			Reason:          opts.Reason,
 			RepositoryNames: repositoryNameList,
-			ScheduleAfter:   scheduleAfter,
+			ScheduleAfter:   opts.ScheduleAfter,
 			Status:          db.RunStatusPending,
-			TaskName:        taskName,
+			TaskName:        opts.TaskName,
 			RunData:         runDataDb,
 		}
 
@@ -142,9 +162,9 @@ func (ws *WorkerService) ScheduleRun(
 
 	// Check for equality to prevent runs based on a cron schedule
 	// to be scheduled twice.
-	if !runDB.ScheduleAfter.Equal(scheduleAfter) {
-		runDB.Reason = reason
-		runDB.ScheduleAfter = scheduleAfter
+	if !runDB.ScheduleAfter.Equal(opts.ScheduleAfter) {
+		runDB.Reason = opts.Reason
+		runDB.ScheduleAfter = opts.ScheduleAfter
 		if err := tx.Save(&runDB).Error; err != nil {
 			return 0, fmt.Errorf("update scheduleAfter of run: %w", err)
 		}
@@ -153,6 +173,140 @@ func (ws *WorkerService) ScheduleRun(
 	return runDB.ID, nil
 }
 
+// ScheduleDependents schedules runs of all tasks that wait for the pull request
+// of the task taskName in the repository repositoryName to be merged.
+// It returns the names of the tasks for which it has scheduled a run.
+func (ws *WorkerService) ScheduleDependents(taskName, repositoryName string, tx *gorm.DB) ([]string, error) {
+	var scheduled []string
+	for _, t := range ws.taskService.ListTasks() {
+		for _, dep := range t.Dependencies() {
+			if dep.Task.Name != taskName {
+				continue
+			}
+
+			var repositoryNames []string
+			switch dep.RepositoryName {
+			case "":
+				// The task waits for the pull request in the same repository.
+				repositoryNames = []string{repositoryName}
+			case repositoryName:
+				// The pull request unblocks all repositories of the task.
+				repositoryNames = nil
+			default:
+				continue
+			}
+
+			log.Log().Infof("Scheduling run of task %s because pull request of task %s in repository %s has been merged", t.Name, taskName, repositoryName)
+			_, err := ws.ScheduleRun(ScheduleRunOptions{
+				Reason:          db.RunReasonDependency,
+				RepositoryNames: repositoryNames,
+				ScheduleAfter:   ws.clock.Now(),
+				TaskName:        t.Name,
+			}, tx)
+			if err != nil {
+				return nil, fmt.Errorf("schedule run of dependent task %s: %w", t.Name, err)
+			}
+
+			scheduled = append(scheduled, t.Name)
+			break
+		}
+	}
+
+	return scheduled, nil
+}
+
+// ExecuteCommand executes command that a user has sent via a comment on the pull request identified by pullRequestUrl.
+// It ignores the repository of the pull request if command is [sbcontext.CommandIgnore].
+// It schedules a run of the task for the repository of the pull request for all other commands.
+// It returns the name of the task that has created the pull request.
+// The name is empty if saturn-bot hasn't created the pull request or if the task doesn't exist anymore.
+func (ws *WorkerService) ExecuteCommand(pullRequestUrl, command string, tx *gorm.DB) (string, error) {
+	if tx == nil {
+		tx = ws.db
+	}
+
+	var taskResult db.TaskResult
+	result := tx.Where("pull_request_url = ?", pullRequestUrl).Order("id DESC").First(&taskResult)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		log.Log().Debugf("Ignoring command %s because no task has created pull request %s", command, pullRequestUrl)
+		return "", nil
+	}
+
+	if result.Error != nil {
+		return "", fmt.Errorf("find task result of pull request %s: %w", pullRequestUrl, result.Error)
+	}
+
+	var run db.Run
+	if err := tx.First(&run, taskResult.RunID).Error; err != nil {
+		return "", fmt.Errorf("read run %d of pull request %s: %w", taskResult.RunID, pullRequestUrl, err)
+	}
+
+	if _, err := ws.findTask(run.TaskName); err != nil {
+		log.Log().Debugf("Ignoring command %s because task %s of pull request %s doesn't exist", command, run.TaskName, pullRequestUrl)
+		return "", nil
+	}
+
+	switch command {
+	case sbcontext.CommandIgnore:
+		log.Log().Infof("Ignoring repository %s for task %s as requested via pull request %s", taskResult.RepositoryName, run.TaskName, pullRequestUrl)
+		ignored := db.IgnoredRepository{RepositoryName: taskResult.RepositoryName, TaskName: run.TaskName}
+		err := tx.
+			Where(ignored).
+			Attrs(db.IgnoredRepository{CreatedAt: ws.clock.Now(), PullRequestUrl: pullRequestUrl}).
+			FirstOrCreate(&ignored).Error
+		if err != nil {
+			return "", fmt.Errorf("ignore repository %s for task %s: %w", taskResult.RepositoryName, run.TaskName, err)
+		}
+
+		return run.TaskName, nil
+	case sbcontext.CommandRecreate:
+		// Recreating the pull request lifts the ignore.
+		err := tx.
+			Where("task_name = ?", run.TaskName).
+			Where("repository_name = ?", taskResult.RepositoryName).
+			Delete(&db.IgnoredRepository{}).Error
+		if err != nil {
+			return "", fmt.Errorf("stop ignoring repository %s for task %s: %w", taskResult.RepositoryName, run.TaskName, err)
+		}
+	}
+
+	// Keep the run data of the original run, like inputs, to render the same branch and pull request.
+	runData := maps.Clone(map[string]string(run.RunData))
+	if runData == nil {
+		runData = map[string]string{}
+	}
+
+	runData[sbcontext.RunDataKeyCommand] = command
+	log.Log().Infof("Scheduling run of task %s for repository %s to execute command %s", run.TaskName, taskResult.RepositoryName, command)
+	_, err := ws.ScheduleRun(ScheduleRunOptions{
+		Reason:          db.RunReasonCommand,
+		RepositoryNames: []string{taskResult.RepositoryName},
+		RunData:         runData,
+		ScheduleAfter:   ws.clock.Now(),
+		TaskName:        run.TaskName,
+	}, tx)
+	if err != nil {
+		return "", fmt.Errorf("schedule run of task %s to execute command %s: %w", run.TaskName, command, err)
+	}
+
+	return run.TaskName, nil
+}
+
+// ListIgnoredRepositories returns the names of the repositories to which users have asked
+// to never apply the task identified by taskName again.
+func (ws *WorkerService) ListIgnoredRepositories(taskName string) ([]string, error) {
+	var names []string
+	err := ws.db.Model(&db.IgnoredRepository{}).
+		Where("task_name = ?", taskName).
+		Order("repository_name ASC").
+		Pluck("repository_name", &names).Error
+	if err != nil {
+		return nil, fmt.Errorf("list ignored repositories of task %s: %w", taskName, err)
+	}
+
+	return names, nil
+}
+
 func (ws *WorkerService) findTask(name string) (*task.Task, error) {
 	t, err := ws.taskService.GetTask(name)
 	return t, err
@@ -181,13 +335,6 @@ func (ws *WorkerService) NextRun() (db.Run, *task.Task, error) {
 		return run, nil, ErrNoRun
 	}
 
-	run.StartedAt = ptr.To(ws.clock.Now())
-	run.Status = db.RunStatusRunning
-	if err := ws.db.Save(&run).Error; err != nil {
-		log.Log().Errorw("Update next run", zap.Error(err))
-		return run, nil, err
-	}
-
 	task, _ := ws.findTask(run.TaskName)
 	if task == nil {
 		if err := ws.db.Delete(&run).Error; err != nil {
@@ -197,6 +344,17 @@ func (ws *WorkerService) NextRun() (db.Run, *task.Task, error) {
 		return run, nil, ErrNoRun
 	}
 
+	run.StartedAt = ptr.To(ws.clock.Now())
+	run.Status = db.RunStatusRunning
+	if task.Revision() != "" {
+		run.TaskRevision = ptr.To(task.Revision())
+	}
+
+	if err := ws.db.Save(&run).Error; err != nil {
+		log.Log().Errorw("Update next run", zap.Error(err))
+		return run, nil, err
+	}
+
 	return run, task, nil
 }
 
@@ -216,9 +374,12 @@ func (ws *WorkerService) ReportRun(req openapi.ReportWorkV1Request) error {
 
 	err = ws.db.Transaction(func(tx *gorm.DB) error {
 		runCurrent.FinishedAt = ptr.To(ws.clock.Now())
-		if req.Error == nil {
+		switch {
+		case ptr.FromDef(req.Cancelled, false):
+			runCurrent.Status = db.RunStatusCancelled
+		case req.Error == nil:
 			runCurrent.Status = db.RunStatusFinished
-		} else {
+		default:
 			runCurrent.Error = req.Error
 			runCurrent.Status = db.RunStatusFailed
 		}
@@ -233,10 +394,20 @@ func (ws *WorkerService) ReportRun(req openapi.ReportWorkV1Request) error {
 				prIsOpen = true
 			}
 
+			// Store the log even if the status hasn't changed because it describes this run.
+			// Skip repositories in which nothing has happened to not store a log of every repository on every run.
+			if taskResult.Log != nil && *taskResult.Log != "" && hasActivity(taskResult) {
+				err := ws.repositoryLogService.SaveLog(runCurrent.ID, taskResult.RepositoryName, *taskResult.Log, tx)
+				if err != nil {
+					return err
+				}
+			}
+
 			var resultDb db.TaskResult
 			resultDbStmt := tx.Select("task_results.*").
 				Joins("INNER JOIN runs ON task_results.run_id = runs.id").
 				Where("task_results.repository_name = ?", taskResult.RepositoryName).
+				Where("task_results.target_branch = ?", ptr.FromDef(taskResult.TargetBranch, "")).
 				Where("runs.task_name = ?", runCurrent.TaskName).
 				Order("created_at DESC").
 				First(&resultDb)
@@ -251,10 +422,13 @@ func (ws *WorkerService) ReportRun(req openapi.ReportWorkV1Request) error {
 			}
 
 			result := db.TaskResult{
+				CreatedAt:      ws.clock.Now(),
 				RepositoryName: taskResult.RepositoryName,
 				Result:         taskResult.Result,
+				RolloutWave:    taskResult.RolloutWave,
 				RunID:          runCurrent.ID,
 				Status:         status,
+				TargetBranch:   ptr.FromDef(taskResult.TargetBranch, ""),
 			}
 			if taskResult.Error != nil {
 				result.Error = taskResult.Error
@@ -267,11 +441,54 @@ func (ws *WorkerService) ReportRun(req openapi.ReportWorkV1Request) error {
 			if err := tx.Save(&result).Error; err != nil {
 				return err
 			}
+
+			if result.Status == db.TaskResultStatusMerged {
+				if _, err := ws.ScheduleDependents(runCurrent.TaskName, result.RepositoryName, tx); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := ws.repositoryLogService.DeleteExpiredLogs(tx); err != nil {
+			return err
 		}
 
 		next := calcNextScheduleTime(runCurrent, ws.clock.Now(), task, prIsOpen)
+		if task.Rollout != nil {
+			eval, err := ws.rolloutService.Evaluate(task, tx)
+			if err != nil {
+				return err
+			}
+
+			if eval.Promoted {
+				// Apply the task to the repositories of the next wave.
+				_, err := ws.ScheduleRun(ScheduleRunOptions{
+					Reason:        db.RunReasonNext,
+					RunData:       followUpRunData(runCurrent.RunData),
+					ScheduleAfter: ws.clock.Now(),
+					TaskName:      runCurrent.TaskName,
+				}, tx)
+				if err != nil {
+					// Not critical because the wave has started and the next run of the task applies it to the repositories of the wave.
+					log.Log().Errorw("Failed to schedule run of next wave of rollout", "task", runCurrent.TaskName, zap.Error(err))
+				}
+			}
+
+			// Check the gate again once the soak time of the current wave has ended.
+			if eval.EvaluateAt != nil && (next == nil || eval.EvaluateAt.Before(ptr.From(next))) {
+				next = eval.EvaluateAt
+			}
+		}
+
 		if next != nil {
-			_, err := ws.ScheduleRun(runCurrent.Reason, runCurrent.RepositoryNames, ptr.From(next), runCurrent.TaskName, runCurrent.RunData, tx)
+			_, err := ws.ScheduleRun(ScheduleRunOptions{
+				ApiTokenName:    runCurrent.ApiTokenName,
+				Reason:          runCurrent.Reason,
+				RepositoryNames: runCurrent.RepositoryNames,
+				RunData:         followUpRunData(runCurrent.RunData),
+				ScheduleAfter:   ptr.From(next),
+				TaskName:        runCurrent.TaskName,
+			}, tx)
 			if err != nil {
 				return err
 			}
@@ -351,6 +568,57 @@ func (ws *WorkerService) DeleteRun(id int) error {
 	return nil
 }
 
+// CancelRun cancels the run identified by id.
+// It deletes the run if it is pending and returns true.
+// It records the request to cancel the run if it is running.
+// The worker that executes the run learns about the request via [WorkerService.HeartbeatRun].
+func (ws *WorkerService) CancelRun(id int) (bool, error) {
+	run, err := ws.GetRun(id)
+	if err != nil {
+		return false, err
+	}
+
+	switch run.Status {
+	case db.RunStatusPending:
+		// Deleting a pending run of these triggers stops all future runs of the trigger.
+		if run.Reason == db.RunReasonCron || run.Reason == db.RunReasonNext {
+			return false, sberror.NewRunCannotCancelError()
+		}
+
+		if err := ws.db.Delete(&run).Error; err != nil {
+			return false, fmt.Errorf("delete pending run '%d': %w", id, err)
+		}
+
+		log.Log().Infof("Cancelled pending run %d of task %s", run.ID, run.TaskName)
+		return true, nil
+	case db.RunStatusRunning:
+		if run.CancelRequestedAt != nil {
+			return false, nil
+		}
+
+		run.CancelRequestedAt = ptr.To(ws.clock.Now())
+		if err := ws.db.Save(&run).Error; err != nil {
+			return false, fmt.Errorf("request cancellation of run '%d': %w", id, err)
+		}
+
+		log.Log().Infof("Requested cancellation of running run %d of task %s", run.ID, run.TaskName)
+		return false, nil
+	default:
+		return false, sberror.NewRunCannotCancelError()
+	}
+}
+
+// HeartbeatRun receives a heartbeat of the worker that executes the run identified by id.
+// It returns true if a user requested to cancel the run.
+func (ws *WorkerService) HeartbeatRun(id int) (bool, error) {
+	run, err := ws.GetRun(id)
+	if err != nil {
+		return false, err
+	}
+
+	return run.CancelRequestedAt != nil, nil
+}
+
 // GetRun returns a [db.Run] identified by id.
 //
 // It returns an error if no run is found.
@@ -536,3 +804,26 @@ func shouldCreateNewTaskResult(resultApi openapi.ReportWorkV1TaskResult, resultD
 
 	return false
 }
+
+// followUpRunData returns the run data of a run that follows a run with runData.
+// It removes the command that a user has sent via a comment because a command applies to one run only.
+func followUpRunData(runData db.StringMap) map[string]string {
+	data := maps.Clone(map[string]string(runData))
+	delete(data, sbcontext.RunDataKeyCommand)
+	return data
+}
+
+// hasActivity returns true if the worker has changed the repository or its pull request
+// or if an error has occurred.
+func hasActivity(taskResult openapi.ReportWorkV1TaskResult) bool {
+	if taskResult.Error != nil {
+		return true
+	}
+
+	switch processor.Result(taskResult.Result) {
+	case processor.ResultNoChanges, processor.ResultNoMatch, processor.ResultPrOpen, processor.ResultSkip, processor.ResultDependencyPending:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,398 @@
+package ui
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/wndhydrnt/saturn-bot/pkg/clock"
+	"github.com/wndhydrnt/saturn-bot/pkg/log"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+)
+
+const (
+	cookieNameLogin    = "saturn-bot-login"
+	cookieNameSession  = "saturn-bot-session"
+	formFieldCsrfToken = "csrf_token"
+	loginTtl           = 10 * time.Minute
+	pathLoginCallback  = "/ui/auth/callback"
+)
+
+var (
+	errCookieInvalid = errors.New("cookie invalid")
+	errCookieExpired = errors.New("cookie expired")
+)
+
+// AuthOptions defines all options to set up the login via OpenID Connect.
+type AuthOptions struct {
+	// BaseUrl is the URL under which users access the server.
+	// Used to construct the redirect URL of the login.
+	BaseUrl      string
+	ClientID     string
+	ClientSecret string
+	Clock        clock.Clock
+	// GroupsClaim is the name of the claim in the ID token that contains the groups of the user.
+	GroupsClaim string
+	IssuerUrl   string
+	// RunGroups are the groups whose members can schedule runs.
+	RunGroups []string
+	Scopes    []string
+	// SessionSecret is the key used to sign cookies.
+	SessionSecret string
+	SessionTtl    time.Duration
+	// ViewGroups are the groups whose members can access the UI.
+	// Every user can access the UI if empty.
+	ViewGroups []string
+}
+
+// auth implements the login via OpenID Connect
+// and manages sessions of users.
+type auth struct {
+	clock         clock.Clock
+	groupsClaim   string
+	oauth2Config  oauth2.Config
+	runGroups     []string
+	secret        []byte
+	secureCookies bool
+	sessionTtl    time.Duration
+	verifier      *oidc.IDTokenVerifier
+	viewGroups    []string
+}
+
+func newAuth(ctx context.Context, opts AuthOptions) (*auth, error) {
+	if opts.ClientID == "" {
+		return nil, errors.New("setting serverUiOidcClientId is required if serverUiOidcIssuerUrl is set")
+	}
+
+	if opts.SessionSecret == "" {
+		return nil, errors.New("setting serverUiSessionSecret is required if serverUiOidcIssuerUrl is set")
+	}
+
+	provider, err := oidc.NewProvider(ctx, opts.IssuerUrl)
+	if err != nil {
+		return nil, fmt.Errorf("discover oidc provider: %w", err)
+	}
+
+	baseUrl := strings.TrimSuffix(opts.BaseUrl, "/")
+	return &auth{
+		clock:       opts.Clock,
+		groupsClaim: opts.GroupsClaim,
+		oauth2Config: oauth2.Config{
+			ClientID:     opts.ClientID,
+			ClientSecret: opts.ClientSecret,
+			Endpoint:     provider.Endpoint(),
+			RedirectURL:  baseUrl + pathLoginCallback,
+			Scopes:       opts.Scopes,
+		},
+		runGroups:     opts.RunGroups,
+		secret:        []byte(opts.SessionSecret),
+		secureCookies: strings.HasPrefix(baseUrl, "https://"),
+		sessionTtl:    opts.SessionTtl,
+		verifier:      provider.Verifier(&oidc.Config{ClientID: opts.ClientID}),
+		viewGroups:    opts.ViewGroups,
+	}, nil
+}
+
+// loginState is stored in a cookie while the user logs in at the provider.
+type loginState struct {
+	ExpiresAt int64  `json:"exp"`
+	Nonce     string `json:"nonce"`
+	Redirect  string `json:"redirect"`
+	State     string `json:"state"`
+	Verifier  string `json:"verifier"`
+}
+
+// session is stored in a cookie after the user has logged in.
+type session struct {
+	ExpiresAt int64    `json:"exp"`
+	Groups    []string `json:"groups,omitempty"`
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+}
+
+// user is the user that sent a request to the UI.
+type user struct {
+	// CanSchedule is true if the user can schedule runs.
+	CanSchedule bool
+	// CsrfToken protects forms of the UI.
+	CsrfToken string
+	Name      string
+}
+
+type userKey struct{}
+
+func withUser(ctx context.Context, u user) context.Context {
+	return context.WithValue(ctx, userKey{}, u)
+}
+
+// userFromContext returns the user that sent the request.
+// It returns nil if the login via OpenID Connect isn't configured.
+// Handlers of forms reject requests without a user, which keeps the UI read-only in that case.
+func userFromContext(ctx context.Context) *user {
+	u, ok := ctx.Value(userKey{}).(user)
+	if !ok {
+		return nil
+	}
+
+	return &u
+}
+
+// Login redirects the user to the provider.
+func (a *auth) Login(w http.ResponseWriter, r *http.Request) {
+	state := loginState{
+		ExpiresAt: a.clock.Now().Add(loginTtl).Unix(),
+		Nonce:     rand.Text(),
+		Redirect:  sanitizeRedirect(r.URL.Query().Get("redirect")),
+		State:     rand.Text(),
+		Verifier:  oauth2.GenerateVerifier(),
+	}
+	value, err := a.encodeCookie(cookieNameLogin, state)
+	if err != nil {
+		renderError(fmt.Errorf("encode login cookie: %w", err), w, r)
+		return
+	}
+
+	http.SetCookie(w, a.newCookie(cookieNameLogin, value, "/ui/auth", int(loginTtl.Seconds())))
+	authUrl := a.oauth2Config.AuthCodeURL(state.State, oidc.Nonce(state.Nonce), oauth2.S256ChallengeOption(state.Verifier))
+	http.Redirect(w, r, authUrl, http.StatusFound)
+}
+
+// Callback handles the redirect from the provider after the user has logged in.
+func (a *auth) Callback(w http.ResponseWriter, r *http.Request) {
+	if errParam := r.URL.Query().Get("error"); errParam != "" {
+		renderLoginError(fmt.Errorf("provider returned error %s: %s", errParam, r.URL.Query().Get("error_description")), w, r)
+		return
+	}
+
+	var state loginState
+	err := a.readCookie(r, cookieNameLogin, &state)
+	if err != nil {
+		renderLoginError(fmt.Errorf("read login cookie: %w", err), w, r)
+		return
+	}
+
+	if state.ExpiresAt < a.clock.Now().Unix() {
+		renderLoginError(errCookieExpired, w, r)
+		return
+	}
+
+	if !hmac.Equal([]byte(r.URL.Query().Get("state")), []byte(state.State)) {
+		renderLoginError(errors.New("state parameter does not match"), w, r)
+		return
+	}
+
+	token, err := a.oauth2Config.Exchange(r.Context(), r.URL.Query().Get("code"), oauth2.VerifierOption(state.Verifier))
+	if err != nil {
+		renderLoginError(fmt.Errorf("exchange code: %w", err), w, r)
+		return
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		renderLoginError(errors.New("token response does not contain an id_token"), w, r)
+		return
+	}
+
+	idToken, err := a.verifier.Verify(r.Context(), rawIDToken)
+	if err != nil {
+		renderLoginError(fmt.Errorf("verify id token: %w", err), w, r)
+		return
+	}
+
+	if !hmac.Equal([]byte(idToken.Nonce), []byte(state.Nonce)) {
+		renderLoginError(errors.New("nonce of id token does not match"), w, r)
+		return
+	}
+
+	var claims map[string]any
+	if err := idToken.Claims(&claims); err != nil {
+		renderLoginError(fmt.Errorf("decode claims of id token: %w", err), w, r)
+		return
+	}
+
+	sess := session{
+		ExpiresAt: a.clock.Now().Add(a.sessionTtl).Unix(),
+		Groups:    readGroupsClaim(claims, a.groupsClaim),
+		ID:        rand.Text(),
+		Name:      readNameClaim(claims, idToken.Subject),
+	}
+	value, err := a.encodeCookie(cookieNameSession, sess)
+	if err != nil {
+		renderError(fmt.Errorf("encode session cookie: %w", err), w, r)
+		return
+	}
+
+	log.Log().Infow("User logged in to UI", "user", sess.Name)
+	http.SetCookie(w, a.newCookie(cookieNameLogin, "", "/ui/auth", -1))
+	http.SetCookie(w, a.newCookie(cookieNameSession, value, "/ui", int(a.sessionTtl.Seconds())))
+	http.Redirect(w, r, state.Redirect, http.StatusFound)
+}
+
+// Logout deletes the session of the user.
+func (a *auth) Logout(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, a.newCookie(cookieNameSession, "", "/ui", -1))
+	http.Redirect(w, r, "/ui/auth/logged-out", http.StatusSeeOther)
+}
+
+// LoggedOut renders the page users see after they have logged out.
+func (a *auth) LoggedOut(w http.ResponseWriter, r *http.Request) {
+	renderTemplate(nil, w, r, "auth_logged_out.html")
+}
+
+// requireLogin redirects users without a valid session to the login.
+// It also ensures that users are members of the groups allowed to access the UI
+// and that forms have been submitted with a valid CSRF token.
+func (a *auth) requireLogin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var sess session
+		err := a.readCookie(r, cookieNameSession, &sess)
+		if err == nil && sess.ExpiresAt < a.clock.Now().Unix() {
+			err = errCookieExpired
+		}
+
+		if err != nil {
+			if r.Method != http.MethodGet && r.Method != http.MethodHead {
+				renderStatus(w, r, http.StatusUnauthorized, "Login required")
+				return
+			}
+
+			http.Redirect(w, r, "/ui/auth/login?redirect="+url.QueryEscape(r.URL.RequestURI()), http.StatusFound)
+			return
+		}
+
+		if len(a.viewGroups) > 0 && !containsAny(a.viewGroups, sess.Groups) {
+			renderStatus(w, r, http.StatusForbidden, "Access denied")
+			return
+		}
+
+		csrfToken := a.sign("csrf|" + sess.ID)
+		if r.Method == http.MethodPost && !hmac.Equal([]byte(r.PostFormValue(formFieldCsrfToken)), []byte(csrfToken)) {
+			renderStatus(w, r, http.StatusForbidden, "Invalid CSRF token")
+			return
+		}
+
+		ctx := withUser(r.Context(), user{
+			CanSchedule: containsAny(a.runGroups, sess.Groups),
+			CsrfToken:   csrfToken,
+			Name:        sess.Name,
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func (a *auth) newCookie(name, value, path string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		HttpOnly: true,
+		MaxAge:   maxAge,
+		Name:     name,
+		Path:     path,
+		SameSite: http.SameSiteLaxMode,
+		Secure:   a.secureCookies,
+		Value:    value,
+	}
+}
+
+// encodeCookie serializes v and signs it.
+// The signature includes the name of the cookie
+// to prevent that the value of one cookie gets used as the value of another.
+func (a *auth) encodeCookie(name string, v any) (string, error) {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + a.sign(name+"|"+encoded), nil
+}
+
+// readCookie verifies the signature of the cookie and deserializes its value into v.
+func (a *auth) readCookie(r *http.Request, name string, v any) error {
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return err
+	}
+
+	encoded, signature, found := strings.Cut(cookie.Value, ".")
+	if !found || !hmac.Equal([]byte(signature), []byte(a.sign(name+"|"+encoded))) {
+		return errCookieInvalid
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return errCookieInvalid
+	}
+
+	return json.Unmarshal(payload, v)
+}
+
+func (a *auth) sign(value string) string {
+	mac := hmac.New(sha256.New, a.secret)
+	_, _ = mac.Write([]byte(value))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func containsAny(allowed []string, groups []string) bool {
+	for _, g := range groups {
+		if slices.Contains(allowed, g) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// readGroupsClaim returns the groups of a user.
+// Providers send groups either as a list of strings or as a single string.
+func readGroupsClaim(claims map[string]any, name string) []string {
+	switch v := claims[name].(type) {
+	case string:
+		return []string{v}
+	case []any:
+		var groups []string
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				groups = append(groups, s)
+			}
+		}
+
+		return groups
+	default:
+		return nil
+	}
+}
+
+func readNameClaim(claims map[string]any, subject string) string {
+	for _, key := range []string{"name", "preferred_username", "email"} {
+		if v, ok := claims[key].(string); ok && v != "" {
+			return v
+		}
+	}
+
+	return subject
+}
+
+func renderLoginError(err error, w http.ResponseWriter, r *http.Request) {
+	log.Log().Warnw("Login to UI failed", zap.Error(err))
+	renderStatus(w, r, http.StatusUnauthorized, "Login failed")
+}
+
+// sanitizeRedirect ensures that the login only redirects to pages of the UI.
+func sanitizeRedirect(redirect string) string {
+	if redirect == "/ui" || strings.HasPrefix(redirect, "/ui/") || strings.HasPrefix(redirect, "/ui?") {
+		return redirect
+	}
+
+	return "/ui"
+}
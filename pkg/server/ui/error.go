@@ -14,18 +14,23 @@ type dataError struct {
 	Message string
 }
 
-func renderApiError(err openapi.Error, w http.ResponseWriter, status int, backLink string) {
+func renderApiError(err openapi.Error, w http.ResponseWriter, r *http.Request, status int, backLink string) {
 	w.WriteHeader(status)
 	data := dataError{
 		Link:    backLink,
 		Error:   err,
 		Message: "Request failed",
 	}
-	renderTemplate(data, w, "error.html")
+	renderTemplate(data, w, r, "error.html")
 }
 
-func renderError(err error, w http.ResponseWriter) {
+func renderError(err error, w http.ResponseWriter, r *http.Request) {
 	log.Log().Errorw("Rendering of UI failed", zap.Error(err))
 	w.WriteHeader(http.StatusInternalServerError)
-	renderTemplate(dataError{Message: err.Error()}, w, "error.html")
+	renderTemplate(dataError{Message: err.Error()}, w, r, "error.html")
+}
+
+func renderStatus(w http.ResponseWriter, r *http.Request, status int, message string) {
+	w.WriteHeader(status)
+	renderTemplate(dataError{Message: message}, w, r, "error.html")
 }
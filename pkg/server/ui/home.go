@@ -16,7 +16,7 @@ type dataHome struct {
 func (u *Ui) Home(w http.ResponseWriter, r *http.Request) {
 	tasksResp, err := u.API.ListTasksV1(r.Context(), openapi.ListTasksV1RequestObject{})
 	if err != nil {
-		renderError(err, w)
+		renderError(err, w, r)
 		return
 	}
 
@@ -33,12 +33,12 @@ func (u *Ui) Home(w http.ResponseWriter, r *http.Request) {
 			ListOptions: &openapi.ListOptions{
 				Limit: 5,
 			},
-			Status: ptr.To([]openapi.RunStatusV1{openapi.Finished, openapi.Failed}),
+			Status: ptr.To([]openapi.RunStatusV1{openapi.Finished, openapi.Failed, openapi.Cancelled}),
 		},
 	}
 	recentRunsResp, err := u.API.ListRunsV1(r.Context(), reqRecent)
 	if err != nil {
-		renderError(err, w)
+		renderError(err, w, r)
 		return
 	}
 
@@ -47,5 +47,5 @@ func (u *Ui) Home(w http.ResponseWriter, r *http.Request) {
 		tplData.RecentRuns = recentRunsObj
 	}
 
-	renderTemplate(tplData, w, "home.html")
+	renderTemplate(tplData, w, r, "home.html")
 }
@@ -17,8 +17,11 @@ type dataResultsIndex struct {
 	DisplayRunLink bool
 	Filters        dataResultsIndexFilters
 	Pagination     pagination
-	TaskName       string
-	TaskResults    []openapi.TaskResultV1
+	// Rollout is the progress of the rollout of the task.
+	// Nil if the task doesn't define a rollout.
+	Rollout     *openapi.TaskRolloutV1
+	TaskName    string
+	TaskResults []openapi.TaskResultV1
 }
 
 // ResultsIndex renders the list of results of the latest run of a task.
@@ -49,7 +52,7 @@ func (u *Ui) ResultsIndex(w http.ResponseWriter, r *http.Request) {
 
 	listTaskResultsResp, err := u.API.ListTaskRecentTaskResultsV1(r.Context(), listTaskResultsReq)
 	if err != nil {
-		renderError(err, w)
+		renderError(err, w, r)
 		return
 	}
 
@@ -61,10 +64,20 @@ func (u *Ui) ResultsIndex(w http.ResponseWriter, r *http.Request) {
 		}
 		data.TaskName = name
 		data.TaskResults = resp.TaskResults
-		renderTemplate(data, w, "results_table.html", "results_index.html")
+		getTaskResp, err := u.API.GetTaskV1(r.Context(), openapi.GetTaskV1RequestObject{Task: name})
+		if err != nil {
+			renderError(err, w, r)
+			return
+		}
+
+		if task, ok := getTaskResp.(openapi.GetTaskV1200JSONResponse); ok {
+			data.Rollout = task.Rollout
+		}
+
+		renderTemplate(data, w, r, "results_table.html", "results_index.html")
 	case openapi.ListTaskRecentTaskResultsV1404JSONResponse:
-		renderApiError(openapi.Error(resp), w, http.StatusNotFound, "")
+		renderApiError(openapi.Error(resp), w, r, http.StatusNotFound, "")
 	case openapi.ListTaskRecentTaskResultsV1500JSONResponse:
-		renderApiError(openapi.Error(resp), w, http.StatusInternalServerError, "")
+		renderApiError(openapi.Error(resp), w, r, http.StatusInternalServerError, "")
 	}
 }
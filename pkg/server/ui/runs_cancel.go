@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/wndhydrnt/saturn-bot/pkg/log"
+	"github.com/wndhydrnt/saturn-bot/pkg/server/api/openapi"
+)
+
+// RunsCancel cancels a run.
+// It redirects to the list of runs of the task if the run was pending and has been deleted.
+// It redirects to the run otherwise.
+func (u *Ui) RunsCancel(w http.ResponseWriter, r *http.Request) {
+	currentUser := userFromContext(r.Context())
+	if currentUser == nil || !currentUser.CanSchedule {
+		renderStatus(w, r, http.StatusForbidden, "Not allowed to cancel runs")
+		return
+	}
+
+	runId, err := strconv.Atoi(chi.URLParam(r, "runId"))
+	if err != nil {
+		renderError(fmt.Errorf("convert parameter runId to int: %w", err), w, r)
+		return
+	}
+
+	getRunResp, err := u.API.GetRunV1(r.Context(), openapi.GetRunV1RequestObject{RunId: runId})
+	if err != nil {
+		renderError(err, w, r)
+		return
+	}
+
+	var run openapi.GetRunV1200JSONResponse
+	switch v := getRunResp.(type) {
+	case openapi.GetRunV1200JSONResponse:
+		run = v
+	case openapi.GetRunV1404JSONResponse:
+		renderApiError(openapi.Error(v), w, r, http.StatusNotFound, "")
+		return
+	default:
+		renderError(fmt.Errorf("unexpected response %T", getRunResp), w, r)
+		return
+	}
+
+	resp, err := u.API.CancelRunV1(r.Context(), openapi.CancelRunV1RequestObject{RunId: runId})
+	if err != nil {
+		renderError(fmt.Errorf("cancel run: %w", err), w, r)
+		return
+	}
+
+	switch v := resp.(type) {
+	case openapi.CancelRunV1200JSONResponse:
+		log.Log().Infow("User cancelled run via UI", "user", currentUser.Name, "task", run.Run.Task, "runId", runId)
+		if v.Deleted {
+			http.Redirect(w, r, "/ui/runs?task="+url.QueryEscape(run.Run.Task), http.StatusSeeOther)
+			return
+		}
+
+		http.Redirect(w, r, fmt.Sprintf("/ui/runs/%d", runId), http.StatusSeeOther)
+	case openapi.CancelRunV1400JSONResponse:
+		renderApiError(openapi.Error(v), w, r, http.StatusBadRequest, "")
+	case openapi.CancelRunV1404JSONResponse:
+		renderApiError(openapi.Error(v), w, r, http.StatusNotFound, "")
+	default:
+		renderError(fmt.Errorf("unexpected response %T", resp), w, r)
+	}
+}
+
+// isRunCancellable returns true if [github.com/wndhydrnt/saturn-bot/pkg/server/api.APIServer.CancelRunV1] accepts the run.
+func isRunCancellable(run openapi.RunV1) bool {
+	if run.CancelRequestedAt != nil {
+		return false
+	}
+
+	switch run.Status {
+	case openapi.Pending:
+		return run.Reason != openapi.Cron && run.Reason != openapi.Next
+	case openapi.Running:
+		return true
+	default:
+		return false
+	}
+}
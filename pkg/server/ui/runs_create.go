@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/wndhydrnt/saturn-bot/pkg/log"
+	"github.com/wndhydrnt/saturn-bot/pkg/ptr"
+	"github.com/wndhydrnt/saturn-bot/pkg/server/api/openapi"
+)
+
+// RunsCreate schedules a run of a task and redirects to the new run.
+// It renders the form again if the API rejects the inputs.
+// Only users that are members of one of the groups allowed to schedule runs can use it.
+func (u *Ui) RunsCreate(w http.ResponseWriter, r *http.Request) {
+	currentUser := userFromContext(r.Context())
+	if currentUser == nil || !currentUser.CanSchedule {
+		renderStatus(w, r, http.StatusForbidden, "Not allowed to schedule runs")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		renderStatus(w, r, http.StatusBadRequest, "Invalid form")
+		return
+	}
+
+	taskName := chi.URLParam(r, "name")
+	// values holds all values to render the form again if the API rejects them.
+	values := map[string]string{}
+	runData := map[string]string{}
+	for key := range r.PostForm {
+		name, isInput := strings.CutPrefix(key, formFieldInputPrefix)
+		if !isInput {
+			continue
+		}
+
+		value := strings.TrimSpace(r.PostForm.Get(key))
+		values[name] = value
+		// Leave out empty values to let the task fall back to the default value of the input.
+		if value != "" {
+			runData[name] = value
+		}
+	}
+
+	req := openapi.ScheduleRunV1Request{TaskName: taskName}
+
+	if len(runData) > 0 {
+		req.RunData = ptr.To(runData)
+	}
+
+	repositoryNamesRaw := r.PostForm.Get("repositoryNames")
+	if repositoryNames := strings.Fields(repositoryNamesRaw); len(repositoryNames) > 0 {
+		req.RepositoryNames = ptr.To(repositoryNames)
+	}
+
+	resp, err := u.API.ScheduleRunV1(r.Context(), openapi.ScheduleRunV1RequestObject{Body: &req})
+	if err != nil {
+		renderError(fmt.Errorf("schedule run: %w", err), w, r)
+		return
+	}
+
+	switch v := resp.(type) {
+	case openapi.ScheduleRunV1200JSONResponse:
+		log.Log().Infow("User scheduled run via UI", "user", currentUser.Name, "task", taskName, "runId", v.RunID)
+		http.Redirect(w, r, fmt.Sprintf("/ui/runs/%d", v.RunID), http.StatusSeeOther)
+	case openapi.ScheduleRunV1400JSONResponse:
+		u.renderRunsNew(w, r, http.StatusBadRequest, dataRunsNew{
+			Errors:          v.Errors,
+			RepositoryNames: repositoryNamesRaw,
+			TaskName:        taskName,
+			Values:          values,
+		})
+	default:
+		renderError(fmt.Errorf("unexpected response %T", resp), w, r)
+	}
+}
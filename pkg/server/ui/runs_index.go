@@ -23,7 +23,7 @@ type dataRunsIndexFilters struct {
 }
 
 var (
-	runStatusOptions        = []string{string(openapi.Failed), string(openapi.Finished), string(openapi.Pending), string(openapi.Running)}
+	runStatusOptions        = []string{string(openapi.Cancelled), string(openapi.Failed), string(openapi.Finished), string(openapi.Pending), string(openapi.Running)}
 	taskResultStatusOptions = []openapi.TaskResultStateV1{openapi.TaskResultStateV1Merged, openapi.TaskResultStateV1Open, openapi.TaskResultStateV1Error, openapi.TaskResultStateV1Closed, openapi.TaskResultStateV1Archived}
 )
 
@@ -41,7 +41,7 @@ func (u *Ui) RunsIndex(w http.ResponseWriter, r *http.Request) {
 
 	listTasksResp, err := u.API.ListTasksV1(r.Context(), openapi.ListTasksV1RequestObject{})
 	if err != nil {
-		renderError(fmt.Errorf("list tasks: %w", err), w)
+		renderError(fmt.Errorf("list tasks: %w", err), w, r)
 		return
 	}
 
@@ -69,7 +69,7 @@ func (u *Ui) RunsIndex(w http.ResponseWriter, r *http.Request) {
 
 	listRunsResp, err := u.API.ListRunsV1(context.Background(), req)
 	if err != nil {
-		renderError(err, w)
+		renderError(err, w, r)
 		return
 	}
 
@@ -82,5 +82,5 @@ func (u *Ui) RunsIndex(w http.ResponseWriter, r *http.Request) {
 		tplData.Runs = payload.Result
 	}
 
-	renderTemplate(tplData, w, "runs_index.html")
+	renderTemplate(tplData, w, r, "runs_index.html")
 }
@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/wndhydrnt/saturn-bot/pkg/ptr"
+	"github.com/wndhydrnt/saturn-bot/pkg/server/api/openapi"
+)
+
+const formFieldInputPrefix = "input."
+
+type dataRunsNew struct {
+	// Errors returned by the API when it validated a previous submission of the form.
+	Errors          []openapi.ErrorDetail
+	Inputs          []openapi.TaskV1Input
+	RepositoryNames string
+	TaskName        string
+	// Values of inputs to pre-fill the form with.
+	Values map[string]string
+}
+
+// RunsNew renders the form to schedule a run of a task.
+func (u *Ui) RunsNew(w http.ResponseWriter, r *http.Request) {
+	currentUser := userFromContext(r.Context())
+	if currentUser == nil || !currentUser.CanSchedule {
+		renderStatus(w, r, http.StatusForbidden, "Not allowed to schedule runs")
+		return
+	}
+
+	u.renderRunsNew(w, r, http.StatusOK, dataRunsNew{
+		TaskName: chi.URLParam(r, "name"),
+		Values:   map[string]string{},
+	})
+}
+
+// renderRunsNew renders the form to schedule a run.
+// It reads the inputs of the task to render a field for each input.
+func (u *Ui) renderRunsNew(w http.ResponseWriter, r *http.Request, status int, data dataRunsNew) {
+	resp, err := u.API.GetTaskV1(r.Context(), openapi.GetTaskV1RequestObject{Task: data.TaskName})
+	if err != nil {
+		renderError(fmt.Errorf("get task: %w", err), w, r)
+		return
+	}
+
+	switch v := resp.(type) {
+	case openapi.GetTaskV1200JSONResponse:
+		data.Inputs = ptr.FromDef(v.Inputs, []openapi.TaskV1Input{})
+		for _, input := range data.Inputs {
+			if _, ok := data.Values[input.Name]; !ok && input.Default != nil {
+				data.Values[input.Name] = ptr.From(input.Default)
+			}
+		}
+
+		w.WriteHeader(status)
+		renderTemplate(data, w, r, "runs_new.html")
+
+	case openapi.GetTaskV1404JSONResponse:
+		renderApiError(openapi.Error(v), w, r, http.StatusNotFound, "")
+
+	case openapi.GetTaskV1500JSONResponse:
+		renderApiError(openapi.Error(v), w, r, http.StatusInternalServerError, "")
+	}
+}
@@ -20,19 +20,19 @@ type dataRunsRepositoryErrorShow struct {
 func (u *Ui) RunsRepositoryErrorShow(w http.ResponseWriter, r *http.Request) {
 	runId, err := strconv.Atoi(chi.URLParam(r, "runId"))
 	if err != nil {
-		renderError(fmt.Errorf("convert parameter runId to int: %w", err), w)
+		renderError(fmt.Errorf("convert parameter runId to int: %w", err), w, r)
 		return
 	}
 
 	repositoryNameRaw := chi.URLParam(r, "repositoryName")
 	if repositoryNameRaw == "" {
-		renderError(fmt.Errorf("cannot extract repositoryName path parameter"), w)
+		renderError(fmt.Errorf("cannot extract repositoryName path parameter"), w, r)
 		return
 	}
 
 	repositoryName, err := url.PathUnescape(repositoryNameRaw)
 	if err != nil {
-		renderError(fmt.Errorf("unescape repository name: %w", err), w)
+		renderError(fmt.Errorf("unescape repository name: %w", err), w, r)
 	}
 
 	listTaskResultsResp, err := u.API.ListTaskResultsV1(r.Context(), openapi.ListTaskResultsV1RequestObject{
@@ -42,19 +42,19 @@ func (u *Ui) RunsRepositoryErrorShow(w http.ResponseWriter, r *http.Request) {
 		},
 	})
 	if err != nil {
-		renderError(err, w)
+		renderError(err, w, r)
 		return
 	}
 
 	switch respObj := listTaskResultsResp.(type) {
 	case openapi.ListTaskResultsV1200JSONResponse:
 		if len(respObj.TaskResults) != 1 {
-			renderError(fmt.Errorf("expected 1 task result, got %d", len(respObj.TaskResults)), w)
+			renderError(fmt.Errorf("expected 1 task result, got %d", len(respObj.TaskResults)), w, r)
 			return
 		}
 
 		if respObj.TaskResults[0].Error == nil {
-			renderError(fmt.Errorf("no error for %s", repositoryName), w)
+			renderError(fmt.Errorf("no error for %s", repositoryName), w, r)
 			return
 		}
 
@@ -63,8 +63,8 @@ func (u *Ui) RunsRepositoryErrorShow(w http.ResponseWriter, r *http.Request) {
 			RepositoryName: respObj.TaskResults[0].RepositoryName,
 			RunId:          respObj.TaskResults[0].RunId,
 		}
-		renderTemplate(data, w, "runs_repository_error_show.html")
+		renderTemplate(data, w, r, "runs_repository_error_show.html")
 	default:
-		renderError(fmt.Errorf("expected ListTaskResultsV1200JSONResponse, got %T", respObj), w)
+		renderError(fmt.Errorf("expected ListTaskResultsV1200JSONResponse, got %T", respObj), w, r)
 	}
 }
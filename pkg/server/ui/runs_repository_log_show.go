@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/wndhydrnt/saturn-bot/pkg/server/api/openapi"
+)
+
+type dataRunsRepositoryLogShow struct {
+	CreatedAt      time.Time
+	Log            string
+	RepositoryName string
+	RunId          int
+}
+
+// RunsRepositoryLogShow renders the log that a worker captured while it applied the task of a run to a repository.
+func (u *Ui) RunsRepositoryLogShow(w http.ResponseWriter, r *http.Request) {
+	runId, err := strconv.Atoi(chi.URLParam(r, "runId"))
+	if err != nil {
+		renderError(fmt.Errorf("convert parameter runId to int: %w", err), w, r)
+		return
+	}
+
+	repositoryName, err := url.PathUnescape(chi.URLParam(r, "repositoryName"))
+	if err != nil {
+		renderError(fmt.Errorf("unescape repository name: %w", err), w, r)
+		return
+	}
+
+	resp, err := u.API.GetRepositoryLogV1(r.Context(), openapi.GetRepositoryLogV1RequestObject{
+		Params: openapi.GetRepositoryLogV1Params{RepositoryName: repositoryName},
+		RunId:  runId,
+	})
+	if err != nil {
+		renderError(err, w, r)
+		return
+	}
+
+	switch respObj := resp.(type) {
+	case openapi.GetRepositoryLogV1200JSONResponse:
+		data := dataRunsRepositoryLogShow{
+			CreatedAt:      respObj.CreatedAt,
+			Log:            respObj.Log,
+			RepositoryName: respObj.RepositoryName,
+			RunId:          respObj.RunId,
+		}
+		renderTemplate(data, w, r, "runs_repository_log_show.html")
+	case openapi.GetRepositoryLogV1404JSONResponse:
+		renderApiError(openapi.Error(respObj), w, r, http.StatusNotFound, fmt.Sprintf("/ui/runs/%d", runId))
+	default:
+		renderError(fmt.Errorf("unexpected response %T", resp), w, r)
+	}
+}
@@ -11,6 +11,7 @@ import (
 )
 
 type dataRunsShow struct {
+	Cancellable    bool
 	DisplayRunLink bool
 	Filters        dataResultsIndexFilters
 	Pagination     pagination
@@ -22,7 +23,7 @@ type dataRunsShow struct {
 func (u *Ui) RunsShow(w http.ResponseWriter, r *http.Request) {
 	runId, err := strconv.Atoi(chi.URLParam(r, "runId"))
 	if err != nil {
-		renderError(fmt.Errorf("convert parameter runId to int: %w", err), w)
+		renderError(fmt.Errorf("convert parameter runId to int: %w", err), w, r)
 		return
 	}
 
@@ -31,7 +32,7 @@ func (u *Ui) RunsShow(w http.ResponseWriter, r *http.Request) {
 	}
 	getRunResp, err := u.API.GetRunV1(r.Context(), getRunReq)
 	if err != nil {
-		renderError(err, w)
+		renderError(err, w, r)
 		return
 	}
 
@@ -45,9 +46,10 @@ func (u *Ui) RunsShow(w http.ResponseWriter, r *http.Request) {
 	}
 	switch getRunObj := getRunResp.(type) {
 	case openapi.GetRunV1200JSONResponse:
+		data.Cancellable = isRunCancellable(getRunObj.Run)
 		data.Run = getRunObj.Run
 	case openapi.GetRunV1404JSONResponse:
-		renderApiError(openapi.Error(getRunObj), w, http.StatusNotFound, "")
+		renderApiError(openapi.Error(getRunObj), w, r, http.StatusNotFound, "")
 		return
 	}
 
@@ -67,7 +69,7 @@ func (u *Ui) RunsShow(w http.ResponseWriter, r *http.Request) {
 
 	listTaskResultsResp, err := u.API.ListTaskResultsV1(r.Context(), listTaskResultsReq)
 	if err != nil {
-		renderError(err, w)
+		renderError(err, w, r)
 		return
 	}
 
@@ -77,5 +79,5 @@ func (u *Ui) RunsShow(w http.ResponseWriter, r *http.Request) {
 		URL:  r.URL,
 	}
 	data.TaskResults = listTaskResultsObj.TaskResults
-	renderTemplate(data, w, "results_table.html", "runs_show.html")
+	renderTemplate(data, w, r, "results_table.html", "runs_show.html")
 }
@@ -12,5 +12,5 @@ type dataInfoIndex struct {
 
 func (u *Ui) StatusIndex(w http.ResponseWriter, r *http.Request) {
 	data := dataInfoIndex{Version: version.Info}
-	renderTemplate(data, w, "status_index.html")
+	renderTemplate(data, w, r, "status_index.html")
 }
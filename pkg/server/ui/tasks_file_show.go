@@ -20,19 +20,19 @@ func (u *Ui) TasksFileShow(w http.ResponseWriter, r *http.Request) {
 	}
 	resp, err := u.API.GetTaskV1(r.Context(), reqOpts)
 	if err != nil {
-		renderError(fmt.Errorf("get task: %w", err), w)
+		renderError(fmt.Errorf("get task: %w", err), w, r)
 		return
 	}
 
 	switch v := resp.(type) {
 	case openapi.GetTaskV1200JSONResponse:
 		data := dataTasksFileShow{Content: v.Content, TaskName: v.Name}
-		renderTemplate(data, w, "tasks_file_show.html")
+		renderTemplate(data, w, r, "tasks_file_show.html")
 
 	case openapi.GetTaskV1404JSONResponse:
-		renderApiError(openapi.Error(v), w, http.StatusNotFound, "")
+		renderApiError(openapi.Error(v), w, r, http.StatusNotFound, "")
 
 	case openapi.GetTaskV1500JSONResponse:
-		renderApiError(openapi.Error(v), w, http.StatusInternalServerError, "")
+		renderApiError(openapi.Error(v), w, r, http.StatusInternalServerError, "")
 	}
 }
@@ -40,7 +40,7 @@ func (u *Ui) TasksIndex(w http.ResponseWriter, r *http.Request) {
 
 	listTasksResp, err := u.API.ListTasksV1(r.Context(), req)
 	if err != nil {
-		renderError(fmt.Errorf("list tasks: %w", err), w)
+		renderError(fmt.Errorf("list tasks: %w", err), w, r)
 		return
 	}
 
@@ -52,5 +52,5 @@ func (u *Ui) TasksIndex(w http.ResponseWriter, r *http.Request) {
 		Page: taskList.Page,
 		URL:  r.URL,
 	}
-	renderTemplate(data, w, "tasks_index.html")
+	renderTemplate(data, w, r, "tasks_index.html")
 }
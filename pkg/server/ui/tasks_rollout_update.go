@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/wndhydrnt/saturn-bot/pkg/log"
+	"github.com/wndhydrnt/saturn-bot/pkg/server/api/openapi"
+)
+
+// TasksRolloutUpdate promotes, pauses or resumes the rollout of a task.
+// It redirects to the results of the task.
+// Only users that are members of one of the groups allowed to schedule runs can use it.
+func (u *Ui) TasksRolloutUpdate(w http.ResponseWriter, r *http.Request) {
+	currentUser := userFromContext(r.Context())
+	if currentUser == nil || !currentUser.CanSchedule {
+		renderStatus(w, r, http.StatusForbidden, "Not allowed to update rollouts")
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		renderStatus(w, r, http.StatusBadRequest, "Invalid form")
+		return
+	}
+
+	taskName := chi.URLParam(r, "name")
+	action := openapi.UpdateTaskRolloutV1RequestAction(r.PostForm.Get("action"))
+	resp, err := u.API.UpdateTaskRolloutV1(r.Context(), openapi.UpdateTaskRolloutV1RequestObject{
+		Body: &openapi.UpdateTaskRolloutV1Request{Action: action},
+		Task: taskName,
+	})
+	if err != nil {
+		renderError(fmt.Errorf("update rollout: %w", err), w, r)
+		return
+	}
+
+	switch v := resp.(type) {
+	case openapi.UpdateTaskRolloutV1200JSONResponse:
+		log.Log().Infow("User updated rollout via UI", "user", currentUser.Name, "task", taskName, "action", action)
+		http.Redirect(w, r, "/ui/tasks/"+url.PathEscape(taskName)+"/results", http.StatusSeeOther)
+	case openapi.UpdateTaskRolloutV1400JSONResponse:
+		renderApiError(openapi.Error(v), w, r, http.StatusBadRequest, "")
+	case openapi.UpdateTaskRolloutV1404JSONResponse:
+		renderApiError(openapi.Error(v), w, r, http.StatusNotFound, "")
+	default:
+		renderError(fmt.Errorf("unexpected response %T", resp), w, r)
+	}
+}
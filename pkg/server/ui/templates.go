@@ -21,12 +21,15 @@ import (
 var templateFS embed.FS
 
 var templateFuncs = template.FuncMap{
+	// currentUser gets replaced by renderTemplate for each request.
+	"currentUser":                func() *user { return nil },
 	"markdown":                   renderMarkdown,
 	"pathEscape":                 url.PathEscape,
 	"renderUrl":                  renderUrl,
 	"runStatusToCssClass":        mapRunStatusToCssClass,
 	"taskResultStatusToCssClass": mapTaskResultStatusToCssClass,
 	"timeSub":                    timeSub,
+	"webhookStatusToCssClass":    mapWebhookDeliveryStatusToCssClass,
 }
 
 var templateRoot = template.Must(template.New("").Funcs(templateFuncs).Funcs(sprig.FuncMap()).ParseFS(templateFS, "templates/base.html"))
@@ -41,6 +44,8 @@ type pagination struct {
 
 func mapRunStatusToCssClass(status openapi.RunStatusV1) string {
 	switch status {
+	case openapi.Cancelled:
+		return "is-dark"
 	case openapi.Failed:
 		return "is-danger"
 	case openapi.Finished:
@@ -67,6 +72,17 @@ func mapTaskResultStatusToCssClass(status openapi.TaskResultStateV1) string {
 	}
 }
 
+func mapWebhookDeliveryStatusToCssClass(status openapi.WebhookDeliveryStatusV1) string {
+	switch status {
+	case openapi.WebhookDeliveryStatusV1Failed:
+		return "is-danger"
+	case openapi.WebhookDeliveryStatusV1Processed:
+		return "is-success"
+	default:
+		return "is-info"
+	}
+}
+
 // renderUrl takes a [url.URL] and returns its string representation.
 //
 // Only the path and the query parameters are returned.
@@ -123,13 +139,16 @@ func renderMarkdown(input string) template.HTML {
 	return template.HTML(markdown.Render(doc, renderer)) //nolint:gosec // input gets escaped above
 }
 
-func renderTemplate(data any, w http.ResponseWriter, names ...string) {
+func renderTemplate(data any, w http.ResponseWriter, r *http.Request, names ...string) {
 	var namesWithPrefix []string
 	for _, n := range names {
 		namesWithPrefix = append(namesWithPrefix, "templates/"+n)
 	}
 
-	tpl, err := template.Must(templateRoot.Clone()).ParseFS(templateFS, namesWithPrefix...)
+	requestFuncs := template.FuncMap{
+		"currentUser": func() *user { return userFromContext(r.Context()) },
+	}
+	tpl, err := template.Must(templateRoot.Clone()).Funcs(requestFuncs).ParseFS(templateFS, namesWithPrefix...)
 	if err != nil {
 		log.Log().Errorw("Parse templates", zap.Error(err))
 		w.WriteHeader(http.StatusInternalServerError)
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -14,18 +15,57 @@ type Ui struct {
 	API openapi.StrictServerInterface
 }
 
-// RegisterUiRoutes initializes [Ui] using apiServer and registers its handlers with the router.
-func RegisterUiRoutes(router chi.Router, apiServer *api.APIServer) {
-	app := &Ui{API: apiServer}
+// RegisterUiRoutesOptions defines all options expected by [RegisterUiRoutes].
+type RegisterUiRoutesOptions struct {
+	APIServer *api.APIServer
+	// Auth configures the login via OpenID Connect.
+	// Users don't need to log in if nil, but the UI is read-only.
+	Auth   *AuthOptions
+	Router chi.Router
+}
+
+// RegisterUiRoutes initializes [Ui] and registers its handlers with the router.
+// It discovers the OpenID Connect provider if [RegisterUiRoutesOptions.Auth] is set.
+func RegisterUiRoutes(ctx context.Context, opts RegisterUiRoutesOptions) error {
+	var uiAuth *auth
+	if opts.Auth != nil {
+		var err error
+		uiAuth, err = newAuth(ctx, *opts.Auth)
+		if err != nil {
+			return err
+		}
+	}
+
+	app := &Ui{API: opts.APIServer}
+	router := opts.Router
 	router.Handle("/", http.RedirectHandler("/ui", http.StatusMovedPermanently))
-	router.Get("/ui", app.Home)
-	router.Get("/ui/runs", app.RunsIndex)
-	router.Get("/ui/runs/{runId}", app.RunsShow)
-	router.Get("/ui/runs/{runId}/{repositoryName}/error", app.RunsRepositoryErrorShow)
-	router.Get("/ui/tasks", app.TasksIndex)
-	router.Get("/ui/tasks/{name}/file", app.TasksFileShow)
-	router.Get("/ui/tasks/{name}/results", app.ResultsIndex)
-	router.Get("/ui/status", app.StatusIndex)
+	router.Group(func(r chi.Router) {
+		if uiAuth != nil {
+			r.Use(uiAuth.requireLogin)
+			r.Post("/ui/auth/logout", uiAuth.Logout)
+		}
+
+		r.Get("/ui", app.Home)
+		r.Get("/ui/runs", app.RunsIndex)
+		r.Get("/ui/runs/{runId}", app.RunsShow)
+		r.Post("/ui/runs/{runId}/cancel", app.RunsCancel)
+		r.Get("/ui/runs/{runId}/{repositoryName}/error", app.RunsRepositoryErrorShow)
+		r.Get("/ui/runs/{runId}/{repositoryName}/log", app.RunsRepositoryLogShow)
+		r.Get("/ui/tasks", app.TasksIndex)
+		r.Get("/ui/tasks/{name}/file", app.TasksFileShow)
+		r.Get("/ui/tasks/{name}/results", app.ResultsIndex)
+		r.Post("/ui/tasks/{name}/rollout", app.TasksRolloutUpdate)
+		r.Post("/ui/tasks/{name}/runs", app.RunsCreate)
+		r.Get("/ui/tasks/{name}/runs/new", app.RunsNew)
+		r.Get("/ui/status", app.StatusIndex)
+		r.Get("/ui/webhooks", app.WebhooksIndex)
+	})
+	if uiAuth != nil {
+		router.Get("/ui/auth/login", uiAuth.Login)
+		router.Get(pathLoginCallback, uiAuth.Callback)
+		router.Get("/ui/auth/logged-out", uiAuth.LoggedOut)
+	}
+
 	router.Group(func(r chi.Router) {
 		r.Use(
 			// Strip the prefix "/ui" from request path
@@ -36,4 +76,5 @@ func RegisterUiRoutes(router chi.Router, apiServer *api.APIServer) {
 		)
 		r.Handle("/ui/assets/*", http.FileServerFS(assetsFS))
 	})
+	return nil
 }
@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"net/http"
+
+	"github.com/wndhydrnt/saturn-bot/pkg/ptr"
+	"github.com/wndhydrnt/saturn-bot/pkg/server/api/openapi"
+)
+
+type dataWebhooksIndex struct {
+	Deliveries []openapi.WebhookDeliveryV1
+	Filters    dataWebhooksIndexFilters
+	Pagination pagination
+}
+
+type dataWebhooksIndexFilters struct {
+	StatusCurrent string
+	StatusList    []string
+}
+
+var webhookDeliveryStatusOptions = []string{
+	string(openapi.WebhookDeliveryStatusV1Failed),
+	string(openapi.WebhookDeliveryStatusV1Pending),
+	string(openapi.WebhookDeliveryStatusV1Processed),
+}
+
+// WebhooksIndex renders the list of received webhooks.
+func (u *Ui) WebhooksIndex(w http.ResponseWriter, r *http.Request) {
+	queryStatus := r.URL.Query().Get("status")
+	tplData := dataWebhooksIndex{
+		Filters: dataWebhooksIndexFilters{
+			StatusCurrent: queryStatus,
+			StatusList:    webhookDeliveryStatusOptions,
+		},
+	}
+
+	req := openapi.ListWebhookDeliveriesV1RequestObject{
+		Params: openapi.ListWebhookDeliveriesV1Params{
+			ListOptions: &openapi.ListOptions{
+				Limit: parseIntParam(r, "limit", 10),
+				Page:  parseIntParam(r, "page", 1),
+			},
+		},
+	}
+	if queryStatus != "" {
+		req.Params.Status = ptr.To([]openapi.WebhookDeliveryStatusV1{openapi.WebhookDeliveryStatusV1(queryStatus)})
+	}
+
+	resp, err := u.API.ListWebhookDeliveriesV1(r.Context(), req)
+	if err != nil {
+		renderError(err, w, r)
+		return
+	}
+
+	switch payload := resp.(type) {
+	case openapi.ListWebhookDeliveriesV1200JSONResponse:
+		tplData.Pagination = pagination{
+			Page: payload.Page,
+			URL:  r.URL,
+		}
+		tplData.Deliveries = payload.Result
+	}
+
+	renderTemplate(tplData, w, r, "webhooks_index.html")
+}
@@ -0,0 +1,322 @@
+package task
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	htmlTemplate "html/template"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/itchyny/gojq"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/wndhydrnt/saturn-bot/pkg/ptr"
+	"github.com/wndhydrnt/saturn-bot/pkg/task/schema"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// RuleDuration identifies problems with durations, like `autoMergeAfter`.
+	RuleDuration = "duration"
+	// RuleFile identifies files referenced by a task that don't exist.
+	RuleFile = "file"
+	// RuleJq identifies jq expressions that don't compile.
+	RuleJq = "jq"
+	// RuleSchema identifies values that don't match the JSON schema of a task.
+	RuleSchema = "schema"
+	// RuleTask identifies problems found while creating the actions, filters and plugins of a task.
+	RuleTask = "task"
+	// RuleTemplate identifies templates that don't parse.
+	RuleTemplate = "template"
+	// RuleYaml identifies YAML that doesn't decode.
+	RuleYaml = "yaml"
+)
+
+// Problem describes an issue in a task file.
+type Problem struct {
+	// Column of the value in the task file that causes the problem.
+	// 0 if the position is unknown.
+	Column int
+	// File is the path to the task file.
+	File string
+	// Line of the value in the task file that causes the problem.
+	// 0 if the position is unknown.
+	Line int
+	// Message describes the problem.
+	Message string
+	// Rule identifies the kind of problem.
+	Rule string
+	// Severity of the problem.
+	// One of SeverityError or SeverityWarning.
+	Severity string
+	// Task is the name of the task that contains the problem.
+	// Empty if the problem can't be connected to a task.
+	Task string
+}
+
+// Location returns the position of the problem in the format "file:line:column".
+func (p Problem) Location() string {
+	if p.Line == 0 {
+		return p.File
+	}
+
+	return fmt.Sprintf("%s:%d:%d", p.File, p.Line, p.Column)
+}
+
+// CheckFile validates the tasks in the file at path without creating actions or filters and without starting plugins.
+// It detects problems that would otherwise only surface during a run, like a template that doesn't parse.
+// Each problem contains the position of the invalid value in the file.
+//
+// The returned error is not nil if the file can't be read.
+func CheckFile(path string) ([]Problem, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read task file '%s': %w", path, err)
+	}
+
+	var problems []Problem
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	// Decode in a loop to account for multiple documents in one file
+	for {
+		var doc yaml.Node
+		err := dec.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			// The decoder can't continue after a syntax error.
+			problems = append(problems, Problem{
+				File:     path,
+				Message:  fmt.Sprintf("decode task from YAML file: %s", err),
+				Rule:     RuleYaml,
+				Severity: SeverityError,
+			})
+			break
+		}
+
+		c := &checker{path: path, root: &doc}
+		if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+			c.root = doc.Content[0]
+		}
+
+		c.check()
+		problems = append(problems, c.problems...)
+	}
+
+	return problems, nil
+}
+
+type checker struct {
+	path     string
+	problems []Problem
+	root     *yaml.Node
+	task     schema.Task
+}
+
+func (c *checker) check() {
+	if err := c.root.Decode(&c.task); err != nil {
+		c.add(RuleYaml, nil, "decode task from YAML file: %s", err)
+		return
+	}
+
+	c.checkSchema()
+	c.checkTemplate(c.task.BranchName, "branchName")
+	c.checkTemplate(c.task.PrTitle, "prTitle")
+	c.checkTemplate(c.task.PrBody, "prBody")
+	for idx, entry := range c.task.TargetBranches {
+		c.checkTemplate(entry, "targetBranches", strconv.Itoa(idx))
+	}
+
+	if c.task.TargetBranchesQuery != nil {
+		c.checkJq(ptr.From(c.task.TargetBranchesQuery), []string{"targetBranchesQuery"})
+	}
+
+	if c.task.AutoMergeAfter != "" {
+		if _, err := time.ParseDuration(c.task.AutoMergeAfter); err != nil {
+			c.add(RuleDuration, []string{"autoMergeAfter"}, "parse autoMergeAfter: %s", err)
+		}
+	}
+
+	c.checkTrigger()
+	c.checkContentFromFile()
+	c.checkPlugins()
+}
+
+func (c *checker) checkSchema() {
+	err := schema.Validate(&c.task)
+	if err == nil {
+		return
+	}
+
+	validationErr, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		c.add(RuleSchema, nil, "validate task: %s", err)
+		return
+	}
+
+	for _, unit := range validationErr.BasicOutput().Errors {
+		if unit.Error == nil {
+			continue
+		}
+
+		c.add(RuleSchema, parseJsonPointer(unit.InstanceLocation), "%s", unit.Error.String())
+	}
+}
+
+func (c *checker) checkTemplate(value string, path ...string) {
+	if value == "" {
+		return
+	}
+
+	if _, err := htmlTemplate.New("").Parse(value); err != nil {
+		c.add(RuleTemplate, path, "parse template of %s: %s", strings.Join(path, "."), err)
+	}
+}
+
+func (c *checker) checkTrigger() {
+	if c.task.Trigger == nil || c.task.Trigger.Webhook == nil {
+		return
+	}
+
+	for idx, trigger := range c.task.Trigger.Webhook.Generic {
+		c.checkWebhook([]string{"trigger", "webhook", "generic", strconv.Itoa(idx)}, trigger.Filters, nil, trigger.RunData)
+	}
+
+	for idx, trigger := range c.task.Trigger.Webhook.Github {
+		c.checkWebhook([]string{"trigger", "webhook", "github", strconv.Itoa(idx)}, trigger.Filters, trigger.Repositories, trigger.RunData)
+	}
+
+	for idx, trigger := range c.task.Trigger.Webhook.Gitlab {
+		c.checkWebhook([]string{"trigger", "webhook", "gitlab", strconv.Itoa(idx)}, trigger.Filters, trigger.Repositories, trigger.RunData)
+	}
+}
+
+func (c *checker) checkWebhook(path []string, filters []string, repositories *string, runData map[string]string) {
+	for idx, expr := range filters {
+		c.checkJq(expr, append(path, "filters", strconv.Itoa(idx)))
+	}
+
+	if repositories != nil {
+		c.checkJq(ptr.From(repositories), append(path, "repositories"))
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(runData)) {
+		c.checkJq(runData[key], append(path, "runData", key))
+	}
+}
+
+func (c *checker) checkJq(expr string, path []string) {
+	query, err := gojq.Parse(expr)
+	if err != nil {
+		c.add(RuleJq, path, "parse jq expression: %s", err)
+		return
+	}
+
+	if _, err := gojq.Compile(query); err != nil {
+		c.add(RuleJq, path, "compile jq expression: %s", err)
+	}
+}
+
+func (c *checker) checkContentFromFile() {
+	taskDir := filepath.Dir(c.path)
+	for idx, a := range c.task.Actions {
+		value, ok := a.Params["contentFromFile"].(string)
+		if !ok {
+			continue
+		}
+
+		path := []string{"actions", strconv.Itoa(idx), "params", "contentFromFile"}
+		// Same logic as the action that reads the file.
+		filePath := strings.TrimSpace(strings.TrimPrefix(value, "$file:"))
+		abs, err := filepath.Abs(filepath.Join(taskDir, filePath))
+		if err != nil {
+			c.add(RuleFile, path, "get absolute path of %s: %s", filePath, err)
+			continue
+		}
+
+		taskDirAbs, err := filepath.Abs(taskDir)
+		if err == nil && !strings.HasPrefix(abs, taskDirAbs) {
+			c.add(RuleFile, path, "path %s escapes directory of task", filePath)
+			continue
+		}
+
+		if _, err := os.Stat(abs); err != nil {
+			c.add(RuleFile, path, "file of contentFromFile does not exist: %s", filePath)
+		}
+	}
+}
+
+func (c *checker) checkPlugins() {
+	for idx, p := range c.task.Plugins {
+		if _, err := os.Stat(p.PathAbs(c.path)); err != nil {
+			c.add(RuleFile, []string{"plugins", strconv.Itoa(idx), "path"}, "plugin file does not exist: %s", p.Path)
+		}
+	}
+}
+
+func (c *checker) add(rule string, path []string, format string, args ...any) {
+	node := findNode(c.root, path)
+	c.problems = append(c.problems, Problem{
+		Column:   node.Column,
+		File:     c.path,
+		Line:     node.Line,
+		Message:  fmt.Sprintf(format, args...),
+		Rule:     rule,
+		Severity: SeverityError,
+		Task:     c.task.Name,
+	})
+}
+
+// findNode returns the node at path.
+// Each element of path is either the key of a mapping or the index of a sequence.
+// It returns the deepest node that exists if path doesn't exist completely.
+func findNode(node *yaml.Node, path []string) *yaml.Node {
+	for _, elem := range path {
+		var next *yaml.Node
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == elem {
+					next = node.Content[i+1]
+					break
+				}
+			}
+		case yaml.SequenceNode:
+			idx, err := strconv.Atoi(elem)
+			if err == nil && idx >= 0 && idx < len(node.Content) {
+				next = node.Content[idx]
+			}
+		}
+
+		if next == nil {
+			return node
+		}
+
+		node = next
+	}
+
+	return node
+}
+
+// parseJsonPointer splits a JSON pointer, like "/filters/0", into its elements.
+func parseJsonPointer(pointer string) []string {
+	if pointer == "" {
+		return nil
+	}
+
+	var elems []string
+	for _, elem := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		elem = strings.ReplaceAll(elem, "~1", "/")
+		elem = strings.ReplaceAll(elem, "~0", "~")
+		elems = append(elems, elem)
+	}
+
+	return elems
+}
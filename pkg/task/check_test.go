@@ -0,0 +1,83 @@
+package task_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wndhydrnt/saturn-bot/pkg/task"
+)
+
+func TestCheckFile(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+		want    []task.Problem
+	}{
+		{
+			name:    "valid task",
+			content: "name: Valid\nbranchName: \"feat/{{.TaskName}}\"\n",
+		},
+		{
+			name:    "invalid template",
+			content: "name: Unit Test\nprTitle: \"{{ .TaskName\"\n",
+			want: []task.Problem{
+				{Column: 10, Line: 2, Message: "parse template of prTitle: template: :1: unclosed action", Rule: task.RuleTemplate, Severity: task.SeverityError, Task: "Unit Test"},
+			},
+		},
+		{
+			name:    "invalid cron expression",
+			content: "name: Unit Test\ntrigger:\n  cron: abc\n",
+			want: []task.Problem{
+				{Column: 9, Line: 3, Message: "'abc' is not valid cron: unsupported cron expression: abc", Rule: task.RuleSchema, Severity: task.SeverityError, Task: "Unit Test"},
+			},
+		},
+		{
+			name:    "invalid jq expression",
+			content: "name: Unit Test\ntrigger:\n  webhook:\n    gitlab:\n      - runData:\n          ref: \".ref |\"\n",
+			want: []task.Problem{
+				{Column: 16, Line: 6, Message: "parse jq expression: unexpected EOF", Rule: task.RuleJq, Severity: task.SeverityError, Task: "Unit Test"},
+			},
+		},
+		{
+			name:    "invalid autoMergeAfter",
+			content: "name: Unit Test\nautoMergeAfter: 3x\n",
+			want: []task.Problem{
+				{Column: 17, Line: 2, Message: `parse autoMergeAfter: time: unknown unit "x" in duration "3x"`, Rule: task.RuleDuration, Severity: task.SeverityError, Task: "Unit Test"},
+			},
+		},
+		{
+			name:    "missing files",
+			content: "name: Unit Test\nactions:\n  - action: fileCreate\n    params:\n      contentFromFile: missing.txt\n      path: x\nplugins:\n  - path: missing.py\n",
+			want: []task.Problem{
+				{Column: 24, Line: 5, Message: "file of contentFromFile does not exist: missing.txt", Rule: task.RuleFile, Severity: task.SeverityError, Task: "Unit Test"},
+				{Column: 11, Line: 8, Message: "plugin file does not exist: missing.py", Rule: task.RuleFile, Severity: task.SeverityError, Task: "Unit Test"},
+			},
+		},
+		{
+			name:    "second document",
+			content: "name: Valid\n---\nname: Invalid\nactive: abc\n",
+			want: []task.Problem{
+				{Column: 1, Line: 3, Message: "decode task from YAML file: yaml: unmarshal errors:\n  line 4: cannot unmarshal !!str `abc` into bool", Rule: task.RuleYaml, Severity: task.SeverityError},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "task.yaml")
+			err := os.WriteFile(path, []byte(tc.content), 0600)
+			require.NoError(t, err)
+			for idx := range tc.want {
+				tc.want[idx].File = path
+			}
+
+			problems, err := task.CheckFile(path)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, problems)
+		})
+	}
+}
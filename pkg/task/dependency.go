@@ -0,0 +1,122 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/wndhydrnt/saturn-bot/pkg/ptr"
+	"github.com/wndhydrnt/saturn-bot/pkg/template"
+)
+
+// Dependency is a pull request that needs to be merged before a task gets applied to a repository.
+type Dependency struct {
+	// RepositoryName is the full name of the repository that contains the pull request.
+	// Empty if the pull request is part of the repository to which the task gets applied.
+	RepositoryName string
+	// Task is the task that has created the pull request.
+	Task *Task
+}
+
+// Dependencies returns the dependencies of the task.
+func (tw *Task) Dependencies() []Dependency {
+	return tw.dependencies
+}
+
+// HasDependents returns true if another task or a repository of the same task depends on a pull request of tw.
+func HasDependents(tasks []*Task, tw *Task) bool {
+	for _, t := range tasks {
+		for _, dep := range t.dependencies {
+			if dep.Task == tw {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// RenderBranchNameForRepository renders the branch name of the task for the repository identified by repoName.
+// data provides additional values, like the run data.
+// It fills the repository values of data from repoName, like "github.com/org/repo".
+func (tw *Task) RenderBranchNameForRepository(repoName string, data template.Data) (string, error) {
+	data.Repository = template.DataRepository{FullName: repoName}
+	parts := strings.Split(repoName, "/")
+	if len(parts) >= 3 {
+		data.Repository.Host = parts[0]
+		data.Repository.Name = parts[len(parts)-1]
+		data.Repository.Owner = strings.Join(parts[1:len(parts)-1], "/")
+	}
+
+	data.TaskName = tw.Name
+	return tw.RenderBranchName(data)
+}
+
+// resolveDependencies links every dependency of tasks to the task that it references.
+// It returns an error if a dependency references an unknown task
+// or if the dependencies of tasks form a cycle.
+func resolveDependencies(tasks []*Task) error {
+	byName := make(map[string]*Task, len(tasks))
+	for _, t := range tasks {
+		byName[t.Name] = t
+	}
+
+	for _, t := range tasks {
+		t.dependencies = nil
+		for idx, def := range t.DependsOn {
+			dep := Dependency{
+				RepositoryName: ptr.FromDef(def.Repository, ""),
+				Task:           t,
+			}
+			if def.Task != nil && *def.Task != t.Name {
+				var ok bool
+				dep.Task, ok = byName[*def.Task]
+				if !ok {
+					return fmt.Errorf("dependency #%d of task %s references unknown task %s", idx, t.Name, *def.Task)
+				}
+			}
+
+			if dep.Task == t && dep.RepositoryName == "" {
+				return fmt.Errorf("dependency #%d of task %s depends on the task itself - set repository or task", idx, t.Name)
+			}
+
+			t.dependencies = append(t.dependencies, dep)
+		}
+	}
+
+	// Detect cycles between tasks. A task that depends on another repository of itself doesn't form a cycle.
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := make(map[*Task]int, len(tasks))
+	var visit func(t *Task) error
+	visit = func(t *Task) error {
+		switch state[t] {
+		case visiting:
+			return fmt.Errorf("dependencies of task %s form a cycle", t.Name)
+		case visited:
+			return nil
+		}
+
+		state[t] = visiting
+		for _, dep := range t.dependencies {
+			if dep.Task == t {
+				continue
+			}
+
+			if err := visit(dep.Task); err != nil {
+				return err
+			}
+		}
+
+		state[t] = visited
+		return nil
+	}
+	for _, t := range tasks {
+		if err := visit(t); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
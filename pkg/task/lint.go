@@ -0,0 +1,306 @@
+package task
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/adhocore/gronx"
+	"github.com/gosimple/slug"
+	"github.com/wndhydrnt/saturn-bot/pkg/ptr"
+	"github.com/wndhydrnt/saturn-bot/pkg/task/schema"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// LintRuleAutoMergeWithoutChangeLimit reports tasks that merge pull requests automatically
+	// without limiting the number of changes per run.
+	LintRuleAutoMergeWithoutChangeLimit = "auto-merge-without-change-limit"
+	// LintRuleCronBelowCacheTtl reports cron triggers that run a task more often
+	// than the repository cache gets updated.
+	LintRuleCronBelowCacheTtl = "cron-below-cache-ttl"
+	// LintRuleDuplicateBranchName reports tasks that share a branch name.
+	LintRuleDuplicateBranchName = "duplicate-branch-name"
+	// LintRuleNoFilters reports tasks without filters.
+	LintRuleNoFilters = "no-filters"
+	// LintRulePushToDefaultBranchBroadFilters reports tasks that push to the default branch
+	// without selecting repositories by their exact name.
+	LintRulePushToDefaultBranchBroadFilters = "push-to-default-branch-broad-filters"
+
+	SeverityError   = "error"
+	SeverityOff     = "off"
+	SeverityWarning = "warning"
+)
+
+var (
+	lintRules = []string{
+		LintRuleAutoMergeWithoutChangeLimit,
+		LintRuleCronBelowCacheTtl,
+		LintRuleDuplicateBranchName,
+		LintRuleNoFilters,
+		LintRulePushToDefaultBranchBroadFilters,
+	}
+	// suppressionRegex matches a comment that suppresses lint rules,
+	// like "# saturn-bot:ignore no-filters".
+	suppressionRegex = regexp.MustCompile(`#\s*saturn-bot:ignore(\s+[a-z0-9,\s-]+)?\s*$`)
+)
+
+// LintRules returns the identifiers of all lint rules.
+func LintRules() []string {
+	return slices.Clone(lintRules)
+}
+
+// LintOptions configures Lint.
+type LintOptions struct {
+	// RepositoryCacheTtl is the time-to-live of the repository cache.
+	// Lint skips rule LintRuleCronBelowCacheTtl if it is 0.
+	RepositoryCacheTtl time.Duration
+	// Severities changes the severity of rules.
+	// Key is the identifier of a rule.
+	// Value is one of SeverityError, SeverityOff or SeverityWarning.
+	// The severity of a rule defaults to SeverityWarning.
+	Severities map[string]string
+}
+
+// Lint checks the tasks in files for settings that are valid, but likely have unintended consequences.
+// It expects that CheckFile has found no problems in files.
+//
+// A comment "# saturn-bot:ignore <rule>[,<rule>]" suppresses the rules for the line of the comment and the line after it.
+// The comment suppresses all rules if it doesn't list any rule.
+func Lint(files []string, opts LintOptions) ([]Problem, error) {
+	severities := map[string]string{}
+	for _, rule := range lintRules {
+		severities[rule] = SeverityWarning
+	}
+
+	for rule, severity := range opts.Severities {
+		if !slices.Contains(lintRules, rule) {
+			return nil, fmt.Errorf("unknown lint rule %s", rule)
+		}
+
+		switch severity {
+		case SeverityError, SeverityOff, SeverityWarning:
+			severities[rule] = severity
+		default:
+			return nil, fmt.Errorf("unsupported severity %s of lint rule %s - one of error, off or warning", severity, rule)
+		}
+	}
+
+	l := &linter{
+		branchNames:        map[string][]lintBranchName{},
+		repositoryCacheTtl: opts.RepositoryCacheTtl,
+	}
+	suppressions := map[string]map[int][]string{}
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read task file '%s': %w", file, err)
+		}
+
+		suppressions[file] = parseSuppressions(b)
+		dec := yaml.NewDecoder(bytes.NewReader(b))
+		for {
+			var doc yaml.Node
+			err := dec.Decode(&doc)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			if err != nil {
+				return nil, fmt.Errorf("decode task from YAML file %s: %w", file, err)
+			}
+
+			c := &checker{path: file, root: &doc}
+			if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+				c.root = doc.Content[0]
+			}
+
+			if err := c.root.Decode(&c.task); err != nil {
+				return nil, fmt.Errorf("decode task from YAML file %s: %w", file, err)
+			}
+
+			// Deactivated tasks don't run.
+			if !c.task.Active {
+				continue
+			}
+
+			l.lint(c)
+		}
+	}
+
+	l.lintBranchNames()
+	var problems []Problem
+	for _, problem := range l.problems {
+		severity := severities[problem.Rule]
+		if severity == SeverityOff || isSuppressed(suppressions[problem.File], problem) {
+			continue
+		}
+
+		problem.Severity = severity
+		problems = append(problems, problem)
+	}
+
+	return problems, nil
+}
+
+type lintBranchName struct {
+	checker *checker
+	path    []string
+}
+
+type linter struct {
+	// branchNames maps a branch name to all tasks that use it.
+	branchNames        map[string][]lintBranchName
+	problems           []Problem
+	repositoryCacheTtl time.Duration
+}
+
+func (l *linter) lint(c *checker) {
+	if len(c.task.Filters) == 0 && len(c.task.Plugins) == 0 {
+		c.add(LintRuleNoFilters, nil, "task has no filters and modifies every repository")
+	}
+
+	if c.task.AutoMerge && c.task.ChangeLimit == 0 {
+		c.add(LintRuleAutoMergeWithoutChangeLimit, []string{"autoMerge"}, "autoMerge is enabled without changeLimit - the task can merge changes into all matching repositories in one run")
+	}
+
+	if c.task.PushToDefaultBranch && !hasExactRepositoryFilter(c.task.Filters) {
+		c.add(LintRulePushToDefaultBranchBroadFilters, []string{"pushToDefaultBranch"}, "pushToDefaultBranch is enabled without a filter that selects repositories by exact owner and name - changes get pushed to every matching repository without review")
+	}
+
+	l.lintCron(c)
+	l.problems = append(l.problems, c.problems...)
+	c.problems = nil
+
+	switch {
+	case c.task.BranchName == "":
+		name := "saturn-bot--" + slug.Make(c.task.Name)
+		l.branchNames[name] = append(l.branchNames[name], lintBranchName{checker: c, path: []string{"name"}})
+	case !strings.Contains(c.task.BranchName, "{{"):
+		// Skip templates because the name of the branch can differ per repository.
+		l.branchNames[c.task.BranchName] = append(l.branchNames[c.task.BranchName], lintBranchName{checker: c, path: []string{"branchName"}})
+	}
+}
+
+func (l *linter) lintCron(c *checker) {
+	if l.repositoryCacheTtl == 0 || c.task.Trigger == nil || c.task.Trigger.Cron == nil {
+		return
+	}
+
+	expr := ptr.From(c.task.Trigger.Cron)
+	// Calculate the shortest interval between ticks from a fixed point in time.
+	// Keeps the result independent of the current time.
+	tick := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	var interval time.Duration
+	for range 10 {
+		next, err := gronx.NextTickAfter(expr, tick, false)
+		if err != nil {
+			return
+		}
+
+		diff := next.Sub(tick)
+		if interval == 0 || diff < interval {
+			interval = diff
+		}
+
+		tick = next
+	}
+
+	if interval < l.repositoryCacheTtl {
+		c.add(LintRuleCronBelowCacheTtl, []string{"trigger", "cron"}, "cron expression runs the task every %s, which is more often than the repository cache TTL of %s - new repositories are only discovered after the cache has expired", interval, l.repositoryCacheTtl)
+	}
+}
+
+func (l *linter) lintBranchNames() {
+	for name, entries := range l.branchNames {
+		if len(entries) < 2 {
+			continue
+		}
+
+		for _, entry := range entries {
+			var others []string
+			for _, other := range entries {
+				if other.checker != entry.checker {
+					others = append(others, fmt.Sprintf("%s (%s)", other.checker.task.Name, other.checker.path))
+				}
+			}
+
+			entry.checker.add(LintRuleDuplicateBranchName, entry.path, "branch %s is also used by %s - the tasks modify the same pull request", name, strings.Join(others, ", "))
+			l.problems = append(l.problems, entry.checker.problems...)
+			entry.checker.problems = nil
+		}
+	}
+
+	// Iterating over a map is random.
+	slices.SortStableFunc(l.problems, func(a, b Problem) int {
+		if a.File != b.File {
+			return strings.Compare(a.File, b.File)
+		}
+
+		return a.Line - b.Line
+	})
+}
+
+// hasExactRepositoryFilter returns true if filters contain a filter "repository"
+// that matches one repository.
+func hasExactRepositoryFilter(filters []schema.Filter) bool {
+	for _, f := range filters {
+		if f.Filter != "repository" || f.Reverse {
+			continue
+		}
+
+		owner, ownerOk := f.Params["owner"].(string)
+		name, nameOk := f.Params["name"].(string)
+		if ownerOk && nameOk && regexp.QuoteMeta(owner) == owner && regexp.QuoteMeta(name) == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseSuppressions maps the number of a line to the rules that a comment suppresses in that line.
+// "*" means all rules.
+func parseSuppressions(b []byte) map[int][]string {
+	suppressions := map[int][]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		match := suppressionRegex.FindStringSubmatch(scanner.Text())
+		if match == nil {
+			continue
+		}
+
+		rules := strings.FieldsFunc(match[1], func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		})
+		if len(rules) == 0 {
+			rules = []string{"*"}
+		}
+
+		suppressions[lineNumber] = rules
+	}
+
+	return suppressions
+}
+
+// isSuppressed returns true if a comment in the line of the problem or in the line before it
+// suppresses the rule of the problem.
+func isSuppressed(suppressions map[int][]string, problem Problem) bool {
+	for _, line := range []int{problem.Line, problem.Line - 1} {
+		rules := suppressions[line]
+		if slices.Contains(rules, "*") || slices.Contains(rules, problem.Rule) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,124 @@
+package task_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wndhydrnt/saturn-bot/pkg/task"
+)
+
+const lintTaskFilters = `filters:
+  - filter: repository
+    params:
+      host: git.local
+      owner: unit
+      name: test
+`
+
+func TestLint(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+		opts    task.LintOptions
+		want    []task.Problem
+	}{
+		{
+			name:    "no problems",
+			content: "name: Unit Test\nautoMerge: true\nchangeLimit: 5\n" + lintTaskFilters,
+		},
+		{
+			name:    "push to default branch with broad filters",
+			content: "name: Unit Test\npushToDefaultBranch: true\nfilters:\n  - filter: repository\n    params:\n      host: git.local\n      owner: unit\n      name: \".*\"\n",
+			want: []task.Problem{
+				{Column: 22, Line: 2, Message: "pushToDefaultBranch is enabled without a filter that selects repositories by exact owner and name - changes get pushed to every matching repository without review", Rule: task.LintRulePushToDefaultBranchBroadFilters, Severity: task.SeverityWarning, Task: "Unit Test"},
+			},
+		},
+		{
+			name:    "push to default branch with exact filter",
+			content: "name: Unit Test\npushToDefaultBranch: true\n" + lintTaskFilters,
+		},
+		{
+			name:    "cron more frequent than cache TTL",
+			content: "name: Unit Test\ntrigger:\n  cron: \"0 * * * *\"\n" + lintTaskFilters,
+			opts:    task.LintOptions{RepositoryCacheTtl: 6 * time.Hour},
+			want: []task.Problem{
+				{Column: 9, Line: 3, Message: "cron expression runs the task every 1h0m0s, which is more often than the repository cache TTL of 6h0m0s - new repositories are only discovered after the cache has expired", Rule: task.LintRuleCronBelowCacheTtl, Severity: task.SeverityWarning, Task: "Unit Test"},
+			},
+		},
+		{
+			name:    "cron less frequent than cache TTL",
+			content: "name: Unit Test\ntrigger:\n  cron: \"0 0 * * *\"\n" + lintTaskFilters,
+			opts:    task.LintOptions{RepositoryCacheTtl: 6 * time.Hour},
+		},
+		{
+			name:    "severity error",
+			content: "name: Unit Test\n",
+			opts:    task.LintOptions{Severities: map[string]string{task.LintRuleNoFilters: task.SeverityError}},
+			want: []task.Problem{
+				{Column: 1, Line: 1, Message: "task has no filters and modifies every repository", Rule: task.LintRuleNoFilters, Severity: task.SeverityError, Task: "Unit Test"},
+			},
+		},
+		{
+			name:    "severity off",
+			content: "name: Unit Test\n",
+			opts:    task.LintOptions{Severities: map[string]string{task.LintRuleNoFilters: task.SeverityOff}},
+		},
+		{
+			name:    "suppression of other rule",
+			content: "name: Unit Test # saturn-bot:ignore duplicate-branch-name\n",
+			want: []task.Problem{
+				{Column: 1, Line: 1, Message: "task has no filters and modifies every repository", Rule: task.LintRuleNoFilters, Severity: task.SeverityWarning, Task: "Unit Test"},
+			},
+		},
+		{
+			name:    "deactivated task",
+			content: "name: Unit Test\nactive: false\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "task.yaml")
+			err := os.WriteFile(path, []byte(tc.content), 0600)
+			require.NoError(t, err)
+			for idx := range tc.want {
+				tc.want[idx].File = path
+			}
+
+			problems, err := task.Lint([]string{path}, tc.opts)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, problems)
+		})
+	}
+}
+
+func TestLint_DuplicateBranchNameAcrossFiles(t *testing.T) {
+	dir := t.TempDir()
+	fileOne := filepath.Join(dir, "one.yaml")
+	err := os.WriteFile(fileOne, []byte("name: Unit Test\n"+lintTaskFilters), 0600)
+	require.NoError(t, err)
+	fileTwo := filepath.Join(dir, "two.yaml")
+	err = os.WriteFile(fileTwo, []byte("name: Other\nbranchName: saturn-bot--unit-test\n"+lintTaskFilters), 0600)
+	require.NoError(t, err)
+
+	problems, err := task.Lint([]string{fileOne, fileTwo}, task.LintOptions{})
+
+	require.NoError(t, err)
+	assert.Equal(t, []task.Problem{
+		{Column: 7, File: fileOne, Line: 1, Message: "branch saturn-bot--unit-test is also used by Other (" + fileTwo + ") - the tasks modify the same pull request", Rule: task.LintRuleDuplicateBranchName, Severity: task.SeverityWarning, Task: "Unit Test"},
+		{Column: 13, File: fileTwo, Line: 2, Message: "branch saturn-bot--unit-test is also used by Unit Test (" + fileOne + ") - the tasks modify the same pull request", Rule: task.LintRuleDuplicateBranchName, Severity: task.SeverityWarning, Task: "Other"},
+	}, problems)
+}
+
+func TestLint_InvalidSeverities(t *testing.T) {
+	_, err := task.Lint(nil, task.LintOptions{Severities: map[string]string{"unknown": task.SeverityError}})
+	require.EqualError(t, err, "unknown lint rule unknown")
+
+	_, err = task.Lint(nil, task.LintOptions{Severities: map[string]string{task.LintRuleNoFilters: "info"}})
+	require.EqualError(t, err, "unsupported severity info of lint rule no-filters - one of error, off or warning")
+}
@@ -0,0 +1,45 @@
+package task
+
+import (
+	"github.com/wndhydrnt/saturn-bot/pkg/host"
+	"github.com/wndhydrnt/saturn-bot/pkg/ptr"
+)
+
+// GithubPullRequestData returns the settings of field `prMetadata.github` of the task.
+func (tw *Task) GithubPullRequestData() host.GithubPullRequestData {
+	if tw.PrMetadata == nil || tw.PrMetadata.Github == nil {
+		return host.GithubPullRequestData{}
+	}
+
+	def := tw.PrMetadata.Github
+	data := host.GithubPullRequestData{Milestone: ptr.FromDef(def.Milestone, "")}
+	for _, project := range def.Projects {
+		data.Projects = append(data.Projects, host.GithubProject{Number: project.Number, Owner: project.Owner})
+	}
+
+	return data
+}
+
+// GitlabPullRequestData returns the settings of field `prMetadata.gitlab` of the task.
+func (tw *Task) GitlabPullRequestData() host.GitlabPullRequestData {
+	if tw.PrMetadata == nil || tw.PrMetadata.Gitlab == nil {
+		return host.GitlabPullRequestData{}
+	}
+
+	def := tw.PrMetadata.Gitlab
+	data := host.GitlabPullRequestData{
+		Milestone:          ptr.FromDef(def.Milestone, ""),
+		RemoveSourceBranch: def.RemoveSourceBranch,
+		Squash:             def.Squash,
+	}
+	for _, rule := range def.ApprovalRules {
+		data.ApprovalRules = append(data.ApprovalRules, host.GitlabApprovalRule{
+			ApprovalsRequired: rule.ApprovalsRequired,
+			Groups:            rule.Groups,
+			Name:              rule.Name,
+			Usernames:         rule.Usernames,
+		})
+	}
+
+	return data
+}
@@ -0,0 +1,127 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"time"
+
+	sbcontext "github.com/wndhydrnt/saturn-bot/pkg/context"
+	"github.com/wndhydrnt/saturn-bot/pkg/filter"
+	"github.com/wndhydrnt/saturn-bot/pkg/host"
+	"github.com/wndhydrnt/saturn-bot/pkg/options"
+	"github.com/wndhydrnt/saturn-bot/pkg/ptr"
+	"github.com/wndhydrnt/saturn-bot/pkg/task/schema"
+)
+
+// rolloutWave is a wave of [schema.TaskRollout] with its filters initialized.
+type rolloutWave struct {
+	filters    []filter.Filter
+	percentage int
+	soakTime   time.Duration
+}
+
+func createRolloutWaves(rollout *schema.TaskRollout, factories options.FilterFactories, hosts []host.Host) ([]rolloutWave, error) {
+	if rollout == nil {
+		return nil, nil
+	}
+
+	var waves []rolloutWave
+	for idx, def := range rollout.Waves {
+		preClone, postClone, err := createFiltersForTask(def.Filters, factories, hosts)
+		if err != nil {
+			return nil, fmt.Errorf("parse filters of rollout wave %s: %w", def.Name, err)
+		}
+
+		// The processor decides if a repository is part of a wave before it clones the repository.
+		if len(postClone) > 0 {
+			return nil, fmt.Errorf("filters of rollout wave %s require a clone of the repository", def.Name)
+		}
+
+		wave := rolloutWave{
+			filters:    preClone,
+			percentage: ptr.FromDef(def.Percentage, 0),
+		}
+		if def.Gate == nil {
+			if idx < len(rollout.Waves)-1 {
+				return nil, fmt.Errorf("rollout wave %s does not define a gate", def.Name)
+			}
+		} else if def.Gate.SoakTime != "" {
+			wave.soakTime, err = time.ParseDuration(def.Gate.SoakTime)
+			if err != nil {
+				return nil, fmt.Errorf("parse soakTime of rollout wave %s: %w", def.Name, err)
+			}
+		}
+
+		waves = append(waves, wave)
+	}
+
+	return waves, nil
+}
+
+// RolloutWave returns the index of the first wave of the rollout that selects repoName.
+// ctx needs to contain the repository because filters of a wave read it from ctx.
+// It returns -1 if no wave selects the repository.
+func (tw *Task) RolloutWave(ctx context.Context, repoName string) (int, error) {
+	bucket := rolloutBucket(repoName)
+	for idx, wave := range tw.rolloutWaves {
+		if wave.percentage > 0 && bucket >= wave.percentage {
+			continue
+		}
+
+		match := true
+		for _, f := range wave.filters {
+			ok, err := f.Do(ctx)
+			if err != nil {
+				return -1, fmt.Errorf("filter %s of rollout wave %s failed: %w", f.String(), tw.Rollout.Waves[idx].Name, err)
+			}
+
+			if !ok {
+				match = false
+				break
+			}
+		}
+
+		if match {
+			return idx, nil
+		}
+	}
+
+	return -1, nil
+}
+
+// CurrentRolloutWave returns the index of the last wave of the rollout that has started.
+// The server sets the index in the run data.
+// All waves have started if the run data doesn't contain the index,
+// for example because a user executes the task via the command `run`.
+func (tw *Task) CurrentRolloutWave() (int, error) {
+	value, ok := tw.RunData()[sbcontext.RunDataKeyRolloutWave]
+	if !ok {
+		return len(tw.rolloutWaves) - 1, nil
+	}
+
+	wave, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("parse %s of run data: %w", sbcontext.RunDataKeyRolloutWave, err)
+	}
+
+	return wave, nil
+}
+
+// RolloutSoakTime returns the soak time of the gate of the wave at index idx.
+func (tw *Task) RolloutSoakTime(idx int) time.Duration {
+	if idx < 0 || idx >= len(tw.rolloutWaves) {
+		return 0
+	}
+
+	return tw.rolloutWaves[idx].soakTime
+}
+
+// rolloutBucket assigns repoName to one of 100 buckets.
+// The bucket of a repository never changes to keep it in the same wave.
+func rolloutBucket(repoName string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(repoName))
+	return int(h.Sum32() % 100)
+}
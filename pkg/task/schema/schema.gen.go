@@ -5,6 +5,7 @@ package schema
 import "encoding/json"
 import "fmt"
 import yaml "gopkg.in/yaml.v3"
+import "reflect"
 
 // An action tells saturn-bot how to modify a repository.
 type Action struct {
@@ -51,6 +52,18 @@ func (j *Action) UnmarshalYAML(value *yaml.Node) error {
 	return nil
 }
 
+// A pull request that needs to be merged first. Set `repository`, `task` or both.
+type Dependency struct {
+	// Full name of the repository that contains the pull request, like
+	// `github.com/org/library`. Defaults to the repository to which saturn-bot
+	// applies the task.
+	Repository *string `json:"repository,omitempty" yaml:"repository,omitempty" mapstructure:"repository,omitempty"`
+
+	// Name of the task that has created the pull request. Defaults to the task
+	// itself.
+	Task *string `json:"task,omitempty" yaml:"task,omitempty" mapstructure:"task,omitempty"`
+}
+
 type Filter struct {
 	// Identifier of the filter.
 	Filter string `json:"filter" yaml:"filter" mapstructure:"filter"`
@@ -104,6 +117,117 @@ func (j *Filter) UnmarshalYAML(value *yaml.Node) error {
 	return nil
 }
 
+type GenericTrigger struct {
+	// Event of the webhook, read from the event header configured for the source. If
+	// unset, webhooks of all events match.
+	Event *string `json:"event,omitempty" yaml:"event,omitempty" mapstructure:"event,omitempty"`
+
+	// jq expressions to apply to the body of the webhook. If all expressions match
+	// the content of the webhook then a new run of the task is scheduled.
+	Filters []string `json:"filters,omitempty" yaml:"filters,omitempty" mapstructure:"filters,omitempty"`
+
+	// Key/value pairs to extract run data from the webhook payload. Key is the key to
+	// set in the run data and value is a jq expression.
+	RunData map[string]string `json:"runData,omitempty" yaml:"runData,omitempty" mapstructure:"runData,omitempty"`
+
+	// Name of the source that sends the webhook. The server configures sources in
+	// `serverWebhookGenericSources`.
+	Source string `json:"source" yaml:"source" mapstructure:"source"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *GenericTrigger) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["source"]; raw != nil && !ok {
+		return fmt.Errorf("field source in GenericTrigger: required")
+	}
+	type Plain GenericTrigger
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = GenericTrigger(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *GenericTrigger) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["source"]; raw != nil && !ok {
+		return fmt.Errorf("field source in GenericTrigger: required")
+	}
+	type Plain GenericTrigger
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = GenericTrigger(plain)
+	return nil
+}
+
+// A project on GitHub.
+type GithubProject struct {
+	// Number of the project, as shown in its URL.
+	Number int `json:"number" yaml:"number" mapstructure:"number"`
+
+	// Login of the organization or the user that owns the project.
+	Owner string `json:"owner" yaml:"owner" mapstructure:"owner"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *GithubProject) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["number"]; raw != nil && !ok {
+		return fmt.Errorf("field number in GithubProject: required")
+	}
+	if _, ok := raw["owner"]; raw != nil && !ok {
+		return fmt.Errorf("field owner in GithubProject: required")
+	}
+	type Plain GithubProject
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if 1 > plain.Number {
+		return fmt.Errorf("field %s: must be >= %v", "number", 1)
+	}
+	*j = GithubProject(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *GithubProject) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["number"]; raw != nil && !ok {
+		return fmt.Errorf("field number in GithubProject: required")
+	}
+	if _, ok := raw["owner"]; raw != nil && !ok {
+		return fmt.Errorf("field owner in GithubProject: required")
+	}
+	type Plain GithubProject
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if 1 > plain.Number {
+		return fmt.Errorf("field %s: must be >= %v", "number", 1)
+	}
+	*j = GithubProject(plain)
+	return nil
+}
+
 type GithubTrigger struct {
 	// GitHub webhook event, like push. See
 	// https://docs.github.com/en/webhooks/webhook-events-and-payloads for a list of
@@ -114,11 +238,81 @@ type GithubTrigger struct {
 	// the content of the webhook then a new run of the task is scheduled.
 	Filters []string `json:"filters,omitempty" yaml:"filters,omitempty" mapstructure:"filters,omitempty"`
 
+	// jq expression that extracts the names of repositories from the body of the
+	// webhook, like `.repository.html_url`. The expression returns a string or a list
+	// of strings. If set, the run only processes these repositories instead of all
+	// repositories of the task. The filters of the task still apply to them. No run
+	// gets scheduled if the expression returns no name.
+	Repositories *string `json:"repositories,omitempty" yaml:"repositories,omitempty" mapstructure:"repositories,omitempty"`
+
 	// Key/value pairs to extract run data from the webhook payload. Key is the key to
 	// set in the run data and value is a jq expression.
 	RunData map[string]string `json:"runData,omitempty" yaml:"runData,omitempty" mapstructure:"runData,omitempty"`
 }
 
+// An approval rule of a merge request on GitLab.
+type GitlabApprovalRule struct {
+	// Number of approvals that the rule requires.
+	ApprovalsRequired int `json:"approvalsRequired" yaml:"approvalsRequired" mapstructure:"approvalsRequired"`
+
+	// Full paths of groups whose members can approve, like `org/team`.
+	Groups []string `json:"groups,omitempty" yaml:"groups,omitempty" mapstructure:"groups,omitempty"`
+
+	// Name of the rule.
+	Name string `json:"name" yaml:"name" mapstructure:"name"`
+
+	// Usernames of users who can approve.
+	Usernames []string `json:"usernames,omitempty" yaml:"usernames,omitempty" mapstructure:"usernames,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *GitlabApprovalRule) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["approvalsRequired"]; raw != nil && !ok {
+		return fmt.Errorf("field approvalsRequired in GitlabApprovalRule: required")
+	}
+	if _, ok := raw["name"]; raw != nil && !ok {
+		return fmt.Errorf("field name in GitlabApprovalRule: required")
+	}
+	type Plain GitlabApprovalRule
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if 0 > plain.ApprovalsRequired {
+		return fmt.Errorf("field %s: must be >= %v", "approvalsRequired", 0)
+	}
+	*j = GitlabApprovalRule(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *GitlabApprovalRule) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["approvalsRequired"]; raw != nil && !ok {
+		return fmt.Errorf("field approvalsRequired in GitlabApprovalRule: required")
+	}
+	if _, ok := raw["name"]; raw != nil && !ok {
+		return fmt.Errorf("field name in GitlabApprovalRule: required")
+	}
+	type Plain GitlabApprovalRule
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if 0 > plain.ApprovalsRequired {
+		return fmt.Errorf("field %s: must be >= %v", "approvalsRequired", 0)
+	}
+	*j = GitlabApprovalRule(plain)
+	return nil
+}
+
 type GitlabTrigger struct {
 	// GitLab webhook event, like push. See
 	// https://docs.gitlab.com/ee/user/project/integrations/webhook_events.html for a
@@ -129,6 +323,13 @@ type GitlabTrigger struct {
 	// the content of the webhook then a new run of the task is scheduled.
 	Filters []string `json:"filters,omitempty" yaml:"filters,omitempty" mapstructure:"filters,omitempty"`
 
+	// jq expression that extracts the names of repositories from the body of the
+	// webhook, like `.project.web_url`. The expression returns a string or a list of
+	// strings. If set, the run only processes these repositories instead of all
+	// repositories of the task. The filters of the task still apply to them. No run
+	// gets scheduled if the expression returns no name.
+	Repositories *string `json:"repositories,omitempty" yaml:"repositories,omitempty" mapstructure:"repositories,omitempty"`
+
 	// Key/value pairs to extract run data from the webhook payload. Key is the key to
 	// set in the run data and value is a jq expression.
 	RunData map[string]string `json:"runData,omitempty" yaml:"runData,omitempty" mapstructure:"runData,omitempty"`
@@ -152,10 +353,10 @@ type Input struct {
 	Validation *string `json:"validation,omitempty" yaml:"validation,omitempty" mapstructure:"validation,omitempty"`
 }
 
-// UnmarshalYAML implements yaml.Unmarshaler.
-func (j *Input) UnmarshalYAML(value *yaml.Node) error {
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Input) UnmarshalJSON(value []byte) error {
 	var raw map[string]interface{}
-	if err := value.Decode(&raw); err != nil {
+	if err := json.Unmarshal(value, &raw); err != nil {
 		return err
 	}
 	if _, ok := raw["name"]; raw != nil && !ok {
@@ -163,17 +364,17 @@ func (j *Input) UnmarshalYAML(value *yaml.Node) error {
 	}
 	type Plain Input
 	var plain Plain
-	if err := value.Decode(&plain); err != nil {
+	if err := json.Unmarshal(value, &plain); err != nil {
 		return err
 	}
 	*j = Input(plain)
 	return nil
 }
 
-// UnmarshalJSON implements json.Unmarshaler.
-func (j *Input) UnmarshalJSON(value []byte) error {
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Input) UnmarshalYAML(value *yaml.Node) error {
 	var raw map[string]interface{}
-	if err := json.Unmarshal(value, &raw); err != nil {
+	if err := value.Decode(&raw); err != nil {
 		return err
 	}
 	if _, ok := raw["name"]; raw != nil && !ok {
@@ -181,7 +382,7 @@ func (j *Input) UnmarshalJSON(value []byte) error {
 	}
 	type Plain Input
 	var plain Plain
-	if err := json.Unmarshal(value, &plain); err != nil {
+	if err := value.Decode(&plain); err != nil {
 		return err
 	}
 	*j = Input(plain)
@@ -235,6 +436,134 @@ func (j *Plugin) UnmarshalYAML(value *yaml.Node) error {
 	return nil
 }
 
+type RolloutWave struct {
+	// Select the repositories of the wave. Only filters that don't require a clone of
+	// a repository are supported. The wave selects all remaining repositories if
+	// neither `filters` nor `percentage` are set.
+	Filters []Filter `json:"filters,omitempty" yaml:"filters,omitempty" mapstructure:"filters,omitempty"`
+
+	// Conditions that need to be met before the next wave starts. Required by all
+	// waves except the last one.
+	Gate *RolloutWaveGate `json:"gate,omitempty" yaml:"gate,omitempty" mapstructure:"gate,omitempty"`
+
+	// Name of the wave. Displayed in the UI.
+	Name string `json:"name" yaml:"name" mapstructure:"name"`
+
+	// Select a share of all repositories. The share is cumulative: a wave with
+	// `percentage: 50` after a wave with `percentage: 10` adds another 40% of
+	// repositories. saturn-bot assigns repositories to shares by the hash of their
+	// name.
+	Percentage *int `json:"percentage,omitempty" yaml:"percentage,omitempty" mapstructure:"percentage,omitempty"`
+}
+
+// Conditions that need to be met before the next wave starts. Required by all
+// waves except the last one.
+type RolloutWaveGate struct {
+	// Number of pull requests of the wave that need to be merged.
+	MinMerged int `json:"minMerged,omitempty" yaml:"minMerged,omitempty" mapstructure:"minMerged,omitempty"`
+
+	// Duration to wait after `minMerged` pull requests have been merged, or after the
+	// wave has started if `minMerged` is `0`. Format is a Go duration, like `24h` or
+	// `30m`.
+	SoakTime string `json:"soakTime,omitempty" yaml:"soakTime,omitempty" mapstructure:"soakTime,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *RolloutWaveGate) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	type Plain RolloutWaveGate
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if v, ok := raw["minMerged"]; !ok || v == nil {
+		plain.MinMerged = 0.0
+	}
+	if 0 > plain.MinMerged {
+		return fmt.Errorf("field %s: must be >= %v", "minMerged", 0)
+	}
+	if v, ok := raw["soakTime"]; !ok || v == nil {
+		plain.SoakTime = ""
+	}
+	*j = RolloutWaveGate(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *RolloutWaveGate) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	type Plain RolloutWaveGate
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if v, ok := raw["minMerged"]; !ok || v == nil {
+		plain.MinMerged = 0.0
+	}
+	if 0 > plain.MinMerged {
+		return fmt.Errorf("field %s: must be >= %v", "minMerged", 0)
+	}
+	if v, ok := raw["soakTime"]; !ok || v == nil {
+		plain.SoakTime = ""
+	}
+	*j = RolloutWaveGate(plain)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *RolloutWave) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["name"]; raw != nil && !ok {
+		return fmt.Errorf("field name in RolloutWave: required")
+	}
+	type Plain RolloutWave
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if plain.Percentage != nil && 100 < *plain.Percentage {
+		return fmt.Errorf("field %s: must be <= %v", "percentage", 100)
+	}
+	if plain.Percentage != nil && 1 > *plain.Percentage {
+		return fmt.Errorf("field %s: must be >= %v", "percentage", 1)
+	}
+	*j = RolloutWave(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *RolloutWave) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["name"]; raw != nil && !ok {
+		return fmt.Errorf("field name in RolloutWave: required")
+	}
+	type Plain RolloutWave
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if plain.Percentage != nil && 100 < *plain.Percentage {
+		return fmt.Errorf("field %s: must be <= %v", "percentage", 100)
+	}
+	if plain.Percentage != nil && 1 > *plain.Percentage {
+		return fmt.Errorf("field %s: must be >= %v", "percentage", 1)
+	}
+	*j = RolloutWave(plain)
+	return nil
+}
+
 type Task struct {
 	// List of actions that modify a repository.
 	Actions []Action `json:"actions,omitempty" yaml:"actions,omitempty" mapstructure:"actions,omitempty"`
@@ -259,6 +588,11 @@ type Task struct {
 	// Go duration, like 5m or 1h.
 	AutoMergeAfter string `json:"autoMergeAfter,omitempty" yaml:"autoMergeAfter,omitempty" mapstructure:"autoMergeAfter,omitempty"`
 
+	// If `true`, enable the auto-merge feature of the git host instead of letting
+	// saturn-bot merge the pull request. The git host merges the pull request as soon
+	// as all checks have passed. Only applied if `autoMerge` is `true`.
+	AutoMergeNative bool `json:"autoMergeNative,omitempty" yaml:"autoMergeNative,omitempty" mapstructure:"autoMergeNative,omitempty"`
+
 	// If set, used as the name of the branch to commit changes to. Defaults to an
 	// auto-generated name if not set.
 	BranchName string `json:"branchName,omitempty" yaml:"branchName,omitempty" mapstructure:"branchName,omitempty"`
@@ -268,6 +602,10 @@ type Task struct {
 	// the same time.
 	ChangeLimit int `json:"changeLimit,omitempty" yaml:"changeLimit,omitempty" mapstructure:"changeLimit,omitempty"`
 
+	// Request reviews from the owners of the files that the task changes. saturn-bot
+	// reads the owners from the CODEOWNERS file of the repository.
+	CodeOwners *TaskCodeOwners `json:"codeOwners,omitempty" yaml:"codeOwners,omitempty" mapstructure:"codeOwners,omitempty"`
+
 	// If set, used as the message when changes get committed. Defaults to an
 	// auto-generated message if not set.
 	CommitMessage string `json:"commitMessage,omitempty" yaml:"commitMessage,omitempty" mapstructure:"commitMessage,omitempty"`
@@ -276,6 +614,14 @@ type Task struct {
 	// subsequent run.
 	CreateOnly bool `json:"createOnly,omitempty" yaml:"createOnly,omitempty" mapstructure:"createOnly,omitempty"`
 
+	// Pull requests that need to be merged before saturn-bot applies the task to a
+	// repository. saturn-bot skips a repository until all pull requests have been
+	// merged.
+	DependsOn []Dependency `json:"dependsOn,omitempty" yaml:"dependsOn,omitempty" mapstructure:"dependsOn,omitempty"`
+
+	// If `true`, create the pull request as a draft.
+	Draft bool `json:"draft,omitempty" yaml:"draft,omitempty" mapstructure:"draft,omitempty"`
+
 	// Filters make saturn-bot pick the repositories to which it applies the task.
 	Filters []Filter `json:"filters,omitempty" yaml:"filters,omitempty" mapstructure:"filters,omitempty"`
 
@@ -292,6 +638,10 @@ type Task struct {
 	// feature.
 	MaxOpenPRs int `json:"maxOpenPRs,omitempty" yaml:"maxOpenPRs,omitempty" mapstructure:"maxOpenPRs,omitempty"`
 
+	// Method to merge a pull request with. One of `merge`, `rebase` or `squash`. Lets
+	// the git host decide if not set.
+	MergeMethod TaskMergeMethod `json:"mergeMethod,omitempty" yaml:"mergeMethod,omitempty" mapstructure:"mergeMethod,omitempty"`
+
 	// If `true`, no new pull request is being created if a previous pull request has
 	// been merged for this task.
 	MergeOnce bool `json:"mergeOnce,omitempty" yaml:"mergeOnce,omitempty" mapstructure:"mergeOnce,omitempty"`
@@ -309,9 +659,23 @@ type Task struct {
 	// If set, used as the body of the pull request.
 	PrBody string `json:"prBody,omitempty" yaml:"prBody,omitempty" mapstructure:"prBody,omitempty"`
 
+	// If set, replaces the footer that saturn-bot appends to the body of the pull
+	// request. Overrides the setting `prFooter` of the configuration.
+	PrFooter string `json:"prFooter,omitempty" yaml:"prFooter,omitempty" mapstructure:"prFooter,omitempty"`
+
+	// Settings of the pull request that only a specific host supports. saturn-bot
+	// applies them when it creates the pull request and keeps them in sync on every
+	// update.
+	PrMetadata *TaskPrMetadata `json:"prMetadata,omitempty" yaml:"prMetadata,omitempty" mapstructure:"prMetadata,omitempty"`
+
 	// If set, used as the title of the pull request.
 	PrTitle string `json:"prTitle,omitempty" yaml:"prTitle,omitempty" mapstructure:"prTitle,omitempty"`
 
+	// If `true`, mark a draft pull request as ready for review once all checks have
+	// passed. saturn-bot adds assignees and reviewers to the pull request at that
+	// point. Only applied if `draft` is `true`.
+	PromoteDraftWhenGreen bool `json:"promoteDraftWhenGreen,omitempty" yaml:"promoteDraftWhenGreen,omitempty" mapstructure:"promoteDraftWhenGreen,omitempty"`
+
 	// If `true`, push changes directly to the default branch, like "main". If
 	// `false`, create a pull request to submit changes.
 	PushToDefaultBranch bool `json:"pushToDefaultBranch,omitempty" yaml:"pushToDefaultBranch,omitempty" mapstructure:"pushToDefaultBranch,omitempty"`
@@ -319,10 +683,290 @@ type Task struct {
 	// A list of usernames to set as reviewers of the pull request.
 	Reviewers []string `json:"reviewers,omitempty" yaml:"reviewers,omitempty" mapstructure:"reviewers,omitempty"`
 
+	// Roll out the task in waves. saturn-bot applies the task only to repositories
+	// that are part of waves that have started. It starts the next wave once the gate
+	// of the current wave has passed. Only relevant in server mode.
+	Rollout *TaskRollout `json:"rollout,omitempty" yaml:"rollout,omitempty" mapstructure:"rollout,omitempty"`
+
+	// Steps to apply to a pull request that stays open for a long time. Each step is
+	// optional and runs at most once per pull request. The time of a step counts from
+	// the creation of the pull request.
+	StalePolicy *TaskStalePolicy `json:"stalePolicy,omitempty" yaml:"stalePolicy,omitempty" mapstructure:"stalePolicy,omitempty"`
+
+	// Branches that pull requests of the task target. Each entry is the name of a
+	// branch, a glob pattern like `release/*` or a template that renders a name or
+	// glob pattern per repository. The task creates one pull request per matching
+	// branch. Targets the default branch of a repository if not set.
+	TargetBranches []string `json:"targetBranches,omitempty" yaml:"targetBranches,omitempty" mapstructure:"targetBranches,omitempty"`
+
+	// jq expression that selects the branches that pull requests of the task target.
+	// Evaluated per repository. Outputs the name of each target branch as a string.
+	// Complements `targetBranches`.
+	TargetBranchesQuery *string `json:"targetBranchesQuery,omitempty" yaml:"targetBranchesQuery,omitempty" mapstructure:"targetBranchesQuery,omitempty"`
+
 	// Define when the task gets executed. Only relevant in server mode.
 	Trigger *TaskTrigger `json:"trigger,omitempty" yaml:"trigger,omitempty" mapstructure:"trigger,omitempty"`
 }
 
+// Request reviews from the owners of the files that the task changes. saturn-bot
+// reads the owners from the CODEOWNERS file of the repository.
+type TaskCodeOwners struct {
+	// Usernames to request a review from if the CODEOWNERS file doesn't exist or
+	// doesn't define owners of the changed files.
+	Fallback []string `json:"fallback,omitempty" yaml:"fallback,omitempty" mapstructure:"fallback,omitempty"`
+
+	// Maximum number of code owners to request a review from. Owners of more changed
+	// files take precedence. No limit if `0`.
+	MaxReviewers int `json:"maxReviewers,omitempty" yaml:"maxReviewers,omitempty" mapstructure:"maxReviewers,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *TaskCodeOwners) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	type Plain TaskCodeOwners
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if v, ok := raw["maxReviewers"]; !ok || v == nil {
+		plain.MaxReviewers = 0.0
+	}
+	if 0 > plain.MaxReviewers {
+		return fmt.Errorf("field %s: must be >= %v", "maxReviewers", 0)
+	}
+	*j = TaskCodeOwners(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *TaskCodeOwners) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	type Plain TaskCodeOwners
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if v, ok := raw["maxReviewers"]; !ok || v == nil {
+		plain.MaxReviewers = 0.0
+	}
+	if 0 > plain.MaxReviewers {
+		return fmt.Errorf("field %s: must be >= %v", "maxReviewers", 0)
+	}
+	*j = TaskCodeOwners(plain)
+	return nil
+}
+
+type TaskMergeMethod string
+
+const TaskMergeMethodBlank TaskMergeMethod = ""
+const TaskMergeMethodMerge TaskMergeMethod = "merge"
+const TaskMergeMethodRebase TaskMergeMethod = "rebase"
+const TaskMergeMethodSquash TaskMergeMethod = "squash"
+
+var enumValues_TaskMergeMethod = []interface{}{
+	"",
+	"merge",
+	"rebase",
+	"squash",
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *TaskMergeMethod) UnmarshalYAML(value *yaml.Node) error {
+	var v string
+	if err := value.Decode(&v); err != nil {
+		return err
+	}
+	var ok bool
+	for _, expected := range enumValues_TaskMergeMethod {
+		if reflect.DeepEqual(v, expected) {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return fmt.Errorf("invalid value (expected one of %#v): %#v", enumValues_TaskMergeMethod, v)
+	}
+	*j = TaskMergeMethod(v)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *TaskMergeMethod) UnmarshalJSON(value []byte) error {
+	var v string
+	if err := json.Unmarshal(value, &v); err != nil {
+		return err
+	}
+	var ok bool
+	for _, expected := range enumValues_TaskMergeMethod {
+		if reflect.DeepEqual(v, expected) {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return fmt.Errorf("invalid value (expected one of %#v): %#v", enumValues_TaskMergeMethod, v)
+	}
+	*j = TaskMergeMethod(v)
+	return nil
+}
+
+// Settings of the pull request that only a specific host supports. saturn-bot
+// applies them when it creates the pull request and keeps them in sync on every
+// update.
+type TaskPrMetadata struct {
+	// Settings that saturn-bot applies to pull requests in repositories on GitHub.
+	Github *TaskPrMetadataGithub `json:"github,omitempty" yaml:"github,omitempty" mapstructure:"github,omitempty"`
+
+	// Settings that saturn-bot applies to merge requests in projects on GitLab.
+	Gitlab *TaskPrMetadataGitlab `json:"gitlab,omitempty" yaml:"gitlab,omitempty" mapstructure:"gitlab,omitempty"`
+}
+
+// Settings that saturn-bot applies to pull requests in repositories on GitHub.
+type TaskPrMetadataGithub struct {
+	// Title of the milestone to assign the pull request to. The milestone needs to
+	// exist and be open.
+	Milestone *string `json:"milestone,omitempty" yaml:"milestone,omitempty" mapstructure:"milestone,omitempty"`
+
+	// Projects to add the pull request to.
+	Projects []GithubProject `json:"projects,omitempty" yaml:"projects,omitempty" mapstructure:"projects,omitempty"`
+}
+
+// Settings that saturn-bot applies to merge requests in projects on GitLab.
+type TaskPrMetadataGitlab struct {
+	// Approval rules to add to the merge request. saturn-bot identifies each rule by
+	// its name and leaves other rules untouched.
+	ApprovalRules []GitlabApprovalRule `json:"approvalRules,omitempty" yaml:"approvalRules,omitempty" mapstructure:"approvalRules,omitempty"`
+
+	// Title of the milestone to assign the merge request to. The milestone needs to
+	// exist in the project or one of its groups.
+	Milestone *string `json:"milestone,omitempty" yaml:"milestone,omitempty" mapstructure:"milestone,omitempty"`
+
+	// Delete the source branch once the merge request has been merged. Defaults to
+	// the setting of the project.
+	RemoveSourceBranch *bool `json:"removeSourceBranch,omitempty" yaml:"removeSourceBranch,omitempty" mapstructure:"removeSourceBranch,omitempty"`
+
+	// Squash the commits of the merge request when it gets merged. Defaults to the
+	// setting of the project.
+	Squash *bool `json:"squash,omitempty" yaml:"squash,omitempty" mapstructure:"squash,omitempty"`
+}
+
+// Roll out the task in waves. saturn-bot applies the task only to repositories
+// that are part of waves that have started. It starts the next wave once the gate
+// of the current wave has passed. Only relevant in server mode.
+type TaskRollout struct {
+	// Ordered list of waves. A repository belongs to the first wave that selects it.
+	Waves []RolloutWave `json:"waves" yaml:"waves" mapstructure:"waves"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *TaskRollout) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["waves"]; raw != nil && !ok {
+		return fmt.Errorf("field waves in TaskRollout: required")
+	}
+	type Plain TaskRollout
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if plain.Waves != nil && len(plain.Waves) < 1 {
+		return fmt.Errorf("field %s length: must be >= %d", "waves", 1)
+	}
+	*j = TaskRollout(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *TaskRollout) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["waves"]; raw != nil && !ok {
+		return fmt.Errorf("field waves in TaskRollout: required")
+	}
+	type Plain TaskRollout
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if plain.Waves != nil && len(plain.Waves) < 1 {
+		return fmt.Errorf("field %s length: must be >= %d", "waves", 1)
+	}
+	*j = TaskRollout(plain)
+	return nil
+}
+
+// Steps to apply to a pull request that stays open for a long time. Each step is
+// optional and runs at most once per pull request. The time of a step counts from
+// the creation of the pull request.
+type TaskStalePolicy struct {
+	// Comment on the pull request and mention its reviewers once it has been open for
+	// the specified amount of time. The value is a Go duration, like 72h.
+	NudgeAfter *string `json:"nudgeAfter,omitempty" yaml:"nudgeAfter,omitempty" mapstructure:"nudgeAfter,omitempty"`
+
+	// Rebase the pull request and apply the actions again once the base branch
+	// contains more than the specified number of commits that the pull request
+	// doesn't contain. saturn-bot rebases even if the pull request has no merge
+	// conflict. Disabled if `0`.
+	RebaseAfterCommits int `json:"rebaseAfterCommits,omitempty" yaml:"rebaseAfterCommits,omitempty" mapstructure:"rebaseAfterCommits,omitempty"`
+
+	// Request reviews again from the reviewers of the pull request once it has been
+	// open for the specified amount of time. The value is a Go duration, like 168h.
+	RerequestReviewAfter *string `json:"rerequestReviewAfter,omitempty" yaml:"rerequestReviewAfter,omitempty" mapstructure:"rerequestReviewAfter,omitempty"`
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *TaskStalePolicy) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	type Plain TaskStalePolicy
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if v, ok := raw["rebaseAfterCommits"]; !ok || v == nil {
+		plain.RebaseAfterCommits = 0.0
+	}
+	if 0 > plain.RebaseAfterCommits {
+		return fmt.Errorf("field %s: must be >= %v", "rebaseAfterCommits", 0)
+	}
+	*j = TaskStalePolicy(plain)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *TaskStalePolicy) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	type Plain TaskStalePolicy
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if v, ok := raw["rebaseAfterCommits"]; !ok || v == nil {
+		plain.RebaseAfterCommits = 0.0
+	}
+	if 0 > plain.RebaseAfterCommits {
+		return fmt.Errorf("field %s: must be >= %v", "rebaseAfterCommits", 0)
+	}
+	*j = TaskStalePolicy(plain)
+	return nil
+}
+
 // Define when the task gets executed. Only relevant in server mode.
 type TaskTrigger struct {
 	// Trigger the task based on a cron schedule.
@@ -338,6 +982,10 @@ type TaskTriggerWebhook struct {
 	// received by the server.
 	Delay int `json:"delay,omitempty" yaml:"delay,omitempty" mapstructure:"delay,omitempty"`
 
+	// Execute the task when the server receives a webhook from a generic source, like
+	// an artifact registry or an internal service.
+	Generic []GenericTrigger `json:"generic,omitempty" yaml:"generic,omitempty" mapstructure:"generic,omitempty"`
+
 	// Execute the task when the server receives a webhook from GitHub.
 	Github []GithubTrigger `json:"github,omitempty" yaml:"github,omitempty" mapstructure:"github,omitempty"`
 
@@ -407,6 +1055,9 @@ func (j *Task) UnmarshalJSON(value []byte) error {
 	if v, ok := raw["autoMergeAfter"]; !ok || v == nil {
 		plain.AutoMergeAfter = ""
 	}
+	if v, ok := raw["autoMergeNative"]; !ok || v == nil {
+		plain.AutoMergeNative = false
+	}
 	if v, ok := raw["branchName"]; !ok || v == nil {
 		plain.BranchName = ""
 	}
@@ -419,21 +1070,33 @@ func (j *Task) UnmarshalJSON(value []byte) error {
 	if v, ok := raw["createOnly"]; !ok || v == nil {
 		plain.CreateOnly = false
 	}
+	if v, ok := raw["draft"]; !ok || v == nil {
+		plain.Draft = false
+	}
 	if v, ok := raw["keepBranchAfterMerge"]; !ok || v == nil {
 		plain.KeepBranchAfterMerge = false
 	}
 	if v, ok := raw["maxOpenPRs"]; !ok || v == nil {
 		plain.MaxOpenPRs = 0.0
 	}
+	if v, ok := raw["mergeMethod"]; !ok || v == nil {
+		plain.MergeMethod = ""
+	}
 	if v, ok := raw["mergeOnce"]; !ok || v == nil {
 		plain.MergeOnce = false
 	}
 	if v, ok := raw["prBody"]; !ok || v == nil {
 		plain.PrBody = ""
 	}
+	if v, ok := raw["prFooter"]; !ok || v == nil {
+		plain.PrFooter = ""
+	}
 	if v, ok := raw["prTitle"]; !ok || v == nil {
 		plain.PrTitle = ""
 	}
+	if v, ok := raw["promoteDraftWhenGreen"]; !ok || v == nil {
+		plain.PromoteDraftWhenGreen = false
+	}
 	if v, ok := raw["pushToDefaultBranch"]; !ok || v == nil {
 		plain.PushToDefaultBranch = false
 	}
@@ -467,6 +1130,9 @@ func (j *Task) UnmarshalYAML(value *yaml.Node) error {
 	if v, ok := raw["autoMergeAfter"]; !ok || v == nil {
 		plain.AutoMergeAfter = ""
 	}
+	if v, ok := raw["autoMergeNative"]; !ok || v == nil {
+		plain.AutoMergeNative = false
+	}
 	if v, ok := raw["branchName"]; !ok || v == nil {
 		plain.BranchName = ""
 	}
@@ -479,21 +1145,33 @@ func (j *Task) UnmarshalYAML(value *yaml.Node) error {
 	if v, ok := raw["createOnly"]; !ok || v == nil {
 		plain.CreateOnly = false
 	}
+	if v, ok := raw["draft"]; !ok || v == nil {
+		plain.Draft = false
+	}
 	if v, ok := raw["keepBranchAfterMerge"]; !ok || v == nil {
 		plain.KeepBranchAfterMerge = false
 	}
 	if v, ok := raw["maxOpenPRs"]; !ok || v == nil {
 		plain.MaxOpenPRs = 0.0
 	}
+	if v, ok := raw["mergeMethod"]; !ok || v == nil {
+		plain.MergeMethod = ""
+	}
 	if v, ok := raw["mergeOnce"]; !ok || v == nil {
 		plain.MergeOnce = false
 	}
 	if v, ok := raw["prBody"]; !ok || v == nil {
 		plain.PrBody = ""
 	}
+	if v, ok := raw["prFooter"]; !ok || v == nil {
+		plain.PrFooter = ""
+	}
 	if v, ok := raw["prTitle"]; !ok || v == nil {
 		plain.PrTitle = ""
 	}
+	if v, ok := raw["promoteDraftWhenGreen"]; !ok || v == nil {
+		plain.PromoteDraftWhenGreen = false
+	}
 	if v, ok := raw["pushToDefaultBranch"]; !ok || v == nil {
 		plain.PushToDefaultBranch = false
 	}
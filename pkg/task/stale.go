@@ -0,0 +1,64 @@
+package task
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/wndhydrnt/saturn-bot/pkg/task/schema"
+)
+
+// StalePolicy is [schema.TaskStalePolicy] with its durations parsed.
+// A duration of 0 disables the step.
+type StalePolicy struct {
+	NudgeAfter           time.Duration
+	RebaseAfterCommits   int
+	RerequestReviewAfter time.Duration
+}
+
+func parseStalePolicy(def *schema.TaskStalePolicy) (*StalePolicy, error) {
+	if def == nil {
+		return nil, nil
+	}
+
+	policy := &StalePolicy{RebaseAfterCommits: def.RebaseAfterCommits}
+	durations := []struct {
+		field string
+		value *string
+		dst   *time.Duration
+	}{
+		{field: "nudgeAfter", value: def.NudgeAfter, dst: &policy.NudgeAfter},
+		{field: "rerequestReviewAfter", value: def.RerequestReviewAfter, dst: &policy.RerequestReviewAfter},
+	}
+	for _, d := range durations {
+		if d.value == nil || *d.value == "" {
+			continue
+		}
+
+		var err error
+		*d.dst, err = time.ParseDuration(*d.value)
+		if err != nil {
+			return nil, fmt.Errorf("parse %s: %w", d.field, err)
+		}
+	}
+
+	return policy, nil
+}
+
+// CalcStalePolicy returns the parsed stale policy of the task.
+// It returns nil if the task doesn't define a stale policy.
+func (tw *Task) CalcStalePolicy() *StalePolicy {
+	if tw.StalePolicy == nil {
+		return nil
+	}
+
+	if tw.stalePolicy == nil {
+		policy, err := parseStalePolicy(tw.StalePolicy)
+		if err != nil {
+			panic(fmt.Sprintf("field `stalePolicy` of task %s is invalid: %s", tw.Name, err))
+		}
+
+		tw.stalePolicy = policy
+	}
+
+	return tw.stalePolicy
+}
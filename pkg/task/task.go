@@ -7,12 +7,15 @@ import (
 	"fmt"
 	htmlTemplate "html/template"
 	"maps"
+	"path"
 	"regexp"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gosimple/slug"
+	"github.com/itchyny/gojq"
 	protoV1 "github.com/wndhydrnt/saturn-bot-go/protocol/v1"
 	"github.com/wndhydrnt/saturn-bot/pkg/action"
 	"github.com/wndhydrnt/saturn-bot/pkg/filter"
@@ -100,13 +103,19 @@ type Task struct {
 	autoMergeAfterDuration *time.Duration
 	changeLimitCount       int
 	checksum               string
+	dependencies           []Dependency
 	filtersPreClone        []filter.Filter
 	filtersPostClone       []filter.Filter
 	openPRs                int
 	path                   string // Path to the file that contains the task.
 	plugins                []*plugin.Plugin
+	queryTargetBranches    *gojq.Code
+	revision               string
+	rolloutWaves           []rolloutWave
+	stalePolicy            *StalePolicy
 	templateBranchName     *htmlTemplate.Template
 	templatePrTitle        *htmlTemplate.Template
+	templateTargetBranches []*htmlTemplate.Template
 	runData                map[string]string
 	inputValidators        map[string]*regexp.Regexp
 }
@@ -139,6 +148,10 @@ func (tw *Task) RenderBranchName(data template.Data) (string, error) {
 	var name string
 	if tw.BranchName == "" {
 		name = "saturn-bot--" + slug.Make(tw.Name)
+		if data.TargetBranch != "" {
+			// Each target branch requires its own branch.
+			name += "--" + slug.Make(data.TargetBranch)
+		}
 	} else {
 		if tw.templateBranchName == nil {
 			var parseErr error
@@ -165,6 +178,134 @@ func (tw *Task) RenderBranchName(data template.Data) (string, error) {
 	return name, nil
 }
 
+// HasTargetBranches returns true if the task defines target branches
+// via TargetBranches or TargetBranchesQuery.
+func (tw *Task) HasTargetBranches() bool {
+	return len(tw.TargetBranches) > 0 || tw.TargetBranchesQuery != nil
+}
+
+// parseTargetBranches parses the templates of TargetBranches and the jq expression of TargetBranchesQuery.
+func (tw *Task) parseTargetBranches() error {
+	templates := make([]*htmlTemplate.Template, 0, len(tw.TargetBranches))
+	for _, entry := range tw.TargetBranches {
+		tpl, err := htmlTemplate.New("").Parse(entry)
+		if err != nil {
+			return fmt.Errorf("parse target branch template: %w", err)
+		}
+
+		templates = append(templates, tpl)
+	}
+
+	tw.templateTargetBranches = templates
+	if tw.TargetBranchesQuery != nil {
+		query, err := gojq.Parse(*tw.TargetBranchesQuery)
+		if err != nil {
+			return fmt.Errorf("parse target branches query: %w", err)
+		}
+
+		tw.queryTargetBranches, err = gojq.Compile(query)
+		if err != nil {
+			return fmt.Errorf("compile target branches query: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// RenderTargetBranches renders the entries of TargetBranches.
+// Each rendered entry is the name of a branch or a glob pattern.
+// Entries that render to an empty string are skipped.
+func (tw *Task) RenderTargetBranches(data template.Data) ([]string, error) {
+	if tw.templateTargetBranches == nil {
+		if err := tw.parseTargetBranches(); err != nil {
+			return nil, err
+		}
+	}
+
+	var patterns []string
+	for _, tpl := range tw.templateTargetBranches {
+		buf := &bytes.Buffer{}
+		err := tpl.Execute(buf, data)
+		if err != nil {
+			return nil, fmt.Errorf("render target branch template: %w", err)
+		}
+
+		pattern := strings.TrimSpace(buf.String())
+		if pattern == "" {
+			continue
+		}
+
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid target branch pattern %s: %w", pattern, err)
+		}
+
+		patterns = append(patterns, pattern)
+	}
+
+	return patterns, nil
+}
+
+// QueryTargetBranches evaluates TargetBranchesQuery for a repository and returns the branches it selects.
+// The input of the query is an object with the keys "branches", "defaultBranch", "repository" and "run".
+// It ignores names of branches that don't exist in branches.
+func (tw *Task) QueryTargetBranches(data template.Data, defaultBranch string, branches []string) ([]string, error) {
+	if tw.TargetBranchesQuery == nil {
+		return nil, nil
+	}
+
+	if tw.queryTargetBranches == nil {
+		if err := tw.parseTargetBranches(); err != nil {
+			return nil, err
+		}
+	}
+
+	// gojq only supports generic types as input.
+	branchesInput := make([]any, 0, len(branches))
+	for _, branch := range branches {
+		branchesInput = append(branchesInput, branch)
+	}
+
+	runInput := make(map[string]any, len(data.Run))
+	for k, v := range data.Run {
+		runInput[k] = v
+	}
+
+	input := map[string]any{
+		"branches":      branchesInput,
+		"defaultBranch": defaultBranch,
+		"repository": map[string]any{
+			"fullName": data.Repository.FullName,
+			"host":     data.Repository.Host,
+			"name":     data.Repository.Name,
+			"owner":    data.Repository.Owner,
+		},
+		"run": runInput,
+	}
+	var selected []string
+	iter := tw.queryTargetBranches.Run(input)
+	for {
+		value, hasNext := iter.Next()
+		if !hasNext {
+			break
+		}
+
+		if err, isErr := value.(error); isErr {
+			return nil, fmt.Errorf("run target branches query: %w", err)
+		}
+
+		name, isString := value.(string)
+		if !isString {
+			return nil, fmt.Errorf("target branches query returned %v - expected the name of a branch", value)
+		}
+
+		if slices.Contains(branches, name) && !slices.Contains(selected, name) {
+			selected = append(selected, name)
+		}
+	}
+
+	return selected, nil
+}
+
 func (tw *Task) Checksum() string {
 	return tw.checksum
 }
@@ -315,6 +456,12 @@ func (tw *Task) Path() string {
 	return tw.path
 }
 
+// Revision returns the commit SHA of the git repository from which the task has been read.
+// It is empty if the task has been read from a local file.
+func (tw *Task) Revision() string {
+	return tw.revision
+}
+
 func (tw *Task) Stop() {
 	for _, p := range tw.plugins {
 		p.Stop()
@@ -339,8 +486,11 @@ func ValidateInputs(data map[string]string, t *Task) []error {
 	var errors []error
 	for _, input := range t.Inputs {
 		value := data[input.Name]
-		if value == "" && input.Default == nil {
-			errors = append(errors, fmt.Errorf("missing value for input '%s'", input.Name))
+		if value == "" {
+			if input.Default == nil {
+				errors = append(errors, fmt.Errorf("missing value for input '%s'", input.Name))
+			}
+
 			continue
 		}
 
@@ -350,7 +500,7 @@ func ValidateInputs(data map[string]string, t *Task) []error {
 			continue
 		}
 
-		if len(input.Options) > 0 && slices.Contains(input.Options, value) {
+		if len(input.Options) > 0 && !slices.Contains(input.Options, value) {
 			errors = append(errors, fmt.Errorf("value of input '%s' must be one of '%s'", input.Name, strings.Join(input.Options, ",")))
 			continue
 		}
@@ -364,11 +514,14 @@ type Registry struct {
 	actionFactories options.ActionFactories
 	filterFactories options.FilterFactories
 	globalLabels    []string
+	globalPrFooter  string
 	hosts           []host.Host
 	isCi            bool
+	mu              sync.RWMutex
 	pathJava        string
 	pathPython      string
 	pluginLogLevel  zapcore.Level
+	revision        string
 	skipPlugins     bool
 	tasks           []*Task
 }
@@ -383,6 +536,7 @@ func NewRegistry(opts options.Opts) *Registry {
 		actionFactories: opts.ActionFactories,
 		filterFactories: opts.FilterFactories,
 		globalLabels:    opts.Config.Labels,
+		globalPrFooter:  opts.Config.PrFooter,
 		hosts:           opts.Hosts,
 		isCi:            opts.IsCi,
 		pathJava:        opts.Config.JavaPath,
@@ -395,10 +549,14 @@ func NewRegistry(opts options.Opts) *Registry {
 // GetTasks returns all tasks registered with the Registry.
 // Should be called only after ReadAll() has been called at least once.
 func (tr *Registry) GetTasks() []*Task {
+	tr.mu.RLock()
+	defer tr.mu.RUnlock()
 	return tr.tasks
 }
 
 // ReadAll takes a list of paths to task files and reads all tasks from the files.
+// It links the dependencies of tasks once it has read all files,
+// because a task can depend on a task defined in another file.
 func (tr *Registry) ReadAll(taskFiles []string) error {
 	for _, path := range taskFiles {
 		err := tr.ReadTasks(path)
@@ -407,6 +565,12 @@ func (tr *Registry) ReadAll(taskFiles []string) error {
 		}
 	}
 
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	if err := resolveDependencies(tr.tasks); err != nil {
+		return fmt.Errorf("resolve dependencies of tasks: %w", err)
+	}
+
 	return nil
 }
 
@@ -426,10 +590,31 @@ func (tr *Registry) ReadTasks(taskFile string) error {
 		wrapper := &Task{
 			checksum: entry.Sha256,
 			path:     entry.Path,
+			revision: tr.revision,
 		}
 		wrapper.Task = entry.Task
 
+		// Parse early to detect an invalid value while reading tasks.
+		// Avoids the panic in CalcAutoMergeAfter().
+		if wrapper.AutoMergeAfter != "" {
+			d, err := time.ParseDuration(wrapper.AutoMergeAfter)
+			if err != nil {
+				return fmt.Errorf("parse autoMergeAfter of task %s: %w", wrapper.Name, err)
+			}
+
+			wrapper.autoMergeAfterDuration = &d
+		}
+
+		// Avoids the panic in CalcStalePolicy().
+		wrapper.stalePolicy, err = parseStalePolicy(wrapper.StalePolicy)
+		if err != nil {
+			return fmt.Errorf("parse stalePolicy of task %s: %w", wrapper.Name, err)
+		}
+
 		wrapper.UpdateLabels(tr.globalLabels...)
+		if wrapper.PrFooter == "" {
+			wrapper.PrFooter = tr.globalPrFooter
+		}
 
 		wrapper.actions, err = createActionsForTask(wrapper.Task.Actions, tr.actionFactories, entry.Path)
 		if err != nil {
@@ -441,6 +626,16 @@ func (tr *Registry) ReadTasks(taskFile string) error {
 			return fmt.Errorf("parse filters of task file '%s': %w", entry.Path, err)
 		}
 
+		// Parse early to detect invalid templates or jq expressions while reading tasks.
+		if err := wrapper.parseTargetBranches(); err != nil {
+			return fmt.Errorf("parse target branches of task %s: %w", wrapper.Name, err)
+		}
+
+		wrapper.rolloutWaves, err = createRolloutWaves(wrapper.Rollout, tr.filterFactories, tr.hosts)
+		if err != nil {
+			return fmt.Errorf("parse rollout of task %s: %w", wrapper.Name, err)
+		}
+
 		for idx, taskPlugin := range entry.Task.Plugins {
 			if tr.skipPlugins {
 				continue
@@ -467,7 +662,46 @@ func (tr *Registry) ReadTasks(taskFile string) error {
 			}
 		}
 
+		tr.mu.Lock()
 		tr.tasks = append(tr.tasks, wrapper)
+		tr.mu.Unlock()
+	}
+
+	return nil
+}
+
+// Reload reads all tasks from taskFiles and replaces the tasks of the registry with them.
+// The registry keeps its current tasks if reading fails.
+// revision is the commit SHA of the git repository that contains taskFiles.
+// Pass an empty string if taskFiles are local files.
+//
+// Tasks returned by GetTasks() before the reload stay unchanged.
+// Their plugins get stopped.
+func (tr *Registry) Reload(taskFiles []string, revision string) error {
+	next := &Registry{
+		actionFactories: tr.actionFactories,
+		filterFactories: tr.filterFactories,
+		globalLabels:    tr.globalLabels,
+		globalPrFooter:  tr.globalPrFooter,
+		hosts:           tr.hosts,
+		isCi:            tr.isCi,
+		pathJava:        tr.pathJava,
+		pathPython:      tr.pathPython,
+		pluginLogLevel:  tr.pluginLogLevel,
+		revision:        revision,
+		skipPlugins:     tr.skipPlugins,
+	}
+	if err := next.ReadAll(taskFiles); err != nil {
+		next.Stop()
+		return err
+	}
+
+	tr.mu.Lock()
+	previous := tr.tasks
+	tr.tasks = next.tasks
+	tr.mu.Unlock()
+	for _, t := range previous {
+		t.Stop()
 	}
 
 	return nil
@@ -504,7 +738,7 @@ func (tr *Registry) startPlugin(taskPath string, taskPlugin schema.Plugin) (*plu
 // This usually happens at the end of a run.
 // Every task can then execute code to clean itself up.
 func (tr *Registry) Stop() {
-	for _, t := range tr.tasks {
+	for _, t := range tr.GetTasks() {
 		t.Stop()
 	}
 }
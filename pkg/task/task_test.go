@@ -1,11 +1,13 @@
 package task_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -17,6 +19,7 @@ import (
 	"github.com/wndhydrnt/saturn-bot/pkg/ptr"
 	"github.com/wndhydrnt/saturn-bot/pkg/task"
 	"github.com/wndhydrnt/saturn-bot/pkg/task/schema"
+	"github.com/wndhydrnt/saturn-bot/pkg/template"
 )
 
 func TestRegistry_ReadAll(t *testing.T) {
@@ -236,6 +239,41 @@ name: Task Two
 	assert.Equal(t, "Task Two", tr.GetTasks()[0].Name)
 }
 
+func TestRegistry_Reload(t *testing.T) {
+	f, err := os.CreateTemp("", "*.yaml")
+	require.NoError(t, err)
+	_, err = f.WriteString("name: Task One\n")
+	require.NoError(t, err)
+	f.Close()
+	defer func() {
+		err := os.Remove(f.Name())
+		require.NoError(t, err)
+	}()
+
+	tr := &task.Registry{}
+	err = tr.ReadAll([]string{f.Name()})
+	require.NoError(t, err)
+	tasksBefore := tr.GetTasks()
+
+	err = os.WriteFile(f.Name(), []byte("name: Task One\n---\nname: Task Two\n"), 0600)
+	require.NoError(t, err)
+	err = tr.Reload([]string{f.Name()}, "3f8a2b1")
+	require.NoError(t, err)
+
+	require.Len(t, tr.GetTasks(), 2)
+	assert.Equal(t, "Task One", tr.GetTasks()[0].Name)
+	assert.Equal(t, "3f8a2b1", tr.GetTasks()[0].Revision())
+	assert.Equal(t, "Task Two", tr.GetTasks()[1].Name)
+	assert.Equal(t, "3f8a2b1", tr.GetTasks()[1].Revision())
+	assert.Len(t, tasksBefore, 1, "Keeps tasks returned before the reload unchanged")
+
+	err = os.WriteFile(f.Name(), []byte("name: [invalid"), 0600)
+	require.NoError(t, err)
+	err = tr.Reload([]string{f.Name()}, "")
+	require.Error(t, err)
+	assert.Len(t, tr.GetTasks(), 2, "Keeps current tasks if reload fails")
+}
+
 func TestRegistry_GlobalLabels(t *testing.T) {
 	tasksRaw := `
 name: Task One
@@ -261,6 +299,23 @@ labels: ["unit", "test"]
 	assert.Equal(t, labelsWant, tr.GetTasks()[0].Labels, "Global labels added, no duplicates")
 }
 
+func TestRegistry_GlobalPrFooter(t *testing.T) {
+	taskPath := filepath.Join(t.TempDir(), "task.yaml")
+	content := `name: global
+---
+name: custom
+prFooter: Footer of task
+`
+	require.NoError(t, os.WriteFile(taskPath, []byte(content), 0600))
+	tr := task.NewRegistry(options.Opts{Config: config.Configuration{PrFooter: "Global footer"}})
+
+	err := tr.ReadAll([]string{taskPath})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Global footer", tr.GetTasks()[0].PrFooter)
+	assert.Equal(t, "Footer of task", tr.GetTasks()[1].PrFooter, "Footer of task takes precedence")
+}
+
 func TestTask_Inputs(t *testing.T) {
 	testCases := []struct {
 		name   string
@@ -322,6 +377,50 @@ func TestTask_Inputs(t *testing.T) {
 	}
 }
 
+func TestValidateInputs(t *testing.T) {
+	testCases := []struct {
+		name string
+		task schema.Task
+		data map[string]string
+		errs []string
+	}{
+		{
+			name: "value is one of the options",
+			task: schema.Task{Inputs: []schema.Input{{Name: "greeting", Options: []string{"Hello", "Hallo"}}}},
+			data: map[string]string{"greeting": "Hallo"},
+		},
+		{
+			name: "value is not one of the options",
+			task: schema.Task{Inputs: []schema.Input{{Name: "greeting", Options: []string{"Hello", "Hallo"}}}},
+			data: map[string]string{"greeting": "Hola"},
+			errs: []string{"value of input 'greeting' must be one of 'Hello,Hallo'"},
+		},
+		{
+			name: "value is empty and input has a default",
+			task: schema.Task{Inputs: []schema.Input{{Name: "greeting", Default: ptr.To("Hello"), Options: []string{"Hello", "Hallo"}}}},
+			data: map[string]string{},
+		},
+		{
+			name: "value is empty and input has no default",
+			task: schema.Task{Inputs: []schema.Input{{Name: "greeting"}}},
+			data: map[string]string{},
+			errs: []string{"missing value for input 'greeting'"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			errs := task.ValidateInputs(tc.data, &task.Task{Task: tc.task})
+			var msgs []string
+			for _, err := range errs {
+				msgs = append(msgs, err.Error())
+			}
+
+			require.Equal(t, tc.errs, msgs)
+		})
+	}
+}
+
 func TestTask_SetInputs_NoSharedState(t *testing.T) {
 	taskOne := &task.Task{Task: schema.Task{
 		Name: "Task One",
@@ -346,3 +445,343 @@ func TestTask_SetInputs_NoSharedState(t *testing.T) {
 	require.Equal(t, map[string]string{"character": "tommy"}, taskTwo.RunData(), "default value in run data")
 	require.Equal(t, map[string]string{}, runData, "state of global run data has not changed")
 }
+
+func TestRegistry_ReadAll_InvalidAutoMergeAfter(t *testing.T) {
+	f, err := os.CreateTemp("", "*.yaml")
+	require.NoError(t, err)
+	_, err = f.WriteString("name: Task One\nautoMergeAfter: 3x\n")
+	require.NoError(t, err)
+	f.Close()
+	defer func() {
+		err := os.Remove(f.Name())
+		require.NoError(t, err)
+	}()
+
+	tr := &task.Registry{}
+	err = tr.ReadAll([]string{f.Name()})
+
+	require.ErrorContains(t, err, `parse autoMergeAfter of task Task One: time: unknown unit "x" in duration "3x"`)
+}
+
+func TestTask_RenderTargetBranches(t *testing.T) {
+	tk := &task.Task{Task: schema.Task{
+		Name:           "unittest",
+		TargetBranches: []string{"main", "release/*", "stable-{{.Repository.Name}}", "{{if .Run.backport}}backport{{end}}"},
+	}}
+	data := template.Data{Repository: template.DataRepository{Name: "test"}, Run: map[string]string{}}
+
+	patterns, err := tk.RenderTargetBranches(data)
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"main", "release/*", "stable-test"}, patterns, "skips entries that render to an empty string")
+}
+
+func TestTask_RenderTargetBranches_InvalidPattern(t *testing.T) {
+	tk := &task.Task{Task: schema.Task{Name: "unittest", TargetBranches: []string{"release/["}}}
+
+	_, err := tk.RenderTargetBranches(template.Data{})
+
+	require.EqualError(t, err, "invalid target branch pattern release/[: syntax error in pattern")
+}
+
+func TestTask_QueryTargetBranches(t *testing.T) {
+	tk := &task.Task{Task: schema.Task{
+		Name:                "unittest",
+		TargetBranchesQuery: ptr.To(`(.branches[] | select(startswith("release/"))), .defaultBranch, "stable-" + .repository.name, .run.extra, "unknown"`),
+	}}
+	data := template.Data{Repository: template.DataRepository{Name: "test"}, Run: map[string]string{"extra": "release/1.0"}}
+
+	branches, err := tk.QueryTargetBranches(data, "main", []string{"main", "release/1.0", "release/2.0", "stable-test"})
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"release/1.0", "release/2.0", "main", "stable-test"}, branches, "ignores duplicates and unknown branches")
+}
+
+func TestTask_QueryTargetBranches_NotAString(t *testing.T) {
+	tk := &task.Task{Task: schema.Task{Name: "unittest", TargetBranchesQuery: ptr.To(".branches | length")}}
+
+	_, err := tk.QueryTargetBranches(template.Data{}, "main", []string{"main"})
+
+	require.EqualError(t, err, "target branches query returned 1 - expected the name of a branch")
+}
+
+func TestRegistry_ReadAll_InvalidTargetBranches(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "template",
+			content: "name: unittest\ntargetBranches:\n  - \"{{.Repository.Name\"\n",
+			wantErr: "parse target branches of task unittest: parse target branch template: template: :1: unclosed action",
+		},
+		{
+			name:    "jq",
+			content: "name: unittest\ntargetBranchesQuery: \".branches[\"\n",
+			wantErr: "parse target branches of task unittest: parse target branches query: unexpected EOF",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			taskPath := filepath.Join(t.TempDir(), "task.yaml")
+			require.NoError(t, os.WriteFile(taskPath, []byte(tc.content), 0600))
+			tr := task.NewRegistry(options.Opts{})
+
+			err := tr.ReadAll([]string{taskPath})
+
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestTask_RenderBranchName_TargetBranch(t *testing.T) {
+	tk := &task.Task{Task: schema.Task{Name: "unittest"}}
+
+	name, err := tk.RenderBranchName(template.Data{TargetBranch: "release/1.0"})
+
+	require.NoError(t, err)
+	require.Equal(t, "saturn-bot--unittest--release-1-0", name)
+}
+
+func TestTask_RolloutWave(t *testing.T) {
+	taskPath := filepath.Join(t.TempDir(), "task.yaml")
+	content := `name: unittest
+rollout:
+  waves:
+    - name: canary
+      percentage: 20
+      gate:
+        minMerged: 1
+    - name: half
+      percentage: 60
+      gate:
+        soakTime: 24h
+    - name: everything
+`
+	require.NoError(t, os.WriteFile(taskPath, []byte(content), 0600))
+	tr := &task.Registry{}
+	require.NoError(t, tr.ReadAll([]string{taskPath}))
+	tk := tr.GetTasks()[0]
+
+	for name, want := range map[string]int{"git.local/unit/a": 0, "git.local/unit/b": 1, "git.local/unit/c": 2} {
+		wave, err := tk.RolloutWave(context.Background(), name)
+		require.NoError(t, err)
+		assert.Equal(t, want, wave, name)
+	}
+
+	assert.Equal(t, 24*time.Hour, tk.RolloutSoakTime(1))
+	current, err := tk.CurrentRolloutWave()
+	require.NoError(t, err)
+	assert.Equal(t, 2, current, "all waves have started if the run data doesn't set the current wave")
+	require.NoError(t, tk.SetInputs(map[string]string{"sb.rolloutWave": "1"}))
+	current, err = tk.CurrentRolloutWave()
+	require.NoError(t, err)
+	assert.Equal(t, 1, current)
+}
+
+func TestRegistry_ReadAll_InvalidRollout(t *testing.T) {
+	testCases := []struct {
+		name    string
+		waves   string
+		wantErr string
+	}{
+		{
+			name: "filter requires clone",
+			waves: `    - name: canary
+      filters:
+        - filter: file
+          params:
+            paths: [go.mod]
+      gate:
+        minMerged: 1
+    - name: everything
+`,
+			wantErr: "parse rollout of task unittest: filters of rollout wave canary require a clone of the repository",
+		},
+		{
+			name: "gate missing",
+			waves: `    - name: canary
+      percentage: 10
+    - name: everything
+`,
+			wantErr: "parse rollout of task unittest: rollout wave canary does not define a gate",
+		},
+		{
+			name: "invalid soak time",
+			waves: `    - name: canary
+      gate:
+        soakTime: 1x
+    - name: everything
+`,
+			wantErr: `parse rollout of task unittest: parse soakTime of rollout wave canary: time: unknown unit "x" in duration "1x"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			taskPath := filepath.Join(t.TempDir(), "task.yaml")
+			content := "name: unittest\nrollout:\n  waves:\n" + tc.waves
+			require.NoError(t, os.WriteFile(taskPath, []byte(content), 0600))
+			tr := task.NewRegistry(options.Opts{FilterFactories: filter.BuiltInFactories})
+
+			err := tr.ReadAll([]string{taskPath})
+
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestRegistry_ReadAll_StalePolicy(t *testing.T) {
+	taskPath := filepath.Join(t.TempDir(), "task.yaml")
+	content := `name: unittest
+stalePolicy:
+  nudgeAfter: 72h
+  rebaseAfterCommits: 50
+`
+	require.NoError(t, os.WriteFile(taskPath, []byte(content), 0600))
+	tr := task.NewRegistry(options.Opts{})
+
+	err := tr.ReadAll([]string{taskPath})
+
+	require.NoError(t, err)
+	want := &task.StalePolicy{
+		NudgeAfter:         72 * time.Hour,
+		RebaseAfterCommits: 50,
+	}
+	require.Equal(t, want, tr.GetTasks()[0].CalcStalePolicy())
+}
+
+func TestRegistry_ReadAll_InvalidStalePolicy(t *testing.T) {
+	taskPath := filepath.Join(t.TempDir(), "task.yaml")
+	content := "name: unittest\nstalePolicy:\n  nudgeAfter: 3d\n"
+	require.NoError(t, os.WriteFile(taskPath, []byte(content), 0600))
+	tr := task.NewRegistry(options.Opts{})
+
+	err := tr.ReadAll([]string{taskPath})
+
+	require.ErrorContains(t, err, `parse stalePolicy of task unittest: parse nudgeAfter: time: unknown unit "d" in duration "3d"`)
+}
+
+func TestRegistry_ReadAll_DependsOn(t *testing.T) {
+	taskPath := filepath.Join(t.TempDir(), "task.yaml")
+	content := `name: upstream
+---
+name: unittest
+dependsOn:
+  - task: upstream
+  - repository: git.local/unit/library
+`
+	require.NoError(t, os.WriteFile(taskPath, []byte(content), 0600))
+	tr := task.NewRegistry(options.Opts{})
+
+	err := tr.ReadAll([]string{taskPath})
+
+	require.NoError(t, err)
+	tasks := tr.GetTasks()
+	want := []task.Dependency{
+		{Task: tasks[0]},
+		{RepositoryName: "git.local/unit/library", Task: tasks[1]},
+	}
+	require.Equal(t, want, tasks[1].Dependencies())
+	require.True(t, task.HasDependents(tasks, tasks[0]))
+	require.True(t, task.HasDependents(tasks, tasks[1]))
+}
+
+func TestRegistry_ReadAll_InvalidDependsOn(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "unknown task",
+			content: "name: unittest\ndependsOn:\n  - task: other\n",
+			wantErr: "resolve dependencies of tasks: dependency #0 of task unittest references unknown task other",
+		},
+		{
+			name:    "depends on itself",
+			content: "name: unittest\ndependsOn:\n  - task: unittest\n",
+			wantErr: "resolve dependencies of tasks: dependency #0 of task unittest depends on the task itself - set repository or task",
+		},
+		{
+			name: "cycle",
+			content: `name: first
+dependsOn:
+  - task: second
+---
+name: second
+dependsOn:
+  - task: first
+`,
+			wantErr: "resolve dependencies of tasks: dependencies of task first form a cycle",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			taskPath := filepath.Join(t.TempDir(), "task.yaml")
+			require.NoError(t, os.WriteFile(taskPath, []byte(tc.content), 0600))
+			tr := task.NewRegistry(options.Opts{})
+
+			err := tr.ReadAll([]string{taskPath})
+
+			require.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestTask_RenderBranchNameForRepository(t *testing.T) {
+	tw := &task.Task{Task: schema.Task{Name: "unittest", BranchName: "update-{{.Repository.Owner}}-{{.Repository.Name}}-{{.TaskName}}"}}
+
+	branchName, err := tw.RenderBranchNameForRepository("git.local/group/sub/library", template.Data{TaskName: "other"})
+
+	require.NoError(t, err)
+	require.Equal(t, "update-group/sub-library-unittest", branchName)
+}
+
+func TestRegistry_ReadAll_PrMetadata(t *testing.T) {
+	taskPath := filepath.Join(t.TempDir(), "task.yaml")
+	content := `name: unittest
+prMetadata:
+  github:
+    milestone: v1.2
+    projects:
+      - owner: acme
+        number: 7
+  gitlab:
+    milestone: v1.2
+    squash: true
+    approvalRules:
+      - name: security
+        approvalsRequired: 1
+        usernames: [alice]
+        groups: [acme/security]
+`
+	require.NoError(t, os.WriteFile(taskPath, []byte(content), 0600))
+	tr := task.NewRegistry(options.Opts{})
+
+	err := tr.ReadAll([]string{taskPath})
+
+	require.NoError(t, err)
+	tw := tr.GetTasks()[0]
+	require.Equal(t, host.GithubPullRequestData{
+		Milestone: "v1.2",
+		Projects:  []host.GithubProject{{Number: 7, Owner: "acme"}},
+	}, tw.GithubPullRequestData())
+	require.Equal(t, host.GitlabPullRequestData{
+		ApprovalRules: []host.GitlabApprovalRule{
+			{ApprovalsRequired: 1, Groups: []string{"acme/security"}, Name: "security", Usernames: []string{"alice"}},
+		},
+		Milestone: "v1.2",
+		Squash:    ptr.To(true),
+	}, tw.GitlabPullRequestData())
+}
+
+func TestTask_PullRequestData_Unset(t *testing.T) {
+	tw := &task.Task{Task: schema.Task{Name: "unittest"}}
+
+	require.Equal(t, host.GithubPullRequestData{}, tw.GithubPullRequestData())
+	require.Equal(t, host.GitlabPullRequestData{}, tw.GitlabPullRequestData())
+}
@@ -35,9 +35,42 @@ func RenderBranchModified(in BranchModifiedInput) (string, error) {
 
 // Data is the root structure passed to templates.
 type Data struct {
+	// Changes describes the changes of the pull request.
+	// Only available in templates of the pull request body.
+	Changes    DataChanges
 	Run        map[string]string
 	Repository DataRepository
-	TaskName   string
+	// RunID is the ID of the run of the server that applies the task.
+	// Empty if saturn-bot doesn't run as a worker.
+	RunID string
+	// RunUrl is the link to the run in the UI of the server.
+	// Empty if saturn-bot doesn't run as a worker.
+	RunUrl string
+	// TargetBranch is the branch that the pull request targets.
+	// Empty if the task doesn't define target branches.
+	TargetBranch string
+	// TaskFileUrl is the link to the file of the task in the UI of the server.
+	// Empty if saturn-bot doesn't run as a worker.
+	TaskFileUrl string
+	TaskName    string
+}
+
+// DataChanges is the sub-resource in templates that describes the changes of a pull request.
+type DataChanges struct {
+	// Actions lists the actions that have changed files, in the order in which saturn-bot has applied them.
+	Actions []string
+	// Additions is the number of added lines of all files.
+	Additions int
+	// Deletions is the number of deleted lines of all files.
+	Deletions int
+	Files     []DataChangedFile
+}
+
+// DataChangedFile is a file that a pull request changes.
+type DataChangedFile struct {
+	Additions int
+	Deletions int
+	Path      string
 }
 
 // DataRepository is the sub-resource in templates that exposes info about a repository.
@@ -52,12 +85,31 @@ type DataRepository struct {
 type PullRequestDescriptionInput struct {
 	AutoMergeText string
 	Body          string
-	IgnoreText    string
+	// Data is the template data of the pull request.
+	Data Data
+	// Footer is a template that replaces the default footer of the description.
+	// Empty to use the default footer.
+	Footer     string
+	IgnoreText string
 }
 
 func RenderPullRequestDescription(in PullRequestDescriptionInput) (string, error) {
+	tpl := templates
+	if in.Footer != "" {
+		var err error
+		tpl, err = htmlTemplate.ParseFS(data, "templates/pull-request-description.tpl")
+		if err != nil {
+			return "", fmt.Errorf("parse pull request description template: %w", err)
+		}
+
+		_, err = tpl.New("pull-request-footer.tpl").Parse(in.Footer)
+		if err != nil {
+			return "", fmt.Errorf("parse pull request footer template: %w", err)
+		}
+	}
+
 	buf := &bytes.Buffer{}
-	err := templates.ExecuteTemplate(buf, "pull-request-description.tpl", in)
+	err := tpl.ExecuteTemplate(buf, "pull-request-description.tpl", in)
 	if err != nil {
 		return "", fmt.Errorf("render pull request description template: %w", err)
 	}
@@ -1,15 +1,3 @@
 {{.Body}}
 
----
-
-**Auto-merge:** {{.AutoMergeText}}
-
-**Ignore:** {{.IgnoreText}}
-
----
-
-- [ ] If you want to rebase this PR, check this box
-
----
-
-_This pull request has been created by [saturn-bot](https://github.com/wndhydrnt/saturn-bot)_ 🪐🤖.
+{{template "pull-request-footer.tpl" .}}
\ No newline at end of file
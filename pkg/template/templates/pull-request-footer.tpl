@@ -0,0 +1,13 @@
+---
+
+**Auto-merge:** {{.AutoMergeText}}
+
+**Ignore:** {{.IgnoreText}}
+
+---
+
+- [ ] If you want to rebase this PR, check this box
+
+---
+
+_This pull request has been created by [saturn-bot](https://github.com/wndhydrnt/saturn-bot)_ 🪐🤖.
@@ -36,7 +36,7 @@ func infoHandler(worker *Worker) http.HandlerFunc {
 		resp := infoResponse{
 			Version: version.Info,
 		}
-		for _, workerTask := range worker.tasks {
+		for _, workerTask := range worker.registry.GetTasks() {
 			resp.Tasks = append(resp.Tasks, infoResponseTask{Path: workerTask.Path(), Checksum: workerTask.Checksum(), Task: workerTask.Name})
 		}
 
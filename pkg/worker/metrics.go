@@ -9,10 +9,11 @@ import (
 )
 
 const (
-	metricNs                  = "saturn_bot"
-	metricSub                 = "worker"
-	metricLabelOpGetWorkV1    = "GetWorkV1"
-	metricLabelOpReportWorkV1 = "ReportWorkV1"
+	metricNs                     = "saturn_bot"
+	metricSub                    = "worker"
+	metricLabelOpGetWorkV1       = "GetWorkV1"
+	metricLabelOpHeartbeatWorkV1 = "HeartbeatWorkV1"
+	metricLabelOpReportWorkV1    = "ReportWorkV1"
 )
 
 var (
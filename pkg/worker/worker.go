@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
@@ -16,6 +17,8 @@ import (
 	"github.com/wndhydrnt/saturn-bot/pkg/client"
 	"github.com/wndhydrnt/saturn-bot/pkg/command"
 	"github.com/wndhydrnt/saturn-bot/pkg/config"
+	sbcontext "github.com/wndhydrnt/saturn-bot/pkg/context"
+	"github.com/wndhydrnt/saturn-bot/pkg/git"
 	"github.com/wndhydrnt/saturn-bot/pkg/host"
 	"github.com/wndhydrnt/saturn-bot/pkg/log"
 	"github.com/wndhydrnt/saturn-bot/pkg/options"
@@ -34,12 +37,17 @@ var (
 type Execution client.GetWorkV1Response
 
 type Result struct {
+	// Cancelled is true if the run stopped early because a user cancelled it.
+	Cancelled   bool
 	RunError    error
 	Execution   Execution
 	TaskResults []command.RunResult
 }
 
 type ExecutionSource interface {
+	// Heartbeat signals that the worker is still processing the execution.
+	// It returns true if a user requested to cancel the execution.
+	Heartbeat(Execution) (bool, error)
 	Next() (Execution, error)
 	Report(Result) error
 }
@@ -48,6 +56,19 @@ type APIExecutionSource struct {
 	client client.ClientWithResponsesInterface
 }
 
+func (a *APIExecutionSource) Heartbeat(exec Execution) (bool, error) {
+	resp, err := a.client.HeartbeatWorkV1WithResponse(ctx, client.HeartbeatWorkV1Request{RunID: exec.RunID})
+	if err != nil {
+		return false, fmt.Errorf("api request to send heartbeat: %w", err)
+	}
+
+	if resp.JSON200 == nil {
+		return false, fmt.Errorf("server returned an unexpected response: %s", resp.Status())
+	}
+
+	return resp.JSON200.Cancelled, nil
+}
+
 func (a *APIExecutionSource) Next() (Execution, error) {
 	resp, err := a.client.GetWorkV1WithResponse(ctx)
 	if err != nil {
@@ -80,7 +101,9 @@ func (a *APIExecutionSource) Report(result Result) error {
 		Task:        result.Execution.Task,
 		TaskResults: mapRunResultsToTaskResults(result.TaskResults),
 	}
-	if result.RunError != nil {
+	if result.Cancelled {
+		payload.Cancelled = ptr.To(true)
+	} else if result.RunError != nil {
 		payload.Error = ptr.To(result.RunError.Error())
 	}
 
@@ -100,10 +123,14 @@ type Worker struct {
 
 	httpServer *http.Server
 	opts       options.Opts
+	registry   *task.Registry
 	resultChan chan Result
-	tasks      []*task.Task
 	stopped    bool
 	stopChan   chan chan struct{}
+	taskPaths  []string
+	// taskRepository is the git repository to read tasks from.
+	// Nil if the worker reads tasks from taskPaths.
+	taskRepository *git.TaskRepository
 }
 
 func NewWorker(configPath string, taskPaths []string) (*Worker, error) {
@@ -122,6 +149,8 @@ func NewWorker(configPath string, taskPaths []string) (*Worker, error) {
 		return nil, err
 	}
 
+	// Capture the log of each repository to upload it to the server.
+	opts.RepositoryLogMaxSize = opts.Config.WorkerRepositoryLogMaxSize
 	reg := task.NewRegistry(options.Opts{
 		ActionFactories: opts.ActionFactories,
 		Config:          opts.Config,
@@ -133,13 +162,45 @@ func NewWorker(configPath string, taskPaths []string) (*Worker, error) {
 		// No need to start plugins here.
 		SkipPlugins: true,
 	})
-	err = reg.ReadAll(taskPaths)
-	if err != nil {
-		return nil, err
+	var taskRepository *git.TaskRepository
+	if opts.Config.TasksGitUrl == "" {
+		if len(taskPaths) == 0 {
+			return nil, fmt.Errorf("no task files passed and setting tasksGitUrl not configured")
+		}
+
+		err = reg.ReadAll(taskPaths)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if len(taskPaths) > 0 {
+			return nil, fmt.Errorf("task files passed and setting tasksGitUrl configured - use one or the other")
+		}
+
+		taskRepository, err = git.NewTaskRepository(opts)
+		if err != nil {
+			return nil, fmt.Errorf("create git repository of tasks: %w", err)
+		}
+
+		revision, gitTaskPaths, err := taskRepository.Latest()
+		if err != nil {
+			return nil, err
+		}
+
+		log.Log().Infof("Reading tasks from revision %s of %s", revision, opts.Config.TasksGitUrl)
+		err = reg.Reload(gitTaskPaths, revision)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	apiKey := opts.Config.WorkerApiKey
+	if apiKey == "" {
+		apiKey = opts.Config.ServerApiKey
 	}
 
 	apiClient, err := client.NewCustomClientWithResponses(client.CustomClientWithResponsesOptions{
-		ApiKey:  opts.Config.ServerApiKey,
+		ApiKey:  apiKey,
 		BaseUrl: opts.Config.WorkerServerAPIBaseURL,
 	})
 	if err != nil {
@@ -154,10 +215,12 @@ func NewWorker(configPath string, taskPaths []string) (*Worker, error) {
 	}
 
 	worker := &Worker{
-		Exec:       &APIExecutionSource{client: apiClient},
-		httpServer: newHttpServer("", router),
-		opts:       opts,
-		tasks:      reg.GetTasks(),
+		Exec:           &APIExecutionSource{client: apiClient},
+		httpServer:     newHttpServer("", router),
+		opts:           opts,
+		registry:       reg,
+		taskPaths:      taskPaths,
+		taskRepository: taskRepository,
 	}
 
 	router.Handle("GET /info", infoHandler(worker))
@@ -207,7 +270,9 @@ func (w *Worker) Start() {
 		case result := <-w.resultChan:
 			log.Log().Debugf("Received result of run %d", result.Execution.RunID)
 			metricRunsTotal.Inc()
-			if result.RunError != nil {
+			if result.Cancelled {
+				log.Log().Infof("Run %d cancelled", result.Execution.RunID)
+			} else if result.RunError != nil {
 				metricRunsFailed.Inc()
 				log.Log().Errorw("Run failed", zap.Error(fmt.Errorf("ID %d: %w", result.Execution.RunID, result.RunError)))
 			}
@@ -245,7 +310,7 @@ func (w *Worker) Stop() chan struct{} {
 }
 
 func (w *Worker) executeRun(exec Execution, result chan Result) {
-	t, err := w.findTaskByName(exec.Task.Name, exec.Task.Hash)
+	t, err := w.findTaskByName(exec.Task.Name, exec.Task.Hash, ptr.FromDef(exec.Task.Revision, ""))
 	if err != nil {
 		result <- Result{
 			RunError:  err,
@@ -268,16 +333,103 @@ func (w *Worker) executeRun(exec Execution, result chan Result) {
 		runData = ptr.From(exec.RunData)
 	}
 
-	results, err := command.ExecuteRun(w.opts, repositories, taskPaths, runData)
+	// Lets templates link to the run and the task in the UI of the server.
+	runData[sbcontext.RunDataKeyRunID] = strconv.Itoa(exec.RunID)
+	runData[sbcontext.RunDataKeyServerUrl] = w.opts.Config.ServerBaseUrl
+
+	runCtx, cancel := context.WithCancel(ctx)
+	go w.sendHeartbeats(runCtx, exec, cancel)
+	results, err := command.ExecuteRun(runCtx, w.opts, repositories, taskPaths, runData)
+	cancel()
 	result <- Result{
+		Cancelled:   errors.Is(err, command.ErrRunCancelled),
 		RunError:    err,
 		Execution:   exec,
 		TaskResults: results,
 	}
 }
 
-func (w *Worker) findTaskByName(name string, hash string) (*task.Task, error) {
-	for _, t := range w.tasks {
+// sendHeartbeats sends a heartbeat of the execution to the server in an interval until ctx is done.
+// It calls cancel if a user requested to cancel the execution.
+func (w *Worker) sendHeartbeats(ctx context.Context, exec Execution, cancel context.CancelFunc) {
+	t := time.NewTicker(w.opts.WorkerLoopInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			cancelled, err := w.Exec.Heartbeat(exec)
+			if err != nil {
+				metricServerRequestsFailed.WithLabelValues(metricLabelOpHeartbeatWorkV1).Inc()
+				log.Log().Warnw("Failed to send heartbeat", zap.Error(fmt.Errorf("ID %d: %w", exec.RunID, err)))
+				continue
+			}
+
+			if cancelled {
+				log.Log().Infof("Cancelling run %d because a user requested it", exec.RunID)
+				cancel()
+				return
+			}
+		}
+	}
+}
+
+// ReloadTasks reads all tasks from their files again.
+// It fetches the latest commit first if the worker reads tasks from a git repository.
+// Runs that the worker currently executes keep the version of the task they started with.
+func (w *Worker) ReloadTasks() error {
+	if w.taskRepository == nil {
+		return w.registry.Reload(w.taskPaths, "")
+	}
+
+	revision, taskPaths, err := w.taskRepository.Latest()
+	if err != nil {
+		return err
+	}
+
+	return w.registry.Reload(taskPaths, revision)
+}
+
+// reloadTasksAtRevision reads all tasks from revision of the git repository of tasks.
+func (w *Worker) reloadTasksAtRevision(revision string) error {
+	taskPaths, err := w.taskRepository.Checkout(revision)
+	if err != nil {
+		return err
+	}
+
+	return w.registry.Reload(taskPaths, revision)
+}
+
+// findTaskByName returns the task identified by name and hash.
+// It reloads all tasks once if it doesn't know the task,
+// because the task might have changed on disk since the worker read it.
+// If the worker reads tasks from a git repository, it reloads the tasks at revision.
+func (w *Worker) findTaskByName(name, hash, revision string) (*task.Task, error) {
+	t, err := lookupTask(w.registry.GetTasks(), name, hash)
+	if err == nil {
+		return t, nil
+	}
+
+	var reloadErr error
+	if w.taskRepository != nil && revision != "" {
+		log.Log().Infof("Reloading tasks at revision '%s' to find task '%s' with hash '%s'", revision, name, hash)
+		reloadErr = w.reloadTasksAtRevision(revision)
+	} else {
+		log.Log().Infof("Reloading tasks to find task '%s' with hash '%s'", name, hash)
+		reloadErr = w.ReloadTasks()
+	}
+
+	if reloadErr != nil {
+		log.Log().Errorw("Failed to reload tasks", zap.Error(reloadErr))
+		return nil, err
+	}
+
+	return lookupTask(w.registry.GetTasks(), name, hash)
+}
+
+func lookupTask(tasks []*task.Task, name string, hash string) (*task.Task, error) {
+	for _, t := range tasks {
 		if t.Name == name {
 			if t.Checksum() == hash {
 				return t, nil
@@ -331,6 +483,16 @@ func mapRunResultsToTaskResults(runResults []command.RunResult) []client.ReportW
 			Result:         int(rr.Result),
 			State:          client.TaskResultStateV1Unknown,
 		}
+		if rr.Log != "" {
+			result.Log = ptr.To(rr.Log)
+		}
+
+		if rr.TargetBranch != "" {
+			result.TargetBranch = ptr.To(rr.TargetBranch)
+		}
+
+		result.RolloutWave = rr.RolloutWave
+
 		updateTaskResultFromRunResult(&result, rr)
 		results = append(results, result)
 	}
@@ -341,6 +503,8 @@ func mapRunResultsToTaskResults(runResults []command.RunResult) []client.ReportW
 func Run(configPath string, taskPaths []string) error {
 	sigs := make(chan os.Signal, 1)
 	signal.Notify(sigs, syscall.SIGINT, syscall.SIGTERM)
+	reloadSigs := make(chan os.Signal, 1)
+	signal.Notify(reloadSigs, syscall.SIGHUP)
 	s, err := NewWorker(configPath, taskPaths)
 	if err != nil {
 		return fmt.Errorf("start worker: %w", err)
@@ -350,7 +514,18 @@ func Run(configPath string, taskPaths []string) error {
 	go s.Start()
 
 	log.Log().Infof("Worker started %s", version.String())
-	sig := <-sigs
+	var sig os.Signal
+	for sig == nil {
+		select {
+		case <-reloadSigs:
+			log.Log().Info("Reloading tasks")
+			if err := s.ReloadTasks(); err != nil {
+				log.Log().Errorw("Failed to reload tasks", zap.Error(err))
+			}
+		case sig = <-sigs:
+		}
+	}
+
 	log.Log().Infof("Caught signal %s - shutting down", sig.String())
 	<-s.Stop()
 	log.Log().Info("Worker stopped")
@@ -365,6 +540,8 @@ func shouldReport(result processor.Result) bool {
 		return false
 	case processor.ResultSkip:
 		return false
+	case processor.ResultDependencyPending:
+		return false
 	case processor.ResultArchived:
 		return false
 	default:
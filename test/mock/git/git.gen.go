@@ -10,8 +10,10 @@
 package git
 
 import (
+	io "io"
 	reflect "reflect"
 
+	git "github.com/wndhydrnt/saturn-bot/pkg/git"
 	host "github.com/wndhydrnt/saturn-bot/pkg/host"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -40,6 +42,36 @@ func (m *MockGitClient) EXPECT() *MockGitClientMockRecorder {
 	return m.recorder
 }
 
+// ChangedFileStats mocks base method.
+func (m *MockGitClient) ChangedFileStats(baseBranch string) ([]git.ChangedFile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangedFileStats", baseBranch)
+	ret0, _ := ret[0].([]git.ChangedFile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangedFileStats indicates an expected call of ChangedFileStats.
+func (mr *MockGitClientMockRecorder) ChangedFileStats(baseBranch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangedFileStats", reflect.TypeOf((*MockGitClient)(nil).ChangedFileStats), baseBranch)
+}
+
+// ChangedFiles mocks base method.
+func (m *MockGitClient) ChangedFiles(baseBranch string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangedFiles", baseBranch)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangedFiles indicates an expected call of ChangedFiles.
+func (mr *MockGitClientMockRecorder) ChangedFiles(baseBranch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangedFiles", reflect.TypeOf((*MockGitClient)(nil).ChangedFiles), baseBranch)
+}
+
 // Cleanup mocks base method.
 func (m *MockGitClient) Cleanup(repo host.Repository) error {
 	m.ctrl.T.Helper()
@@ -68,6 +100,36 @@ func (mr *MockGitClientMockRecorder) CommitChanges(msg any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitChanges", reflect.TypeOf((*MockGitClient)(nil).CommitChanges), msg)
 }
 
+// CountCommitsBehind mocks base method.
+func (m *MockGitClient) CountCommitsBehind(branchName, baseBranch string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCommitsBehind", branchName, baseBranch)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCommitsBehind indicates an expected call of CountCommitsBehind.
+func (mr *MockGitClientMockRecorder) CountCommitsBehind(branchName, baseBranch any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCommitsBehind", reflect.TypeOf((*MockGitClient)(nil).CountCommitsBehind), branchName, baseBranch)
+}
+
+// Diff mocks base method.
+func (m *MockGitClient) Diff(stat, color bool) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Diff", stat, color)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Diff indicates an expected call of Diff.
+func (mr *MockGitClientMockRecorder) Diff(stat, color any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Diff", reflect.TypeOf((*MockGitClient)(nil).Diff), stat, color)
+}
+
 // Execute mocks base method.
 func (m *MockGitClient) Execute(arg ...string) (string, string, error) {
 	m.ctrl.T.Helper()
@@ -147,6 +209,33 @@ func (mr *MockGitClientMockRecorder) Push(branchName, force any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockGitClient)(nil).Push), branchName, force)
 }
 
+// RemoteBranches mocks base method.
+func (m *MockGitClient) RemoteBranches() ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoteBranches")
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoteBranches indicates an expected call of RemoteBranches.
+func (mr *MockGitClientMockRecorder) RemoteBranches() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoteBranches", reflect.TypeOf((*MockGitClient)(nil).RemoteBranches))
+}
+
+// SetLogCapture mocks base method.
+func (m *MockGitClient) SetLogCapture(w io.Writer) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetLogCapture", w)
+}
+
+// SetLogCapture indicates an expected call of SetLogCapture.
+func (mr *MockGitClientMockRecorder) SetLogCapture(w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLogCapture", reflect.TypeOf((*MockGitClient)(nil).SetLogCapture), w)
+}
+
 // UpdateTaskBranch mocks base method.
 func (m *MockGitClient) UpdateTaskBranch(branchName string, forceRebase bool, repo host.Repository) (bool, error) {
 	m.ctrl.T.Helper()
@@ -161,3 +250,18 @@ func (mr *MockGitClientMockRecorder) UpdateTaskBranch(branchName, forceRebase, r
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTaskBranch", reflect.TypeOf((*MockGitClient)(nil).UpdateTaskBranch), branchName, forceRebase, repo)
 }
+
+// WriteTree mocks base method.
+func (m *MockGitClient) WriteTree() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WriteTree")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WriteTree indicates an expected call of WriteTree.
+func (mr *MockGitClientMockRecorder) WriteTree() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteTree", reflect.TypeOf((*MockGitClient)(nil).WriteTree))
+}
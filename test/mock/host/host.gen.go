@@ -172,6 +172,20 @@ func (mr *MockRepositoryMockRecorder) DeletePullRequestComment(comment, pr any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePullRequestComment", reflect.TypeOf((*MockRepository)(nil).DeletePullRequestComment), comment, pr)
 }
 
+// EnableAutoMerge mocks base method.
+func (m *MockRepository) EnableAutoMerge(opts host.MergeOptions, pr *host.PullRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableAutoMerge", opts, pr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableAutoMerge indicates an expected call of EnableAutoMerge.
+func (mr *MockRepositoryMockRecorder) EnableAutoMerge(opts, pr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableAutoMerge", reflect.TypeOf((*MockRepository)(nil).EnableAutoMerge), opts, pr)
+}
+
 // FindPullRequest mocks base method.
 func (m *MockRepository) FindPullRequest(branch string) (*host.PullRequest, error) {
 	m.ctrl.T.Helper()
@@ -287,18 +301,32 @@ func (mr *MockRepositoryMockRecorder) ListPullRequestComments(pr any) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPullRequestComments", reflect.TypeOf((*MockRepository)(nil).ListPullRequestComments), pr)
 }
 
+// MarkPullRequestReady mocks base method.
+func (m *MockRepository) MarkPullRequestReady(pr *host.PullRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPullRequestReady", pr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkPullRequestReady indicates an expected call of MarkPullRequestReady.
+func (mr *MockRepositoryMockRecorder) MarkPullRequestReady(pr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPullRequestReady", reflect.TypeOf((*MockRepository)(nil).MarkPullRequestReady), pr)
+}
+
 // MergePullRequest mocks base method.
-func (m *MockRepository) MergePullRequest(deleteBranch bool, pr *host.PullRequest) error {
+func (m *MockRepository) MergePullRequest(opts host.MergeOptions, pr *host.PullRequest) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MergePullRequest", deleteBranch, pr)
+	ret := m.ctrl.Call(m, "MergePullRequest", opts, pr)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // MergePullRequest indicates an expected call of MergePullRequest.
-func (mr *MockRepositoryMockRecorder) MergePullRequest(deleteBranch, pr any) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) MergePullRequest(opts, pr any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergePullRequest", reflect.TypeOf((*MockRepository)(nil).MergePullRequest), deleteBranch, pr)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergePullRequest", reflect.TypeOf((*MockRepository)(nil).MergePullRequest), opts, pr)
 }
 
 // Name mocks base method.
@@ -343,6 +371,20 @@ func (mr *MockRepositoryMockRecorder) Raw() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Raw", reflect.TypeOf((*MockRepository)(nil).Raw))
 }
 
+// RequestPullRequestReview mocks base method.
+func (m *MockRepository) RequestPullRequestReview(reviewers []string, pr *host.PullRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPullRequestReview", reviewers, pr)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequestPullRequestReview indicates an expected call of RequestPullRequestReview.
+func (mr *MockRepositoryMockRecorder) RequestPullRequestReview(reviewers, pr any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPullRequestReview", reflect.TypeOf((*MockRepository)(nil).RequestPullRequestReview), reviewers, pr)
+}
+
 // UpdatePullRequest mocks base method.
 func (m *MockRepository) UpdatePullRequest(data host.PullRequestData, pr *host.PullRequest) error {
 	m.ctrl.T.Helper()
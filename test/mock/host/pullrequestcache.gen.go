@@ -196,6 +196,20 @@ func (mr *MockPullRequestCacheMockRecorder) LastUpdatedAtFor(arg0 any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastUpdatedAtFor", reflect.TypeOf((*MockPullRequestCache)(nil).LastUpdatedAtFor), arg0)
 }
 
+// ListByRepository mocks base method.
+func (m *MockPullRequestCache) ListByRepository(repoName string) []*host.PullRequest {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListByRepository", repoName)
+	ret0, _ := ret[0].([]*host.PullRequest)
+	return ret0
+}
+
+// ListByRepository indicates an expected call of ListByRepository.
+func (mr *MockPullRequestCacheMockRecorder) ListByRepository(repoName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListByRepository", reflect.TypeOf((*MockPullRequestCache)(nil).ListByRepository), repoName)
+}
+
 // Set mocks base method.
 func (m *MockPullRequestCache) Set(branchName, repoName string, pr *host.PullRequest) {
 	m.ctrl.T.Helper()
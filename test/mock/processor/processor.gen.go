@@ -10,6 +10,7 @@
 package processor
 
 import (
+	context "context"
 	reflect "reflect"
 
 	host "github.com/wndhydrnt/saturn-bot/pkg/host"
@@ -43,15 +44,15 @@ func (m *MockRepositoryTaskProcessor) EXPECT() *MockRepositoryTaskProcessorMockR
 }
 
 // Process mocks base method.
-func (m *MockRepositoryTaskProcessor) Process(dryRun bool, repo host.Repository, tasks []*task.Task, doFilter bool) []processor.ProcessResult {
+func (m *MockRepositoryTaskProcessor) Process(ctx context.Context, dryRun bool, repo host.Repository, tasks []*task.Task, doFilter bool) []processor.ProcessResult {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Process", dryRun, repo, tasks, doFilter)
+	ret := m.ctrl.Call(m, "Process", ctx, dryRun, repo, tasks, doFilter)
 	ret0, _ := ret[0].([]processor.ProcessResult)
 	return ret0
 }
 
 // Process indicates an expected call of Process.
-func (mr *MockRepositoryTaskProcessorMockRecorder) Process(dryRun, repo, tasks, doFilter any) *gomock.Call {
+func (mr *MockRepositoryTaskProcessorMockRecorder) Process(ctx, dryRun, repo, tasks, doFilter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Process", reflect.TypeOf((*MockRepositoryTaskProcessor)(nil).Process), dryRun, repo, tasks, doFilter)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Process", reflect.TypeOf((*MockRepositoryTaskProcessor)(nil).Process), ctx, dryRun, repo, tasks, doFilter)
 }